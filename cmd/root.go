@@ -216,7 +216,7 @@ func initConfig() {
 				fmt.Printf("error setting mongo: %s\n", err)
 			}
 			if err := jwt.Update(
-				nil, cfg.JWTConfig.TokenDuration, cfg.JWTConfig.RefreshDuration, cfg.JWTConfig.RefreshBuffer,
+				cfg.JWTConfig.TokenDuration, cfg.JWTConfig.RefreshDuration, cfg.JWTConfig.RefreshBuffer,
 			); err != nil {
 				fmt.Printf("error setting jwt: %s\n", err)
 			}
@@ -13,7 +13,7 @@ import (
 	"data-collection-hub-server/pkg/mongo"
 	"data-collection-hub-server/pkg/redis"
 	logging "data-collection-hub-server/pkg/zap"
-	"github.com/casbin/mongodb-adapter/v3"
+	casbinv2 "github.com/casbin/casbin/v2"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/contrib/casbin"
 	"github.com/gofiber/fiber/v2"
@@ -30,6 +30,7 @@ type App struct {
 	Tasks      *tasks.Tasks
 	Mongo      *mongo.Mongo
 	Redis      *redis.Redis
+	Enforcer   *casbinv2.Enforcer
 	Ctx        context.Context
 }
 
@@ -37,6 +38,7 @@ type App struct {
 func New(
 	ctx context.Context, zap *logging.Zap, config *config.Config, router *router.Router,
 	middleware *middleware.Middleware, tasks *tasks.Tasks, mongo *mongo.Mongo, redis *redis.Redis,
+	enforcer *casbinv2.Enforcer,
 ) (*App, error) {
 	app := &App{
 		Zap:        zap,
@@ -46,6 +48,7 @@ func New(
 		Tasks:      tasks,
 		Mongo:      mongo,
 		Redis:      redis,
+		Enforcer:   enforcer,
 		Ctx:        ctx,
 	}
 
@@ -89,6 +92,11 @@ func (a *App) Init(ctx context.Context) error {
 		},
 	)
 
+	// Serve the uploaded files of the local storage, before the middleware so that they need no token
+	if a.Config.StorageConfig.Driver == "local" {
+		app.Static(a.Config.StorageConfig.LocalURLPrefix, a.Config.StorageConfig.LocalDir)
+	}
+
 	// Register Middleware
 	if err := a.Middleware.Register(app); err != nil {
 		return err
@@ -103,15 +111,10 @@ func (a *App) Init(ctx context.Context) error {
 
 	// Ping
 
-	// Set Casbin
-	adapter, err := mongodbadapter.NewAdapter(a.Config.CasbinConfig.PolicyAdapterUrl)
-	if err != nil {
-		return err
-	}
+	// Set Casbin, sharing the enforcer of the services so that role changes apply without a restart
 	c := casbin.New(
 		casbin.Config{
-			ModelFilePath: a.Config.CasbinConfig.ModelPath,
-			PolicyAdapter: adapter,
+			Enforcer: a.Enforcer,
 			Lookup: func(c *fiber.Ctx) string {
 				return c.Locals(config.UserIDKey).(string)
 			},
@@ -122,9 +125,10 @@ func (a *App) Init(ctx context.Context) error {
 	)
 
 	idempotencyMiddleware := a.Middleware.IdempotencyMiddleware.IdempotencyMiddleware()
+	organizationMiddleware := a.Middleware.OrganizationMiddleware.OrganizationMiddleware()
 
 	// Register routers
-	a.Router.RegisterRouter(app, c, idempotencyMiddleware)
+	a.Router.RegisterRouter(app, c, idempotencyMiddleware, organizationMiddleware)
 
 	// Set app
 	a.App = app
@@ -5,10 +5,17 @@ import (
 )
 
 type Admin struct {
-	DataAuditApi     *mods.DataAuditApi
-	StatisticApi     *mods.StatisticApi
-	UserApi          *mods.UserApi
-	NoticeApi        *mods.NoticeApi
-	DocumentationApi *mods.DocumentationApi
-	LogsApi          *mods.LogsApi
+	DataAuditApi       *mods.DataAuditApi
+	StatisticApi       *mods.StatisticApi
+	UserApi            *mods.UserApi
+	NoticeApi          *mods.NoticeApi
+	DocumentationApi   *mods.DocumentationApi
+	LogsApi            *mods.LogsApi
+	ThemeApi           *mods.ThemeApi
+	ReAuditApi         *mods.ReAuditApi
+	InviteCodeApi      *mods.InviteCodeApi
+	TwoFactorPolicyApi *mods.TwoFactorPolicyApi
+	LoginLockoutApi    *mods.LoginLockoutApi
+	OrganizationApi    *mods.OrganizationApi
+	PolicyApi          *mods.PolicyApi
 }
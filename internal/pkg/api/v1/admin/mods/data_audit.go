@@ -2,6 +2,7 @@ package mods
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
@@ -150,10 +151,14 @@ func (d *DataAuditApi) GetInstructionDataList(c *fiber.Ctx) error {
 		updateStartTimePtr = &updateStartTime
 		updateEndTimePtr = &updateEndTime
 	}
+	metadata, err := parseMetadataFilter(req.Metadata)
+	if err != nil {
+		return err
+	}
 	resp, err := d.DataAuditService.GetInstructionDataList(
 		c.UserContext(),
 		req.Page, req.PageSize, req.Desc, userIDPtr, createStartTimePtr, createEndTimePtr, updateStartTimePtr,
-		updateEndTimePtr, req.Theme, req.Status, req.Query,
+		updateEndTimePtr, req.Theme, req.Status, req.Query, metadata, req.SourceType, req.License,
 	)
 	if err != nil {
 		return err
@@ -312,6 +317,7 @@ func (d *DataAuditApi) UpdateInstructionData(c *fiber.Ctx) error {
 	// update instruction data
 	err = d.DataAuditService.UpdateInstructionData(
 		ctx, &instructionDataID, userIDPtr, req.Instruction, req.Input, req.Output, req.Theme, req.Source, req.Note,
+		req.Metadata,
 	)
 
 	var (
@@ -415,9 +421,14 @@ func (d *DataAuditApi) ExportInstructionData(c *fiber.Ctx) error {
 		updateStartTimePtr = &updateStartTime
 		updateEndTimePtr = &updateEndTime
 	}
+	metadata, err := parseMetadataFilter(req.Metadata)
+	if err != nil {
+		return err
+	}
 	data, err := d.DataAuditService.ExportInstructionData(
 		c.UserContext(), req.Desc,
 		userIDPtr, createStartTimePtr, createEndTimePtr, updateStartTimePtr, updateEndTimePtr, req.Theme, req.Status,
+		metadata, req.SourceType, req.License, req.PermissiveOnly,
 	)
 	if err != nil {
 		return err
@@ -518,9 +529,14 @@ func (d *DataAuditApi) ExportInstructionDataAsAlpaca(c *fiber.Ctx) error {
 		updateStartTimePtr = &updateStartTime
 		updateEndTimePtr = &updateEndTime
 	}
+	metadata, err := parseMetadataFilter(req.Metadata)
+	if err != nil {
+		return err
+	}
 	data, err := d.DataAuditService.ExportInstructionDataAsAlpaca(
 		c.UserContext(), req.Desc,
 		userIDPtr, createStartTimePtr, createEndTimePtr, updateStartTimePtr, updateEndTimePtr, req.Theme, req.Status,
+		metadata, req.SourceType, req.License, req.PermissiveOnly,
 	)
 	if err != nil {
 		return err
@@ -606,3 +622,25 @@ func (d *DataAuditApi) DeleteInstructionData(c *fiber.Ctx) error {
 		},
 	)
 }
+
+// parseMetadataFilter parses the metadata filter given as a JSON object of field-value pairs, e.g.
+// {"difficulty": "hard"}. Only exact matches on scalar values are supported.
+func parseMetadataFilter(filter *string) (map[string]interface{}, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(*filter), &metadata); err != nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("invalid metadata filter %s (should be a JSON object)", *filter))
+	}
+	for field, value := range metadata {
+		if field == "" || strings.HasPrefix(field, "$") {
+			return nil, errors.InvalidRequest(fmt.Errorf("invalid metadata field %s", field))
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, errors.InvalidRequest(fmt.Errorf("invalid value of metadata field %s (should be scalar)", field))
+		}
+	}
+	return metadata, nil
+}
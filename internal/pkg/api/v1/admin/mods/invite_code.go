@@ -0,0 +1,245 @@
+package mods
+
+import (
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InviteCodeApi struct {
+	InviteCodeService adminservice.InviteCodeService
+	LogsService       sysservice.LogsService
+	Validator         *validator.Validate
+}
+
+// InsertInviteCode inserts a new invite code.
+//
+//	@description	Insert a new invite code. Users registered with the code get its role and organization.
+//	@id				admin-insert-invite-code
+//	@summary		insert invite code
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertInviteCodeRequest	body	admin.InsertInviteCodeRequest	true	"Insert invite code request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.InsertInviteCodeResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500					{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/invite-code	[post]
+func (i *InviteCodeApi) InsertInviteCode(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertInviteCodeRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := i.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+	if err != nil {
+		return errors.InvalidRequest(
+			fmt.Errorf("invalid expiry time %s (should be in RFC3339 format)", *req.ExpiresAt),
+		)
+	}
+	resp, err := i.InviteCodeService.InsertInviteCode(ctx, req.Role, req.Organization, req.MaxUses, &expiresAt)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypeInviteCode
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert invite code failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = i.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		inviteCodeID, _ = primitive.ObjectIDFromHex(resp.InviteCodeID)
+		description     = fmt.Sprintf("Insert invite code: %s", resp.InviteCodeID)
+		status          = config.OperationStatusSuccess
+	)
+	_ = i.LogsService.CacheOperationLog(
+		ctx, &userID, &inviteCodeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetInviteCode returns the invite code by ID, with its redemptions.
+//
+//	@description	Get the invite code by ID, with the users registered with it.
+//	@id				admin-get-invite-code
+//	@summary		get invite code
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetInviteCodeRequest	query	admin.GetInviteCodeRequest	true	"Get invite code request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.GetInviteCodeResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}							"Invite code not found"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/invite-code	[get]
+func (i *InviteCodeApi) GetInviteCode(c *fiber.Ctx) error {
+	req := new(admin.GetInviteCodeRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := i.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	inviteCodeID, err := primitive.ObjectIDFromHex(*req.InviteCodeID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid invite code ID"))
+	}
+	resp, err := i.InviteCodeService.GetInviteCode(c.UserContext(), &inviteCodeID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetInviteCodeList returns the invite code list.
+//
+//	@description	Get the invite code list.
+//	@id				admin-get-invite-code-list
+//	@summary		get invite code list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetInviteCodeListRequest	query	admin.GetInviteCodeListRequest	true	"Get invite code list request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.GetInviteCodeListResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/invite-code/list	[get]
+func (i *InviteCodeApi) GetInviteCodeList(c *fiber.Ctx) error {
+	req := new(admin.GetInviteCodeListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := i.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := i.InviteCodeService.GetInviteCodeList(
+		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Role, req.Organization,
+	)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// DeleteInviteCode deletes the invite code.
+//
+//	@description	Delete the invite code. Users already registered with the code are kept.
+//	@id				admin-delete-invite-code
+//	@summary		delete invite code
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteInviteCodeRequest	query	admin.DeleteInviteCodeRequest	true	"Delete invite code request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=nil}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}	"Invite code not found"
+//	@failure		500					{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/invite-code	[delete]
+func (i *InviteCodeApi) DeleteInviteCode(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteInviteCodeRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := i.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	inviteCodeID, err := primitive.ObjectIDFromHex(*req.InviteCodeID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid invite code ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeInviteCode
+	)
+	err = i.InviteCodeService.DeleteInviteCode(ctx, &inviteCodeID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete invite code failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = i.LogsService.CacheOperationLog(
+			ctx, &userID, &inviteCodeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete invite code: %s", *req.InviteCodeID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = i.LogsService.CacheOperationLog(
+		ctx, &userID, &inviteCodeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -0,0 +1,112 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoginLockoutApi struct {
+	LoginLockoutService adminservice.LoginLockoutService
+	LogsService         sysservice.LogsService
+	Validator           *validator.Validate
+}
+
+// GetLoginLockoutList returns the accounts and IP addresses locked after too many failed logins.
+//
+//	@description	Get the accounts and IP addresses currently locked after too many failed logins, the most recent lockouts first.
+//	@id				admin-get-login-lockout-list
+//	@summary		get login lockout list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.GetLoginLockoutListResponse}	"Success"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/login-lockout/list	[get]
+func (l *LoginLockoutApi) GetLoginLockoutList(c *fiber.Ctx) error {
+	resp, err := l.LoginLockoutService.GetLoginLockoutList(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// DeleteLoginLockout unlocks an account or IP address.
+//
+//	@description	Unlock an account (kind ACCOUNT, value is the email) or an IP address (kind IP) and clear its failed logins.
+//	@id				admin-delete-login-lockout
+//	@summary		delete login lockout
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteLoginLockoutRequest	query	admin.DeleteLoginLockoutRequest	true	"Delete login lockout request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/login-lockout	[delete]
+func (l *LoginLockoutApi) DeleteLoginLockout(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteLoginLockoutRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := l.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	err := l.LoginLockoutService.DeleteLoginLockout(ctx, req.Kind, req.Value)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeLoginLockout
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete login lockout failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = l.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete login lockout: %s %s", *req.Kind, *req.Value)
+		status      = config.OperationStatusSuccess
+	)
+	_ = l.LogsService.CacheOperationLog(
+		ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -20,7 +20,7 @@ type LogsApi struct {
 
 // GetLoginLogList returns the login log list.
 //
-//	@description	Get the login log list.
+//	@description	Get the login log list. Failed logins are logged with their reason and can be filtered by status.
 //	@id				admin-get-login-log-list
 //	@summary		get login log list
 //	@tags			Admin API
@@ -71,7 +71,7 @@ func (l *LogsApi) GetLoginLogList(c *fiber.Ctx) error {
 	}
 
 	resp, err := l.LogsService.GetLoginLogList(
-		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Query, createdBefore, createdAfter,
+		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Query, req.Status, createdBefore, createdAfter,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,645 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OrganizationApi struct {
+	OrganizationService adminservice.OrganizationService
+	LogsService         sysservice.LogsService
+	Validator           *validator.Validate
+}
+
+// InsertOrganization inserts a new organization.
+//
+//	@description	Insert a new organization. The name of an organization is unique and cannot be changed.
+//	@id				admin-insert-organization
+//	@summary		insert organization
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertOrganizationRequest	body	admin.InsertOrganizationRequest	true	"Insert organization request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=admin.InsertOrganizationResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		409						{object}	vo.Response{data=nil}								"Organization already exists"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/organization	[post]
+func (o *OrganizationApi) InsertOrganization(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertOrganizationRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := o.OrganizationService.InsertOrganization(ctx, req.Name, req.Description)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypeOrganization
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert organization %s failed: %s", *req.Name, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		organizationID, _ = primitive.ObjectIDFromHex(resp.OrganizationID)
+		description       = fmt.Sprintf("Insert organization: %s", *req.Name)
+		status            = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetOrganization returns the organization by ID.
+//
+//	@description	Get the organization by ID, with its member count and organization admins. Organization admins can
+//	@description	only get their own organization.
+//	@id				admin-get-organization
+//	@summary		get organization
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetOrganizationRequest	query	admin.GetOrganizationRequest	true	"Get organization request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=admin.GetOrganizationResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}							"Organization not found"
+//	@failure		500						{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/organization	[get]
+func (o *OrganizationApi) GetOrganization(c *fiber.Ctx) error {
+	req := new(admin.GetOrganizationRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	resp, err := o.OrganizationService.GetOrganization(c.UserContext(), &organizationID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetOrganizationList returns the organization list.
+//
+//	@description	Get the organization list, optionally searched by name.
+//	@id				admin-get-organization-list
+//	@summary		get organization list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetOrganizationListRequest	query	admin.GetOrganizationListRequest	true	"Get organization list request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.GetOrganizationListResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/organization/list	[get]
+func (o *OrganizationApi) GetOrganizationList(c *fiber.Ctx) error {
+	req := new(admin.GetOrganizationListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := o.OrganizationService.GetOrganizationList(
+		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Query,
+	)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// UpdateOrganization updates the organization.
+//
+//	@description	Update the description of the organization.
+//	@id				admin-update-organization
+//	@summary		update organization
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.UpdateOrganizationRequest	body	admin.UpdateOrganizationRequest	true	"Update organization request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}	"Organization not found"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization	[put]
+func (o *OrganizationApi) UpdateOrganization(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.UpdateOrganizationRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.UpdateOrganization(ctx, &organizationID, req.Description)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Update organization failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Update organization: %s", *req.OrganizationID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteOrganization deletes the organization.
+//
+//	@description	Delete the organization. Only organizations without members can be deleted.
+//	@id				admin-delete-organization
+//	@summary		delete organization
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteOrganizationRequest	query	admin.DeleteOrganizationRequest	true	"Delete organization request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}	"Organization not found"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization	[delete]
+func (o *OrganizationApi) DeleteOrganization(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteOrganizationRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.DeleteOrganization(ctx, &organizationID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete organization failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete organization: %s", *req.OrganizationID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// GetOrganizationMemberList returns the members of the organization.
+//
+//	@description	Get the members of the organization, marking its organization admins. Organization admins can only
+//	@description	get the members of their own organization.
+//	@id				admin-get-organization-member-list
+//	@summary		get organization member list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetOrganizationMemberListRequest	query	admin.GetOrganizationMemberListRequest	true	"Get organization member list request"
+//	@security		Bearer
+//	@success		200									{object}	vo.Response{data=admin.GetOrganizationMemberListResponse}	"Success"
+//	@failure		400									{object}	vo.Response{data=nil}										"Invalid request"
+//	@failure		401									{object}	vo.Response{data=nil}										"Unauthorized"
+//	@failure		403									{object}	vo.Response{data=nil}										"Forbidden"
+//	@failure		404									{object}	vo.Response{data=nil}										"Organization not found"
+//	@failure		500									{object}	vo.Response{data=nil}										"Internal server error"
+//	@router			/admin/organization/member/list	[get]
+func (o *OrganizationApi) GetOrganizationMemberList(c *fiber.Ctx) error {
+	req := new(admin.GetOrganizationMemberListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	resp, err := o.OrganizationService.GetOrganizationMemberList(
+		c.UserContext(), &organizationID, req.Page, req.PageSize,
+	)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// InsertOrganizationMember adds a user to the organization.
+//
+//	@description	Add a user to the organization. A user can only be a member of one organization: it leaves its
+//	@description	previous organization, and loses its organization admin role there.
+//	@id				admin-insert-organization-member
+//	@summary		insert organization member
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertOrganizationMemberRequest	body	admin.InsertOrganizationMemberRequest	true	"Insert organization member request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=nil}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404								{object}	vo.Response{data=nil}	"Organization or user not found"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization/member	[post]
+func (o *OrganizationApi) InsertOrganizationMember(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertOrganizationMemberRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	memberID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.InsertOrganizationMember(ctx, &organizationID, &memberID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert organization member %s failed: %s", *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Insert organization member: %s", *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteOrganizationMember removes a user from the organization.
+//
+//	@description	Remove a user from the organization, and its organization admin role with it.
+//	@id				admin-delete-organization-member
+//	@summary		delete organization member
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteOrganizationMemberRequest	query	admin.DeleteOrganizationMemberRequest	true	"Delete organization member request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=nil}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404								{object}	vo.Response{data=nil}	"Organization or member not found"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization/member	[delete]
+func (o *OrganizationApi) DeleteOrganizationMember(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteOrganizationMemberRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	memberID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.DeleteOrganizationMember(ctx, &organizationID, &memberID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete organization member %s failed: %s", *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete organization member: %s", *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// InsertOrganizationAdmin makes a member an organization admin.
+//
+//	@description	Make a member of the organization an organization admin. Organization admins can review, export
+//	@description	and see statistics of the data of their organization's users only.
+//	@id				admin-insert-organization-admin
+//	@summary		insert organization admin
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertOrganizationAdminRequest	body	admin.InsertOrganizationAdminRequest	true	"Insert organization admin request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=nil}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404								{object}	vo.Response{data=nil}	"Organization or user not found"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization/admin	[post]
+func (o *OrganizationApi) InsertOrganizationAdmin(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertOrganizationAdminRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	adminID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.InsertOrganizationAdmin(ctx, &organizationID, &adminID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert organization admin %s failed: %s", *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Insert organization admin: %s", *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteOrganizationAdmin revokes the organization admin role of a member.
+//
+//	@description	Revoke the organization admin role of a member of the organization. The user stays a member.
+//	@id				admin-delete-organization-admin
+//	@summary		delete organization admin
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteOrganizationAdminRequest	query	admin.DeleteOrganizationAdminRequest	true	"Delete organization admin request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=nil}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404								{object}	vo.Response{data=nil}	"Organization or organization admin not found"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/organization/admin	[delete]
+func (o *OrganizationApi) DeleteOrganizationAdmin(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteOrganizationAdminRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(*req.OrganizationID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid organization ID"))
+	}
+	adminID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeOrganization
+	)
+	err = o.OrganizationService.DeleteOrganizationAdmin(ctx, &organizationID, &adminID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete organization admin %s failed: %s", *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = o.LogsService.CacheOperationLog(
+			ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete organization admin: %s", *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = o.LogsService.CacheOperationLog(
+		ctx, &userID, &organizationID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -0,0 +1,456 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PolicyApi struct {
+	PolicyService adminservice.PolicyService
+	LogsService   sysservice.LogsService
+	Validator     *validator.Validate
+}
+
+// GetPolicyList returns the casbin policies.
+//
+//	@description	Get the casbin policies, i.e. the permissions granted to each role, optionally of one role. Built-in
+//	@description	policies are restored on startup and cannot be deleted.
+//	@id				admin-get-policy-list
+//	@summary		get policy list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetPolicyListRequest	query	admin.GetPolicyListRequest	true	"Get policy list request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.GetPolicyListResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/policy/list	[get]
+func (p *PolicyApi) GetPolicyList(c *fiber.Ctx) error {
+	req := new(admin.GetPolicyListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := p.PolicyService.GetPolicyList(c.UserContext(), req.Role)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// InsertPolicy grants a permission to a role.
+//
+//	@description	Grant the permission to act on an object to a role. Granting a permission to a new role creates a
+//	@description	custom role.
+//	@id				admin-insert-policy
+//	@summary		insert policy
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertPolicyRequest	body	admin.InsertPolicyRequest	true	"Insert policy request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		409				{object}	vo.Response{data=nil}	"Policy already exists"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/policy	[post]
+func (p *PolicyApi) InsertPolicy(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertPolicyRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypePolicy
+	)
+	err := p.PolicyService.InsertPolicy(ctx, req.Role, req.Object, req.Action)
+	if err != nil {
+		var (
+			description = fmt.Sprintf(
+				"Insert policy (%s, %s, %s) failed: %s", *req.Role, *req.Object, *req.Action, err.Error(),
+			)
+			status = config.OperationStatusFailure
+		)
+		_ = p.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Insert policy: (%s, %s, %s)", *req.Role, *req.Object, *req.Action)
+		status      = config.OperationStatusSuccess
+	)
+	_ = p.LogsService.CacheOperationLog(
+		ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeletePolicy revokes a permission from a role.
+//
+//	@description	Revoke the permission to act on an object from a role. Built-in policies cannot be deleted.
+//	@id				admin-delete-policy
+//	@summary		delete policy
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeletePolicyRequest	query	admin.DeletePolicyRequest	true	"Delete policy request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404				{object}	vo.Response{data=nil}	"Policy not found"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/policy	[delete]
+func (p *PolicyApi) DeletePolicy(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeletePolicyRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypePolicy
+	)
+	err := p.PolicyService.DeletePolicy(ctx, req.Role, req.Object, req.Action)
+	if err != nil {
+		var (
+			description = fmt.Sprintf(
+				"Delete policy (%s, %s, %s) failed: %s", *req.Role, *req.Object, *req.Action, err.Error(),
+			)
+			status = config.OperationStatusFailure
+		)
+		_ = p.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete policy: (%s, %s, %s)", *req.Role, *req.Object, *req.Action)
+		status      = config.OperationStatusSuccess
+	)
+	_ = p.LogsService.CacheOperationLog(
+		ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// GetRoleGroupingList returns the roles granted to users.
+//
+//	@description	Get the roles granted to users, optionally of one user or of one role.
+//	@id				admin-get-role-grouping-list
+//	@summary		get role grouping list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetRoleGroupingListRequest	query	admin.GetRoleGroupingListRequest	true	"Get role grouping list request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.GetRoleGroupingListResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/role-grouping/list	[get]
+func (p *PolicyApi) GetRoleGroupingList(c *fiber.Ctx) error {
+	req := new(admin.GetRoleGroupingListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := p.PolicyService.GetRoleGroupingList(c.UserContext(), req.UserID, req.Role)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// InsertRoleGrouping grants a role to a user.
+//
+//	@description	Grant a fine-grained or custom role, such as REVIEWER, EXPORTER or VIEWER, to a user on top of its
+//	@description	role. USER and ADMIN are managed through the user API, ORG_ADMIN through the organization API.
+//	@id				admin-insert-role-grouping
+//	@summary		insert role grouping
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertRoleGroupingRequest	body	admin.InsertRoleGroupingRequest	true	"Insert role grouping request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}	"User not found"
+//	@failure		409						{object}	vo.Response{data=nil}	"User already has the role"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/role-grouping	[post]
+func (p *PolicyApi) InsertRoleGrouping(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertRoleGroupingRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	grantedUserID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypePolicy
+	)
+	err = p.PolicyService.InsertRoleGrouping(ctx, &grantedUserID, req.Role)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Grant role %s to user %s failed: %s", *req.Role, *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = p.LogsService.CacheOperationLog(
+			ctx, &userID, &grantedUserID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Grant role %s to user: %s", *req.Role, *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = p.LogsService.CacheOperationLog(
+		ctx, &userID, &grantedUserID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteRoleGrouping revokes a role from a user.
+//
+//	@description	Revoke a fine-grained or custom role from a user.
+//	@id				admin-delete-role-grouping
+//	@summary		delete role grouping
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteRoleGroupingRequest	query	admin.DeleteRoleGroupingRequest	true	"Delete role grouping request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}	"User does not have the role"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/role-grouping	[delete]
+func (p *PolicyApi) DeleteRoleGrouping(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteRoleGroupingRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	revokedUserID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypePolicy
+	)
+	err = p.PolicyService.DeleteRoleGrouping(ctx, &revokedUserID, req.Role)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Revoke role %s from user %s failed: %s", *req.Role, *req.UserID, err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = p.LogsService.CacheOperationLog(
+			ctx, &userID, &revokedUserID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Revoke role %s from user: %s", *req.Role, *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = p.LogsService.CacheOperationLog(
+		ctx, &userID, &revokedUserID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// GetUserPermission returns the effective permissions of a user.
+//
+//	@description	Get the roles of a user, including inherited ones, and the permissions they grant.
+//	@id				admin-get-user-permission
+//	@summary		get user permission
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetUserPermissionRequest	query	admin.GetUserPermissionRequest	true	"Get user permission request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=admin.GetUserPermissionResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/permission/list	[get]
+func (p *PolicyApi) GetUserPermission(c *fiber.Ctx) error {
+	req := new(admin.GetUserPermissionRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+	resp, err := p.PolicyService.GetUserPermission(c.UserContext(), &userID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// CheckPermission dry-runs an access check.
+//
+//	@description	Check whether a user is allowed to act on an object, without acting, and return the matched policy.
+//	@id				admin-check-permission
+//	@summary		check permission
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.CheckPermissionRequest	query	admin.CheckPermissionRequest	true	"Check permission request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.CheckPermissionResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/permission/check	[get]
+func (p *PolicyApi) CheckPermission(c *fiber.Ctx) error {
+	req := new(admin.CheckPermissionRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := p.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user ID"))
+	}
+	resp, err := p.PolicyService.CheckPermission(c.UserContext(), &userID, req.Object, req.Action)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
@@ -0,0 +1,226 @@
+package mods
+
+import (
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReAuditApi struct {
+	ReAuditService adminservice.ReAuditService
+	LogsService    sysservice.LogsService
+	Validator      *validator.Validate
+}
+
+// GetReAuditList returns the re-audit queue of the current admin.
+//
+//	@description	Get the approved records sampled for re-audit. Records approved by the current admin are left out.
+//	@id				admin-get-re-audit-list
+//	@summary		get re-audit list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetReAuditListRequest	query	admin.GetReAuditListRequest	true	"Get re-audit list request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.GetReAuditListResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/re-audit/list	[get]
+func (r *ReAuditApi) GetReAuditList(c *fiber.Ctx) error {
+	req := new(admin.GetReAuditListRequest)
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := r.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	var (
+		reviewerID               primitive.ObjectID
+		reviewerIDPtr            *primitive.ObjectID
+		startTime, endTime       time.Time
+		startTimePtr, endTimePtr *time.Time
+		err                      error
+	)
+	if req.ReviewerID != nil {
+		reviewerID, err = primitive.ObjectIDFromHex(*req.ReviewerID)
+		if err != nil {
+			return errors.InvalidRequest(fmt.Errorf("invalid reviewer ID"))
+		}
+		reviewerIDPtr = &reviewerID
+	}
+	if req.StartTime != nil {
+		startTime, err = time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid start time %s (should be in RFC3339 format)", *req.StartTime),
+			)
+		}
+		startTimePtr = &startTime
+	}
+	if req.EndTime != nil {
+		endTime, err = time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid end time %s (should be in RFC3339 format)", *req.EndTime),
+			)
+		}
+		endTimePtr = &endTime
+	}
+
+	resp, err := r.ReAuditService.GetReAuditList(
+		c.UserContext(), req.Page, req.PageSize, req.Desc, reviewerIDPtr, req.Theme, req.Status, startTimePtr,
+		endTimePtr,
+	)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// SubmitReAudit submits the outcome of a re-audit.
+//
+//	@description	Confirm or overturn the approval of a record sampled for re-audit.
+//	@id				admin-submit-re-audit
+//	@summary		submit re-audit
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.SubmitReAuditRequest	body	admin.SubmitReAuditRequest	true	"Submit re-audit request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404				{object}	vo.Response{data=nil}	"Re-audit not found"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/re-audit	[put]
+func (r *ReAuditApi) SubmitReAudit(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.SubmitReAuditRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := r.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	reAuditID, err := primitive.ObjectIDFromHex(*req.ReAuditID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid re-audit ID"))
+	}
+	err = r.ReAuditService.SubmitReAudit(ctx, &reAuditID, req.Status, req.Message)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeReAudit
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Submit re-audit failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = r.LogsService.CacheOperationLog(
+			ctx, &userID, &reAuditID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Submit re-audit: %s (%s)", *req.ReAuditID, *req.Status)
+		status      = config.OperationStatusSuccess
+	)
+	_ = r.LogsService.CacheOperationLog(
+		ctx, &userID, &reAuditID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// GetReAuditStatistic returns the estimated error rate of the reviewers.
+//
+//	@description	Get the estimated error rate per reviewer and theme, i.e. the share of re-audited approvals that were overturned.
+//	@id				admin-get-re-audit-statistic
+//	@summary		get re-audit statistic
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetReAuditStatisticRequest	query	admin.GetReAuditStatisticRequest	true	"Get re-audit statistic request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.GetReAuditStatisticResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/re-audit/statistic	[get]
+func (r *ReAuditApi) GetReAuditStatistic(c *fiber.Ctx) error {
+	req := new(admin.GetReAuditStatisticRequest)
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := r.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	var (
+		startTime, endTime       time.Time
+		startTimePtr, endTimePtr *time.Time
+		err                      error
+	)
+	if req.StartTime != nil {
+		startTime, err = time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid start time %s (should be in RFC3339 format)", *req.StartTime),
+			)
+		}
+		startTimePtr = &startTime
+	}
+	if req.EndTime != nil {
+		endTime, err = time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid end time %s (should be in RFC3339 format)", *req.EndTime),
+			)
+		}
+		endTimePtr = &endTime
+	}
+
+	resp, err := r.ReAuditService.GetReAuditStatistic(c.UserContext(), req.Theme, startTimePtr, endTimePtr)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
@@ -199,3 +199,65 @@ func (s *StatisticApi) GetUserStatisticList(c *fiber.Ctx) error {
 		},
 	)
 }
+
+// GetReviewAgreement returns the inter-annotator agreement of the reviewers.
+//
+//	@description	Get the agreement between reviewers on records reviewed by more than one of them, overall and per theme, with Fleiss' kappa and the Cohen's kappa of every pair of reviewers.
+//	@id				admin-get-review-agreement
+//	@summary		get review agreement
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetReviewAgreementRequest	query	admin.GetReviewAgreementRequest	true	"Get review agreement request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=admin.GetReviewAgreementResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/review-agreement	[get]
+func (s *StatisticApi) GetReviewAgreement(c *fiber.Ctx) error {
+	req := new(admin.GetReviewAgreementRequest)
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := s.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	var (
+		startTime, endTime       time.Time
+		startTimePtr, endTimePtr *time.Time
+		err                      error
+	)
+	if req.StartTime != nil {
+		startTime, err = time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid start time %s (should be in RFC3339 format)", *req.StartTime),
+			)
+		}
+		startTimePtr = &startTime
+	}
+	if req.EndTime != nil {
+		endTime, err = time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid end time %s (should be in RFC3339 format)", *req.EndTime),
+			)
+		}
+		endTimePtr = &endTime
+	}
+
+	resp, err := s.StatisticService.GetReviewAgreement(c.UserContext(), req.Theme, startTimePtr, endTimePtr)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
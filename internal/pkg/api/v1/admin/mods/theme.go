@@ -0,0 +1,238 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ThemeApi struct {
+	ThemeService adminservice.ThemeService
+	LogsService  sysservice.LogsService
+	Validator    *validator.Validate
+}
+
+// InsertTheme inserts a new theme with its metadata template.
+//
+//	@description	Insert a new theme. The schema is a JSON Schema (draft 2020-12) describing the metadata fields of the theme.
+//	@id				admin-insert-theme
+//	@summary		insert theme
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.InsertThemeRequest	body	admin.InsertThemeRequest	true	"Insert theme request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=string}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}		"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}		"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}		"Forbidden"
+//	@failure		409				{object}	vo.Response{data=nil}		"Duplicate theme name"
+//	@failure		500				{object}	vo.Response{data=nil}		"Internal server error"
+//	@router			/admin/theme	[post]
+func (t *ThemeApi) InsertTheme(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.InsertThemeRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	schemaJSON, err := json.Marshal(req.Schema)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid schema"))
+	}
+	schema := string(schemaJSON)
+	themeIDHex, err := t.ThemeService.InsertTheme(ctx, req.Name, req.Description, &schema)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypeTheme
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert theme failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		themeID, _  = primitive.ObjectIDFromHex(themeIDHex)
+		description = fmt.Sprintf("Insert theme: %s", themeIDHex)
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &themeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    themeIDHex,
+		},
+	)
+}
+
+// UpdateTheme updates the theme.
+//
+//	@description	Update the theme. A new schema only applies to submissions and updates made afterwards. Records refer to their theme by name, so the name cannot be changed.
+//	@id				admin-update-theme
+//	@summary		update theme
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.UpdateThemeRequest	body	admin.UpdateThemeRequest	true	"Update theme request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404				{object}	vo.Response{data=nil}	"Theme not found"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/theme	[put]
+func (t *ThemeApi) UpdateTheme(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.UpdateThemeRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	themeID, err := primitive.ObjectIDFromHex(*req.ThemeID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid theme id"))
+	}
+	var schema *string
+	if req.Schema != nil {
+		schemaJSON, err := json.Marshal(req.Schema)
+		if err != nil {
+			return errors.InvalidRequest(fmt.Errorf("invalid schema"))
+		}
+		s := string(schemaJSON)
+		schema = &s
+	}
+	err = t.ThemeService.UpdateTheme(ctx, &themeID, req.Name, req.Description, schema)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeTheme
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Update theme failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, &themeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Update theme: %s", *req.ThemeID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &themeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteTheme deletes the theme.
+//
+//	@description	Delete the theme.
+//	@id				admin-delete-theme
+//	@summary		delete theme
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteThemeRequest	query	admin.DeleteThemeRequest	true	"Delete theme request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403				{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404				{object}	vo.Response{data=nil}	"Theme not found"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/theme	[delete]
+func (t *ThemeApi) DeleteTheme(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteThemeRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	themeID, err := primitive.ObjectIDFromHex(*req.ThemeID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid theme id"))
+	}
+
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeTheme
+	)
+	err = t.ThemeService.DeleteTheme(ctx, &themeID)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete theme failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, &themeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete theme: %s", *req.ThemeID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &themeID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -0,0 +1,112 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	adminservice "data-collection-hub-server/internal/pkg/service/admin/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TwoFactorPolicyApi struct {
+	TwoFactorPolicyService adminservice.TwoFactorPolicyService
+	LogsService            sysservice.LogsService
+	Validator              *validator.Validate
+}
+
+// GetTwoFactorPolicyList returns the two-factor policy of every role.
+//
+//	@description	Get whether two-factor authentication is enforced for each role.
+//	@id				admin-get-two-factor-policy-list
+//	@summary		get two-factor policy list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=admin.GetTwoFactorPolicyListResponse}	"Success"
+//	@failure		401								{object}	vo.Response{data=nil}									"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}									"Forbidden"
+//	@failure		500								{object}	vo.Response{data=nil}									"Internal server error"
+//	@router			/admin/two-factor-policy/list	[get]
+func (t *TwoFactorPolicyApi) GetTwoFactorPolicyList(c *fiber.Ctx) error {
+	resp, err := t.TwoFactorPolicyService.GetTwoFactorPolicyList(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// UpdateTwoFactorPolicy enforces or relaxes two-factor authentication for a role.
+//
+//	@description	Set whether two-factor authentication is enforced for a role. Users of an enforced role have to enroll on their next login and can not disable it.
+//	@id				admin-update-two-factor-policy
+//	@summary		update two-factor policy
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.UpdateTwoFactorPolicyRequest	body	admin.UpdateTwoFactorPolicyRequest	true	"Update two-factor policy request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=nil}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		500							{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/two-factor-policy	[put]
+func (t *TwoFactorPolicyApi) UpdateTwoFactorPolicy(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.UpdateTwoFactorPolicyRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	err := t.TwoFactorPolicyService.UpdateTwoFactorPolicy(ctx, req.Role, req.Enforced)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeTwoFactorPolicy
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Update two-factor policy failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Update two-factor policy: %s (enforced: %t)", *req.Role, *req.Enforced)
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
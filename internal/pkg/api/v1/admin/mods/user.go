@@ -1,7 +1,11 @@
 package mods
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
@@ -24,7 +28,7 @@ type UserApi struct {
 
 // InsertUser inserts a new user.
 //
-//	@description	Insert a new user.
+//	@description	Insert a new user. The password must follow the password policy.
 //	@id				admin-insert-user
 //	@summary		insert user
 //	@tags			Admin API
@@ -135,7 +139,7 @@ func (u *UserApi) GetUser(c *fiber.Ctx) error {
 
 // GetUserList returns the list of users based on the query parameters.
 //
-//	@description	Get the list of users based on the query parameters.
+//	@description	Get the list of users based on the query parameters. With suspended, only the users which are, or are not, suspended are listed.
 //	@id				admin-get-user-list
 //	@summary		get user list
 //	@tags			Admin API
@@ -209,8 +213,8 @@ func (u *UserApi) GetUserList(c *fiber.Ctx) error {
 	}
 
 	resp, err := u.UserService.GetUserList(
-		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Role, lastLoginStartTimePtr, lastLoginEndTimePtr,
-		createdStartTimePtr, createdEndTimePtr, req.Query,
+		c.UserContext(), req.Page, req.PageSize, req.Desc, req.Role, req.Suspended, lastLoginStartTimePtr,
+		lastLoginEndTimePtr, createdStartTimePtr, createdEndTimePtr, req.Query,
 	)
 	if err != nil {
 		return err
@@ -227,7 +231,7 @@ func (u *UserApi) GetUserList(c *fiber.Ctx) error {
 
 // UpdateUser updates the user.
 //
-//	@description	Update the user.
+//	@description	Update the user. A user losing the ADMIN role has all of its tokens revoked and has to log in again.
 //	@id				admin-update-user
 //	@summary		update user
 //	@tags			Admin API
@@ -257,7 +261,7 @@ func (u *UserApi) UpdateUser(c *fiber.Ctx) error {
 		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
 	}
 
-	err = u.UserService.UpdateUser(ctx, &userID, req.Username, req.Email, req.Organization)
+	err = u.UserService.UpdateUser(ctx, &userID, req.Username, req.Email, req.Role, req.Organization)
 
 	var (
 		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
@@ -296,7 +300,7 @@ func (u *UserApi) UpdateUser(c *fiber.Ctx) error {
 
 // DeleteUser deletes a user by user ID.
 //
-//	@description	Delete the user by ID.
+//	@description	Delete the user by ID. The user and its instruction data are soft deleted and its roles removed, so that it can be restored until it is purged after the retention period.
 //	@id				admin-delete-user
 //	@summary		delete user by ID
 //	@tags			Admin API
@@ -364,7 +368,7 @@ func (u *UserApi) DeleteUser(c *fiber.Ctx) error {
 
 // ChangeUserPassword changes a user's password.
 //
-//	@description	Change the user's password.
+//	@description	Change the user's password. The new password must follow the password policy and differ from the recent passwords, and all tokens of the user are revoked.
 //	@id				admin-change-user-password
 //	@summary		change user password
 //	@tags			Admin API
@@ -405,3 +409,792 @@ func (u *UserApi) ChangeUserPassword(c *fiber.Ctx) error {
 		},
 	)
 }
+
+// DeleteUserSessionList force-logs out a user.
+//
+//	@description	Revoke all sessions of the user, logging it out of all devices. Its access and refresh tokens stop working at once.
+//	@id				admin-delete-user-session-list
+//	@summary		delete user session list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.DeleteUserSessionListRequest	query	admin.DeleteUserSessionListRequest	true	"Delete user session list request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=nil}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404							{object}	vo.Response{data=nil}	"User not found"
+//	@failure		500							{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/user/session/list	[delete]
+func (u *UserApi) DeleteUserSessionList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.DeleteUserSessionListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	count, err := u.UserService.DeleteUserSessionList(ctx, &userID)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeDelete
+		entityType    = config.EntityTypeSession
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to force logout user %s", *req.UserID)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Force logout user %s: %d sessions", *req.UserID, count)
+		status      = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// SuspendUser suspends a user.
+//
+//	@description	Suspend the user, until expires_at if given, indefinitely otherwise. A suspended user cannot log in, all of its tokens are revoked and its access tokens are refused. The data of the user is kept, and counts in the statistics.
+//	@id				admin-suspend-user
+//	@summary		suspend user
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.SuspendUserRequest	body	admin.SuspendUserRequest	true	"Suspend user request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=nil}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}	"User not found"
+//	@failure		500					{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/user/suspend	[put]
+func (u *UserApi) SuspendUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.SuspendUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	var (
+		expiresAt    time.Time
+		expiresAtPtr *time.Time
+		until        = "indefinitely"
+	)
+	if req.ExpiresAt != nil {
+		expiresAt, err = time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			return errors.InvalidRequest(
+				fmt.Errorf("invalid expiry time %s (should be in RFC3339 format)", *req.ExpiresAt),
+			)
+		}
+		expiresAtPtr = &expiresAt
+		until = fmt.Sprintf("until %s", *req.ExpiresAt)
+	}
+	err = u.UserService.SuspendUser(ctx, &userID, req.Reason, expiresAtPtr)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeUpdate
+		entityType    = config.EntityTypeUser
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to suspend user %s", *req.UserID)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Suspend user %s %s: %s", *req.UserID, until, *req.Reason)
+		status      = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// ReactivateUser lifts the suspension of a user.
+//
+//	@description	Lift the suspension of the user, who can log in again.
+//	@id				admin-reactivate-user
+//	@summary		reactivate user
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.ReactivateUserRequest	body	admin.ReactivateUserRequest	true	"Reactivate user request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404						{object}	vo.Response{data=nil}	"User not found"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/user/reactivate	[put]
+func (u *UserApi) ReactivateUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.ReactivateUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	err = u.UserService.ReactivateUser(ctx, &userID)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeUpdate
+		entityType    = config.EntityTypeUser
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to reactivate user %s", *req.UserID)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Reactivate user %s", *req.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// ExportUserData downloads the personal data of a user.
+//
+//	@description	Download a zip archive of the personal data held about the user, to answer its data access request: its profile, instruction data, login logs and operation logs, each as a JSON file.
+//	@id				admin-export-user-data
+//	@summary		export user data
+//	@tags			Admin API
+//	@accept			json
+//	@produce		application/zip
+//	@param			admin.ExportUserDataRequest	query	admin.ExportUserDataRequest	true	"Export user data request"
+//	@security		Bearer
+//	@success		200					{file}		file					"Personal data archive"
+//	@failure		400					{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}	"User not found"
+//	@failure		500					{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/user/export	[get]
+func (u *UserApi) ExportUserData(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.ExportUserDataRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	data, err := u.UserService.ExportUserData(ctx, &userID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeExport
+		entityType    = config.EntityTypeUser
+		description   = fmt.Sprintf("Export personal data of user %s", *req.UserID)
+		status        = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(
+		fiber.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%s", fmt.Sprintf("user_data_%s.zip", *req.UserID)),
+	)
+	return c.Send(data)
+}
+
+// EraseUser erases a user and its personal data.
+//
+//	@description	Erase the user, when it leaves and asks for the deletion of its data. The user is deleted, and the copies of its username and email in the login and operation logs are anonymised. Its approved instruction data is kept under a pseudonymous ID which nothing links back to the user, and its other instruction data is deleted. Erasure cannot be undone.
+//	@id				admin-erase-user
+//	@summary		erase user
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.EraseUserRequest	body	admin.EraseUserRequest	true	"Erase user request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.EraseUserResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}						"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}						"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}						"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}						"User not found"
+//	@failure		500					{object}	vo.Response{data=nil}						"Internal server error"
+//	@router			/admin/user/erase	[post]
+func (u *UserApi) EraseUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.EraseUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	resp, err := u.UserService.EraseUser(ctx, &userID)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeDelete
+		entityType    = config.EntityTypeUser
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to erase user %s", *req.UserID)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf(
+			"Erase user %s: %d instruction data kept under a pseudonym, %d deleted",
+			*req.UserID, resp.InstructionDataKept, resp.InstructionDataDeleted,
+		)
+		status = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetDeletedUserList returns the list of deleted users.
+//
+//	@description	Get the list of the deleted users, sorted by deletion time, with the time each one is purged at unless it is restored.
+//	@id				admin-get-deleted-user-list
+//	@summary		get deleted user list
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.GetDeletedUserListRequest	query	admin.GetDeletedUserListRequest	true	"Get deleted user list request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=admin.GetDeletedUserListResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403						{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/user/deleted/list	[get]
+func (u *UserApi) GetDeletedUserList(c *fiber.Ctx) error {
+	req := new(admin.GetDeletedUserListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	resp, err := u.UserService.GetDeletedUserList(c.UserContext(), req.Page, req.PageSize, req.Desc)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// RestoreUser restores a deleted user.
+//
+//	@description	Restore a deleted user which is not purged yet. Its roles, including its organization admin roles, are granted back, and the instruction data deleted along with it is visible again.
+//	@id				admin-restore-user
+//	@summary		restore user
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.RestoreUserRequest	body	admin.RestoreUserRequest	true	"Restore user request"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.RestoreUserResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		404					{object}	vo.Response{data=nil}							"Deleted user not found"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/user/restore	[put]
+func (u *UserApi) RestoreUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.RestoreUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	resp, err := u.UserService.RestoreUser(ctx, &userID)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeUpdate
+		entityType    = config.EntityTypeUser
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to restore user %s", *req.UserID)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf(
+			"Restore user %s: %d instruction data restored", *req.UserID, resp.InstructionDataRestored,
+		)
+		status = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// PurgeUserList purges the users deleted for longer than the retention period.
+//
+//	@description	Permanently delete the users deleted for longer than the retention period, which the scheduled purge would delete next. Their instruction data is deleted, reassigned to the configured user, or anonymised, following the purge policy, and their login and operation logs are anonymised. Purging cannot be undone.
+//	@id				admin-purge-user-list
+//	@summary		purge deleted users
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=admin.PurgeUserListResponse}	"Success"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}							"Forbidden"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/admin/user/purge	[post]
+func (u *UserApi) PurgeUserList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	resp, err := u.UserService.PurgeUserList(ctx)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeDelete
+		entityType    = config.EntityTypeUser
+	)
+
+	if err != nil {
+		var (
+			description = "Failed to purge deleted users"
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Purge deleted users: %d purged (policy: %s)", resp.Total, resp.Policy)
+		status      = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// maxImportUserRows is the maximum number of users of an import.
+const maxImportUserRows = 1000
+
+// importUserColumns are the columns of the CSV file of an import, in any order.
+var importUserColumns = []string{"username", "email", "role", "organization"}
+
+// ImpersonateUser issues a token to act as a user.
+//
+//	@description	Issue a short-lived access token of the user to the admin, to see what the user sees. The token is read-only unless read_only is false, and cannot change the credentials of the user. Admins cannot be impersonated. Every request made with the token is recorded in the operation log with both the admin and the user.
+//	@id				admin-impersonate-user
+//	@summary		impersonate user
+//	@tags			Admin API
+//	@accept			json
+//	@produce		json
+//	@param			admin.ImpersonateUserRequest	body	admin.ImpersonateUserRequest	true	"Impersonate user request"
+//	@security		Bearer
+//	@success		200							{object}	vo.Response{data=admin.ImpersonateUserResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}								"Forbidden"
+//	@failure		404							{object}	vo.Response{data=nil}								"User not found"
+//	@failure		500							{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/admin/user/impersonate	[post]
+func (u *UserApi) ImpersonateUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.ImpersonateUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userID, err := primitive.ObjectIDFromHex(*req.UserID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid user id"))
+	}
+	readOnly := req.ReadOnly == nil || *req.ReadOnly
+	resp, err := u.UserService.ImpersonateUser(ctx, &userID, readOnly)
+
+	var (
+		operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr        = c.IP()
+		userAgent     = c.Get(fiber.HeaderUserAgent)
+		operation     = config.OperationTypeCreate
+		entityType    = config.EntityTypeImpersonation
+		mode          = "read-only"
+	)
+	if !readOnly {
+		mode = "read-write"
+	}
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Failed to impersonate user %s (%s)", *req.UserID, mode)
+			status      = config.OperationStatusFailure
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Impersonate user %s (%s) until %s", *req.UserID, mode, resp.ExpiresAt)
+		status      = config.OperationStatusSuccess
+	)
+	_ = u.LogsService.CacheOperationLog(
+		ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// ImportUser creates or updates users in bulk from a CSV file.
+//
+//	@description	Create or update users in bulk from a CSV file, uploaded as the "file" field of a multipart form,
+//	@description	with a header row naming the username, email, role and organization columns. Users are matched by
+//	@description	email: existing users are updated, others are created with a random initial password. The result is
+//	@description	a CSV file reporting the action and status of every row, with the initial passwords, which are not
+//	@description	returned anywhere else. With validateOnly, rows are only checked.
+//	@id				admin-import-user
+//	@summary		import users
+//	@tags			Admin API
+//	@accept			mpfd
+//	@produce		text/csv
+//	@param			file			formData	file	true	"CSV file of users"
+//	@param			validateOnly	formData	bool	false	"Only check the rows"
+//	@security		Bearer
+//	@success		200					{file}		file					"Result file"
+//	@failure		400					{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403					{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		500					{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/admin/user/import	[post]
+func (u *UserApi) ImportUser(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(admin.ImportUserRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := u.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+	validateOnly := req.ValidateOnly != nil && *req.ValidateOnly
+
+	rows, err := parseImportUserFile(c)
+	if err != nil {
+		return err
+	}
+
+	var (
+		results                  = make([]*admin.ImportUserResult, 0, len(rows))
+		emails                   = make(map[string]int, len(rows))
+		usernames                = make(map[string]int, len(rows))
+		created, updated, failed int
+	)
+	for i, row := range rows {
+		number := i + 2 // the header is the first row
+		result := &admin.ImportUserResult{
+			Row:          number,
+			Username:     *row.Username,
+			Email:        *row.Email,
+			Role:         *row.Role,
+			Organization: *row.Organization,
+		}
+		if errs := u.Validator.Struct(row); errs != nil {
+			err = common.FormatValidateError(errs)
+		} else if previous, ok := emails[strings.ToLower(*row.Email)]; ok {
+			err = fmt.Errorf("email %s is already in row %d", *row.Email, previous)
+		} else if previous, ok = usernames[*row.Username]; ok {
+			err = fmt.Errorf("username %s is already in row %d", *row.Username, previous)
+		} else {
+			emails[strings.ToLower(*row.Email)] = number
+			usernames[*row.Username] = number
+			var imported *admin.ImportUserResult
+			if imported, err = u.UserService.ImportUser(ctx, row, validateOnly); err == nil {
+				imported.Row = number
+				result = imported
+			}
+		}
+		if err != nil {
+			failed++
+			result.Status, result.Message = config.OperationStatusFailure, err.Error()
+		} else {
+			if result.Action == config.OperationTypeCreate {
+				created++
+			} else {
+				updated++
+			}
+			result.Status = config.OperationStatusSuccess
+		}
+		results = append(results, result)
+	}
+
+	if !validateOnly {
+		var (
+			operatorID, _ = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+			ipAddr        = c.IP()
+			userAgent     = c.Get(fiber.HeaderUserAgent)
+			operation     = config.OperationTypeCreate
+			entityType    = config.EntityTypeUser
+			description   = fmt.Sprintf(
+				"Import users: %d created, %d updated, %d failed", created, updated, failed,
+			)
+			status = config.OperationStatusSuccess
+		)
+		_ = u.LogsService.CacheOperationLog(
+			ctx, &operatorID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+	}
+
+	data, err := formatImportUserResult(results)
+	if err != nil {
+		return errors.ServiceError(fmt.Errorf("failed to write result file"))
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "no-store") // the file holds the initial passwords
+	c.Set(
+		fiber.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%s", fmt.Sprintf("user_import_%s.csv", time.Now().Format(time.RFC3339))),
+	)
+	return c.Send(data)
+}
+
+// parseImportUserFile reads the rows of the CSV file of an import.
+func parseImportUserFile(c *fiber.Ctx) ([]*admin.ImportUserRow, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("failed to validate request[field: file] is required"))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("failed to open the file"))
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("failed to parse the file: %s", err.Error()))
+	}
+	if len(records) < 2 {
+		return nil, errors.InvalidRequest(fmt.Errorf("the file has no users"))
+	}
+	if len(records)-1 > maxImportUserRows {
+		return nil, errors.InvalidRequest(fmt.Errorf("the file has more than %d users", maxImportUserRows))
+	}
+
+	// Spreadsheet applications may start the file with a byte order mark
+	indexes := make(map[string]int, len(importUserColumns))
+	for i, column := range records[0] {
+		indexes[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	for _, column := range importUserColumns {
+		if _, ok := indexes[column]; !ok {
+			return nil, errors.InvalidRequest(fmt.Errorf("the file has no %s column", column))
+		}
+	}
+	field := func(record []string, column string) *string {
+		var value string
+		if i := indexes[column]; i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+		return &value
+	}
+	rows := make([]*admin.ImportUserRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		role := strings.ToUpper(*field(record, "role"))
+		rows = append(
+			rows, &admin.ImportUserRow{
+				Username:     field(record, "username"),
+				Email:        field(record, "email"),
+				Role:         &role,
+				Organization: field(record, "organization"),
+			},
+		)
+	}
+	return rows, nil
+}
+
+// formatImportUserResult writes the result file of an import.
+func formatImportUserResult(results []*admin.ImportUserResult) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(
+		[]string{"row", "username", "email", "role", "organization", "action", "status", "message", "password"},
+	); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if err := writer.Write(
+			[]string{
+				strconv.Itoa(result.Row), result.Username, result.Email, result.Role, result.Organization,
+				result.Action, result.Status, result.Message, result.Password,
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
@@ -10,4 +10,10 @@ type Common struct {
 	DocumentationApi *mods.DocumentationApi
 	NoticeApi        *mods.NoticeApi
 	IdempotencyApi   *mods.IdempotencyApi
+	ThemeApi         *mods.ThemeApi
+	TwoFactorApi     *mods.TwoFactorApi
+	AccessTokenApi   *mods.AccessTokenApi
+	OIDCApi          *mods.OIDCApi
+	SessionApi       *mods.SessionApi
+	JWKSApi          *mods.JWKSApi
 }
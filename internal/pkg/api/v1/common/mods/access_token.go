@@ -0,0 +1,184 @@
+package mods
+
+import (
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AccessTokenApi struct {
+	AccessTokenService commonservice.AccessTokenService
+	LogsService        sysservice.LogsService
+	Validator          *validator.Validate
+}
+
+// InsertAccessToken creates a personal access token.
+//
+//	@description	Create a personal access token of the current user, to be sent as a bearer token by scripts. The token is only shown once.
+//	@id				common-insert-access-token
+//	@summary		insert access token
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.InsertAccessTokenRequest	body	common.InsertAccessTokenRequest	true	"Insert access token request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=common.InsertAccessTokenResponse}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		500				{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/access-token	[post]
+func (a *AccessTokenApi) InsertAccessToken(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.InsertAccessTokenRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := a.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+	if err != nil {
+		return errors.InvalidRequest(
+			fmt.Errorf("invalid expiry time %s (should be in RFC3339 format)", *req.ExpiresAt),
+		)
+	}
+	resp, err := a.AccessTokenService.InsertAccessToken(ctx, req.Name, req.Scopes, &expiresAt)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeCreate
+		entityType = config.EntityTypeAccessToken
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert access token failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		accessTokenID, _ = primitive.ObjectIDFromHex(resp.AccessTokenID)
+		description      = fmt.Sprintf("Insert access token: %s (%s)", resp.AccessTokenID, *req.Name)
+		status           = config.OperationStatusSuccess
+	)
+	_ = a.LogsService.CacheOperationLog(
+		ctx, &userID, &accessTokenID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetAccessTokenList returns the personal access tokens of the current user.
+//
+//	@description	Get the personal access tokens of the current user. The tokens themselves are not returned.
+//	@id				common-get-access-token-list
+//	@summary		get access token list
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=common.GetAccessTokenListResponse}	"Success"
+//	@failure		401						{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/access-token/list		[get]
+func (a *AccessTokenApi) GetAccessTokenList(c *fiber.Ctx) error {
+	resp, err := a.AccessTokenService.GetAccessTokenList(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// DeleteAccessToken revokes a personal access token.
+//
+//	@description	Revoke a personal access token of the current user.
+//	@id				common-delete-access-token
+//	@summary		delete access token
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.DeleteAccessTokenRequest	query	common.DeleteAccessTokenRequest	true	"Delete access token request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		404				{object}	vo.Response{data=nil}	"Access token not found"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/access-token	[delete]
+func (a *AccessTokenApi) DeleteAccessToken(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.DeleteAccessTokenRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := a.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	accessTokenID, err := primitive.ObjectIDFromHex(*req.AccessTokenID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid access token ID"))
+	}
+	err = a.AccessTokenService.DeleteAccessToken(ctx, &accessTokenID)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeAccessToken
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete access token failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, &accessTokenID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete access token: %s", *req.AccessTokenID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = a.LogsService.CacheOperationLog(
+		ctx, &userID, &accessTokenID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
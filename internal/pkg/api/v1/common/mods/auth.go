@@ -3,6 +3,7 @@ package mods
 import (
 	"fmt"
 
+	"data-collection-hub-server/internal/pkg/config"
 	"data-collection-hub-server/internal/pkg/domain/vo"
 	"data-collection-hub-server/internal/pkg/domain/vo/common"
 	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
@@ -23,7 +24,7 @@ type AuthApi struct {
 
 // Login logs in the user and returns a token.
 //
-//	@description	Log in the user and return a token.
+//	@description	Log in the user and return a token. If two-factor authentication is enabled for the user or enforced for the role, a challenge token is returned instead, to be completed at /auth/two-factor/verify (or /auth/two-factor/enroll and /auth/two-factor/confirm when enrollment is required). Failed logins delay and eventually lock the account and the IP address. password_expired is set when the password is older than the maximum age of the password policy, in which case the tokens only allow changing it at /change-password.
 //	@id				common-login
 //	@summary		login
 //	@tags			Auth API
@@ -33,6 +34,7 @@ type AuthApi struct {
 //	@success		200					{object}	vo.Response{data=common.LoginResponse}	"Success"
 //	@failure		400					{object}	vo.Response{data=nil}					"Invalid request"
 //	@failure		401					{object}	vo.Response{data=nil}					"Unauthorized"
+//	@failure		429					{object}	vo.Response{data=nil}					"Too many failed logins"
 //	@failure		500					{object}	vo.Response{data=nil}					"Internal server error"
 //	@router			/login																																																																																																																				[post]
 func (a *AuthApi) Login(c *fiber.Ctx) error {
@@ -46,15 +48,20 @@ func (a *AuthApi) Login(c *fiber.Ctx) error {
 		return errors.InvalidRequest(utils.FormatValidateError(errs))
 	}
 
-	resp, err := a.AuthService.Login(ctx, req.Email, req.Password)
+	ipAddr := c.IP()
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	resp, err := a.AuthService.Login(ctx, req.Email, req.Password, &ipAddr)
 	if err != nil {
+		reason := err.Error()
+		_ = a.LogsService.CacheFailedLoginLog(ctx, req.Email, &ipAddr, &userAgent, &reason)
 		return err
 	}
 
-	userID, _ := primitive.ObjectIDFromHex(resp.Meta.UserID)
-	ipAddr := c.IP()
-	userAgent := c.Get(fiber.HeaderUserAgent)
-	_ = a.LogsService.CacheLoginLog(ctx, &userID, &ipAddr, &userAgent)
+	// The login is logged once the two-factor challenge is completed
+	if !resp.TwoFactorRequired {
+		userID, _ := primitive.ObjectIDFromHex(resp.Meta.UserID)
+		_ = a.LogsService.CacheLoginLog(ctx, &userID, &ipAddr, &userAgent)
+	}
 	return c.JSON(
 		vo.Response{
 			Code:    errors.CodeSuccess,
@@ -64,6 +71,188 @@ func (a *AuthApi) Login(c *fiber.Ctx) error {
 	)
 }
 
+// Register creates a user by redeeming an invite code.
+//
+//	@description	Register a user with an invite code. The role and organization of the user are those of the code. The password must follow the password policy.
+//	@id				common-register
+//	@summary		register
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.RegisterRequest	body		common.RegisterRequest		true	"Register request"
+//	@success		200						{object}	vo.Response{data=string}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}		"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}		"Invite code invalid"
+//	@failure		409						{object}	vo.Response{data=nil}		"Duplicate email"
+//	@failure		500						{object}	vo.Response{data=nil}		"Internal server error"
+//	@router			/register				[post]
+func (a *AuthApi) Register(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.RegisterRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := a.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	userIDHex, err := a.AuthService.Register(ctx, req.InviteCode, req.Username, req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	var (
+		userID, _   = primitive.ObjectIDFromHex(userIDHex)
+		ipAddr      = c.IP()
+		userAgent   = c.Get(fiber.HeaderUserAgent)
+		operation   = config.OperationTypeCreate
+		entityType  = config.EntityTypeUser
+		description = fmt.Sprintf("Register user with invite code: %s", userIDHex)
+		status      = config.OperationStatusSuccess
+	)
+	_ = a.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    userIDHex,
+		},
+	)
+}
+
+// ForgotPassword mails a password reset token to the user.
+//
+//	@description	Mail a single-use, time-limited password reset token to the user with the email. The response is the same whether or not the email is registered.
+//	@id				common-forgot-password
+//	@summary		forgot password
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.ForgotPasswordRequest	body		common.ForgotPasswordRequest	true	"Forgot password request"
+//	@success		200								{object}	vo.Response{data=nil}			"Success"
+//	@failure		400								{object}	vo.Response{data=nil}			"Invalid request"
+//	@failure		500								{object}	vo.Response{data=nil}			"Internal server error"
+//	@router			/forgot-password				[post]
+func (a *AuthApi) ForgotPassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ForgotPasswordRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := a.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	userIDHex, err := a.AuthService.ForgotPassword(ctx, req.Email)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(userIDHex)
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Request password reset failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		// A failure past the lookup of the email is answered as a success, not to tell that the email is registered
+		if userIDHex == "" {
+			return err
+		}
+	} else if userIDHex == "" {
+		var (
+			description = fmt.Sprintf("Request password reset for unregistered email %s", *req.Email)
+			status      = config.OperationStatusFailure
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+	} else {
+		var (
+			description = fmt.Sprintf("Request password reset: %s", userIDHex)
+			status      = config.OperationStatusSuccess
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// ResetPassword sets a new password with a reset token.
+//
+//	@description	Set a new password with a password reset token. The token can only be used once. The password must follow the password policy and differ from the recent passwords, and all tokens of the user are revoked.
+//	@id				common-reset-password
+//	@summary		reset password
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.ResetPasswordRequest	body		common.ResetPasswordRequest	true	"Reset password request"
+//	@success		200							{object}	vo.Response{data=nil}		"Success"
+//	@failure		400							{object}	vo.Response{data=nil}		"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}		"Reset token invalid"
+//	@failure		500							{object}	vo.Response{data=nil}		"Internal server error"
+//	@router			/reset-password				[post]
+func (a *AuthApi) ResetPassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ResetPasswordRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := a.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	userIDHex, err := a.AuthService.ResetPassword(ctx, req.Token, req.NewPassword)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(userIDHex)
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Reset password failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = a.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Reset password: %s", userIDHex)
+		status      = config.OperationStatusSuccess
+	)
+	_ = a.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
 // Logout logs out the user.
 //
 //	@description	Log out the user.
@@ -102,7 +291,7 @@ func (a *AuthApi) Logout(c *fiber.Ctx) error {
 
 // RefreshToken refreshes the user's token.
 //
-//	@description	Refresh the user's token.
+//	@description	Refresh the user's token. The refresh token is rotated: use the returned one next time, presenting a used one again revokes the session.
 //	@id				common-refresh-token
 //	@summary		refresh token
 //	@tags			Auth API
@@ -141,7 +330,7 @@ func (a *AuthApi) RefreshToken(c *fiber.Ctx) error {
 
 // ChangePassword changes the user's password.
 //
-//	@description	Change the user's password.
+//	@description	Change the user's password. The new password must follow the password policy and differ from the recent passwords, and all tokens of the user are revoked.
 //	@id				common-change-password
 //	@summary		change password
 //	@tags			Auth API
@@ -0,0 +1,24 @@
+package mods
+
+import (
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSApi struct {
+	JWKSService commonservice.JWKSService
+}
+
+// GetJWKS returns the public keys verifying the JWTs.
+//
+//	@description	Get the public keys verifying the JWTs in JWK Set format (RFC 7517), so that other services can verify the tokens. Tokens name their key in the kid header. The response is not wrapped.
+//	@id				common-get-jwks
+//	@summary		get JSON web key set
+//	@tags			Common API
+//	@produce		json
+//	@success		200						{object}	jwt.JSONWebKeySet	"Success"
+//	@router			/.well-known/jwks.json	[get]
+func (j *JWKSApi) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(j.JWKSService.GetJWKS(c.UserContext()))
+}
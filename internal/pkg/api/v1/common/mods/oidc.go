@@ -0,0 +1,91 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OIDCApi struct {
+	OIDCService commonservice.OIDCService
+	LogsService sysservice.LogsService
+	Validator   *validator.Validate
+}
+
+// Authorize starts the single sign-on with the OpenID Connect provider.
+//
+//	@description	Start the single sign-on. The user should be sent to the returned URL of the identity provider, which redirects back to the frontend with a code and a state to be posted to /auth/oidc/callback.
+//	@id				common-oidc-authorize
+//	@summary		oidc authorize
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@success		200					{object}	vo.Response{data=common.OIDCAuthorizeResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Single sign-on not enabled"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/auth/oidc/authorize	[get]
+func (o *OIDCApi) Authorize(c *fiber.Ctx) error {
+	resp, err := o.OIDCService.Authorize(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// Callback completes the single sign-on and returns a token.
+//
+//	@description	Complete the single sign-on with the code and state the identity provider redirected back with. Users signing in for the first time are linked by email or provisioned.
+//	@id				common-oidc-callback
+//	@summary		oidc callback
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.OIDCCallbackRequest	body		common.OIDCCallbackRequest				true	"OIDC callback request"
+//	@success		200							{object}	vo.Response{data=common.LoginResponse}	"Success"
+//	@failure		400							{object}	vo.Response{data=nil}					"Invalid request"
+//	@failure		401							{object}	vo.Response{data=nil}					"Unauthorized"
+//	@failure		403							{object}	vo.Response{data=nil}					"Not allowed to sign in"
+//	@failure		500							{object}	vo.Response{data=nil}					"Internal server error"
+//	@router			/auth/oidc/callback			[post]
+func (o *OIDCApi) Callback(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.OIDCCallbackRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := o.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	resp, err := o.OIDCService.Callback(ctx, req.Code, req.State)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := primitive.ObjectIDFromHex(resp.Meta.UserID)
+	ipAddr := c.IP()
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	_ = o.LogsService.CacheLoginLog(ctx, &userID, &ipAddr, &userAgent)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
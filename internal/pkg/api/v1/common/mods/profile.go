@@ -1,14 +1,26 @@
 package mods
 
 import (
+	"fmt"
+	"io"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
 	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
 	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
 	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ProfileApi struct {
 	ProfileService commonservice.ProfileService
+	LogsService    sysservice.LogsService
+	Validator      *validator.Validate
 }
 
 // GetProfile returns the profile.
@@ -39,3 +51,289 @@ func (api *ProfileApi) GetProfile(c *fiber.Ctx) error {
 		},
 	)
 }
+
+// UpdateProfile updates the profile.
+//
+//	@description	Change the username and organization of the current user. The username must not be taken, and the members of the organizations managed as tenants are managed by their admins.
+//	@id				common-update-profile
+//	@summary		update profile
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.UpdateProfileRequest	body	common.UpdateProfileRequest	true	"Update profile request"
+//	@security		Bearer
+//	@success		200			{object}	vo.Response{data=nil}	"Success"
+//	@failure		400			{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401			{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403			{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		500			{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/profile	[put]
+func (api *ProfileApi) UpdateProfile(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.UpdateProfileRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := api.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	err := api.ProfileService.UpdateProfile(ctx, req.Username, req.Organization)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Update profile failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = api.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Update profile: %s", profileChanges(req))
+		status      = config.OperationStatusSuccess
+	)
+	_ = api.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// ChangeEmail requests an email change.
+//
+//	@description	Mail a confirmation link to the new email of the current user. The email is changed once the link is confirmed.
+//	@id				common-change-email
+//	@summary		change email
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.ChangeEmailRequest	body	common.ChangeEmailRequest	true	"Change email request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/profile/email	[post]
+func (api *ProfileApi) ChangeEmail(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ChangeEmailRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := api.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	if err := api.ProfileService.ChangeEmail(ctx, req.Email); err != nil {
+		return err
+	}
+
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// ConfirmEmail confirms an email change.
+//
+//	@description	Change the email of the current user to the one the confirmation token was mailed to. The previous email is notified.
+//	@id				common-confirm-email
+//	@summary		confirm email
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.ConfirmEmailRequest	body	common.ConfirmEmailRequest	true	"Confirm email request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/profile/email/confirm	[post]
+func (api *ProfileApi) ConfirmEmail(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ConfirmEmailRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := api.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	previousEmail, err := api.ProfileService.ConfirmEmail(ctx, req.Token)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Change email failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = api.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Change email from %s", previousEmail)
+		status      = config.OperationStatusSuccess
+	)
+	_ = api.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// UpdateAvatar uploads the avatar.
+//
+//	@description	Upload a PNG, JPEG, GIF or WebP image as the avatar of the current user, replacing the previous one.
+//	@id				common-update-avatar
+//	@summary		update avatar
+//	@tags			Common API
+//	@accept			multipart/form-data
+//	@produce		json
+//	@param			avatar	formData	file	true	"Avatar image"
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=common.UpdateAvatarResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401					{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		500					{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/profile/avatar		[put]
+func (api *ProfileApi) UpdateAvatar(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to validate request[field: avatar] is required"))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to open the file"))
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to read the file"))
+	}
+
+	resp, err := api.ProfileService.UpdateAvatar(ctx, data)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Update avatar failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = api.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Update avatar: %s", resp.Avatar)
+		status      = config.OperationStatusSuccess
+	)
+	_ = api.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// ExportPersonalData downloads the personal data of the current user.
+//
+//	@description	Download a zip archive of the personal data held about the current user: its profile, instruction data, login logs and operation logs, each as a JSON file.
+//	@id				common-export-personal-data
+//	@summary		export personal data
+//	@tags			Common API
+//	@accept			json
+//	@produce		application/zip
+//	@security		Bearer
+//	@success		200					{file}		file					"Personal data archive"
+//	@failure		401					{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		500					{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/profile/export		[get]
+func (api *ProfileApi) ExportPersonalData(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	data, err := api.ProfileService.ExportPersonalData(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		userID, _   = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr      = c.IP()
+		userAgent   = c.Get(fiber.HeaderUserAgent)
+		operation   = config.OperationTypeExport
+		entityType  = config.EntityTypeUser
+		description = "Export personal data"
+		status      = config.OperationStatusSuccess
+	)
+	_ = api.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(
+		fiber.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%s", fmt.Sprintf("personal_data_%s.zip", time.Now().Format(time.RFC3339))),
+	)
+	return c.Send(data)
+}
+
+// profileChanges describes the fields of the profile update for the operation log.
+func profileChanges(req *common.UpdateProfileRequest) string {
+	var changes string
+	if req.Username != nil {
+		changes += fmt.Sprintf("username=%s ", *req.Username)
+	}
+	if req.Organization != nil {
+		changes += fmt.Sprintf("organization=%s ", *req.Organization)
+	}
+	if changes == "" {
+		return "no change"
+	}
+	return changes[:len(changes)-1]
+}
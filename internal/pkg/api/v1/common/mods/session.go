@@ -0,0 +1,177 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionApi struct {
+	SessionService commonservice.SessionService
+	LogsService    sysservice.LogsService
+	Validator      *validator.Validate
+}
+
+// GetSessionList returns the devices the current user is logged in on.
+//
+//	@description	Get the active sessions of the current user, one per login, with the device IP address and user agent. The session of the request is marked current.
+//	@id				common-get-session-list
+//	@summary		get session list
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=common.GetSessionListResponse}	"Success"
+//	@failure		401				{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		500				{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/session/list	[get]
+func (s *SessionApi) GetSessionList(c *fiber.Ctx) error {
+	resp, err := s.SessionService.GetSessionList(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// DeleteSession logs out a device.
+//
+//	@description	Revoke a session of the current user. Its access and refresh tokens stop working at once.
+//	@id				common-delete-session
+//	@summary		delete session
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.DeleteSessionRequest	query	common.DeleteSessionRequest	true	"Delete session request"
+//	@security		Bearer
+//	@success		200			{object}	vo.Response{data=nil}	"Success"
+//	@failure		400			{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401			{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		404			{object}	vo.Response{data=nil}	"Session not found"
+//	@failure		500			{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/session	[delete]
+func (s *SessionApi) DeleteSession(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.DeleteSessionRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := s.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	sessionID, err := primitive.ObjectIDFromHex(*req.SessionID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid session ID"))
+	}
+	err = s.SessionService.DeleteSession(ctx, &sessionID)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeSession
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete session failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = s.LogsService.CacheOperationLog(
+			ctx, &userID, &sessionID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete session: %s", *req.SessionID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = s.LogsService.CacheOperationLog(
+		ctx, &userID, &sessionID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
+// DeleteSessionList logs out all devices.
+//
+//	@description	Revoke all sessions of the current user, or all but the current one if exceptCurrent is true.
+//	@id				common-delete-session-list
+//	@summary		delete session list
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.DeleteSessionListRequest	query	common.DeleteSessionListRequest	false	"Delete session list request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=nil}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		500				{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/session/list	[delete]
+func (s *SessionApi) DeleteSessionList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.DeleteSessionListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := s.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	exceptCurrent := req.ExceptCurrent != nil && *req.ExceptCurrent
+	count, err := s.SessionService.DeleteSessionList(ctx, exceptCurrent)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeDelete
+		entityType = config.EntityTypeSession
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Delete session list failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = s.LogsService.CacheOperationLog(
+			ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Delete session list: %d sessions", count)
+		status      = config.OperationStatusSuccess
+	)
+	_ = s.LogsService.CacheOperationLog(
+		ctx, &userID, nil, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -0,0 +1,100 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ThemeApi struct {
+	ThemeService commonservice.ThemeService
+	Validator    *validator.Validate
+}
+
+// GetTheme returns the theme and its metadata template by ID.
+//
+//	@description	Get the theme and its metadata template by ID.
+//	@id				common-get-theme
+//	@summary		get theme by ID
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.GetThemeRequest	query	common.GetThemeRequest	true	"Get theme request"
+//	@security		Bearer
+//	@success		200		{object}	vo.Response{data=common.GetThemeResponse}	"Success"
+//	@failure		400		{object}	vo.Response{data=nil}						"Invalid request"
+//	@failure		401		{object}	vo.Response{data=nil}						"Unauthorized"
+//	@failure		404		{object}	vo.Response{data=nil}						"Theme not found"
+//	@failure		500		{object}	vo.Response{data=nil}						"Internal server error"
+//	@router			/theme	[get]
+func (t *ThemeApi) GetTheme(c *fiber.Ctx) error {
+	req := new(common.GetThemeRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	themeID, err := primitive.ObjectIDFromHex(*req.ThemeID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid theme id"))
+	}
+	resp, err := t.ThemeService.GetTheme(c.UserContext(), &themeID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// GetThemeList returns the theme list.
+//
+//	@description	Get the theme list.
+//	@id				common-get-theme-list
+//	@summary		get theme list
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.GetThemeListRequest	query	common.GetThemeListRequest	true	"Get theme list request"
+//	@security		Bearer
+//	@success		200				{object}	vo.Response{data=common.GetThemeListResponse}	"Success"
+//	@failure		400				{object}	vo.Response{data=nil}							"Invalid request"
+//	@failure		401				{object}	vo.Response{data=nil}							"Unauthorized"
+//	@failure		500				{object}	vo.Response{data=nil}							"Internal server error"
+//	@router			/theme/list		[get]
+func (t *ThemeApi) GetThemeList(c *fiber.Ctx) error {
+	req := new(common.GetThemeListRequest)
+
+	if err := c.QueryParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	resp, err := t.ThemeService.GetThemeList(c.UserContext(), req.Page, req.PageSize, req.Query)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
@@ -0,0 +1,319 @@
+package mods
+
+import (
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	commonservice "data-collection-hub-server/internal/pkg/service/common/mods"
+	sysservice "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	utils "data-collection-hub-server/pkg/utils/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TwoFactorApi struct {
+	TwoFactorService commonservice.TwoFactorService
+	LogsService      sysservice.LogsService
+	Validator        *validator.Validate
+}
+
+// VerifyTwoFactor completes a login with a TOTP code or a recovery code.
+//
+//	@description	Complete the login challenge with a TOTP code or a one-time recovery code and return the tokens.
+//	@id				common-verify-two-factor
+//	@summary		verify two-factor
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.VerifyTwoFactorRequest	body		common.VerifyTwoFactorRequest			true	"Verify two-factor request"
+//	@success		200								{object}	vo.Response{data=common.LoginResponse}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}					"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}					"Challenge or code invalid"
+//	@failure		500								{object}	vo.Response{data=nil}					"Internal server error"
+//	@router			/auth/two-factor/verify			[post]
+func (t *TwoFactorApi) VerifyTwoFactor(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.VerifyTwoFactorRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	resp, err := t.TwoFactorService.Verify(ctx, req.ChallengeToken, req.Code, req.RecoveryCode)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := primitive.ObjectIDFromHex(resp.Meta.UserID)
+	ipAddr := c.IP()
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	_ = t.LogsService.CacheLoginLog(ctx, &userID, &ipAddr, &userAgent)
+	if req.RecoveryCode != nil {
+		var (
+			operation   = config.OperationTypeUpdate
+			entityType  = config.EntityTypeUser
+			description = fmt.Sprintf("Log in with recovery code: %s", resp.Meta.UserID)
+			status      = config.OperationStatusSuccess
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// EnrollTwoFactorWithChallenge starts the enrollment required to complete a login.
+//
+//	@description	Start the two-factor enrollment of a user who has to enroll to complete the login challenge. Returns the TOTP secret and its provisioning URI, to be shown as a QR code.
+//	@id				common-enroll-two-factor-with-challenge
+//	@summary		enroll two-factor with challenge
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.TwoFactorChallengeRequest	body		common.TwoFactorChallengeRequest					true	"Two-factor challenge request"
+//	@success		200									{object}	vo.Response{data=common.EnrollTwoFactorResponse}	"Success"
+//	@failure		400									{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401									{object}	vo.Response{data=nil}								"Challenge invalid"
+//	@failure		500									{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/auth/two-factor/enroll				[post]
+func (t *TwoFactorApi) EnrollTwoFactorWithChallenge(c *fiber.Ctx) error {
+	req := new(common.TwoFactorChallengeRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	resp, err := t.TwoFactorService.Enroll(c.UserContext(), req.ChallengeToken)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// ConfirmTwoFactorWithChallenge confirms the enrollment and completes the login.
+//
+//	@description	Confirm the pending enrollment with a TOTP code, complete the login challenge and return the tokens with the one-time recovery codes.
+//	@id				common-confirm-two-factor-with-challenge
+//	@summary		confirm two-factor with challenge
+//	@tags			Auth API
+//	@accept			json
+//	@produce		json
+//	@param			common.ConfirmTwoFactorRequest	body		common.ConfirmTwoFactorRequest						true	"Confirm two-factor request"
+//	@success		200								{object}	vo.Response{data=common.ConfirmTwoFactorResponse}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}								"Challenge or code invalid"
+//	@failure		500								{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/auth/two-factor/confirm		[post]
+func (t *TwoFactorApi) ConfirmTwoFactorWithChallenge(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ConfirmTwoFactorRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+	if req.ChallengeToken == nil {
+		return errors.InvalidRequest(fmt.Errorf("challenge token is required"))
+	}
+
+	resp, err := t.TwoFactorService.Confirm(ctx, req.ChallengeToken, req.Code)
+	if err != nil {
+		return err
+	}
+
+	var (
+		userID, _   = primitive.ObjectIDFromHex(resp.Login.Meta.UserID)
+		ipAddr      = c.IP()
+		userAgent   = c.Get(fiber.HeaderUserAgent)
+		operation   = config.OperationTypeUpdate
+		entityType  = config.EntityTypeUser
+		description = fmt.Sprintf("Enable two-factor authentication: %s", resp.Login.Meta.UserID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	_ = t.LogsService.CacheLoginLog(ctx, &userID, &ipAddr, &userAgent)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// EnrollTwoFactor starts the two-factor enrollment of the current user.
+//
+//	@description	Start the two-factor enrollment of the current user. Returns the TOTP secret and its provisioning URI, to be shown as a QR code.
+//	@id				common-enroll-two-factor
+//	@summary		enroll two-factor
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@security		Bearer
+//	@success		200					{object}	vo.Response{data=common.EnrollTwoFactorResponse}	"Success"
+//	@failure		400					{object}	vo.Response{data=nil}								"Already enabled"
+//	@failure		401					{object}	vo.Response{data=nil}								"Unauthorized"
+//	@failure		500					{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/two-factor/enroll	[post]
+func (t *TwoFactorApi) EnrollTwoFactor(c *fiber.Ctx) error {
+	resp, err := t.TwoFactorService.Enroll(c.UserContext(), nil)
+	if err != nil {
+		return err
+	}
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// ConfirmTwoFactor enables two-factor authentication for the current user.
+//
+//	@description	Confirm the pending enrollment of the current user with a TOTP code. Returns the one-time recovery codes, which are only shown once.
+//	@id				common-confirm-two-factor
+//	@summary		confirm two-factor
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.ConfirmTwoFactorRequest	body	common.ConfirmTwoFactorRequest	true	"Confirm two-factor request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=common.ConfirmTwoFactorResponse}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}								"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}								"Code wrong"
+//	@failure		500						{object}	vo.Response{data=nil}								"Internal server error"
+//	@router			/two-factor/confirm		[post]
+func (t *TwoFactorApi) ConfirmTwoFactor(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.ConfirmTwoFactorRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	resp, err := t.TwoFactorService.Confirm(ctx, nil, req.Code)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Enable two-factor authentication failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Enable two-factor authentication: %s", userID.Hex())
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    resp,
+		},
+	)
+}
+
+// DisableTwoFactor disables two-factor authentication for the current user.
+//
+//	@description	Disable two-factor authentication of the current user with the password and a TOTP code. Not allowed when two-factor authentication is enforced for the role of the user.
+//	@id				common-disable-two-factor
+//	@summary		disable two-factor
+//	@tags			Common API
+//	@accept			json
+//	@produce		json
+//	@param			common.DisableTwoFactorRequest	body	common.DisableTwoFactorRequest	true	"Disable two-factor request"
+//	@security		Bearer
+//	@success		200						{object}	vo.Response{data=nil}	"Success"
+//	@failure		400						{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401						{object}	vo.Response{data=nil}	"Password or code wrong"
+//	@failure		403						{object}	vo.Response{data=nil}	"Enforced for the role"
+//	@failure		500						{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/two-factor/disable		[post]
+func (t *TwoFactorApi) DisableTwoFactor(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(common.DisableTwoFactorRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := t.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(utils.FormatValidateError(errs))
+	}
+
+	err := t.TwoFactorService.Disable(ctx, req.Password, req.Code)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeUser
+	)
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Disable two-factor authentication failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = t.LogsService.CacheOperationLog(
+			ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Disable two-factor authentication: %s", userID.Hex())
+		status      = config.OperationStatusSuccess
+	)
+	_ = t.LogsService.CacheOperationLog(
+		ctx, &userID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
@@ -59,7 +59,8 @@ func (d *DatasetApi) InsertInstructionData(c *fiber.Ctx) error {
 	}
 
 	instructionDataIDHex, err := d.DatasetService.InsertInstructionData(
-		ctx, req.Instruction, req.Input, req.Output, req.Theme, req.Source, req.Note,
+		ctx, req.Instruction, req.Input, req.Output, req.Theme, req.Source, req.Note, req.Metadata,
+		req.SourceType, req.SourceURL, req.License, req.RightsAttested,
 	)
 	var (
 		entityID, _ = primitive.ObjectIDFromHex(instructionDataIDHex)
@@ -97,6 +98,151 @@ func (d *DatasetApi) InsertInstructionData(c *fiber.Ctx) error {
 	)
 }
 
+// InsertInstructionDataDraft saves the instruction data as a draft.
+//
+//	@description	Save the instruction data as a draft. Drafts are not visible to the reviewers until submitted.
+//	@id				user-insert-instruction-data-draft
+//	@summary		insert instruction data draft
+//	@tags			User API
+//	@accept			json
+//	@produce		json
+//	@param			user.InsertInstructionDataDraftRequest	body	user.InsertInstructionDataDraftRequest	true	"Insert instruction data draft request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=string}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/user/instruction-data/draft	[post]
+func (d *DatasetApi) InsertInstructionDataDraft(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(user.InsertInstructionDataDraftRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := d.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+
+	instructionDataIDHex, err := d.DatasetService.InsertInstructionDataDraft(
+		ctx, req.Instruction, req.Input, req.Output, req.Theme, req.Source, req.Note, req.Metadata,
+		req.SourceType, req.SourceURL, req.License, req.RightsAttested,
+	)
+	var (
+		entityID, _ = primitive.ObjectIDFromHex(instructionDataIDHex)
+		ipAddr      = c.IP()
+		userAgent   = c.Get(fiber.HeaderUserAgent)
+		operation   = config.OperationTypeCreate
+		entityType  = config.EntityTypeInstruction
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Insert instruction data draft failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = d.LogsService.CacheOperationLog(
+			ctx, &userID, &entityID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Insert instruction data draft: %s", instructionDataIDHex)
+		status      = config.OperationStatusSuccess
+	)
+	_ = d.LogsService.CacheOperationLog(
+		ctx, &userID, &entityID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    instructionDataIDHex,
+		},
+	)
+}
+
+// SubmitInstructionData submits the instruction data draft for review.
+//
+//	@description	Submit the instruction data draft for review.
+//	@id				user-submit-instruction-data
+//	@summary		submit instruction data draft
+//	@tags			User API
+//	@accept			json
+//	@produce		json
+//	@param			user.SubmitInstructionDataRequest	body	user.SubmitInstructionDataRequest	true	"Submit instruction data request"
+//	@security		Bearer
+//	@success		200								{object}	vo.Response{data=nil}	"Success"
+//	@failure		400								{object}	vo.Response{data=nil}	"Invalid request"
+//	@failure		401								{object}	vo.Response{data=nil}	"Unauthorized"
+//	@failure		403								{object}	vo.Response{data=nil}	"Forbidden"
+//	@failure		404								{object}	vo.Response{data=nil}	"Instruction data not found"
+//	@failure		500								{object}	vo.Response{data=nil}	"Internal server error"
+//	@router			/user/instruction-data/submit	[post]
+func (d *DatasetApi) SubmitInstructionData(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	req := new(user.SubmitInstructionDataRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to parse request"))
+	}
+	if errs := d.Validator.Struct(req); errs != nil {
+		return errors.InvalidRequest(common.FormatValidateError(errs))
+	}
+
+	instructionDataID, err := primitive.ObjectIDFromHex(*req.InstructionDataID)
+	if err != nil {
+		return errors.InvalidRequest(fmt.Errorf("invalid instruction data id"))
+	}
+
+	err = d.DatasetService.SubmitInstructionData(ctx, &instructionDataID)
+	var (
+		userID, _  = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+		ipAddr     = c.IP()
+		userAgent  = c.Get(fiber.HeaderUserAgent)
+		operation  = config.OperationTypeUpdate
+		entityType = config.EntityTypeInstruction
+	)
+
+	if err != nil {
+		var (
+			description = fmt.Sprintf("Submit instruction data failed: %s", err.Error())
+			status      = config.OperationStatusFailure
+		)
+		_ = d.LogsService.CacheOperationLog(
+			ctx, &userID, &instructionDataID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+
+	var (
+		description = fmt.Sprintf("Submit instruction data: %s", *req.InstructionDataID)
+		status      = config.OperationStatusSuccess
+	)
+	_ = d.LogsService.CacheOperationLog(
+		ctx, &userID, &instructionDataID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+	)
+	return c.JSON(
+		vo.Response{
+			Code:    errors.CodeSuccess,
+			Message: errors.MessageSuccess,
+			Data:    nil,
+		},
+	)
+}
+
 // GetInstructionData returns the instruction data.
 //
 //	@description	Get the instruction data.
@@ -237,6 +383,7 @@ func (d *DatasetApi) UpdateInstructionData(c *fiber.Ctx) error {
 
 	err = d.DatasetService.UpdateInstructionData(
 		ctx, &instructionDataID, req.Instruction, req.Input, req.Output, req.Theme, req.Source, req.Note,
+		req.Metadata, req.SourceType, req.SourceURL, req.License, req.RightsAttested,
 	)
 	var (
 		userID, _   = primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
@@ -13,17 +13,32 @@ var (
 )
 
 type Config struct {
-	BaseConfig        mods.BaseConfig        `mapstructure:"base" yaml:"base"`
-	CasbinConfig      mods.CasbinConfig      `mapstructure:"casbin" yaml:"casbin"`
-	FiberConfig       mods.FiberConfig       `mapstructure:"fiber" yaml:"fiber"`
-	JWTConfig         mods.JWTConfig         `mapstructure:"jwt" yaml:"jwt"`
-	MongoConfig       mods.MongoConfig       `mapstructure:"mongo" yaml:"mongo"`
-	PrometheusConfig  mods.PrometheusConfig  `mapstructure:"prometheus" yaml:"prometheus"`
-	MiddlewareConfig  mods.MiddlewareConfig  `mapstructure:"middleware" yaml:"middleware"`
-	CacheConfig       mods.CacheConfig       `mapstructure:"cache" yaml:"cache"`
-	TasksConfig       mods.TasksConfig       `mapstructure:"tasks" yaml:"tasks"`
-	ZapConfig         mods.ZapConfig         `mapstructure:"zap" yaml:"zap"`
-	IdempotencyConfig mods.IdempotencyConfig `mapstructure:"idempotency" yaml:"idempotency"`
+	BaseConfig            mods.BaseConfig            `mapstructure:"base" yaml:"base"`
+	CasbinConfig          mods.CasbinConfig          `mapstructure:"casbin" yaml:"casbin"`
+	FiberConfig           mods.FiberConfig           `mapstructure:"fiber" yaml:"fiber"`
+	JWTConfig             mods.JWTConfig             `mapstructure:"jwt" yaml:"jwt"`
+	MongoConfig           mods.MongoConfig           `mapstructure:"mongo" yaml:"mongo"`
+	PrometheusConfig      mods.PrometheusConfig      `mapstructure:"prometheus" yaml:"prometheus"`
+	MiddlewareConfig      mods.MiddlewareConfig      `mapstructure:"middleware" yaml:"middleware"`
+	CacheConfig           mods.CacheConfig           `mapstructure:"cache" yaml:"cache"`
+	TasksConfig           mods.TasksConfig           `mapstructure:"tasks" yaml:"tasks"`
+	ZapConfig             mods.ZapConfig             `mapstructure:"zap" yaml:"zap"`
+	IdempotencyConfig     mods.IdempotencyConfig     `mapstructure:"idempotency" yaml:"idempotency"`
+	ReviewConfig          mods.ReviewConfig          `mapstructure:"review" yaml:"review"`
+	MailerConfig          mods.MailerConfig          `mapstructure:"mailer" yaml:"mailer"`
+	PasswordResetConfig   mods.PasswordResetConfig   `mapstructure:"password_reset" yaml:"password_reset"`
+	TwoFactorConfig       mods.TwoFactorConfig       `mapstructure:"two_factor" yaml:"two_factor"`
+	AccessTokenConfig     mods.AccessTokenConfig     `mapstructure:"access_token" yaml:"access_token"`
+	OIDCConfig            mods.OIDCConfig            `mapstructure:"oidc" yaml:"oidc"`
+	AuthenticatorConfig   mods.AuthenticatorConfig   `mapstructure:"authenticator" yaml:"authenticator"`
+	LDAPConfig            mods.LDAPConfig            `mapstructure:"ldap" yaml:"ldap"`
+	LoginProtectionConfig mods.LoginProtectionConfig `mapstructure:"login_protection" yaml:"login_protection"`
+	SessionConfig         mods.SessionConfig         `mapstructure:"session" yaml:"session"`
+	PasswordPolicyConfig  mods.PasswordPolicyConfig  `mapstructure:"password_policy" yaml:"password_policy"`
+	ImpersonationConfig   mods.ImpersonationConfig   `mapstructure:"impersonation" yaml:"impersonation"`
+	StorageConfig         mods.StorageConfig         `mapstructure:"storage" yaml:"storage"`
+	ProfileConfig         mods.ProfileConfig         `mapstructure:"profile" yaml:"profile"`
+	UserRetentionConfig   mods.UserRetentionConfig   `mapstructure:"user_retention" yaml:"user_retention"`
 }
 
 // New returns instance of Config
@@ -8,31 +8,90 @@ import (
 const (
 	UserIDKey    = zap.UserIDKey
 	RequestIDKey = zap.RequestIDKey
+	SessionIDKey = "SessionID"
+	IPAddressKey = "IPAddress"
+	UserAgentKey = "UserAgent"
+	// Organization the admin data of the request is restricted to, set for organization admins
+	OrganizationKey = "Organization"
+	// Impersonation the request is made under, set for the tokens issued to an admin impersonating a user
+	ImpersonationKey = "Impersonation"
 )
 
 // Enum Values
 const (
+	InstructionDataStatusDraft    = "DRAFT"
 	InstructionDataStatusPending  = "PENDING"
 	InstructionDataStatusApproved = "APPROVED"
 	InstructionDataStatusRejected = "REJECTED"
 
+	SourceTypeOriginal  = "ORIGINAL"
+	SourceTypeWeb       = "WEB"
+	SourceTypeBook      = "BOOK"
+	SourceTypeSynthetic = "SYNTHETIC"
+
+	ReAuditStatusPending    = "PENDING"
+	ReAuditStatusConfirmed  = "CONFIRMED"
+	ReAuditStatusOverturned = "OVERTURNED"
+
 	NoticeTypeUrgent = "URGENT"
 	NoticeTypeNormal = "NORMAL"
 
 	OperationTypeCreate = "CREATE"
 	OperationTypeUpdate = "UPDATE"
 	OperationTypeDelete = "DELETE"
+	// Request made by an admin impersonating a user
+	OperationTypeImpersonate = "IMPERSONATE"
+	// Export of the personal data of a user
+	OperationTypeExport = "EXPORT"
 
-	EntityTypeInstruction   = "INSTRUCTION"
-	EntityTypeUser          = "USER"
-	EntityTypeDocumentation = "DOCUMENTATION"
-	EntityTypeNotice        = "NOTICE"
+	EntityTypeInstruction     = "INSTRUCTION"
+	EntityTypeUser            = "USER"
+	EntityTypeDocumentation   = "DOCUMENTATION"
+	EntityTypeNotice          = "NOTICE"
+	EntityTypeTheme           = "THEME"
+	EntityTypeReAudit         = "RE_AUDIT"
+	EntityTypeInviteCode      = "INVITE_CODE"
+	EntityTypeTwoFactorPolicy = "TWO_FACTOR_POLICY"
+	EntityTypeAccessToken     = "ACCESS_TOKEN"
+	EntityTypeLoginLockout    = "LOGIN_LOCKOUT"
+	EntityTypeSession         = "SESSION"
+	EntityTypeOrganization    = "ORGANIZATION"
+	EntityTypePolicy          = "POLICY"
+	EntityTypeImpersonation   = "IMPERSONATION"
 
 	OperationStatusSuccess = "SUCCESS"
 	OperationStatusFailure = "FAILURE"
 
+	LoginStatusSuccess = "SUCCESS"
+	LoginStatusFailure = "FAILURE"
+
+	LoginLockoutKindAccount = "ACCOUNT"
+	LoginLockoutKindIP      = "IP"
+
 	UserRoleUser  = "USER"
 	UserRoleAdmin = "ADMIN"
+	// Granted on top of the role of the user, in the domain of an organization
+	UserRoleOrgAdmin = "ORG_ADMIN"
+	// Fine-grained roles granted on top of the role of the user
+	UserRoleReviewer = "REVIEWER"
+	UserRoleExporter = "EXPORTER"
+	UserRoleViewer   = "VIEWER"
+
+	// What is done with the instruction data of a user purged after its retention period
+	UserPurgePolicyDelete    = "DELETE"
+	UserPurgePolicyReassign  = "REASSIGN"
+	UserPurgePolicyAnonymise = "ANONYMISE"
+
+	// Casbin policy objects and actions, checked on routes as "object:action" permissions
+	PolicyObjectInstructionData = "instruction-data"
+	PolicyObjectStatistic       = "statistic"
+	PolicyActionRead            = "read"
+	PolicyActionReview          = "review"
+	PolicyActionExport          = "export"
+
+	AccessTokenPrefix            = "dch_"
+	AccessTokenScopeDatasetRead  = "dataset:read"
+	AccessTokenScopeDatasetWrite = "dataset:write"
 )
 
 // MongoDB Collection Name
@@ -43,15 +102,34 @@ const (
 	LoginLogCollectionName        = "login_log"
 	OperationLogCollectionName    = "operation_log"
 	UserCollectionName            = "user"
+	ThemeCollectionName           = "theme"
+	ReviewCollectionName          = "review"
+	ReAuditCollectionName         = "re_audit"
+	InviteCodeCollectionName      = "invite_code"
+	TwoFactorPolicyCollectionName = "two_factor_policy"
+	AccessTokenCollectionName     = "access_token"
+	SessionCollectionName         = "session"
+	JwtKeyCollectionName          = "jwt_key"
+	OrganizationCollectionName    = "organization"
 )
 
 // cache Prefix / Key
 const (
-	NoticeCachePrefix         = "dao:notice"
-	UserCachePrefix           = "dao:user"
-	DocumentationCachePrefix  = "dao:documentation"
-	TokenBlacklistCachePrefix = "token:blacklist"
-	IdempotencyCachePrefix    = "idempotency"
+	NoticeCachePrefix          = "dao:notice"
+	UserCachePrefix            = "dao:user"
+	DocumentationCachePrefix   = "dao:documentation"
+	ThemeCachePrefix           = "dao:theme"
+	TokenBlacklistCachePrefix  = "token:blacklist"
+	PasswordResetCachePrefix   = "password:reset"
+	EmailChangeCachePrefix     = "email:change"
+	TwoFactorCachePrefix       = "two-factor"
+	OIDCStateCachePrefix       = "oidc:state"
+	IdempotencyCachePrefix     = "idempotency"
+	LoginFailureCachePrefix    = "login:failure"
+	LoginDelayCachePrefix      = "login:delay"
+	LoginLockoutCachePrefix    = "login:lockout"
+	SessionRevokedCachePrefix  = "session:revoked"
+	TokenValidAfterCachePrefix = "token:valid-after"
 
 	LoginLogCacheKey     = "log:login"
 	OperationLogCacheKey = "log:operation"
@@ -0,0 +1,14 @@
+package mods
+
+import (
+	"time"
+)
+
+type AccessTokenConfig struct {
+	// Longest lifetime a personal access token can be created with
+	MaxTTL time.Duration `mapstructure:"access_token_max_ttl" yaml:"access_token_max_ttl" default:"8760h"`
+	// Maximum number of personal access tokens per user
+	MaxPerUser int64 `mapstructure:"access_token_max_per_user" yaml:"access_token_max_per_user" default:"20"`
+	// Minimum interval between two updates of the last-used time of a token
+	LastUsedInterval time.Duration `mapstructure:"access_token_last_used_interval" yaml:"access_token_last_used_interval" default:"1m"`
+}
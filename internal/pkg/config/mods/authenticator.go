@@ -0,0 +1,6 @@
+package mods
+
+type AuthenticatorConfig struct {
+	// Authenticators tried in order on login, 'local' (password in the user collection) and 'ldap'
+	Chain []string `mapstructure:"authenticator_chain" yaml:"authenticator_chain" default:"['local']"`
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// FiberConfig configures the fiber app. ProxyHeader carries the client IP and is only read from TrustedProxies when
+// EnableTrustedProxyCheck is set. The IP is the key of the login lockout, so the proxy must overwrite the header
+// rather than append to it.
 type FiberConfig struct {
 	Prefork                 bool          `mapstructure:"prefork" yaml:"prefork" default:"false"`
 	ServerHeader            string        `mapstructure:"server_header" yaml:"server_header" default:""`
@@ -14,11 +17,11 @@ type FiberConfig struct {
 	IdleTimeout             time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout" default:"2m"`
 	ReadBufferSize          int           `mapstructure:"read_buffer_size" yaml:"read_buffer_size" default:"4096"`
 	WriteBufferSize         int           `mapstructure:"write_buffer_size" yaml:"write_buffer_size" default:"4096"`
-	ProxyHeader             string        `mapstructure:"proxy_header" yaml:"proxy_header" default:"X-Forwarded-For"`
+	ProxyHeader             string        `mapstructure:"proxy_header" yaml:"proxy_header" default:"X-Real-IP"`
 	DisableKeepalive        bool          `mapstructure:"disable_keepalive" yaml:"disable_keepalive" default:"false"`
 	DisableStartupMessage   bool          `mapstructure:"disable_startup_message" yaml:"disable_startup_message" default:"true"`
 	ReduceMemoryUsage       bool          `mapstructure:"reduce_memory_usage" yaml:"reduce_memory_usage" default:"false"`
-	EnableTrustedProxyCheck bool          `mapstructure:"enable_trusted_proxy_check" yaml:"enable_trusted_proxy_check" default:"false"`
-	TrustedProxies          []string      `mapstructure:"trusted_proxies" yaml:"trusted_proxies" default:""`
+	EnableTrustedProxyCheck bool          `mapstructure:"enable_trusted_proxy_check" yaml:"enable_trusted_proxy_check" default:"true"`
+	TrustedProxies          []string      `mapstructure:"trusted_proxies" yaml:"trusted_proxies" default:"['127.0.0.1', '::1']"`
 	EnablePrintRoutes       bool          `mapstructure:"enable_print_routes" yaml:"enable_print_routes" default:"true"`
 }
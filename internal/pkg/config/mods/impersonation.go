@@ -0,0 +1,10 @@
+package mods
+
+import (
+	"time"
+)
+
+type ImpersonationConfig struct {
+	// Lifetime of the access token issued to an admin impersonating a user
+	TokenDuration time.Duration `mapstructure:"impersonation_token_duration" yaml:"impersonation_token_duration" default:"15m"`
+}
@@ -8,4 +8,8 @@ type JWTConfig struct {
 	TokenDuration   time.Duration `mapstructure:"jwt_token_duration" yaml:"jwt_token_duration" default:"7200s"`
 	RefreshDuration time.Duration `mapstructure:"jwt_refresh_duration" yaml:"jwt_refresh_duration" default:"14400s"`
 	RefreshBuffer   time.Duration `mapstructure:"jwt_refresh_buffer" yaml:"jwt_refresh_buffer" default:"300s"`
+	// Secret encrypting the private keys of the key ring in the database, required
+	KeyEncryptionKey string `mapstructure:"jwt_key_encryption_key" yaml:"jwt_key_encryption_key" default:""`
+	// Minimum interval between two reloads of the key ring for tokens signed by a key of another instance
+	KeyReloadInterval time.Duration `mapstructure:"jwt_key_reload_interval" yaml:"jwt_key_reload_interval" default:"10s"`
 }
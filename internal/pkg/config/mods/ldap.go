@@ -0,0 +1,30 @@
+package mods
+
+import (
+	"time"
+)
+
+type LDAPConfig struct {
+	// ldap:// or ldaps:// URL of the directory
+	URL string `mapstructure:"ldap_url" yaml:"ldap_url" default:"ldap://localhost:389"`
+	// Skip verifying the certificate of ldaps:// directories, for testing only
+	InsecureSkipVerify bool `mapstructure:"ldap_insecure_skip_verify" yaml:"ldap_insecure_skip_verify" default:"false"`
+	// Service account searching the user entries, anonymous search if empty
+	BindDN       string `mapstructure:"ldap_bind_dn" yaml:"ldap_bind_dn"`
+	BindPassword string `mapstructure:"ldap_bind_password" yaml:"ldap_bind_password"`
+	// Subtree searched for the user entries
+	BaseDN string `mapstructure:"ldap_base_dn" yaml:"ldap_base_dn"`
+	// Filter finding the user entry, %s is replaced by the escaped email
+	UserFilter string        `mapstructure:"ldap_user_filter" yaml:"ldap_user_filter" default:"(mail=%s)"`
+	Timeout    time.Duration `mapstructure:"ldap_timeout" yaml:"ldap_timeout" default:"10s"`
+	// Attributes mapped to the user fields
+	UsernameAttribute     string `mapstructure:"ldap_username_attribute" yaml:"ldap_username_attribute" default:"uid"`
+	EmailAttribute        string `mapstructure:"ldap_email_attribute" yaml:"ldap_email_attribute" default:"mail"`
+	OrganizationAttribute string `mapstructure:"ldap_organization_attribute" yaml:"ldap_organization_attribute" default:"o"`
+	GroupAttribute        string `mapstructure:"ldap_group_attribute" yaml:"ldap_group_attribute" default:"memberOf"`
+	// Group DNs granting the ADMIN and USER roles. If both are empty, roles are not managed by the directory.
+	AdminGroups []string `mapstructure:"ldap_admin_groups" yaml:"ldap_admin_groups"`
+	UserGroups  []string `mapstructure:"ldap_user_groups" yaml:"ldap_user_groups"`
+	// Organization of provisioned users without the organization attribute
+	DefaultOrganization string `mapstructure:"ldap_default_organization" yaml:"ldap_default_organization"`
+}
@@ -0,0 +1,20 @@
+package mods
+
+import (
+	"time"
+)
+
+type LoginProtectionConfig struct {
+	// Failed logins of an account within the window before the account is locked
+	AccountThreshold int64 `mapstructure:"login_protection_account_threshold" yaml:"login_protection_account_threshold" default:"5"`
+	// Failed logins from an IP address within the window before the IP address is locked
+	IPThreshold int64 `mapstructure:"login_protection_ip_threshold" yaml:"login_protection_ip_threshold" default:"20"`
+	// Time after the first failed login in which failures are counted
+	Window time.Duration `mapstructure:"login_protection_window" yaml:"login_protection_window" default:"15m"`
+	// Time an account or IP address stays locked
+	LockoutDuration time.Duration `mapstructure:"login_protection_lockout_duration" yaml:"login_protection_lockout_duration" default:"15m"`
+	// Delay before the next login of an account after its first failure, doubled with every further failure
+	DelayBase time.Duration `mapstructure:"login_protection_delay_base" yaml:"login_protection_delay_base" default:"1s"`
+	// Upper bound of the delay between two logins of an account
+	DelayMax time.Duration `mapstructure:"login_protection_delay_max" yaml:"login_protection_delay_max" default:"30s"`
+}
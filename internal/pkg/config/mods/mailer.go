@@ -0,0 +1,13 @@
+package mods
+
+type MailerConfig struct {
+	// Driver of the mailer, 'smtp' | 'file'. The file driver is meant for development, the mails hold reset links
+	Driver       string `mapstructure:"mailer_driver" yaml:"mailer_driver" default:"smtp"`
+	From         string `mapstructure:"mailer_from" yaml:"mailer_from" default:"no-reply@localhost"`
+	SMTPHost     string `mapstructure:"mailer_smtp_host" yaml:"mailer_smtp_host" default:"localhost"`
+	SMTPPort     int    `mapstructure:"mailer_smtp_port" yaml:"mailer_smtp_port" default:"587"`
+	SMTPUsername string `mapstructure:"mailer_smtp_username" yaml:"mailer_smtp_username" default:""`
+	SMTPPassword string `mapstructure:"mailer_smtp_password" yaml:"mailer_smtp_password" default:""`
+	// File the mails are appended to by the file driver, 'stdout' writes to the standard output
+	FilePath string `mapstructure:"mailer_file_path" yaml:"mailer_file_path" default:"stdout"`
+}
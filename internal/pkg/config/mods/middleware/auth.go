@@ -1,5 +1,5 @@
 package middleware
 
 type AuthConfig struct {
-	SkippedPathPrefixes []string `mapstructure:"skipped_path_prefixes" yaml:"skipped_path_prefixes" default:"['/api/v1/auth/login', '/api/v1/auth/refresh', '/api/v1/auth/logout', '/ping']"`
+	SkippedPathPrefixes []string `mapstructure:"skipped_path_prefixes" yaml:"skipped_path_prefixes" default:"['/api/v1/auth/login', '/api/v1/auth/register', '/api/v1/auth/forgot-password', '/api/v1/auth/reset-password', '/api/v1/auth/two-factor/verify', '/api/v1/auth/two-factor/enroll', '/api/v1/auth/two-factor/confirm', '/api/v1/auth/oidc/authorize', '/api/v1/auth/oidc/callback', '/api/v1/auth/refresh', '/api/v1/auth/logout', '/ping', '/.well-known/jwks.json']"`
 }
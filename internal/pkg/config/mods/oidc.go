@@ -0,0 +1,33 @@
+package mods
+
+import (
+	"time"
+)
+
+type OIDCConfig struct {
+	// Enable single sign-on with the OpenID Connect provider
+	Enabled bool `mapstructure:"oidc_enabled" yaml:"oidc_enabled" default:"false"`
+	// Issuer identifier of the provider, the discovery document is fetched from it
+	Issuer       string `mapstructure:"oidc_issuer" yaml:"oidc_issuer"`
+	ClientID     string `mapstructure:"oidc_client_id" yaml:"oidc_client_id"`
+	ClientSecret string `mapstructure:"oidc_client_secret" yaml:"oidc_client_secret"`
+	// Page of the frontend the provider redirects to with the code and state
+	RedirectURL string   `mapstructure:"oidc_redirect_url" yaml:"oidc_redirect_url"`
+	Scopes      []string `mapstructure:"oidc_scopes" yaml:"oidc_scopes" default:"['openid', 'email', 'profile']"`
+	// Time the user has to complete the sign-in at the provider
+	StateTTL time.Duration `mapstructure:"oidc_state_ttl" yaml:"oidc_state_ttl" default:"10m"`
+	// Timeout of requests to the provider
+	Timeout time.Duration `mapstructure:"oidc_timeout" yaml:"oidc_timeout" default:"10s"`
+	// Claims mapped to the user fields
+	UsernameClaim     string `mapstructure:"oidc_username_claim" yaml:"oidc_username_claim" default:"preferred_username"`
+	EmailClaim        string `mapstructure:"oidc_email_claim" yaml:"oidc_email_claim" default:"email"`
+	OrganizationClaim string `mapstructure:"oidc_organization_claim" yaml:"oidc_organization_claim" default:"organization"`
+	GroupsClaim       string `mapstructure:"oidc_groups_claim" yaml:"oidc_groups_claim" default:"groups"`
+	// Groups granting the ADMIN and USER roles. If both are empty, roles are not managed by the provider.
+	AdminGroups []string `mapstructure:"oidc_admin_groups" yaml:"oidc_admin_groups"`
+	UserGroups  []string `mapstructure:"oidc_user_groups" yaml:"oidc_user_groups"`
+	// Create users signing in for the first time, otherwise only existing users can be linked by email
+	AutoProvision bool `mapstructure:"oidc_auto_provision" yaml:"oidc_auto_provision" default:"true"`
+	// Organization of provisioned users without the organization claim
+	DefaultOrganization string `mapstructure:"oidc_default_organization" yaml:"oidc_default_organization"`
+}
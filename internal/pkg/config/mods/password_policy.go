@@ -0,0 +1,21 @@
+package mods
+
+import (
+	"time"
+)
+
+type PasswordPolicyConfig struct {
+	// Minimum number of characters of a password
+	MinLength int `mapstructure:"password_policy_min_length" yaml:"password_policy_min_length" default:"8"`
+	// Character classes a password must contain
+	RequireUpper   bool `mapstructure:"password_policy_require_upper" yaml:"password_policy_require_upper" default:"true"`
+	RequireLower   bool `mapstructure:"password_policy_require_lower" yaml:"password_policy_require_lower" default:"true"`
+	RequireDigit   bool `mapstructure:"password_policy_require_digit" yaml:"password_policy_require_digit" default:"true"`
+	RequireSpecial bool `mapstructure:"password_policy_require_special" yaml:"password_policy_require_special" default:"false"`
+	// File of common passwords to reject (one per line) on top of the built-in list, empty for the built-in list only
+	CommonPasswordFile string `mapstructure:"password_policy_common_password_file" yaml:"password_policy_common_password_file" default:""`
+	// Number of recent passwords, the current one included, which can not be reused, 0 to allow reuse
+	HistorySize int `mapstructure:"password_policy_history_size" yaml:"password_policy_history_size" default:"5"`
+	// Time after which a password has to be changed, 0 for no expiry
+	MaxAge time.Duration `mapstructure:"password_policy_max_age" yaml:"password_policy_max_age" default:"0s"`
+}
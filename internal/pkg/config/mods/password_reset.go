@@ -0,0 +1,12 @@
+package mods
+
+import (
+	"time"
+)
+
+type PasswordResetConfig struct {
+	// Time a reset token stays valid
+	TokenTTL time.Duration `mapstructure:"password_reset_token_ttl" yaml:"password_reset_token_ttl" default:"30m"`
+	// Link sent to the user, '%s' is replaced by the reset token
+	ResetURL string `mapstructure:"password_reset_url" yaml:"password_reset_url" default:"http://localhost:3000/reset-password?token=%s"`
+}
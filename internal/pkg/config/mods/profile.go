@@ -0,0 +1,14 @@
+package mods
+
+import (
+	"time"
+)
+
+type ProfileConfig struct {
+	// Time an email change confirmation token stays valid
+	EmailChangeTokenTTL time.Duration `mapstructure:"profile_email_change_token_ttl" yaml:"profile_email_change_token_ttl" default:"30m"`
+	// Link sent to the new email, '%s' is replaced by the confirmation token
+	EmailChangeURL string `mapstructure:"profile_email_change_url" yaml:"profile_email_change_url" default:"http://localhost:3000/confirm-email?token=%s"`
+	// Largest avatar image accepted, in bytes
+	AvatarMaxSize int64 `mapstructure:"profile_avatar_max_size" yaml:"profile_avatar_max_size" default:"2097152"`
+}
@@ -0,0 +1,16 @@
+package mods
+
+import (
+	"time"
+)
+
+type ReviewConfig struct {
+	// Reviewers who disagree with the consensus of the other reviewers more often than the threshold are flagged
+	OutlierThreshold float64 `mapstructure:"outlier_threshold" yaml:"outlier_threshold" default:"0.3"`
+	// Minimum number of records compared with the consensus before a reviewer can be flagged
+	OutlierMinItems int64 `mapstructure:"outlier_min_items" yaml:"outlier_min_items" default:"10"`
+	// Fraction of the records approved by each reviewer in each theme that is drawn into the re-audit queue
+	ReAuditSampleRate float64 `mapstructure:"re_audit_sample_rate" yaml:"re_audit_sample_rate" default:"0.05"`
+	// Records approved within the window before each sampling run are eligible for re-audit
+	ReAuditWindow time.Duration `mapstructure:"re_audit_window" yaml:"re_audit_window" default:"24h"`
+}
@@ -0,0 +1,10 @@
+package mods
+
+import (
+	"time"
+)
+
+type SessionConfig struct {
+	// Minimum interval between two updates of the last-seen time of a session
+	LastSeenInterval time.Duration `mapstructure:"session_last_seen_interval" yaml:"session_last_seen_interval" default:"1m"`
+}
@@ -0,0 +1,10 @@
+package mods
+
+type StorageConfig struct {
+	// Driver of the storage of uploaded files, 'local'
+	Driver string `mapstructure:"storage_driver" yaml:"storage_driver" default:"local"`
+	// Directory the local driver stores the files in
+	LocalDir string `mapstructure:"storage_local_dir" yaml:"storage_local_dir" default:"./storage"`
+	// URL prefix the files of the local driver are served under
+	LocalURLPrefix string `mapstructure:"storage_local_url_prefix" yaml:"storage_local_url_prefix" default:"/storage"`
+}
@@ -1,6 +1,14 @@
 package mods
 
+import (
+	"time"
+)
+
 type TasksConfig struct {
-	SyncLogsSpec  string `mapstructure:"sync_logs_spec" yaml:"sync_logs_spec" default:"@hourly"`
-	UpdateKeySpec string `mapstructure:"update_key_spec" yaml:"update_key_spec" default:"@weekly"`
+	SyncLogsSpec    string        `mapstructure:"sync_logs_spec" yaml:"sync_logs_spec" default:"@hourly"`
+	UpdateKeySpec   string        `mapstructure:"update_key_spec" yaml:"update_key_spec" default:"@weekly"`
+	CleanDraftsSpec string        `mapstructure:"clean_drafts_spec" yaml:"clean_drafts_spec" default:"@daily"`
+	DraftRetention  time.Duration `mapstructure:"draft_retention" yaml:"draft_retention" default:"720h"`
+	ReAuditSpec     string        `mapstructure:"re_audit_spec" yaml:"re_audit_spec" default:"@daily"`
+	PurgeUsersSpec  string        `mapstructure:"purge_users_spec" yaml:"purge_users_spec" default:"@daily"`
 }
@@ -0,0 +1,20 @@
+package mods
+
+import (
+	"time"
+)
+
+type TwoFactorConfig struct {
+	// Issuer shown by authenticator apps
+	Issuer string `mapstructure:"two_factor_issuer" yaml:"two_factor_issuer" default:"Data Collection Hub"`
+	// Time a login challenge stays valid between the password step and the code step
+	ChallengeTTL time.Duration `mapstructure:"two_factor_challenge_ttl" yaml:"two_factor_challenge_ttl" default:"5m"`
+	// Wrong codes accepted for a login challenge before the password step has to be repeated
+	ChallengeMaxAttempts int `mapstructure:"two_factor_challenge_max_attempts" yaml:"two_factor_challenge_max_attempts" default:"5"`
+	// Time an enrollment stays pending before it has to be confirmed with a code
+	EnrollmentTTL time.Duration `mapstructure:"two_factor_enrollment_ttl" yaml:"two_factor_enrollment_ttl" default:"10m"`
+	// Number of one-time recovery codes issued on enrollment
+	RecoveryCodeCount int `mapstructure:"two_factor_recovery_code_count" yaml:"two_factor_recovery_code_count" default:"10"`
+	// Time steps accepted before and after the current one, to tolerate clock drift
+	Skew int `mapstructure:"two_factor_skew" yaml:"two_factor_skew" default:"1"`
+}
@@ -0,0 +1,14 @@
+package mods
+
+import (
+	"time"
+)
+
+type UserRetentionConfig struct {
+	// Time a deleted user stays restorable before it is purged
+	Period time.Duration `mapstructure:"user_retention_period" yaml:"user_retention_period" default:"720h"`
+	// What is done with the instruction data of a purged user, 'DELETE' | 'REASSIGN' | 'ANONYMISE'
+	PurgePolicy string `mapstructure:"user_retention_purge_policy" yaml:"user_retention_purge_policy" default:"ANONYMISE"`
+	// ID of the user the instruction data of purged users is reassigned to, for the 'REASSIGN' policy
+	ReassignTo string `mapstructure:"user_retention_reassign_to" yaml:"user_retention_reassign_to" default:""`
+}
@@ -41,6 +41,17 @@ func (c *Cache) Get(ctx context.Context, key string) (*string, error) {
 	return &result, nil
 }
 
+// GetDelete gets the value of the key and deletes the key atomically, so that the value is read at most once.
+func (c *Cache) GetDelete(ctx context.Context, key string) (*string, error) {
+	result, err := c.Redis.RedisClient.GetDel(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, c.Nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (c *Cache) Set(ctx context.Context, key string, value string, ttl *time.Duration) error {
 	if ttl == nil {
 		return c.Redis.RedisClient.Set(ctx, key, value, c.Config.CacheConfig.DefaultTTL).Err()
@@ -48,6 +59,14 @@ func (c *Cache) Set(ctx context.Context, key string, value string, ttl *time.Dur
 	return c.Redis.RedisClient.Set(ctx, key, value, *ttl).Err()
 }
 
+// SetNX sets the key only if it does not exist, atomically. Returns whether the key was set.
+func (c *Cache) SetNX(ctx context.Context, key string, value string, ttl *time.Duration) (bool, error) {
+	if ttl == nil {
+		ttl = &c.Config.CacheConfig.DefaultTTL
+	}
+	return c.Redis.RedisClient.SetNX(ctx, key, value, *ttl).Result()
+}
+
 func (c *Cache) GetList(ctx context.Context, key string, cacheList interface{}) error {
 	result, err := c.Redis.RedisClient.Get(ctx, key).Result()
 	if errors.Is(err, redis.Nil) {
@@ -107,3 +126,50 @@ func (c *Cache) Flush(ctx context.Context, prefix *string) error {
 	}
 	return nil
 }
+
+// Incr increments the counter of the key and returns the new value. The TTL is set when the counter is created, so
+// that the counter expires a fixed time after its first increment.
+func (c *Cache) Incr(ctx context.Context, key string, ttl *time.Duration) (int64, error) {
+	if ttl == nil {
+		ttl = &c.Config.CacheConfig.DefaultTTL
+	}
+	result, err := c.Redis.RedisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if result == 1 {
+		if err = c.Redis.RedisClient.Expire(ctx, key, *ttl).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// TTL returns the remaining time to live of the key.
+func (c *Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	result, err := c.Redis.RedisClient.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if result == -2 { // the key does not exist
+		return 0, c.Nil
+	}
+	return result, nil
+}
+
+// Keys returns the keys with the prefix. The keys are iterated with SCAN, which does not block the server like KEYS.
+func (c *Cache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		keys []string
+		seen = make(map[string]struct{})
+	)
+	iter := c.Redis.RedisClient.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		if _, ok := seen[iter.Val()]; ok { // SCAN may return a key more than once
+			continue
+		}
+		seen[iter.Val()] = struct{}{}
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
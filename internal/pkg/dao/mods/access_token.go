@@ -0,0 +1,199 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type AccessTokenDao interface {
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*entity.AccessTokenModel, error)
+	GetAccessTokenList(ctx context.Context, userID primitive.ObjectID) ([]entity.AccessTokenModel, error)
+	CountAccessToken(ctx context.Context, userID primitive.ObjectID) (*int64, error)
+	InsertAccessToken(
+		ctx context.Context, userID primitive.ObjectID, name, tokenHash string, scopes []string, expiresAt time.Time,
+	) (primitive.ObjectID, error)
+	UpdateAccessTokenLastUsed(ctx context.Context, accessTokenID primitive.ObjectID, interval time.Duration) error
+	DeleteAccessToken(ctx context.Context, userID, accessTokenID primitive.ObjectID) error
+	DeleteAccessTokenList(ctx context.Context, userID primitive.ObjectID) (*int64, error)
+}
+
+type AccessTokenDaoImpl struct {
+	core *dao.Core
+}
+
+func NewAccessTokenDao(ctx context.Context, core *dao.Core) (AccessTokenDao, error) {
+	var _ AccessTokenDao = (*AccessTokenDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"token_hash"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"user_id"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.AccessTokenCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &AccessTokenDaoImpl{core}, nil
+}
+
+// GetAccessTokenByHash returns the unexpired access token with the hash.
+func (a *AccessTokenDaoImpl) GetAccessTokenByHash(
+	ctx context.Context, tokenHash string,
+) (*entity.AccessTokenModel, error) {
+	var accessToken entity.AccessTokenModel
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	err := collection.Find(
+		ctx, bson.M{"token_hash": tokenHash, "expires_at": bson.M{"$gt": time.Now()}},
+	).One(&accessToken)
+	if err != nil {
+		a.core.Logger.Error("AccessTokenDaoImpl.GetAccessTokenByHash: failed to find access token", zap.Error(err))
+		return nil, err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.GetAccessTokenByHash: success",
+		zap.String("accessTokenID", accessToken.AccessTokenID.Hex()),
+	)
+	return &accessToken, nil
+}
+
+func (a *AccessTokenDaoImpl) GetAccessTokenList(
+	ctx context.Context, userID primitive.ObjectID,
+) ([]entity.AccessTokenModel, error) {
+	var accessTokenList []entity.AccessTokenModel
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	err := collection.Find(ctx, bson.M{"user_id": userID}).Sort("-created_at").All(&accessTokenList)
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.GetAccessTokenList: failed to find access tokens", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.GetAccessTokenList: success",
+		zap.String("userID", userID.Hex()), zap.Int("count", len(accessTokenList)),
+	)
+	return accessTokenList, nil
+}
+
+// CountAccessToken counts the unexpired access tokens of the user.
+func (a *AccessTokenDaoImpl) CountAccessToken(ctx context.Context, userID primitive.ObjectID) (*int64, error) {
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	count, err := collection.Find(
+		ctx, bson.M{"user_id": userID, "expires_at": bson.M{"$gt": time.Now()}},
+	).Count()
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.CountAccessToken: failed to count access tokens", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.CountAccessToken: success", zap.String("userID", userID.Hex()), zap.Int64("count", count),
+	)
+	return &count, nil
+}
+
+func (a *AccessTokenDaoImpl) InsertAccessToken(
+	ctx context.Context, userID primitive.ObjectID, name, tokenHash string, scopes []string, expiresAt time.Time,
+) (primitive.ObjectID, error) {
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	doc := bson.M{
+		"user_id":      userID,
+		"name":         name,
+		"token_hash":   tokenHash,
+		"scopes":       scopes,
+		"expires_at":   expiresAt,
+		"last_used_at": time.Time{},
+		"created_at":   time.Now(),
+	}
+	result, err := collection.InsertOne(ctx, doc)
+	delete(doc, "token_hash")
+	docJSON, _ := json.Marshal(doc)
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.InsertAccessToken: failed to insert access token", zap.Error(err),
+			zap.ByteString(config.AccessTokenCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.InsertAccessToken: success",
+		zap.String("accessTokenID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.AccessTokenCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+// UpdateAccessTokenLastUsed sets the last-used time of the access token to now, unless it was set within the
+// interval, so that a busy token does not cause a write on every request.
+func (a *AccessTokenDaoImpl) UpdateAccessTokenLastUsed(
+	ctx context.Context, accessTokenID primitive.ObjectID, interval time.Duration,
+) error {
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	_, err := collection.UpdateAll(
+		ctx, bson.M{"_id": accessTokenID, "last_used_at": bson.M{"$lt": time.Now().Add(-interval)}},
+		bson.M{"$set": bson.M{"last_used_at": time.Now()}},
+	)
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.UpdateAccessTokenLastUsed: failed to update access token", zap.Error(err),
+			zap.String("accessTokenID", accessTokenID.Hex()),
+		)
+		return err
+	}
+	return nil
+}
+
+// DeleteAccessToken deletes the access token of the user. Tokens of other users are not found.
+func (a *AccessTokenDaoImpl) DeleteAccessToken(ctx context.Context, userID, accessTokenID primitive.ObjectID) error {
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	err := collection.Remove(ctx, bson.M{"_id": accessTokenID, "user_id": userID})
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.DeleteAccessToken: failed to delete access token", zap.Error(err),
+			zap.String("accessTokenID", accessTokenID.Hex()), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.DeleteAccessToken: success", zap.String("accessTokenID", accessTokenID.Hex()),
+	)
+	return nil
+}
+
+// DeleteAccessTokenList deletes all access tokens of the user, expired or not.
+func (a *AccessTokenDaoImpl) DeleteAccessTokenList(ctx context.Context, userID primitive.ObjectID) (*int64, error) {
+	collection := a.core.Mongo.MongoClient.Database(a.core.Mongo.DatabaseName).Collection(config.AccessTokenCollectionName)
+	result, err := collection.RemoveAll(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		a.core.Logger.Error(
+			"AccessTokenDaoImpl.DeleteAccessTokenList: failed to delete access tokens",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	a.core.Logger.Info(
+		"AccessTokenDaoImpl.DeleteAccessTokenList: success",
+		zap.Int64("count", result.DeletedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.DeletedCount, nil
+}
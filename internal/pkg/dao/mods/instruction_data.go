@@ -23,10 +23,11 @@ type InstructionDataDao interface {
 	GetInstructionDataList(
 		ctx context.Context, offset, limit int64, desc bool, userID *primitive.ObjectID, theme, statusCode *string,
 		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time, query *string,
+		metadata map[string]interface{}, sourceType *string, licenses []string,
 	) ([]entity.InstructionDataModel, *int64, error)
 	CountInstructionData(
 		ctx context.Context, userID *primitive.ObjectID, theme, statusCode *string,
-		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
+		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time, metadata map[string]interface{},
 	) (*int64, error)
 	AggregateCountInstructionData(
 		ctx context.Context, groupBy *string, createStartTime, createEndTime *time.Time,
@@ -35,16 +36,30 @@ type InstructionDataDao interface {
 		ctx context.Context,
 		userID primitive.ObjectID,
 		rowInstruction, rowInput, rowOutput, theme, source, note, statusCode, statusMessage string,
+		metadata map[string]interface{}, provenance *entity.Provenance,
 	) (primitive.ObjectID, error)
 	UpdateInstructionData(
 		ctx context.Context, instructionDataID primitive.ObjectID, userID *primitive.ObjectID,
 		rowInstruction, rowInput, rowOutput, theme, source, note, statusCode, statusMessage *string,
+		metadata map[string]interface{}, provenance *entity.Provenance,
 	) error
 	SoftDeleteInstructionData(ctx context.Context, instructionDataID primitive.ObjectID) error
 	SoftDeleteInstructionDataList(
 		ctx context.Context, userID *primitive.ObjectID, theme, statusCode *string,
 		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
 	) (*int64, error)
+	SoftDeleteUserInstructionDataList(
+		ctx context.Context, userID primitive.ObjectID, deletedAt time.Time,
+	) (*int64, error)
+	RestoreUserInstructionDataList(
+		ctx context.Context, userID primitive.ObjectID, deletedAt time.Time,
+	) (*int64, error)
+	PseudonymizeInstructionDataList(
+		ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+	) (*int64, error)
+	ReassignInstructionDataList(
+		ctx context.Context, userID, toUserID primitive.ObjectID, toUsername string,
+	) (*int64, error)
 	DeleteInstructionData(ctx context.Context, instructionDataID primitive.ObjectID) error
 	DeleteInstructionDataList(
 		ctx context.Context, userID *primitive.ObjectID, theme, statusCode *string,
@@ -64,6 +79,7 @@ func NewInstructionDataDao(ctx context.Context, core *dao.Core, userDao UserDao)
 		ctx, []options.IndexModel{
 			{Key: []string{"user_id"}}, {Key: []string{"theme"}}, {Key: []string{"status.code"}},
 			{Key: []string{"created_at"}}, {Key: []string{"updated_at"}},
+			{Key: []string{"provenance.source_type"}}, {Key: []string{"provenance.license"}},
 		},
 	)
 	if err != nil {
@@ -81,7 +97,11 @@ func (i *InstructionDataDaoImpl) GetInstructionDataByID(
 ) (*entity.InstructionDataModel, error) {
 	var instructionData entity.InstructionDataModel
 	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
-	err := collection.Find(ctx, bson.M{"_id": instructionDataID, "deleted": false}).One(&instructionData)
+	doc := bson.M{"_id": instructionDataID, "deleted": false}
+	if err := i.organizationScope(ctx, doc); err != nil {
+		return nil, err
+	}
+	err := collection.Find(ctx, doc).One(&instructionData)
 	if err != nil {
 		i.Dao.Logger.Error(
 			"InstructionDataDaoImpl.GetInstructionDataByID: failed to find instruction data",
@@ -101,6 +121,7 @@ func (i *InstructionDataDaoImpl) GetInstructionDataList(
 	ctx context.Context, offset, limit int64, desc bool, userID *primitive.ObjectID,
 	theme, statusCode *string,
 	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time, query *string,
+	metadata map[string]interface{}, sourceType *string, licenses []string,
 ) ([]entity.InstructionDataModel, *int64, error) {
 	var instructionDataList []entity.InstructionDataModel
 	var err error
@@ -115,6 +136,8 @@ func (i *InstructionDataDaoImpl) GetInstructionDataList(
 	}
 	if statusCode != nil {
 		doc["status.code"] = *statusCode
+	} else {
+		doc["status.code"] = bson.M{"$ne": config.InstructionDataStatusDraft} // Drafts are listed only on demand
 	}
 	if createStartTime != nil && createEndTime != nil {
 		doc["created_at"] = bson.M{"$gte": *createStartTime, "$lte": *createEndTime}
@@ -129,6 +152,18 @@ func (i *InstructionDataDaoImpl) GetInstructionDataList(
 			{"username": bson.M{"$regex": primitive.Regex{Pattern: pattern, Options: "i"}}},
 		}
 	}
+	for field, value := range metadata {
+		doc["metadata."+field] = value
+	}
+	if sourceType != nil {
+		doc["provenance.source_type"] = *sourceType
+	}
+	if licenses != nil {
+		doc["provenance.license"] = bson.M{"$in": licenses}
+	}
+	if err = i.organizationScope(ctx, doc); err != nil {
+		return nil, nil, err
+	}
 	docJSON, _ := json.Marshal(doc)
 	cursor := collection.Find(ctx, doc)
 	count, err := cursor.Count()
@@ -162,7 +197,7 @@ func (i *InstructionDataDaoImpl) GetInstructionDataList(
 
 func (i *InstructionDataDaoImpl) CountInstructionData(
 	ctx context.Context, userID *primitive.ObjectID, theme, statusCode *string,
-	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
+	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time, metadata map[string]interface{},
 ) (*int64, error) {
 	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
 	doc := bson.M{"deleted": false}
@@ -174,6 +209,8 @@ func (i *InstructionDataDaoImpl) CountInstructionData(
 	}
 	if statusCode != nil {
 		doc["status.code"] = *statusCode
+	} else {
+		doc["status.code"] = bson.M{"$ne": config.InstructionDataStatusDraft} // Drafts are counted only on demand
 	}
 	if createStartTime != nil && createEndTime != nil {
 		doc["created_at"] = bson.M{"$gte": *createStartTime, "$lte": *createEndTime}
@@ -181,6 +218,12 @@ func (i *InstructionDataDaoImpl) CountInstructionData(
 	if updateStartTime != nil && updateEndTime != nil {
 		doc["updated_at"] = bson.M{"$gte": *updateStartTime, "$lte": *updateEndTime}
 	}
+	for field, value := range metadata {
+		doc["metadata."+field] = value
+	}
+	if err := i.organizationScope(ctx, doc); err != nil {
+		return nil, err
+	}
 	docJSON, _ := json.Marshal(doc)
 
 	count, err := collection.Find(ctx, doc).Count()
@@ -202,10 +245,13 @@ func (i *InstructionDataDaoImpl) AggregateCountInstructionData(
 	ctx context.Context, groupBy *string, createStartTime, createEndTime *time.Time,
 ) (map[string]int64, error) {
 	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
-	match := bson.M{"deleted": false}
+	match := bson.M{"deleted": false, "status.code": bson.M{"$ne": config.InstructionDataStatusDraft}}
 	if createStartTime != nil && createEndTime != nil {
 		match["created_at"] = bson.M{"$gte": *createStartTime, "$lte": *createEndTime}
 	}
+	if err := i.organizationScope(ctx, match); err != nil {
+		return nil, err
+	}
 	pipeline := []bson.M{
 		{"$match": match},
 		{"$group": bson.M{"_id": "$" + *groupBy, "count": bson.M{"$sum": 1}}},
@@ -231,10 +277,31 @@ func (i *InstructionDataDaoImpl) AggregateCountInstructionData(
 	return countMap, nil
 }
 
+// organizationScope restricts the filter to the data of the members of the organization the context is scoped to, if
+// any, so that organization admins only read the data of their organization.
+func (i *InstructionDataDaoImpl) organizationScope(ctx context.Context, doc bson.M) error {
+	organization, ok := ctx.Value(config.OrganizationKey).(string)
+	if !ok {
+		return nil
+	}
+	userIDs, err := i.UserDao.GetUserIDListByOrganization(ctx, organization)
+	if err != nil {
+		return err
+	}
+	if userID, ok := doc["user_id"]; ok {
+		doc["$and"] = bson.A{bson.M{"user_id": userID}, bson.M{"user_id": bson.M{"$in": userIDs}}}
+		delete(doc, "user_id")
+	} else {
+		doc["user_id"] = bson.M{"$in": userIDs}
+	}
+	return nil
+}
+
 func (i *InstructionDataDaoImpl) InsertInstructionData(
 	ctx context.Context,
 	userID primitive.ObjectID,
 	rowInstruction, rowInput, rowOutput, theme, source, note, statusCode, statusMessage string,
+	metadata map[string]interface{}, provenance *entity.Provenance,
 ) (primitive.ObjectID, error) {
 	user, err := i.UserDao.GetUserByID(ctx, userID)
 	if err != nil {
@@ -245,6 +312,12 @@ func (i *InstructionDataDaoImpl) InsertInstructionData(
 		return primitive.NilObjectID, err
 	}
 	username := user.Username
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	if provenance == nil {
+		provenance = &entity.Provenance{}
+	}
 	doc := bson.M{
 		"user_id":  userID,
 		"username": username,
@@ -253,9 +326,16 @@ func (i *InstructionDataDaoImpl) InsertInstructionData(
 			"input":       rowInput,
 			"output":      rowOutput,
 		},
-		"theme":  theme,
-		"source": source,
-		"note":   note,
+		"theme":    theme,
+		"source":   source,
+		"note":     note,
+		"metadata": metadata,
+		"provenance": bson.M{
+			"source_type":     provenance.SourceType,
+			"url":             provenance.URL,
+			"license":         provenance.License,
+			"rights_attested": provenance.RightsAttested,
+		},
 		"status": bson.M{
 			"code":    statusCode,
 			"message": statusMessage,
@@ -287,6 +367,7 @@ func (i *InstructionDataDaoImpl) UpdateInstructionData(
 	ctx context.Context,
 	instructionDataID primitive.ObjectID, userID *primitive.ObjectID,
 	rowInstruction, rowInput, rowOutput, theme, source, note, statusCode, statusMessage *string,
+	metadata map[string]interface{}, provenance *entity.Provenance,
 ) error {
 	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
 	doc := bson.M{"updated_at": time.Now()}
@@ -327,6 +408,17 @@ func (i *InstructionDataDaoImpl) UpdateInstructionData(
 	if statusMessage != nil {
 		doc["status.message"] = *statusMessage
 	}
+	if metadata != nil {
+		doc["metadata"] = metadata
+	}
+	if provenance != nil {
+		doc["provenance"] = bson.M{
+			"source_type":     provenance.SourceType,
+			"url":             provenance.URL,
+			"license":         provenance.License,
+			"rights_attested": provenance.RightsAttested,
+		}
+	}
 	docJSON, _ := json.Marshal(doc)
 
 	err := collection.UpdateId(ctx, instructionDataID, bson.M{"$set": doc})
@@ -398,13 +490,109 @@ func (i *InstructionDataDaoImpl) SoftDeleteInstructionDataList(
 			"InstructionDataDaoImpl.SoftDeleteInstructionDataList: failed to delete instruction data list",
 			zap.Error(err), zap.ByteString(config.InstructionDataCollectionName, docJSON),
 		)
-	} else {
-		i.Dao.Logger.Info(
-			"InstructionDataDaoImpl.SoftDeleteInstructionDataList: success",
-			zap.Int64("count", result.ModifiedCount), zap.ByteString(config.InstructionDataCollectionName, docJSON),
+		return nil, err
+	}
+	i.Dao.Logger.Info(
+		"InstructionDataDaoImpl.SoftDeleteInstructionDataList: success",
+		zap.Int64("count", result.ModifiedCount), zap.ByteString(config.InstructionDataCollectionName, docJSON),
+	)
+	return &result.ModifiedCount, nil
+}
+
+// SoftDeleteUserInstructionDataList soft deletes the instruction data of a user deleted at deletedAt, with the same
+// deletion time, so that RestoreUserInstructionDataList restores only the data deleted along with the user.
+func (i *InstructionDataDaoImpl) SoftDeleteUserInstructionDataList(
+	ctx context.Context, userID primitive.ObjectID, deletedAt time.Time,
+) (*int64, error) {
+	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"user_id": userID, "deleted": false},
+		bson.M{"$set": bson.M{"deleted": true, "deleted_at": deletedAt}},
+	)
+	if err != nil {
+		i.Dao.Logger.Error(
+			"InstructionDataDaoImpl.SoftDeleteUserInstructionDataList: failed to delete instruction data list",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	i.Dao.Logger.Info(
+		"InstructionDataDaoImpl.SoftDeleteUserInstructionDataList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
+
+// RestoreUserInstructionDataList restores the instruction data soft deleted along with the user deleted at deletedAt.
+// The data deleted on its own stays deleted.
+func (i *InstructionDataDaoImpl) RestoreUserInstructionDataList(
+	ctx context.Context, userID primitive.ObjectID, deletedAt time.Time,
+) (*int64, error) {
+	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"user_id": userID, "deleted": true, "deleted_at": deletedAt},
+		bson.M{"$set": bson.M{"deleted": false, "deleted_at": nil}},
+	)
+	if err != nil {
+		i.Dao.Logger.Error(
+			"InstructionDataDaoImpl.RestoreUserInstructionDataList: failed to restore instruction data list",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	i.Dao.Logger.Info(
+		"InstructionDataDaoImpl.RestoreUserInstructionDataList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
+
+// PseudonymizeInstructionDataList attributes the approved instruction data of the user to the pseudonymous ID and
+// username, so that it no longer links to the user.
+func (i *InstructionDataDaoImpl) PseudonymizeInstructionDataList(
+	ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+) (*int64, error) {
+	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
+	doc := bson.M{"user_id": userID, "deleted": false, "status.code": config.InstructionDataStatusApproved}
+	result, err := collection.UpdateAll(
+		ctx, doc, bson.M{"$set": bson.M{"user_id": pseudonymID, "username": pseudonym, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		i.Dao.Logger.Error(
+			"InstructionDataDaoImpl.PseudonymizeInstructionDataList: failed to update instruction data list",
+			zap.Error(err), zap.String("userID", userID.Hex()),
 		)
+		return nil, err
 	}
-	return &result.ModifiedCount, err
+	i.Dao.Logger.Info(
+		"InstructionDataDaoImpl.PseudonymizeInstructionDataList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
+
+// ReassignInstructionDataList attributes the instruction data of the user to another user.
+func (i *InstructionDataDaoImpl) ReassignInstructionDataList(
+	ctx context.Context, userID, toUserID primitive.ObjectID, toUsername string,
+) (*int64, error) {
+	collection := i.Dao.Mongo.MongoClient.Database(i.Dao.Mongo.DatabaseName).Collection(config.InstructionDataCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"user_id": userID, "deleted": false},
+		bson.M{"$set": bson.M{"user_id": toUserID, "username": toUsername, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		i.Dao.Logger.Error(
+			"InstructionDataDaoImpl.ReassignInstructionDataList: failed to update instruction data list",
+			zap.Error(err), zap.String("userID", userID.Hex()), zap.String("toUserID", toUserID.Hex()),
+		)
+		return nil, err
+	}
+	i.Dao.Logger.Info(
+		"InstructionDataDaoImpl.ReassignInstructionDataList: success",
+		zap.Int64("count", result.ModifiedCount),
+		zap.String("userID", userID.Hex()), zap.String("toUserID", toUserID.Hex()),
+	)
+	return &result.ModifiedCount, nil
 }
 
 func (i *InstructionDataDaoImpl) DeleteInstructionData(
@@ -0,0 +1,243 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type InviteCodeDao interface {
+	GetInviteCodeByID(ctx context.Context, inviteCodeID primitive.ObjectID) (*entity.InviteCodeModel, error)
+	GetInviteCodeList(
+		ctx context.Context, offset, limit int64, desc bool, role, organization *string,
+	) ([]entity.InviteCodeModel, *int64, error)
+	InsertInviteCode(
+		ctx context.Context, code, role, organization string, maxUses int64, expiresAt time.Time,
+		createdBy primitive.ObjectID,
+	) (primitive.ObjectID, error)
+	RedeemInviteCode(ctx context.Context, code string) (*entity.InviteCodeModel, error)
+	ReleaseInviteCode(ctx context.Context, inviteCodeID primitive.ObjectID) error
+	InsertRedemption(ctx context.Context, inviteCodeID, userID primitive.ObjectID, username string) error
+	DeleteInviteCode(ctx context.Context, inviteCodeID primitive.ObjectID) error
+}
+
+type InviteCodeDaoImpl struct {
+	core *dao.Core
+}
+
+func NewInviteCodeDao(ctx context.Context, core *dao.Core) (InviteCodeDao, error) {
+	var _ InviteCodeDao = (*InviteCodeDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"code"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"role"}}, {Key: []string{"organization"}}, {Key: []string{"created_at"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.InviteCodeCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &InviteCodeDaoImpl{core}, nil
+}
+
+func (i *InviteCodeDaoImpl) GetInviteCodeByID(
+	ctx context.Context, inviteCodeID primitive.ObjectID,
+) (*entity.InviteCodeModel, error) {
+	var inviteCode entity.InviteCodeModel
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	err := collection.Find(ctx, bson.M{"_id": inviteCodeID}).One(&inviteCode)
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.GetInviteCodeByID: failed to find invite code", zap.Error(err),
+			zap.String("inviteCodeID", inviteCodeID.Hex()),
+		)
+		return nil, err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.GetInviteCodeByID: success", zap.String("inviteCodeID", inviteCodeID.Hex()),
+	)
+	return &inviteCode, nil
+}
+
+func (i *InviteCodeDaoImpl) GetInviteCodeList(
+	ctx context.Context, offset, limit int64, desc bool, role, organization *string,
+) ([]entity.InviteCodeModel, *int64, error) {
+	var inviteCodeList []entity.InviteCodeModel
+	var err error
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	doc := bson.M{}
+	if role != nil {
+		doc["role"] = *role
+	}
+	if organization != nil {
+		doc["organization"] = *organization
+	}
+	docJSON, _ := json.Marshal(doc)
+	cursor := collection.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.GetInviteCodeList: failed to count invite codes",
+			zap.Error(err), zap.ByteString(config.InviteCodeCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-created_at").Skip(offset).Limit(limit).All(&inviteCodeList)
+	} else {
+		err = cursor.Skip(offset).Limit(limit).All(&inviteCodeList)
+	}
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.GetInviteCodeList: failed to find invite codes",
+			zap.Error(err), zap.ByteString(config.InviteCodeCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.GetInviteCodeList: success",
+		zap.Int64("count", count), zap.ByteString(config.InviteCodeCollectionName, docJSON),
+	)
+	return inviteCodeList, &count, nil
+}
+
+func (i *InviteCodeDaoImpl) InsertInviteCode(
+	ctx context.Context, code, role, organization string, maxUses int64, expiresAt time.Time,
+	createdBy primitive.ObjectID,
+) (primitive.ObjectID, error) {
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	doc := bson.M{
+		"code":         code,
+		"role":         role,
+		"organization": organization,
+		"max_uses":     maxUses,
+		"used_count":   int64(0),
+		"redemptions":  bson.A{},
+		"created_by":   createdBy,
+		"expires_at":   expiresAt,
+		"created_at":   time.Now(),
+		"updated_at":   time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	result, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.InsertInviteCode: failed to insert invite code", zap.Error(err),
+			zap.ByteString(config.InviteCodeCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.InsertInviteCode: success",
+		zap.String("inviteCodeID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.InviteCodeCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+// RedeemInviteCode atomically takes one use of the invite code and returns the updated code. A code which does not
+// exist, has expired or has no uses left is not found.
+func (i *InviteCodeDaoImpl) RedeemInviteCode(ctx context.Context, code string) (*entity.InviteCodeModel, error) {
+	var inviteCode entity.InviteCodeModel
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	filter := bson.M{
+		"code":       code,
+		"expires_at": bson.M{"$gt": time.Now()},
+		"$expr":      bson.M{"$lt": bson.A{"$used_count", "$max_uses"}},
+	}
+	err := collection.Find(ctx, filter).Apply(
+		qmgo.Change{
+			Update:    bson.M{"$inc": bson.M{"used_count": 1}, "$set": bson.M{"updated_at": time.Now()}},
+			ReturnNew: true,
+		}, &inviteCode,
+	)
+	if err != nil {
+		i.core.Logger.Error("InviteCodeDaoImpl.RedeemInviteCode: failed to redeem invite code", zap.Error(err))
+		return nil, err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.RedeemInviteCode: success", zap.String("inviteCodeID", inviteCode.InviteCodeID.Hex()),
+	)
+	return &inviteCode, nil
+}
+
+// ReleaseInviteCode gives back a use taken by RedeemInviteCode, when the registration failed afterwards.
+func (i *InviteCodeDaoImpl) ReleaseInviteCode(ctx context.Context, inviteCodeID primitive.ObjectID) error {
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	err := collection.UpdateOne(
+		ctx, bson.M{"_id": inviteCodeID, "used_count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"used_count": -1}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.ReleaseInviteCode: failed to release invite code", zap.Error(err),
+			zap.String("inviteCodeID", inviteCodeID.Hex()),
+		)
+		return err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.ReleaseInviteCode: success", zap.String("inviteCodeID", inviteCodeID.Hex()),
+	)
+	return nil
+}
+
+// InsertRedemption records the user registered with the invite code.
+func (i *InviteCodeDaoImpl) InsertRedemption(
+	ctx context.Context, inviteCodeID, userID primitive.ObjectID, username string,
+) error {
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	doc := bson.M{
+		"user_id":     userID,
+		"username":    username,
+		"redeemed_at": time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	err := collection.UpdateId(
+		ctx, inviteCodeID, bson.M{"$push": bson.M{"redemptions": doc}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.InsertRedemption: failed to insert redemption", zap.Error(err),
+			zap.String("inviteCodeID", inviteCodeID.Hex()), zap.ByteString(config.InviteCodeCollectionName, docJSON),
+		)
+		return err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.InsertRedemption: success",
+		zap.String("inviteCodeID", inviteCodeID.Hex()), zap.ByteString(config.InviteCodeCollectionName, docJSON),
+	)
+	return nil
+}
+
+func (i *InviteCodeDaoImpl) DeleteInviteCode(ctx context.Context, inviteCodeID primitive.ObjectID) error {
+	collection := i.core.Mongo.MongoClient.Database(i.core.Mongo.DatabaseName).Collection(config.InviteCodeCollectionName)
+	err := collection.RemoveId(ctx, inviteCodeID)
+	if err != nil {
+		i.core.Logger.Error(
+			"InviteCodeDaoImpl.DeleteInviteCode: failed to delete invite code", zap.Error(err),
+			zap.String("inviteCodeID", inviteCodeID.Hex()),
+		)
+		return err
+	}
+	i.core.Logger.Info(
+		"InviteCodeDaoImpl.DeleteInviteCode: success", zap.String("inviteCodeID", inviteCodeID.Hex()),
+	)
+	return nil
+}
@@ -0,0 +1,136 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// JwtKeyDao persists the JWT key ring, so that tokens survive a restart and are verified by every instance. The
+// private keys are stored encrypted with the configured key encryption key.
+type JwtKeyDao interface {
+	InsertJwtKey(ctx context.Context, key *jwt.Key) error
+	GetJwtKeyList(ctx context.Context) ([]*jwt.Key, error)
+	RetireJwtKeyList(ctx context.Context, exceptKeyID string, expiresAt time.Time) (int64, error)
+}
+
+type JwtKeyDaoImpl struct {
+	core *dao.Core
+}
+
+func NewJwtKeyDao(ctx context.Context, core *dao.Core) (JwtKeyDao, error) {
+	var _ JwtKeyDao = (*JwtKeyDaoImpl)(nil)
+	if core.Config.JWTConfig.KeyEncryptionKey == "" {
+		return nil, fmt.Errorf("jwt_key_encryption_key is not configured")
+	}
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.JwtKeyCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"expires_at"},
+				IndexOptions: opt.Index().SetExpireAfterSeconds(0), // expired keys are removed by MongoDB
+			},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.JwtKeyCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &JwtKeyDaoImpl{core}, nil
+}
+
+func (j *JwtKeyDaoImpl) InsertJwtKey(ctx context.Context, key *jwt.Key) error {
+	collection := j.core.Mongo.MongoClient.Database(j.core.Mongo.DatabaseName).Collection(config.JwtKeyCollectionName)
+	privateKey, err := key.MarshalPrivateKey()
+	if err != nil {
+		j.core.Logger.Error(
+			"JwtKeyDaoImpl.InsertJwtKey: failed to marshal private key", zap.Error(err), zap.String("keyID", key.ID),
+		)
+		return err
+	}
+	if privateKey, err = crypt.Encrypt(j.core.Config.JWTConfig.KeyEncryptionKey, privateKey); err != nil {
+		j.core.Logger.Error(
+			"JwtKeyDaoImpl.InsertJwtKey: failed to encrypt private key", zap.Error(err), zap.String("keyID", key.ID),
+		)
+		return err
+	}
+	doc := entity.JwtKeyModel{
+		KeyID:      key.ID,
+		PrivateKey: privateKey,
+		CreatedAt:  key.CreatedAt,
+	}
+	if _, err = collection.InsertOne(ctx, doc); err != nil {
+		j.core.Logger.Error(
+			"JwtKeyDaoImpl.InsertJwtKey: failed to insert key", zap.Error(err), zap.String("keyID", key.ID),
+		)
+		return err
+	}
+	j.core.Logger.Info("JwtKeyDaoImpl.InsertJwtKey: success", zap.String("keyID", key.ID))
+	return nil
+}
+
+// GetJwtKeyList returns the unexpired keys, the signing ones and the retired ones still verifying tokens. Keys which
+// can not be decrypted with the key encryption key are left out.
+func (j *JwtKeyDaoImpl) GetJwtKeyList(ctx context.Context) ([]*jwt.Key, error) {
+	var keyList []entity.JwtKeyModel
+	collection := j.core.Mongo.MongoClient.Database(j.core.Mongo.DatabaseName).Collection(config.JwtKeyCollectionName)
+	err := collection.Find(
+		ctx, bson.M{"$or": bson.A{bson.M{"expires_at": nil}, bson.M{"expires_at": bson.M{"$gt": time.Now()}}}},
+	).Sort("-created_at").All(&keyList)
+	if err != nil {
+		j.core.Logger.Error("JwtKeyDaoImpl.GetJwtKeyList: failed to find keys", zap.Error(err))
+		return nil, err
+	}
+	keys := make([]*jwt.Key, 0, len(keyList))
+	for _, doc := range keyList {
+		privateKey, err := crypt.Decrypt(j.core.Config.JWTConfig.KeyEncryptionKey, doc.PrivateKey)
+		if err != nil { // stored in plain or under another key encryption key, its tokens are no longer accepted
+			j.core.Logger.Warn(
+				"JwtKeyDaoImpl.GetJwtKeyList: skipping key which can not be decrypted", zap.Error(err),
+				zap.String("keyID", doc.KeyID),
+			)
+			continue
+		}
+		key, err := jwt.ParseKey(doc.KeyID, privateKey, doc.CreatedAt)
+		if err != nil {
+			j.core.Logger.Error(
+				"JwtKeyDaoImpl.GetJwtKeyList: failed to parse private key", zap.Error(err),
+				zap.String("keyID", doc.KeyID),
+			)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	j.core.Logger.Info("JwtKeyDaoImpl.GetJwtKeyList: success", zap.Int("count", len(keys)))
+	return keys, nil
+}
+
+// RetireJwtKeyList retires the signing keys but the excepted one. They keep verifying tokens until expiresAt.
+func (j *JwtKeyDaoImpl) RetireJwtKeyList(ctx context.Context, exceptKeyID string, expiresAt time.Time) (int64, error) {
+	collection := j.core.Mongo.MongoClient.Database(j.core.Mongo.DatabaseName).Collection(config.JwtKeyCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"_id": bson.M{"$ne": exceptKeyID}, "retired_at": nil},
+		bson.M{"$set": bson.M{"retired_at": time.Now(), "expires_at": expiresAt}},
+	)
+	if err != nil {
+		j.core.Logger.Error("JwtKeyDaoImpl.RetireJwtKeyList: failed to retire keys", zap.Error(err))
+		return 0, err
+	}
+	j.core.Logger.Info(
+		"JwtKeyDaoImpl.RetireJwtKeyList: success",
+		zap.String("exceptKeyID", exceptKeyID), zap.Int64("count", result.ModifiedCount),
+	)
+	return result.ModifiedCount, nil
+}
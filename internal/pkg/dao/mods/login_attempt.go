@@ -0,0 +1,198 @@
+package mods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/goccy/go-json"
+	"go.uber.org/zap"
+)
+
+// LoginAttemptDao keeps the failed logins, delays and lockouts of accounts and IP addresses in cache. The kind is
+// either LoginLockoutKindAccount, with the email as value, or LoginLockoutKindIP, with the IP address as value.
+type LoginAttemptDao interface {
+	IncrLoginFailure(ctx context.Context, kind, value string, window time.Duration) (int64, error)
+	ResetLoginFailure(ctx context.Context, kind, value string) error
+	SetLoginDelay(ctx context.Context, kind, value string, delay time.Duration) error
+	GetLoginDelay(ctx context.Context, kind, value string) (time.Duration, error)
+	LockLogin(ctx context.Context, kind, value string, failures int64, duration time.Duration) error
+	GetLoginLockout(ctx context.Context, kind, value string) (*entity.LoginLockoutCache, error)
+	GetLoginLockoutList(ctx context.Context) ([]entity.LoginLockoutCache, error)
+	DeleteLoginLockout(ctx context.Context, kind, value string) error
+}
+
+type LoginAttemptDaoImpl struct {
+	core  *dao.Core
+	cache *dao.Cache
+}
+
+func NewLoginAttemptDao(core *dao.Core, cache *dao.Cache) LoginAttemptDao {
+	var _ LoginAttemptDao = (*LoginAttemptDaoImpl)(nil) // Ensure that the interface is implemented
+	return &LoginAttemptDaoImpl{
+		core:  core,
+		cache: cache,
+	}
+}
+
+func loginAttemptKey(prefix, kind, value string) string {
+	return fmt.Sprintf("%s:%s:%s", prefix, kind, strings.ToLower(value))
+}
+
+// IncrLoginFailure counts a failed login and returns the failures within the window, which starts at the first one.
+func (l *LoginAttemptDaoImpl) IncrLoginFailure(
+	ctx context.Context, kind, value string, window time.Duration,
+) (int64, error) {
+	failures, err := l.cache.Incr(ctx, loginAttemptKey(config.LoginFailureCachePrefix, kind, value), &window)
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.IncrLoginFailure: failed to count failed login",
+			zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+		)
+		return 0, err
+	}
+	return failures, nil
+}
+
+func (l *LoginAttemptDaoImpl) ResetLoginFailure(ctx context.Context, kind, value string) error {
+	err := l.cache.Delete(ctx, loginAttemptKey(config.LoginFailureCachePrefix, kind, value))
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.ResetLoginFailure: failed to reset failed logins",
+			zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+		)
+		return err
+	}
+	return l.cache.Delete(ctx, loginAttemptKey(config.LoginDelayCachePrefix, kind, value))
+}
+
+func (l *LoginAttemptDaoImpl) SetLoginDelay(ctx context.Context, kind, value string, delay time.Duration) error {
+	err := l.cache.Set(ctx, loginAttemptKey(config.LoginDelayCachePrefix, kind, value), config.CacheTrue, &delay)
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.SetLoginDelay: failed to set login delay",
+			zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+		)
+	}
+	return err
+}
+
+// GetLoginDelay returns the time left before the next login is accepted, 0 if there is no delay.
+func (l *LoginAttemptDaoImpl) GetLoginDelay(ctx context.Context, kind, value string) (time.Duration, error) {
+	ttl, err := l.cache.TTL(ctx, loginAttemptKey(config.LoginDelayCachePrefix, kind, value))
+	if errors.Is(err, dao.CacheNil{}) {
+		return 0, nil
+	} else if err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.GetLoginDelay: failed to get login delay",
+			zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+		)
+		return 0, err
+	}
+	return ttl, nil
+}
+
+func (l *LoginAttemptDaoImpl) LockLogin(
+	ctx context.Context, kind, value string, failures int64, duration time.Duration,
+) error {
+	lockout := entity.LoginLockoutCache{
+		Kind:     kind,
+		Value:    strings.ToLower(value),
+		Failures: failures,
+		LockedAt: time.Now(),
+	}
+	lockoutJSON, err := json.Marshal(lockout)
+	if err != nil {
+		l.core.Logger.Error("LoginAttemptDaoImpl.LockLogin: failed to marshal lockout", zap.Error(err))
+		return err
+	}
+	err = l.cache.Set(ctx, loginAttemptKey(config.LoginLockoutCachePrefix, kind, value), string(lockoutJSON), &duration)
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.LockLogin: failed to set lockout",
+			zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+		)
+		return err
+	}
+	l.core.Logger.Info(
+		"LoginAttemptDaoImpl.LockLogin: success",
+		zap.String("kind", kind), zap.String("value", value), zap.Int64("failures", failures),
+	)
+	return nil
+}
+
+// GetLoginLockout returns the lockout of the account or IP address, or dao.CacheNil if it is not locked.
+func (l *LoginAttemptDaoImpl) GetLoginLockout(
+	ctx context.Context, kind, value string,
+) (*entity.LoginLockoutCache, error) {
+	return l.getLoginLockout(ctx, loginAttemptKey(config.LoginLockoutCachePrefix, kind, value))
+}
+
+func (l *LoginAttemptDaoImpl) getLoginLockout(ctx context.Context, key string) (*entity.LoginLockoutCache, error) {
+	lockoutJSON, err := l.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := l.cache.TTL(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var lockout entity.LoginLockoutCache
+	if err = json.Unmarshal([]byte(*lockoutJSON), &lockout); err != nil {
+		l.core.Logger.Error(
+			"LoginAttemptDaoImpl.getLoginLockout: failed to unmarshal lockout",
+			zap.Error(err), zap.String("key", key),
+		)
+		return nil, err
+	}
+	lockout.ExpiresAt = time.Now().Add(ttl)
+	return &lockout, nil
+}
+
+func (l *LoginAttemptDaoImpl) GetLoginLockoutList(ctx context.Context) ([]entity.LoginLockoutCache, error) {
+	keys, err := l.cache.Keys(ctx, config.LoginLockoutCachePrefix+":")
+	if err != nil {
+		l.core.Logger.Error("LoginAttemptDaoImpl.GetLoginLockoutList: failed to list lockouts", zap.Error(err))
+		return nil, err
+	}
+	lockoutList := make([]entity.LoginLockoutCache, 0, len(keys))
+	for _, key := range keys {
+		lockout, err := l.getLoginLockout(ctx, key)
+		if errors.Is(err, dao.CacheNil{}) { // expired in the meantime
+			continue
+		} else if err != nil {
+			l.core.Logger.Error(
+				"LoginAttemptDaoImpl.GetLoginLockoutList: failed to get lockout",
+				zap.Error(err), zap.String("key", key),
+			)
+			return nil, err
+		}
+		lockoutList = append(lockoutList, *lockout)
+	}
+	l.core.Logger.Info("LoginAttemptDaoImpl.GetLoginLockoutList: success", zap.Int("count", len(lockoutList)))
+	return lockoutList, nil
+}
+
+// DeleteLoginLockout lifts the lockout of the account or IP address and forgets its failed logins.
+func (l *LoginAttemptDaoImpl) DeleteLoginLockout(ctx context.Context, kind, value string) error {
+	for _, prefix := range []string{
+		config.LoginLockoutCachePrefix, config.LoginFailureCachePrefix, config.LoginDelayCachePrefix,
+	} {
+		if err := l.cache.Delete(ctx, loginAttemptKey(prefix, kind, value)); err != nil {
+			l.core.Logger.Error(
+				"LoginAttemptDaoImpl.DeleteLoginLockout: failed to delete lockout",
+				zap.Error(err), zap.String("kind", kind), zap.String("value", value),
+			)
+			return err
+		}
+	}
+	l.core.Logger.Info(
+		"LoginAttemptDaoImpl.DeleteLoginLockout: success", zap.String("kind", kind), zap.String("value", value),
+	)
+	return nil
+}
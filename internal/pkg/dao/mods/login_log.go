@@ -11,6 +11,7 @@ import (
 	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/pkg/utils/common"
 	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo"
 	"github.com/qiniu/qmgo/options"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,16 +23,25 @@ type LoginLogDao interface {
 	GetLoginLogList(
 		ctx context.Context,
 		offset, limit int64, desc bool, startTime, endTime *time.Time, userID *primitive.ObjectID,
-		ipAddress, userAgent, query *string,
+		ipAddress, userAgent, status, query *string,
 	) ([]entity.LoginLogModel, *int64, error)
 	InsertLoginLog(
 		ctx context.Context,
 		UserID primitive.ObjectID, IPAddress, UserAgent string,
 	) (primitive.ObjectID, error)
+	InsertFailedLoginLog(
+		ctx context.Context, email, IPAddress, UserAgent, reason string,
+	) (primitive.ObjectID, error)
 	CacheLoginLog(
 		ctx context.Context, userID primitive.ObjectID, IPAddress, UserAgent string,
 	) error
+	CacheFailedLoginLog(
+		ctx context.Context, email, IPAddress, UserAgent, reason string,
+	) error
 	SyncLoginLog(ctx context.Context)
+	PseudonymizeLoginLogList(
+		ctx context.Context, userID primitive.ObjectID, email string, pseudonymID primitive.ObjectID, pseudonym string,
+	) (*int64, error)
 	DeleteLoginLog(ctx context.Context, LoginLogID primitive.ObjectID) error
 	DeleteLoginLogList(
 		ctx context.Context, startTime, endTime *time.Time, userID *primitive.ObjectID,
@@ -49,7 +59,9 @@ func NewLoginLogDao(ctx context.Context, core *dao.Core, cache *dao.Cache, userD
 	var _ LoginLogDao = (*LoginLogDaoImpl)(nil) // Ensure that the interface is implemented
 	coll := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.LoginLogCollectionName)
 	err := coll.CreateIndexes(
-		ctx, []options.IndexModel{{Key: []string{"created_at"}}, {Key: []string{"user_id"}}},
+		ctx, []options.IndexModel{
+			{Key: []string{"created_at"}}, {Key: []string{"user_id"}}, {Key: []string{"status", "-created_at"}},
+		},
 	)
 	if err != nil {
 		core.Logger.Error(
@@ -86,7 +98,7 @@ func (l *LoginLogDaoImpl) GetLoginLogByID(
 func (l *LoginLogDaoImpl) GetLoginLogList(
 	ctx context.Context,
 	offset, limit int64, desc bool, startTime, endTime *time.Time, userID *primitive.ObjectID,
-	ipAddress, userAgent, query *string,
+	ipAddress, userAgent, status, query *string,
 ) ([]entity.LoginLogModel, *int64, error) {
 	coll := l.core.Mongo.MongoClient.Database(l.core.Mongo.DatabaseName).Collection(config.LoginLogCollectionName)
 	var loginLogList []entity.LoginLogModel
@@ -104,6 +116,9 @@ func (l *LoginLogDaoImpl) GetLoginLogList(
 	if userAgent != nil {
 		doc["user_agent"] = *userAgent
 	}
+	if status != nil {
+		doc["status"] = *status
+	}
 	if query != nil {
 		safetyQuery := common.EscapeSpecialChars(*query)
 		pattern := fmt.Sprintf(".*%s.*", safetyQuery)
@@ -160,6 +175,8 @@ func (l *LoginLogDaoImpl) InsertLoginLog(
 		"email":      user.Email,
 		"ip_address": ipAddress,
 		"user_agent": userAgent,
+		"status":     config.LoginStatusSuccess,
+		"reason":     "",
 		"created_at": time.Now(),
 	}
 	docJSON, _ := json.Marshal(doc)
@@ -169,14 +186,58 @@ func (l *LoginLogDaoImpl) InsertLoginLog(
 			"LoginLogDaoImpl.InsertLoginLog: failed to insert login log",
 			zap.Error(err), zap.ByteString(config.LoginLogCollectionName, docJSON),
 		)
-	} else {
-		l.core.Logger.Info(
-			"LoginLogDaoImpl.InsertLoginLog: success",
-			zap.String("loginLogID", result.InsertedID.(primitive.ObjectID).Hex()),
-			zap.ByteString(config.LoginLogCollectionName, docJSON),
+		return primitive.NilObjectID, err
+	}
+	l.core.Logger.Info(
+		"LoginLogDaoImpl.InsertLoginLog: success",
+		zap.String("loginLogID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.LoginLogCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+// InsertFailedLoginLog inserts the log of a failed login. The email may not belong to any user, in which case the
+// user ID is left nil.
+func (l *LoginLogDaoImpl) InsertFailedLoginLog(
+	ctx context.Context, email, ipAddress, userAgent, reason string,
+) (primitive.ObjectID, error) {
+	coll := l.core.Mongo.MongoClient.Database(l.core.Mongo.DatabaseName).Collection(config.LoginLogCollectionName)
+	doc := bson.M{
+		"user_id":    primitive.NilObjectID,
+		"username":   "",
+		"email":      email,
+		"ip_address": ipAddress,
+		"user_agent": userAgent,
+		"status":     config.LoginStatusFailure,
+		"reason":     reason,
+		"created_at": time.Now(),
+	}
+	user, err := l.userDao.GetUserByEmail(ctx, email)
+	if err == nil {
+		doc["user_id"] = user.UserID
+		doc["username"] = user.Username
+	} else if !errors.Is(err, qmgo.ErrNoSuchDocuments) {
+		l.core.Logger.Error(
+			"LoginLogDaoImpl.InsertFailedLoginLog: failed to get user",
+			zap.Error(err), zap.String("email", email),
 		)
+		return primitive.NilObjectID, err
 	}
-	return result.InsertedID.(primitive.ObjectID), err
+	docJSON, _ := json.Marshal(doc)
+	result, err := coll.InsertOne(ctx, doc)
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginLogDaoImpl.InsertFailedLoginLog: failed to insert login log",
+			zap.Error(err), zap.ByteString(config.LoginLogCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	l.core.Logger.Info(
+		"LoginLogDaoImpl.InsertFailedLoginLog: success",
+		zap.String("loginLogID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.LoginLogCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
 }
 
 // CacheLoginLog caches login logs in cache
@@ -187,6 +248,7 @@ func (l *LoginLogDaoImpl) CacheLoginLog(
 		UserIDHex: userID.Hex(),
 		IPAddress: IPAddress,
 		UserAgent: UserAgent,
+		Status:    config.LoginStatusSuccess,
 		CreatedAt: time.Now(),
 	}
 	loginLogJSON, err := json.Marshal(loginLog)
@@ -197,6 +259,26 @@ func (l *LoginLogDaoImpl) CacheLoginLog(
 	return l.cache.RightPush(ctx, config.LoginLogCacheKey, string(loginLogJSON))
 }
 
+// CacheFailedLoginLog caches the log of a failed login in cache
+func (l *LoginLogDaoImpl) CacheFailedLoginLog(
+	ctx context.Context, email, IPAddress, UserAgent, reason string,
+) error {
+	loginLog := entity.LoginLogCache{
+		Email:     email,
+		IPAddress: IPAddress,
+		UserAgent: UserAgent,
+		Status:    config.LoginStatusFailure,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	loginLogJSON, err := json.Marshal(loginLog)
+	if err != nil {
+		l.core.Logger.Error("LoginLogDaoImpl.CacheFailedLoginLog: failed to marshal login log", zap.Error(err))
+		return err
+	}
+	return l.cache.RightPush(ctx, config.LoginLogCacheKey, string(loginLogJSON))
+}
+
 // SyncLoginLog syncs login logs from cache to database
 func (l *LoginLogDaoImpl) SyncLoginLog(ctx context.Context) {
 	for {
@@ -218,6 +300,17 @@ func (l *LoginLogDaoImpl) SyncLoginLog(ctx context.Context) {
 			)
 			continue
 		}
+		if loginLog.Status == config.LoginStatusFailure {
+			if _, err := l.InsertFailedLoginLog(
+				ctx, loginLog.Email, loginLog.IPAddress, loginLog.UserAgent, loginLog.Reason,
+			); err != nil {
+				l.core.Logger.Error(
+					"LoginLogDaoImpl.SyncLoginLog: failed to insert failed login log",
+					zap.Error(err), zap.String("loginLogJSON", *loginLogJSON),
+				)
+			}
+			continue
+		}
 		userID, err := primitive.ObjectIDFromHex(loginLog.UserIDHex)
 		if err != nil {
 			l.core.Logger.Error(
@@ -237,6 +330,30 @@ func (l *LoginLogDaoImpl) SyncLoginLog(ctx context.Context) {
 	}
 }
 
+// PseudonymizeLoginLogList replaces the user ID, username and email of the login logs of the user, including the
+// failed logins with its email, with the pseudonymous ID and username.
+func (l *LoginLogDaoImpl) PseudonymizeLoginLogList(
+	ctx context.Context, userID primitive.ObjectID, email string, pseudonymID primitive.ObjectID, pseudonym string,
+) (*int64, error) {
+	coll := l.core.Mongo.MongoClient.Database(l.core.Mongo.DatabaseName).Collection(config.LoginLogCollectionName)
+	doc := bson.M{"$or": bson.A{bson.M{"user_id": userID}, bson.M{"email": email}}}
+	result, err := coll.UpdateAll(
+		ctx, doc, bson.M{"$set": bson.M{"user_id": pseudonymID, "username": pseudonym, "email": ""}},
+	)
+	if err != nil {
+		l.core.Logger.Error(
+			"LoginLogDaoImpl.PseudonymizeLoginLogList: failed to update login logs",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	l.core.Logger.Info(
+		"LoginLogDaoImpl.PseudonymizeLoginLogList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
+
 func (l *LoginLogDaoImpl) DeleteLoginLog(ctx context.Context, loginLogID primitive.ObjectID) error {
 	coll := l.core.Mongo.MongoClient.Database(l.core.Mongo.DatabaseName).Collection(config.LoginLogCollectionName)
 	err := coll.RemoveId(ctx, loginLogID)
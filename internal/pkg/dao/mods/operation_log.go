@@ -33,6 +33,9 @@ type OperationLogDao interface {
 		ipAddress, userAgent, operation, entityType, description, status string,
 	) error
 	SyncOperationLog(ctx context.Context)
+	PseudonymizeOperationLogList(
+		ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+	) (*int64, error)
 	DeleteOperationLog(ctx context.Context, operationLogID primitive.ObjectID) error
 	DeleteOperationLogList(
 		ctx context.Context, startTime, endTime *time.Time, userID, entityID *primitive.ObjectID,
@@ -278,6 +281,39 @@ func (o *OperationLogDaoImpl) SyncOperationLog(ctx context.Context) {
 	}
 }
 
+// PseudonymizeOperationLogList replaces the user ID, username and email of the operation logs of the user with the
+// pseudonymous ID and username, and the user as the entity of operations with the pseudonymous ID.
+func (o *OperationLogDaoImpl) PseudonymizeOperationLogList(
+	ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+) (*int64, error) {
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OperationLogCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"user_id": userID},
+		bson.M{"$set": bson.M{"user_id": pseudonymID, "username": pseudonym, "email": ""}},
+	)
+	if err != nil {
+		o.core.Logger.Error(
+			"OperationLogDaoImpl.PseudonymizeOperationLogList: failed to update operation logs",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	if _, err = collection.UpdateAll(
+		ctx, bson.M{"entity_id": userID}, bson.M{"$set": bson.M{"entity_id": pseudonymID}},
+	); err != nil {
+		o.core.Logger.Error(
+			"OperationLogDaoImpl.PseudonymizeOperationLogList: failed to update operation log entities",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	o.core.Logger.Info(
+		"OperationLogDaoImpl.PseudonymizeOperationLogList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("userID", userID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
+
 func (o *OperationLogDaoImpl) DeleteOperationLog(ctx context.Context, operationLogID primitive.ObjectID) error {
 	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OperationLogCollectionName)
 	err := collection.RemoveId(ctx, operationLogID)
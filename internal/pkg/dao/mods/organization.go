@@ -0,0 +1,198 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type OrganizationDao interface {
+	GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*entity.OrganizationModel, error)
+	GetOrganizationByName(ctx context.Context, name string) (*entity.OrganizationModel, error)
+	GetOrganizationList(
+		ctx context.Context, offset, limit int64, desc bool, query *string,
+	) ([]entity.OrganizationModel, *int64, error)
+	InsertOrganization(ctx context.Context, name, description string) (primitive.ObjectID, error)
+	UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, description *string) error
+	DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error
+}
+
+type OrganizationDaoImpl struct {
+	core *dao.Core
+}
+
+func NewOrganizationDao(ctx context.Context, core *dao.Core) (OrganizationDao, error) {
+	var _ OrganizationDao = (*OrganizationDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"name"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"created_at"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.OrganizationCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &OrganizationDaoImpl{core}, nil
+}
+
+func (o *OrganizationDaoImpl) GetOrganizationByID(
+	ctx context.Context, organizationID primitive.ObjectID,
+) (*entity.OrganizationModel, error) {
+	var organization entity.OrganizationModel
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	err := collection.Find(ctx, bson.M{"_id": organizationID}).One(&organization)
+	if err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.GetOrganizationByID: failed to find organization", zap.Error(err),
+			zap.String("organizationID", organizationID.Hex()),
+		)
+		return nil, err
+	}
+	o.core.Logger.Info(
+		"OrganizationDaoImpl.GetOrganizationByID: success", zap.String("organizationID", organizationID.Hex()),
+	)
+	return &organization, nil
+}
+
+func (o *OrganizationDaoImpl) GetOrganizationByName(
+	ctx context.Context, name string,
+) (*entity.OrganizationModel, error) {
+	var organization entity.OrganizationModel
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	err := collection.Find(ctx, bson.M{"name": name}).One(&organization)
+	if err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.GetOrganizationByName: failed to find organization", zap.Error(err),
+			zap.String("name", name),
+		)
+		return nil, err
+	}
+	o.core.Logger.Info("OrganizationDaoImpl.GetOrganizationByName: success", zap.String("name", name))
+	return &organization, nil
+}
+
+func (o *OrganizationDaoImpl) GetOrganizationList(
+	ctx context.Context, offset, limit int64, desc bool, query *string,
+) ([]entity.OrganizationModel, *int64, error) {
+	var organizationList []entity.OrganizationModel
+	var err error
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	doc := bson.M{}
+	if query != nil {
+		pattern := fmt.Sprintf(".*%s.*", common.EscapeSpecialChars(*query))
+		doc["name"] = bson.M{"$regex": primitive.Regex{Pattern: pattern, Options: "i"}}
+	}
+	docJSON, _ := json.Marshal(doc)
+	cursor := collection.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.GetOrganizationList: failed to count organizations",
+			zap.Error(err), zap.ByteString(config.OrganizationCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-created_at").Skip(offset).Limit(limit).All(&organizationList)
+	} else {
+		err = cursor.Skip(offset).Limit(limit).All(&organizationList)
+	}
+	if err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.GetOrganizationList: failed to find organizations",
+			zap.Error(err), zap.ByteString(config.OrganizationCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	o.core.Logger.Info(
+		"OrganizationDaoImpl.GetOrganizationList: success",
+		zap.Int64("count", count), zap.ByteString(config.OrganizationCollectionName, docJSON),
+	)
+	return organizationList, &count, nil
+}
+
+func (o *OrganizationDaoImpl) InsertOrganization(
+	ctx context.Context, name, description string,
+) (primitive.ObjectID, error) {
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	doc := bson.M{
+		"name":        name,
+		"description": description,
+		"created_at":  time.Now(),
+		"updated_at":  time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	result, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.InsertOrganization: failed to insert organization", zap.Error(err),
+			zap.ByteString(config.OrganizationCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	o.core.Logger.Info(
+		"OrganizationDaoImpl.InsertOrganization: success",
+		zap.String("organizationID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.OrganizationCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+// UpdateOrganization updates the description of the organization. The name is the organization of its members and
+// can not be changed.
+func (o *OrganizationDaoImpl) UpdateOrganization(
+	ctx context.Context, organizationID primitive.ObjectID, description *string,
+) error {
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	doc := bson.M{"updated_at": time.Now()}
+	if description != nil {
+		doc["description"] = *description
+	}
+	docJSON, _ := json.Marshal(doc)
+	if err := collection.UpdateId(ctx, organizationID, bson.M{"$set": doc}); err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.UpdateOrganization: failed to update organization", zap.Error(err),
+			zap.String("organizationID", organizationID.Hex()),
+			zap.ByteString(config.OrganizationCollectionName, docJSON),
+		)
+		return err
+	}
+	o.core.Logger.Info(
+		"OrganizationDaoImpl.UpdateOrganization: success", zap.String("organizationID", organizationID.Hex()),
+		zap.ByteString(config.OrganizationCollectionName, docJSON),
+	)
+	return nil
+}
+
+func (o *OrganizationDaoImpl) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	collection := o.core.Mongo.MongoClient.Database(o.core.Mongo.DatabaseName).Collection(config.OrganizationCollectionName)
+	if err := collection.RemoveId(ctx, organizationID); err != nil {
+		o.core.Logger.Error(
+			"OrganizationDaoImpl.DeleteOrganization: failed to delete organization", zap.Error(err),
+			zap.String("organizationID", organizationID.Hex()),
+		)
+		return err
+	}
+	o.core.Logger.Info(
+		"OrganizationDaoImpl.DeleteOrganization: success", zap.String("organizationID", organizationID.Hex()),
+	)
+	return nil
+}
@@ -0,0 +1,264 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type ReAuditDao interface {
+	GetReAuditByID(ctx context.Context, reAuditID primitive.ObjectID) (*entity.ReAuditModel, error)
+	GetReAuditList(
+		ctx context.Context, offset, limit int64, desc bool, reviewerID, excludeReviewerID *primitive.ObjectID,
+		theme, status *string, startTime, endTime *time.Time,
+	) ([]entity.ReAuditModel, *int64, error)
+	InsertReAudit(
+		ctx context.Context, instructionDataID, reviewerID primitive.ObjectID, reviewerName, theme string,
+	) (primitive.ObjectID, error)
+	UpdateReAudit(ctx context.Context, reAuditID, auditorID primitive.ObjectID, status, message string) error
+	GetUserReAuditList(ctx context.Context, userID primitive.ObjectID) ([]entity.ReAuditModel, error)
+	PseudonymizeReAuditList(
+		ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+	) (*int64, error)
+}
+
+type ReAuditDaoImpl struct {
+	core    *dao.Core
+	userDao UserDao
+}
+
+func NewReAuditDao(ctx context.Context, core *dao.Core, userDao UserDao) (ReAuditDao, error) {
+	var _ ReAuditDao = (*ReAuditDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"instruction_data_id", "reviewer_id"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"reviewer_id"}}, {Key: []string{"auditor_id"}}, {Key: []string{"theme"}},
+			{Key: []string{"status"}}, {Key: []string{"created_at"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(fmt.Sprintf("Failed to create indexes for %s", config.ReAuditCollectionName), zap.Error(err))
+		return nil, err
+	}
+	return &ReAuditDaoImpl{core, userDao}, nil
+}
+
+func (r *ReAuditDaoImpl) GetReAuditByID(ctx context.Context, reAuditID primitive.ObjectID) (*entity.ReAuditModel, error) {
+	var reAudit entity.ReAuditModel
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	err := collection.Find(ctx, bson.M{"_id": reAuditID}).One(&reAudit)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.GetReAuditByID: failed to find re-audit", zap.Error(err),
+			zap.String("reAuditID", reAuditID.Hex()),
+		)
+		return nil, err
+	}
+	r.core.Logger.Info("ReAuditDaoImpl.GetReAuditByID: success", zap.String("reAuditID", reAuditID.Hex()))
+	return &reAudit, nil
+}
+
+// GetReAuditList returns the sampled records, the time range applies to the time of sampling. Records approved by
+// excludeReviewerID are left out, so that the queue of an admin never contains their own approvals. A limit of 0
+// returns all matching records.
+func (r *ReAuditDaoImpl) GetReAuditList(
+	ctx context.Context, offset, limit int64, desc bool, reviewerID, excludeReviewerID *primitive.ObjectID,
+	theme, status *string, startTime, endTime *time.Time,
+) ([]entity.ReAuditModel, *int64, error) {
+	var reAuditList []entity.ReAuditModel
+	var err error
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	doc := bson.M{}
+	reviewerDoc := bson.M{}
+	if reviewerID != nil {
+		reviewerDoc["$eq"] = *reviewerID
+	}
+	if excludeReviewerID != nil {
+		reviewerDoc["$ne"] = *excludeReviewerID
+	}
+	if len(reviewerDoc) > 0 {
+		doc["reviewer_id"] = reviewerDoc
+	}
+	if theme != nil {
+		doc["theme"] = *theme
+	}
+	if status != nil {
+		doc["status"] = *status
+	}
+	if startTime != nil && endTime != nil {
+		doc["created_at"] = bson.M{"$gte": *startTime, "$lte": *endTime}
+	}
+	docJSON, _ := json.Marshal(doc)
+	cursor := collection.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.GetReAuditList: failed to count re-audits",
+			zap.Error(err), zap.ByteString(config.ReAuditCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-created_at").Skip(offset).Limit(limit).All(&reAuditList)
+	} else {
+		err = cursor.Skip(offset).Limit(limit).All(&reAuditList)
+	}
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.GetReAuditList: failed to find re-audits",
+			zap.Error(err), zap.ByteString(config.ReAuditCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	r.core.Logger.Info(
+		"ReAuditDaoImpl.GetReAuditList: success",
+		zap.Int64("count", count), zap.ByteString(config.ReAuditCollectionName, docJSON),
+	)
+	return reAuditList, &count, nil
+}
+
+// InsertReAudit puts the instruction data approved by the reviewer into the re-audit queue. A record already sampled
+// for the same reviewer results in a duplicate key error.
+func (r *ReAuditDaoImpl) InsertReAudit(
+	ctx context.Context, instructionDataID, reviewerID primitive.ObjectID, reviewerName, theme string,
+) (primitive.ObjectID, error) {
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	doc := bson.M{
+		"instruction_data_id": instructionDataID,
+		"reviewer_id":         reviewerID,
+		"reviewer_name":       reviewerName,
+		"theme":               theme,
+		"auditor_id":          primitive.NilObjectID,
+		"auditor_name":        "",
+		"status":              config.ReAuditStatusPending,
+		"message":             "",
+		"created_at":          time.Now(),
+		"updated_at":          time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	result, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.InsertReAudit: failed to insert re-audit", zap.Error(err),
+			zap.ByteString(config.ReAuditCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	r.core.Logger.Info(
+		"ReAuditDaoImpl.InsertReAudit: success",
+		zap.String("reAuditID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.ReAuditCollectionName, docJSON),
+	)
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+// UpdateReAudit records the outcome of a pending re-audit. A re-audit which is no longer pending is not found.
+func (r *ReAuditDaoImpl) UpdateReAudit(
+	ctx context.Context, reAuditID, auditorID primitive.ObjectID, status, message string,
+) error {
+	auditor, err := r.userDao.GetUserByID(ctx, auditorID)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.UpdateReAudit: failed to GetUserByID",
+			zap.String("auditorID", auditorID.Hex()), zap.Error(err),
+		)
+		return err
+	}
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	doc := bson.M{
+		"auditor_id":   auditorID,
+		"auditor_name": auditor.Username,
+		"status":       status,
+		"message":      message,
+		"updated_at":   time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	err = collection.UpdateOne(
+		ctx, bson.M{"_id": reAuditID, "status": config.ReAuditStatusPending}, bson.M{"$set": doc},
+	)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.UpdateReAudit: failed to update re-audit", zap.Error(err),
+			zap.String("reAuditID", reAuditID.Hex()), zap.ByteString(config.ReAuditCollectionName, docJSON),
+		)
+		return err
+	}
+	r.core.Logger.Info(
+		"ReAuditDaoImpl.UpdateReAudit: success",
+		zap.String("reAuditID", reAuditID.Hex()), zap.ByteString(config.ReAuditCollectionName, docJSON),
+	)
+	return nil
+}
+
+// GetUserReAuditList returns the records approved or re-audited by the user.
+func (r *ReAuditDaoImpl) GetUserReAuditList(
+	ctx context.Context, userID primitive.ObjectID,
+) ([]entity.ReAuditModel, error) {
+	var reAuditList []entity.ReAuditModel
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	err := collection.Find(
+		ctx, bson.M{"$or": bson.A{bson.M{"reviewer_id": userID}, bson.M{"auditor_id": userID}}},
+	).Sort("created_at").All(&reAuditList)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.GetUserReAuditList: failed to find re-audits",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	r.core.Logger.Info(
+		"ReAuditDaoImpl.GetUserReAuditList: success",
+		zap.Int("count", len(reAuditList)), zap.String("userID", userID.Hex()),
+	)
+	return reAuditList, nil
+}
+
+// PseudonymizeReAuditList replaces the ID and username of the user, both as the reviewer and as the auditor of the
+// records, with the pseudonymous ID and username. The count is that of the records updated in either role.
+func (r *ReAuditDaoImpl) PseudonymizeReAuditList(
+	ctx context.Context, userID, pseudonymID primitive.ObjectID, pseudonym string,
+) (*int64, error) {
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReAuditCollectionName)
+	reviewed, err := collection.UpdateAll(
+		ctx, bson.M{"reviewer_id": userID},
+		bson.M{"$set": bson.M{"reviewer_id": pseudonymID, "reviewer_name": pseudonym}},
+	)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.PseudonymizeReAuditList: failed to update re-audit reviewers",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	audited, err := collection.UpdateAll(
+		ctx, bson.M{"auditor_id": userID},
+		bson.M{"$set": bson.M{"auditor_id": pseudonymID, "auditor_name": pseudonym}},
+	)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReAuditDaoImpl.PseudonymizeReAuditList: failed to update re-audit auditors",
+			zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	count := reviewed.ModifiedCount + audited.ModifiedCount
+	r.core.Logger.Info(
+		"ReAuditDaoImpl.PseudonymizeReAuditList: success",
+		zap.Int64("count", count), zap.String("userID", userID.Hex()),
+	)
+	return &count, nil
+}
@@ -0,0 +1,175 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type ReviewDao interface {
+	GetReviewList(
+		ctx context.Context, offset, limit int64, desc bool, instructionDataID, reviewerID *primitive.ObjectID,
+		theme, decision *string, startTime, endTime *time.Time,
+	) ([]entity.ReviewModel, *int64, error)
+	UpsertReview(
+		ctx context.Context, instructionDataID, reviewerID primitive.ObjectID, theme, decision, message string,
+	) error
+	PseudonymizeReviewList(
+		ctx context.Context, reviewerID, pseudonymID primitive.ObjectID, pseudonym string,
+	) (*int64, error)
+}
+
+type ReviewDaoImpl struct {
+	core    *dao.Core
+	userDao UserDao
+}
+
+func NewReviewDao(ctx context.Context, core *dao.Core, userDao UserDao) (ReviewDao, error) {
+	var _ ReviewDao = (*ReviewDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.ReviewCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"instruction_data_id", "reviewer_id"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"reviewer_id"}}, {Key: []string{"theme"}}, {Key: []string{"updated_at"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(fmt.Sprintf("Failed to create indexes for %s", config.ReviewCollectionName), zap.Error(err))
+		return nil, err
+	}
+	return &ReviewDaoImpl{core, userDao}, nil
+}
+
+// GetReviewList returns the review decisions, the time range applies to the time of the latest decision. A limit of
+// 0 returns all matching decisions.
+func (r *ReviewDaoImpl) GetReviewList(
+	ctx context.Context, offset, limit int64, desc bool, instructionDataID, reviewerID *primitive.ObjectID,
+	theme, decision *string, startTime, endTime *time.Time,
+) ([]entity.ReviewModel, *int64, error) {
+	var reviewList []entity.ReviewModel
+	var err error
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReviewCollectionName)
+	doc := bson.M{}
+	if instructionDataID != nil {
+		doc["instruction_data_id"] = *instructionDataID
+	}
+	if reviewerID != nil {
+		doc["reviewer_id"] = *reviewerID
+	}
+	if theme != nil {
+		doc["theme"] = *theme
+	}
+	if decision != nil {
+		doc["decision"] = *decision
+	}
+	if startTime != nil && endTime != nil {
+		doc["updated_at"] = bson.M{"$gte": *startTime, "$lte": *endTime}
+	}
+	docJSON, _ := json.Marshal(doc)
+	cursor := collection.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		r.core.Logger.Error(
+			"ReviewDaoImpl.GetReviewList: failed to count reviews",
+			zap.Error(err), zap.ByteString(config.ReviewCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-updated_at").Skip(offset).Limit(limit).All(&reviewList)
+	} else {
+		err = cursor.Skip(offset).Limit(limit).All(&reviewList)
+	}
+	if err != nil {
+		r.core.Logger.Error(
+			"ReviewDaoImpl.GetReviewList: failed to find reviews",
+			zap.Error(err), zap.ByteString(config.ReviewCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	r.core.Logger.Info(
+		"ReviewDaoImpl.GetReviewList: success",
+		zap.Int64("count", count), zap.ByteString(config.ReviewCollectionName, docJSON),
+	)
+	return reviewList, &count, nil
+}
+
+// UpsertReview records the decision of the reviewer on the instruction data. Each reviewer has at most one decision
+// per instruction data, a later decision replaces the earlier one.
+func (r *ReviewDaoImpl) UpsertReview(
+	ctx context.Context, instructionDataID, reviewerID primitive.ObjectID, theme, decision, message string,
+) error {
+	reviewer, err := r.userDao.GetUserByID(ctx, reviewerID)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReviewDaoImpl.UpsertReview: failed to GetUserByID",
+			zap.String("reviewerID", reviewerID.Hex()), zap.Error(err),
+		)
+		return err
+	}
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReviewCollectionName)
+	filter := bson.M{"instruction_data_id": instructionDataID, "reviewer_id": reviewerID}
+	doc := bson.M{
+		"reviewer_name": reviewer.Username,
+		"theme":         theme,
+		"decision":      decision,
+		"message":       message,
+		"updated_at":    time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	err = collection.UpdateOne(
+		ctx, filter, bson.M{"$set": doc, "$setOnInsert": bson.M{"created_at": time.Now()}},
+		options.UpdateOptions{UpdateOptions: opt.Update().SetUpsert(true)},
+	)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReviewDaoImpl.UpsertReview: failed to upsert review", zap.Error(err),
+			zap.String("instructionDataID", instructionDataID.Hex()), zap.String("reviewerID", reviewerID.Hex()),
+			zap.ByteString(config.ReviewCollectionName, docJSON),
+		)
+		return err
+	}
+	r.core.Logger.Info(
+		"ReviewDaoImpl.UpsertReview: success",
+		zap.String("instructionDataID", instructionDataID.Hex()), zap.String("reviewerID", reviewerID.Hex()),
+		zap.ByteString(config.ReviewCollectionName, docJSON),
+	)
+	return nil
+}
+
+// PseudonymizeReviewList replaces the reviewer ID and username of the decisions of the reviewer with the pseudonymous
+// ID and username.
+func (r *ReviewDaoImpl) PseudonymizeReviewList(
+	ctx context.Context, reviewerID, pseudonymID primitive.ObjectID, pseudonym string,
+) (*int64, error) {
+	collection := r.core.Mongo.MongoClient.Database(r.core.Mongo.DatabaseName).Collection(config.ReviewCollectionName)
+	result, err := collection.UpdateAll(
+		ctx, bson.M{"reviewer_id": reviewerID},
+		bson.M{"$set": bson.M{"reviewer_id": pseudonymID, "reviewer_name": pseudonym}},
+	)
+	if err != nil {
+		r.core.Logger.Error(
+			"ReviewDaoImpl.PseudonymizeReviewList: failed to update reviews",
+			zap.Error(err), zap.String("reviewerID", reviewerID.Hex()),
+		)
+		return nil, err
+	}
+	r.core.Logger.Info(
+		"ReviewDaoImpl.PseudonymizeReviewList: success",
+		zap.Int64("count", result.ModifiedCount), zap.String("reviewerID", reviewerID.Hex()),
+	)
+	return &result.ModifiedCount, nil
+}
@@ -0,0 +1,357 @@
+package mods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/qiniu/qmgo"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type SessionDao interface {
+	InsertSession(
+		ctx context.Context, userID primitive.ObjectID, ipAddress, userAgent, refreshTokenID string,
+		expiresAt time.Time,
+	) (primitive.ObjectID, error)
+	GetSessionByID(ctx context.Context, sessionID primitive.ObjectID) (*entity.SessionModel, error)
+	GetSessionList(ctx context.Context, userID primitive.ObjectID) ([]entity.SessionModel, error)
+	UpdateSessionLastSeen(ctx context.Context, sessionID primitive.ObjectID, interval time.Duration) error
+	RotateSessionRefreshToken(
+		ctx context.Context, sessionID primitive.ObjectID, refreshTokenID, newRefreshTokenID string,
+		expiresAt time.Time,
+	) error
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+	DeleteSession(ctx context.Context, userID, sessionID primitive.ObjectID) error
+	DeleteSessionList(ctx context.Context, userID primitive.ObjectID, exceptSessionID *primitive.ObjectID) (int64, error)
+	RevokeUserTokens(ctx context.Context, userID primitive.ObjectID) error
+	GetUserTokensValidAfter(ctx context.Context, userID string) (int64, error)
+}
+
+type SessionDaoImpl struct {
+	core  *dao.Core
+	cache *dao.Cache
+}
+
+func NewSessionDao(ctx context.Context, core *dao.Core, cache *dao.Cache) (SessionDao, error) {
+	var _ SessionDao = (*SessionDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{Key: []string{"user_id", "-last_seen_at"}},
+			{
+				Key:          []string{"expires_at"},
+				IndexOptions: opt.Index().SetExpireAfterSeconds(0), // expired sessions are removed by MongoDB
+			},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.SessionCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &SessionDaoImpl{core: core, cache: cache}, nil
+}
+
+func (s *SessionDaoImpl) InsertSession(
+	ctx context.Context, userID primitive.ObjectID, ipAddress, userAgent, refreshTokenID string,
+	expiresAt time.Time,
+) (primitive.ObjectID, error) {
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	now := time.Now()
+	doc := bson.M{
+		"user_id":          userID,
+		"ip_address":       ipAddress,
+		"user_agent":       userAgent,
+		"refresh_token_id": refreshTokenID,
+		"created_at":       now,
+		"last_seen_at":     now,
+		"expires_at":       expiresAt,
+	}
+	result, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.InsertSession: failed to insert session", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return primitive.NilObjectID, err
+	}
+	sessionID := result.InsertedID.(primitive.ObjectID)
+	s.core.Logger.Info(
+		"SessionDaoImpl.InsertSession: success",
+		zap.String("sessionID", sessionID.Hex()), zap.String("userID", userID.Hex()),
+	)
+	return sessionID, nil
+}
+
+// GetSessionByID returns the unexpired session with the ID.
+func (s *SessionDaoImpl) GetSessionByID(
+	ctx context.Context, sessionID primitive.ObjectID,
+) (*entity.SessionModel, error) {
+	var session entity.SessionModel
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	err := collection.Find(ctx, bson.M{"_id": sessionID, "expires_at": bson.M{"$gt": time.Now()}}).One(&session)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.GetSessionByID: failed to find session", zap.Error(err),
+			zap.String("sessionID", sessionID.Hex()),
+		)
+		return nil, err
+	}
+	s.core.Logger.Info("SessionDaoImpl.GetSessionByID: success", zap.String("sessionID", sessionID.Hex()))
+	return &session, nil
+}
+
+// GetSessionList returns the unexpired sessions of the user, the most recently seen first.
+func (s *SessionDaoImpl) GetSessionList(
+	ctx context.Context, userID primitive.ObjectID,
+) ([]entity.SessionModel, error) {
+	var sessionList []entity.SessionModel
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	err := collection.Find(
+		ctx, bson.M{"user_id": userID, "expires_at": bson.M{"$gt": time.Now()}},
+	).Sort("-last_seen_at").All(&sessionList)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.GetSessionList: failed to find sessions", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	s.core.Logger.Info(
+		"SessionDaoImpl.GetSessionList: success",
+		zap.String("userID", userID.Hex()), zap.Int("count", len(sessionList)),
+	)
+	return sessionList, nil
+}
+
+// UpdateSessionLastSeen sets the last-seen time of the session to now, unless it was set within the interval, so
+// that a busy session does not cause a write on every request.
+func (s *SessionDaoImpl) UpdateSessionLastSeen(
+	ctx context.Context, sessionID primitive.ObjectID, interval time.Duration,
+) error {
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	_, err := collection.UpdateAll(
+		ctx, bson.M{"_id": sessionID, "last_seen_at": bson.M{"$lt": time.Now().Add(-interval)}},
+		bson.M{"$set": bson.M{"last_seen_at": time.Now()}},
+	)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.UpdateSessionLastSeen: failed to update session", zap.Error(err),
+			zap.String("sessionID", sessionID.Hex()),
+		)
+		return err
+	}
+	return nil
+}
+
+// RotateSessionRefreshToken replaces the refresh token of the session with a new one and extends the session to its
+// expiry. Returns qmgo.ErrNoSuchDocuments if the refresh token is no longer the one of the session, so that of two
+// concurrent refreshes with the same token only one succeeds.
+func (s *SessionDaoImpl) RotateSessionRefreshToken(
+	ctx context.Context, sessionID primitive.ObjectID, refreshTokenID, newRefreshTokenID string,
+	expiresAt time.Time,
+) error {
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	err := collection.UpdateOne(
+		ctx, bson.M{"_id": sessionID, "refresh_token_id": refreshTokenID},
+		bson.M{
+			"$set": bson.M{
+				"refresh_token_id": newRefreshTokenID, "expires_at": expiresAt, "last_seen_at": time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.RotateSessionRefreshToken: failed to update session", zap.Error(err),
+			zap.String("sessionID", sessionID.Hex()),
+		)
+		return err
+	}
+	s.core.Logger.Info(
+		"SessionDaoImpl.RotateSessionRefreshToken: success", zap.String("sessionID", sessionID.Hex()),
+	)
+	return nil
+}
+
+// IsSessionRevoked reports whether the session has been revoked while access tokens issued for it may still be
+// unexpired.
+func (s *SessionDaoImpl) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	_, err := s.cache.Get(ctx, fmt.Sprintf("%s:%s", config.SessionRevokedCachePrefix, sessionID))
+	if errors.Is(err, dao.CacheNil{}) {
+		return false, nil
+	} else if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.IsSessionRevoked: failed to get revoked session", zap.Error(err),
+			zap.String("sessionID", sessionID),
+		)
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteSession revokes the session of the user. Sessions of other users are not found.
+func (s *SessionDaoImpl) DeleteSession(ctx context.Context, userID, sessionID primitive.ObjectID) error {
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	err := collection.Remove(ctx, bson.M{"_id": sessionID, "user_id": userID})
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.DeleteSession: failed to delete session", zap.Error(err),
+			zap.String("sessionID", sessionID.Hex()), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	if err = s.markRevoked(ctx, sessionID); err != nil {
+		return err
+	}
+	s.core.Logger.Info("SessionDaoImpl.DeleteSession: success", zap.String("sessionID", sessionID.Hex()))
+	return nil
+}
+
+// DeleteSessionList revokes all sessions of the user but the excepted one, and returns the number of revoked sessions.
+func (s *SessionDaoImpl) DeleteSessionList(
+	ctx context.Context, userID primitive.ObjectID, exceptSessionID *primitive.ObjectID,
+) (int64, error) {
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.SessionCollectionName)
+	doc := bson.M{"user_id": userID}
+	if exceptSessionID != nil {
+		doc["_id"] = bson.M{"$ne": *exceptSessionID}
+	}
+	var sessionList []entity.SessionModel
+	if err := collection.Find(ctx, doc).Select(bson.M{"_id": 1}).All(&sessionList); err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.DeleteSessionList: failed to find sessions", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return 0, err
+	}
+	if len(sessionList) == 0 {
+		return 0, nil
+	}
+	sessionIDs := make([]primitive.ObjectID, 0, len(sessionList))
+	for _, session := range sessionList {
+		sessionIDs = append(sessionIDs, session.SessionID)
+	}
+	// Mark first, so that a failure leaves the sessions listed and the revocation can be retried
+	for _, sessionID := range sessionIDs {
+		if err := s.markRevoked(ctx, sessionID); err != nil {
+			return 0, err
+		}
+	}
+	result, err := collection.RemoveAll(ctx, bson.M{"_id": bson.M{"$in": sessionIDs}})
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.DeleteSessionList: failed to delete sessions", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return 0, err
+	}
+	s.core.Logger.Info(
+		"SessionDaoImpl.DeleteSessionList: success",
+		zap.String("userID", userID.Hex()), zap.Int64("count", result.DeletedCount),
+	)
+	return result.DeletedCount, nil
+}
+
+// RevokeUserTokens invalidates every token issued to the user so far, sessions or not, and revokes all sessions of
+// the user. The valid-after time is stored on the user, and cached.
+func (s *SessionDaoImpl) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID) error {
+	validAfter := time.Now().Truncate(time.Millisecond)
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	err := collection.UpdateOne(
+		ctx, bson.M{"_id": userID}, bson.M{"$max": bson.M{"tokens_valid_after": validAfter}},
+	)
+	if err != nil && !errors.Is(err, qmgo.ErrNoSuchDocuments) {
+		s.core.Logger.Error(
+			"SessionDaoImpl.RevokeUserTokens: failed to store valid-after time", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	err = s.cache.Set(
+		ctx, fmt.Sprintf("%s:%s", config.TokenValidAfterCachePrefix, userID.Hex()),
+		strconv.FormatInt(validAfter.UnixMilli(), 10), &s.core.Config.JWTConfig.RefreshDuration,
+	)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.RevokeUserTokens: failed to cache valid-after time", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	if _, err = s.DeleteSessionList(ctx, userID, nil); err != nil {
+		return err
+	}
+	s.core.Logger.Info("SessionDaoImpl.RevokeUserTokens: success", zap.String("userID", userID.Hex()))
+	return nil
+}
+
+// GetUserTokensValidAfter returns the unix time in milliseconds until which tokens of the user were revoked, 0 if none
+// were. The time is read from the user on a cache miss.
+func (s *SessionDaoImpl) GetUserTokensValidAfter(ctx context.Context, userID string) (int64, error) {
+	key := fmt.Sprintf("%s:%s", config.TokenValidAfterCachePrefix, userID)
+	validAfter, err := s.cache.Get(ctx, key)
+	if err == nil {
+		return strconv.ParseInt(*validAfter, 10, 64)
+	} else if !errors.Is(err, dao.CacheNil{}) {
+		s.core.Logger.Error(
+			"SessionDaoImpl.GetUserTokensValidAfter: failed to get valid-after time", zap.Error(err),
+			zap.String("userID", userID),
+		)
+		return 0, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+	var user entity.UserModel
+	collection := s.core.Mongo.MongoClient.Database(s.core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	err = collection.Find(ctx, bson.M{"_id": id}).Select(bson.M{"tokens_valid_after": 1}).One(&user)
+	if err != nil && !errors.Is(err, qmgo.ErrNoSuchDocuments) {
+		s.core.Logger.Error(
+			"SessionDaoImpl.GetUserTokensValidAfter: failed to find user", zap.Error(err),
+			zap.String("userID", userID),
+		)
+		return 0, err
+	}
+	var millis int64
+	if !user.TokensValidAfter.IsZero() {
+		millis = user.TokensValidAfter.UnixMilli()
+	}
+	// set only if absent, so that a revocation cached in the meantime is not overwritten by the time read before it
+	if _, err = s.cache.SetNX(
+		ctx, key, strconv.FormatInt(millis, 10), &s.core.Config.JWTConfig.RefreshDuration,
+	); err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.GetUserTokensValidAfter: failed to cache valid-after time", zap.Error(err),
+			zap.String("userID", userID),
+		)
+	}
+	return millis, nil
+}
+
+// markRevoked remembers the revoked session for as long as its access tokens may be valid.
+func (s *SessionDaoImpl) markRevoked(ctx context.Context, sessionID primitive.ObjectID) error {
+	err := s.cache.Set(
+		ctx, fmt.Sprintf("%s:%s", config.SessionRevokedCachePrefix, sessionID.Hex()), config.CacheTrue,
+		&s.core.Config.JWTConfig.TokenDuration,
+	)
+	if err != nil {
+		s.core.Logger.Error(
+			"SessionDaoImpl.markRevoked: failed to mark session revoked", zap.Error(err),
+			zap.String("sessionID", sessionID.Hex()),
+		)
+	}
+	return err
+}
@@ -0,0 +1,241 @@
+package mods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/utils/common"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type ThemeDao interface {
+	GetThemeByID(ctx context.Context, themeID primitive.ObjectID) (*entity.ThemeModel, error)
+	GetThemeByName(ctx context.Context, name string) (*entity.ThemeModel, error)
+	GetThemeList(ctx context.Context, offset, limit int64, desc bool, query *string) (
+		[]entity.ThemeModel, *int64, error,
+	)
+	InsertTheme(ctx context.Context, name, description, schema string) (primitive.ObjectID, error)
+	UpdateTheme(ctx context.Context, themeID primitive.ObjectID, name, description, schema *string) error
+	DeleteTheme(ctx context.Context, themeID primitive.ObjectID) error
+}
+
+type ThemeDaoImpl struct {
+	core  *dao.Core
+	cache *dao.Cache
+}
+
+func NewThemeDao(ctx context.Context, core *dao.Core, cache *dao.Cache) (ThemeDao, error) {
+	var _ ThemeDao = (*ThemeDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"name"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+			{Key: []string{"created_at"}},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(fmt.Sprintf("Failed to create indexes for %s", config.ThemeCollectionName), zap.Error(err))
+		return nil, err
+	}
+	return &ThemeDaoImpl{core, cache}, nil
+}
+
+func (t *ThemeDaoImpl) GetThemeByID(ctx context.Context, themeID primitive.ObjectID) (*entity.ThemeModel, error) {
+	var theme entity.ThemeModel
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	err := collection.Find(ctx, bson.M{"_id": themeID}).One(&theme)
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.GetThemeByID: failed to find theme", zap.Error(err), zap.String("themeID", themeID.Hex()),
+		)
+		return nil, err
+	}
+	t.core.Logger.Info("ThemeDaoImpl.GetThemeByID: success", zap.String("themeID", themeID.Hex()))
+	return &theme, nil
+}
+
+func (t *ThemeDaoImpl) GetThemeByName(ctx context.Context, name string) (*entity.ThemeModel, error) {
+	var theme entity.ThemeModel
+	key := fmt.Sprintf("%s:name:%s", config.ThemeCachePrefix, name)
+	cache, err := t.cache.Get(ctx, key)
+	if errors.Is(err, dao.CacheNil{}) {
+		t.core.Logger.Info("ThemeDaoImpl.GetThemeByName: cache miss", zap.String("name", name))
+	} else if err != nil {
+		t.core.Logger.Error("ThemeDaoImpl.GetThemeByName: failed to get cache", zap.Error(err), zap.String("key", key))
+	} else {
+		t.core.Logger.Info("ThemeDaoImpl.GetThemeByName: cache hit", zap.String("name", name))
+		if err = json.Unmarshal([]byte(*cache), &theme); err != nil {
+			t.core.Logger.Error(
+				"ThemeDaoImpl.GetThemeByName: failed to unmarshal cache", zap.Error(err),
+				zap.String("name", name), zap.String("cache", *cache),
+			)
+			return nil, err
+		}
+		return &theme, nil
+	}
+
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	if err = collection.Find(ctx, bson.M{"name": name}).One(&theme); err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.GetThemeByName: failed to find theme", zap.Error(err), zap.String("name", name),
+		)
+		return nil, err
+	}
+	docJSON, _ := json.Marshal(theme)
+	if err = t.cache.Set(ctx, key, string(docJSON), &t.core.Config.CacheConfig.ThemeCacheTTL); err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.GetThemeByName: failed to set cache", zap.Error(err),
+			zap.String("key", key), zap.ByteString(config.ThemeCollectionName, docJSON),
+		)
+	} else {
+		t.core.Logger.Info("ThemeDaoImpl.GetThemeByName: cache set", zap.String("key", key))
+	}
+	t.core.Logger.Info("ThemeDaoImpl.GetThemeByName: success", zap.String("name", name))
+	return &theme, nil
+}
+
+func (t *ThemeDaoImpl) GetThemeList(
+	ctx context.Context, offset, limit int64, desc bool, query *string,
+) ([]entity.ThemeModel, *int64, error) {
+	var themeList []entity.ThemeModel
+	var err error
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	doc := bson.M{}
+	if query != nil {
+		safetyQuery := common.EscapeSpecialChars(*query)
+		pattern := fmt.Sprintf(".*%s.*", safetyQuery)
+		doc["$or"] = []bson.M{
+			{"name": bson.M{"$regex": primitive.Regex{Pattern: pattern, Options: "i"}}},
+			{"description": bson.M{"$regex": primitive.Regex{Pattern: pattern, Options: "i"}}},
+		}
+	}
+	docJSON, _ := json.Marshal(doc)
+	cursor := collection.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.GetThemeList: failed to count themes",
+			zap.Error(err), zap.ByteString(config.ThemeCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-created_at").Skip(offset).Limit(limit).All(&themeList)
+	} else {
+		err = cursor.Skip(offset).Limit(limit).All(&themeList)
+	}
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.GetThemeList: failed to find themes",
+			zap.Error(err), zap.ByteString(config.ThemeCollectionName, docJSON),
+		)
+		return nil, nil, err
+	}
+	t.core.Logger.Info(
+		"ThemeDaoImpl.GetThemeList: success",
+		zap.Int64("count", count), zap.ByteString(config.ThemeCollectionName, docJSON),
+	)
+	return themeList, &count, nil
+}
+
+func (t *ThemeDaoImpl) InsertTheme(
+	ctx context.Context, name, description, schema string,
+) (primitive.ObjectID, error) {
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	doc := bson.M{
+		"name":        name,
+		"description": description,
+		"schema":      schema,
+		"created_at":  time.Now(),
+		"updated_at":  time.Now(),
+	}
+	docJSON, _ := json.Marshal(doc)
+	result, err := collection.InsertOne(ctx, doc)
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.InsertTheme: failed to insert theme", zap.Error(err),
+			zap.ByteString(config.ThemeCollectionName, docJSON),
+		)
+		return primitive.NilObjectID, err
+	}
+	t.core.Logger.Info(
+		"ThemeDaoImpl.InsertTheme: success",
+		zap.String("themeID", result.InsertedID.(primitive.ObjectID).Hex()),
+		zap.ByteString(config.ThemeCollectionName, docJSON),
+	)
+	prefix := config.ThemeCachePrefix
+	if err = t.cache.Flush(ctx, &prefix); err != nil {
+		t.core.Logger.Error("ThemeDaoImpl.InsertTheme: failed to flush cache", zap.Error(err))
+	} else {
+		t.core.Logger.Info("ThemeDaoImpl.InsertTheme: cache flush success")
+	}
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+func (t *ThemeDaoImpl) UpdateTheme(
+	ctx context.Context, themeID primitive.ObjectID, name, description, schema *string,
+) error {
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	doc := bson.M{"updated_at": time.Now()}
+	if name != nil {
+		doc["name"] = *name
+	}
+	if description != nil {
+		doc["description"] = *description
+	}
+	if schema != nil {
+		doc["schema"] = *schema
+	}
+	docJSON, _ := json.Marshal(doc)
+	err := collection.UpdateId(ctx, themeID, bson.M{"$set": doc})
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.UpdateTheme: failed to update theme", zap.Error(err),
+			zap.String("themeID", themeID.Hex()), zap.ByteString(config.ThemeCollectionName, docJSON),
+		)
+		return err
+	}
+	t.core.Logger.Info(
+		"ThemeDaoImpl.UpdateTheme: success",
+		zap.String("themeID", themeID.Hex()), zap.ByteString(config.ThemeCollectionName, docJSON),
+	)
+	prefix := config.ThemeCachePrefix
+	if err = t.cache.Flush(ctx, &prefix); err != nil {
+		t.core.Logger.Error("ThemeDaoImpl.UpdateTheme: failed to flush cache", zap.Error(err))
+	} else {
+		t.core.Logger.Info("ThemeDaoImpl.UpdateTheme: cache flush success")
+	}
+	return nil
+}
+
+func (t *ThemeDaoImpl) DeleteTheme(ctx context.Context, themeID primitive.ObjectID) error {
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.ThemeCollectionName)
+	err := collection.RemoveId(ctx, themeID)
+	if err != nil {
+		t.core.Logger.Error(
+			"ThemeDaoImpl.DeleteTheme: failed to delete theme", zap.Error(err), zap.String("themeID", themeID.Hex()),
+		)
+		return err
+	}
+	t.core.Logger.Info("ThemeDaoImpl.DeleteTheme: success", zap.String("themeID", themeID.Hex()))
+	prefix := config.ThemeCachePrefix
+	if err = t.cache.Flush(ctx, &prefix); err != nil {
+		t.core.Logger.Error("ThemeDaoImpl.DeleteTheme: failed to flush cache", zap.Error(err))
+	} else {
+		t.core.Logger.Info("ThemeDaoImpl.DeleteTheme: cache flush success")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	opt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type TwoFactorPolicyDao interface {
+	GetTwoFactorPolicyByRole(ctx context.Context, role string) (*entity.TwoFactorPolicyModel, error)
+	GetTwoFactorPolicyList(ctx context.Context) ([]entity.TwoFactorPolicyModel, error)
+	UpsertTwoFactorPolicy(ctx context.Context, role string, enforced bool, updatedBy primitive.ObjectID) error
+}
+
+type TwoFactorPolicyDaoImpl struct {
+	core *dao.Core
+}
+
+func NewTwoFactorPolicyDao(ctx context.Context, core *dao.Core) (TwoFactorPolicyDao, error) {
+	var _ TwoFactorPolicyDao = (*TwoFactorPolicyDaoImpl)(nil)
+	collection := core.Mongo.MongoClient.Database(core.Mongo.DatabaseName).Collection(config.TwoFactorPolicyCollectionName)
+	err := collection.CreateIndexes(
+		ctx, []options.IndexModel{
+			{
+				Key:          []string{"role"},
+				IndexOptions: opt.Index().SetUnique(true),
+			},
+		},
+	)
+	if err != nil {
+		core.Logger.Error(
+			fmt.Sprintf("Failed to create indexes for %s", config.TwoFactorPolicyCollectionName), zap.Error(err),
+		)
+		return nil, err
+	}
+	return &TwoFactorPolicyDaoImpl{core}, nil
+}
+
+func (t *TwoFactorPolicyDaoImpl) GetTwoFactorPolicyByRole(
+	ctx context.Context, role string,
+) (*entity.TwoFactorPolicyModel, error) {
+	var policy entity.TwoFactorPolicyModel
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.TwoFactorPolicyCollectionName)
+	err := collection.Find(ctx, bson.M{"role": role}).One(&policy)
+	if err != nil {
+		t.core.Logger.Error(
+			"TwoFactorPolicyDaoImpl.GetTwoFactorPolicyByRole: failed to find policy", zap.Error(err),
+			zap.String("role", role),
+		)
+		return nil, err
+	}
+	t.core.Logger.Info("TwoFactorPolicyDaoImpl.GetTwoFactorPolicyByRole: success", zap.String("role", role))
+	return &policy, nil
+}
+
+func (t *TwoFactorPolicyDaoImpl) GetTwoFactorPolicyList(ctx context.Context) ([]entity.TwoFactorPolicyModel, error) {
+	var policyList []entity.TwoFactorPolicyModel
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.TwoFactorPolicyCollectionName)
+	err := collection.Find(ctx, bson.M{}).Sort("role").All(&policyList)
+	if err != nil {
+		t.core.Logger.Error("TwoFactorPolicyDaoImpl.GetTwoFactorPolicyList: failed to find policies", zap.Error(err))
+		return nil, err
+	}
+	t.core.Logger.Info(
+		"TwoFactorPolicyDaoImpl.GetTwoFactorPolicyList: success", zap.Int("count", len(policyList)),
+	)
+	return policyList, nil
+}
+
+// UpsertTwoFactorPolicy sets whether 2FA is enforced for the role, creating the policy of the role if needed.
+func (t *TwoFactorPolicyDaoImpl) UpsertTwoFactorPolicy(
+	ctx context.Context, role string, enforced bool, updatedBy primitive.ObjectID,
+) error {
+	collection := t.core.Mongo.MongoClient.Database(t.core.Mongo.DatabaseName).Collection(config.TwoFactorPolicyCollectionName)
+	_, err := collection.Upsert(
+		ctx, bson.M{"role": role}, bson.M{
+			"role":       role,
+			"enforced":   enforced,
+			"updated_by": updatedBy,
+			"updated_at": time.Now(),
+		},
+	)
+	if err != nil {
+		t.core.Logger.Error(
+			"TwoFactorPolicyDaoImpl.UpsertTwoFactorPolicy: failed to upsert policy", zap.Error(err),
+			zap.String("role", role), zap.Bool("enforced", enforced),
+		)
+		return err
+	}
+	t.core.Logger.Info(
+		"TwoFactorPolicyDaoImpl.UpsertTwoFactorPolicy: success", zap.String("role", role),
+		zap.Bool("enforced", enforced),
+	)
+	return nil
+}
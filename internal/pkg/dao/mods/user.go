@@ -23,12 +23,19 @@ type UserDao interface {
 	GetUserByID(ctx context.Context, userID primitive.ObjectID) (*entity.UserModel, error)
 	GetUserByUsername(ctx context.Context, username string) (*entity.UserModel, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.UserModel, error)
+	GetUserByOIDCSubject(ctx context.Context, issuer, subject string) (*entity.UserModel, error)
+	GetUserByLDAPDN(ctx context.Context, dn string) (*entity.UserModel, error)
 	GetUserList(
 		ctx context.Context,
-		offset, limit int64, desc bool, organization, role *string,
+		offset, limit int64, desc bool, organization, role *string, suspended *bool,
 		createStartTime, createEndTime, updateStartTime, updateEndTime, lastLoginStartTime, lastLoginEndTime *time.Time,
 		query *string,
 	) ([]entity.UserModel, *int64, error)
+	GetUserIDListByOrganization(ctx context.Context, organization string) ([]primitive.ObjectID, error)
+	GetDeletedUserByID(ctx context.Context, userID primitive.ObjectID) (*entity.UserModel, error)
+	GetDeletedUserList(
+		ctx context.Context, offset, limit int64, desc bool, deletedBefore *time.Time,
+	) ([]entity.UserModel, *int64, error)
 	CountUser(
 		ctx context.Context, organization, role *string,
 		createStartTime, createEndTime, updateStartTime, updateEndTime, lastLoginStartTime, lastLoginEndTime *time.Time,
@@ -37,12 +44,29 @@ type UserDao interface {
 	UpdateUser(
 		ctx context.Context, userID primitive.ObjectID, username, email, password, role, organization *string,
 	) error
+	UpdateUserPassword(ctx context.Context, userID primitive.ObjectID, password string, passwordHistory []string) error
 	UpdateUserLastLogin(ctx context.Context, userID primitive.ObjectID) error
-	SoftDeleteUser(ctx context.Context, userID primitive.ObjectID) error
+	UpdateUserAvatar(ctx context.Context, userID primitive.ObjectID, avatar string) error
+	GetUserPasswordHistory(ctx context.Context, userID primitive.ObjectID) ([]string, error)
+	GetUserTwoFactor(ctx context.Context, userID primitive.ObjectID) (*entity.TwoFactorModel, error)
+	EnableUserTwoFactor(ctx context.Context, userID primitive.ObjectID, secret string, recoveryCodes []string) error
+	DisableUserTwoFactor(ctx context.Context, userID primitive.ObjectID) error
+	UseUserRecoveryCode(ctx context.Context, userID primitive.ObjectID, recoveryCode string) error
+	LinkUserOIDC(ctx context.Context, userID primitive.ObjectID, issuer, subject string) error
+	LinkUserLDAP(ctx context.Context, userID primitive.ObjectID, dn string) error
+	SuspendUser(
+		ctx context.Context, userID primitive.ObjectID, reason string, expiresAt *time.Time,
+		suspendedBy primitive.ObjectID,
+	) error
+	ReactivateUser(ctx context.Context, userID primitive.ObjectID) error
+	SoftDeleteUser(
+		ctx context.Context, userID primitive.ObjectID, deletedAt time.Time, deletion entity.DeletionModel,
+	) error
 	SoftDeleteUserList(
 		ctx context.Context, organization, role *string,
 		createStartTime, createEndTime, updateStartTime, updateEndTime, lastLoginStartTime, lastLoginEndTime *time.Time,
 	) (*int64, error)
+	RestoreUser(ctx context.Context, userID primitive.ObjectID) error
 	DeleteUser(ctx context.Context, userID primitive.ObjectID) error
 	DeleteUserList(
 		ctx context.Context, organization, role *string,
@@ -69,6 +93,7 @@ func NewUserDao(ctx context.Context, core *dao.Core, cache *dao.Cache) (UserDao,
 				IndexOptions: opt.Index().SetUnique(true),
 			},
 			{Key: []string{"created_at"}}, {Key: []string{"updated_at"}},
+			{Key: []string{"oidc.issuer", "oidc.subject"}}, {Key: []string{"ldap.dn"}},
 		},
 	); err != nil {
 		core.Logger.Error(
@@ -160,6 +185,37 @@ func (u *UserDaoImpl) GetUserByEmail(ctx context.Context, email string) (*entity
 	}
 }
 
+// GetUserByOIDCSubject returns the user linked to the subject of the OpenID Connect issuer.
+func (u *UserDaoImpl) GetUserByOIDCSubject(ctx context.Context, issuer, subject string) (*entity.UserModel, error) {
+	var user entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.Find(
+		ctx, bson.M{"oidc.issuer": issuer, "oidc.subject": subject, "deleted": false},
+	).One(&user); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetUserByOIDCSubject: failed to find user", zap.Error(err),
+			zap.String("issuer", issuer), zap.String("subject", subject),
+		)
+		return nil, err
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.GetUserByOIDCSubject: success", zap.String("issuer", issuer), zap.String("subject", subject),
+	)
+	return &user, nil
+}
+
+// GetUserByLDAPDN returns the user provisioned through LDAP for the directory entry.
+func (u *UserDaoImpl) GetUserByLDAPDN(ctx context.Context, dn string) (*entity.UserModel, error) {
+	var user entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.Find(ctx, bson.M{"ldap.dn": dn, "deleted": false}).One(&user); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.GetUserByLDAPDN: failed to find user", zap.Error(err), zap.String("dn", dn))
+		return nil, err
+	}
+	u.Core.Logger.Info("UserDaoImpl.GetUserByLDAPDN: success", zap.String("dn", dn))
+	return &user, nil
+}
+
 func (u *UserDaoImpl) GetUserByUsername(ctx context.Context, username string) (*entity.UserModel, error) {
 	var user entity.UserModel
 	key := fmt.Sprintf("%s:username:%s", config.UserCachePrefix, username)
@@ -204,7 +260,7 @@ func (u *UserDaoImpl) GetUserByUsername(ctx context.Context, username string) (*
 
 func (u *UserDaoImpl) GetUserList(
 	ctx context.Context,
-	offset, limit int64, desc bool, organization, role *string,
+	offset, limit int64, desc bool, organization, role *string, suspended *bool,
 	createStartTime, createEndTime, updateStartTime, updateEndTime, lastLoginStartTime, lastLoginEndTime *time.Time,
 	query *string,
 ) ([]entity.UserModel, *int64, error) {
@@ -222,6 +278,22 @@ func (u *UserDaoImpl) GetUserList(
 		doc["role"] = *role
 		key += fmt.Sprintf(":role:%s", *role)
 	}
+	if suspended != nil {
+		// a suspension past its expiry no longer counts
+		suspension := bson.M{
+			"suspension.suspended": true,
+			"$or": bson.A{
+				bson.M{"suspension.expires_at": nil},
+				bson.M{"suspension.expires_at": bson.M{"$gt": time.Now()}},
+			},
+		}
+		if *suspended {
+			doc["$and"] = bson.A{suspension}
+		} else {
+			doc["$nor"] = bson.A{suspension}
+		}
+		key += fmt.Sprintf(":suspended:%t", *suspended)
+	}
 	if createStartTime != nil && createEndTime != nil {
 		doc["created_at"] = bson.M{"$gte": createStartTime, "$lte": createEndTime}
 		key += fmt.Sprintf(":createStartTime:%s:createEndTime:%s", createStartTime, createEndTime)
@@ -276,6 +348,7 @@ func (u *UserDaoImpl) GetUserList(
 					Role:         user.Role,
 					Organization: user.Organization,
 					LastLogin:    user.LastLogin,
+					Suspension:   user.Suspension,
 					Deleted:      user.Deleted,
 					CreatedAt:    user.CreatedAt,
 					UpdatedAt:    user.UpdatedAt,
@@ -325,6 +398,7 @@ func (u *UserDaoImpl) GetUserList(
 				Role:         user.Role,
 				Organization: user.Organization,
 				LastLogin:    user.LastLogin,
+				Suspension:   user.Suspension,
 				Deleted:      user.Deleted,
 				CreatedAt:    user.CreatedAt,
 				UpdatedAt:    user.UpdatedAt,
@@ -348,6 +422,87 @@ func (u *UserDaoImpl) GetUserList(
 	return userList, &count, nil
 }
 
+// GetUserIDListByOrganization returns the IDs of the members of the organization, deleted users included so that
+// their data stays with the organization.
+func (u *UserDaoImpl) GetUserIDListByOrganization(
+	ctx context.Context, organization string,
+) ([]primitive.ObjectID, error) {
+	var userList []entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	err := coll.Find(ctx, bson.M{"organization": organization}).Select(bson.M{"_id": 1}).All(&userList)
+	if err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetUserIDListByOrganization: failed", zap.Error(err),
+			zap.String("organization", organization),
+		)
+		return nil, err
+	}
+	userIDs := make([]primitive.ObjectID, 0, len(userList))
+	for _, user := range userList {
+		userIDs = append(userIDs, user.UserID)
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.GetUserIDListByOrganization: success",
+		zap.String("organization", organization), zap.Int("count", len(userIDs)),
+	)
+	return userIDs, nil
+}
+
+// GetDeletedUserByID returns the user if it is soft deleted. Deleted users are not cached.
+func (u *UserDaoImpl) GetDeletedUserByID(ctx context.Context, userID primitive.ObjectID) (*entity.UserModel, error) {
+	var user entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.Find(ctx, bson.M{"_id": userID, "deleted": true}).One(&user); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetDeletedUserByID: failed to find user", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	u.Core.Logger.Info("UserDaoImpl.GetDeletedUserByID: success", zap.String("userID", userID.Hex()))
+	return &user, nil
+}
+
+// GetDeletedUserList returns the soft deleted users, the ones deleted before deletedBefore only if given, sorted by
+// deletion time. Deleted users are not cached.
+func (u *UserDaoImpl) GetDeletedUserList(
+	ctx context.Context, offset, limit int64, desc bool, deletedBefore *time.Time,
+) ([]entity.UserModel, *int64, error) {
+	var userList []entity.UserModel
+	doc := bson.M{"deleted": true}
+	if deletedBefore != nil {
+		doc["deleted_at"] = bson.M{"$lt": *deletedBefore}
+	}
+	docJSON, _ := json.Marshal(doc)
+
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	cursor := coll.Find(ctx, doc)
+	count, err := cursor.Count()
+	if err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetDeletedUserList: failed to count userList",
+			zap.ByteString(config.UserCollectionName, docJSON), zap.Error(err),
+		)
+		return nil, nil, err
+	}
+	if desc {
+		err = cursor.Sort("-deleted_at").Skip(offset).Limit(limit).All(&userList)
+	} else {
+		err = cursor.Sort("deleted_at").Skip(offset).Limit(limit).All(&userList)
+	}
+	if err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetDeletedUserList: failed to find userList",
+			zap.ByteString(config.UserCollectionName, docJSON), zap.Error(err),
+		)
+		return nil, nil, err
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.GetDeletedUserList: success",
+		zap.ByteString(config.UserCollectionName, docJSON), zap.Int64("count", count),
+	)
+	return userList, &count, nil
+}
+
 func (u *UserDaoImpl) CountUser(
 	ctx context.Context,
 	organization, role *string,
@@ -414,16 +569,17 @@ func (u *UserDaoImpl) InsertUser(
 ) (primitive.ObjectID, error) {
 	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
 	doc := bson.M{
-		"username":     username,
-		"email":        email,
-		"password":     password,
-		"role":         role,
-		"organization": organization,
-		"last_login":   time.Time{},
-		"deleted":      false,
-		"created_at":   time.Now(),
-		"updated_at":   time.Now(),
-		"deleted_at":   nil,
+		"username":            username,
+		"email":               email,
+		"password":            password,
+		"role":                role,
+		"organization":        organization,
+		"last_login":          time.Time{},
+		"password_changed_at": time.Now(),
+		"deleted":             false,
+		"created_at":          time.Now(),
+		"updated_at":          time.Now(),
+		"deleted_at":          nil,
 	}
 	docJSON, _ := json.Marshal(doc)
 	result, err := coll.InsertOne(ctx, doc)
@@ -489,6 +645,33 @@ func (u *UserDaoImpl) UpdateUser(
 	return nil
 }
 
+// UpdateUserPassword replaces the password crypt of the user and the crypts of its previous passwords.
+func (u *UserDaoImpl) UpdateUserPassword(
+	ctx context.Context, userID primitive.ObjectID, password string, passwordHistory []string,
+) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	doc := bson.M{
+		"password":            password,
+		"password_history":    passwordHistory,
+		"password_changed_at": time.Now(),
+		"updated_at":          time.Now(),
+	}
+	if err := coll.UpdateId(ctx, userID, bson.M{"$set": doc}); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.UpdateUserPassword: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.UpdateUserPassword: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.UpdateUserPassword: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.UpdateUserPassword: cache flushed")
+	}
+	return nil
+}
+
 func (u *UserDaoImpl) UpdateUserLastLogin(ctx context.Context, userID primitive.ObjectID) error {
 	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
 	doc := bson.M{"last_login": time.Now()}
@@ -513,15 +696,273 @@ func (u *UserDaoImpl) UpdateUserLastLogin(ctx context.Context, userID primitive.
 	return nil
 }
 
-func (u *UserDaoImpl) SoftDeleteUser(ctx context.Context, userID primitive.ObjectID) error {
+// UpdateUserAvatar replaces the storage name of the avatar image of the user.
+func (u *UserDaoImpl) UpdateUserAvatar(ctx context.Context, userID primitive.ObjectID, avatar string) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	doc := bson.M{"avatar": avatar, "updated_at": time.Now()}
+	if err := coll.UpdateId(ctx, userID, bson.M{"$set": doc}); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.UpdateUserAvatar: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+			zap.String("avatar", avatar),
+		)
+		return err
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.UpdateUserAvatar: success", zap.String("userID", userID.Hex()), zap.String("avatar", avatar),
+	)
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.UpdateUserAvatar: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.UpdateUserAvatar: cache flushed")
+	}
+	return nil
+}
+
+// GetUserPasswordHistory returns the crypts of the previous passwords of the user, which are not cached.
+func (u *UserDaoImpl) GetUserPasswordHistory(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	var user entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.Find(
+		ctx, bson.M{"_id": userID, "deleted": false},
+	).Select(bson.M{"password_history": 1}).One(&user); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetUserPasswordHistory: failed to find user", zap.Error(err),
+			zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	u.Core.Logger.Info("UserDaoImpl.GetUserPasswordHistory: success", zap.String("userID", userID.Hex()))
+	return user.PasswordHistory, nil
+}
+
+// GetUserTwoFactor returns the TOTP settings of the user with its secret and recovery codes, which are not cached.
+func (u *UserDaoImpl) GetUserTwoFactor(ctx context.Context, userID primitive.ObjectID) (*entity.TwoFactorModel, error) {
+	var user entity.UserModel
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.Find(
+		ctx, bson.M{"_id": userID, "deleted": false},
+	).Select(bson.M{"two_factor": 1}).One(&user); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.GetUserTwoFactor: failed to find user", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return nil, err
+	}
+	u.Core.Logger.Info("UserDaoImpl.GetUserTwoFactor: success", zap.String("userID", userID.Hex()))
+	return &user.TwoFactor, nil
+}
+
+// EnableUserTwoFactor enables TOTP for the user, replacing the secret and recovery codes (stored as bcrypt hashes).
+func (u *UserDaoImpl) EnableUserTwoFactor(
+	ctx context.Context, userID primitive.ObjectID, secret string, recoveryCodes []string,
+) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	doc := bson.M{
+		"two_factor": bson.M{
+			"enabled":        true,
+			"secret":         secret,
+			"recovery_codes": recoveryCodes,
+			"enabled_at":     time.Now(),
+		},
+		"updated_at": time.Now(),
+	}
+	if err := coll.UpdateId(ctx, userID, bson.M{"$set": doc}); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.EnableUserTwoFactor: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.EnableUserTwoFactor: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.EnableUserTwoFactor: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.EnableUserTwoFactor: cache flushed")
+	}
+	return nil
+}
+
+func (u *UserDaoImpl) DisableUserTwoFactor(ctx context.Context, userID primitive.ObjectID) error {
 	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
 	if err := coll.UpdateId(
-		ctx, userID, bson.M{"$set": bson.M{"deleted": true, "deleted_at": time.Now()}},
+		ctx, userID, bson.M{"$unset": bson.M{"two_factor": ""}, "$set": bson.M{"updated_at": time.Now()}},
+	); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.DisableUserTwoFactor: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.DisableUserTwoFactor: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.DisableUserTwoFactor: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.DisableUserTwoFactor: cache flushed")
+	}
+	return nil
+}
+
+// UseUserRecoveryCode atomically removes the recovery code (bcrypt hash) of the user. A code which is not among the
+// unused codes of the user is not found.
+func (u *UserDaoImpl) UseUserRecoveryCode(ctx context.Context, userID primitive.ObjectID, recoveryCode string) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{"_id": userID, "two_factor.enabled": true, "two_factor.recovery_codes": recoveryCode},
+		bson.M{"$pull": bson.M{"two_factor.recovery_codes": recoveryCode}, "$set": bson.M{"updated_at": time.Now()}},
+	); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.UseUserRecoveryCode: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.UseUserRecoveryCode: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.UseUserRecoveryCode: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.UseUserRecoveryCode: cache flushed")
+	}
+	return nil
+}
+
+// LinkUserOIDC links the user to the subject of the OpenID Connect issuer. A user already linked to another subject
+// is not found.
+func (u *UserDaoImpl) LinkUserOIDC(ctx context.Context, userID primitive.ObjectID, issuer, subject string) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{
+			"_id": userID,
+			"$or": bson.A{
+				bson.M{"oidc.subject": bson.M{"$exists": false}},
+				bson.M{"oidc.subject": ""},
+				bson.M{"oidc.issuer": issuer, "oidc.subject": subject},
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"oidc":       bson.M{"issuer": issuer, "subject": subject, "linked_at": time.Now()},
+				"updated_at": time.Now(),
+			},
+		},
+	); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.LinkUserOIDC: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+			zap.String("issuer", issuer), zap.String("subject", subject),
+		)
+		return err
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.LinkUserOIDC: success", zap.String("userID", userID.Hex()), zap.String("subject", subject),
+	)
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.LinkUserOIDC: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.LinkUserOIDC: cache flushed")
+	}
+	return nil
+}
+
+// LinkUserLDAP marks the user as provisioned through LDAP for the directory entry. A user already linked to another
+// entry is not found.
+func (u *UserDaoImpl) LinkUserLDAP(ctx context.Context, userID primitive.ObjectID, dn string) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{
+			"_id": userID,
+			"$or": bson.A{
+				bson.M{"ldap.dn": bson.M{"$exists": false}},
+				bson.M{"ldap.dn": ""},
+				bson.M{"ldap.dn": dn},
+			},
+		},
+		bson.M{"$set": bson.M{"ldap": bson.M{"dn": dn, "linked_at": time.Now()}, "updated_at": time.Now()}},
+	); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.LinkUserLDAP: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+			zap.String("dn", dn),
+		)
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.LinkUserLDAP: success", zap.String("userID", userID.Hex()), zap.String("dn", dn))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.LinkUserLDAP: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.LinkUserLDAP: cache flushed")
+	}
+	return nil
+}
+
+// SuspendUser suspends the user with the reason, until expiresAt if given. The data of the user is left untouched.
+func (u *UserDaoImpl) SuspendUser(
+	ctx context.Context, userID primitive.ObjectID, reason string, expiresAt *time.Time,
+	suspendedBy primitive.ObjectID,
+) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	doc := bson.M{
+		"suspension": bson.M{
+			"suspended":    true,
+			"reason":       reason,
+			"suspended_by": suspendedBy,
+			"suspended_at": time.Now(),
+			"expires_at":   expiresAt,
+		},
+		"updated_at": time.Now(),
+	}
+	if err := coll.UpdateOne(ctx, bson.M{"_id": userID, "deleted": false}, bson.M{"$set": doc}); err != nil {
+		u.Core.Logger.Error(
+			"UserDaoImpl.SuspendUser: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
+		return err
+	}
+	u.Core.Logger.Info(
+		"UserDaoImpl.SuspendUser: success", zap.String("userID", userID.Hex()), zap.String("reason", reason),
+	)
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.SuspendUser: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.SuspendUser: cache flushed")
+	}
+	return nil
+}
+
+// ReactivateUser lifts the suspension of the user.
+func (u *UserDaoImpl) ReactivateUser(ctx context.Context, userID primitive.ObjectID) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{"_id": userID, "deleted": false},
+		bson.M{"$unset": bson.M{"suspension": ""}, "$set": bson.M{"updated_at": time.Now()}},
 	); err != nil {
-		u.Core.Logger.Error("UserDaoImpl.DeleteUser", zap.Error(err), zap.String("userID", userID.Hex()))
+		u.Core.Logger.Error(
+			"UserDaoImpl.ReactivateUser: failed", zap.Error(err), zap.String("userID", userID.Hex()),
+		)
 		return err
 	}
-	u.Core.Logger.Info("UserDaoImpl.DeleteUser", zap.String("userID", userID.Hex()))
+	u.Core.Logger.Info("UserDaoImpl.ReactivateUser: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.ReactivateUser: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.ReactivateUser: cache flushed")
+	}
+	return nil
+}
+
+// SoftDeleteUser marks the user deleted at deletedAt, recording what to restore with it.
+func (u *UserDaoImpl) SoftDeleteUser(
+	ctx context.Context, userID primitive.ObjectID, deletedAt time.Time, deletion entity.DeletionModel,
+) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{"_id": userID, "deleted": false},
+		bson.M{"$set": bson.M{"deleted": true, "deleted_at": deletedAt, "deletion": deletion}},
+	); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.SoftDeleteUser: failed", zap.Error(err), zap.String("userID", userID.Hex()))
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.SoftDeleteUser: success", zap.String("userID", userID.Hex()))
 	prefix := config.UserCachePrefix
 	if err := u.Cache.Flush(ctx, &prefix); err != nil {
 		u.Core.Logger.Error("UserDaoImpl.SoftDeleteUser: failed to flush cache", zap.Error(err))
@@ -573,6 +1014,29 @@ func (u *UserDaoImpl) SoftDeleteUserList(
 	return &result.ModifiedCount, err
 }
 
+// RestoreUser undoes the soft deletion of the user.
+func (u *UserDaoImpl) RestoreUser(ctx context.Context, userID primitive.ObjectID) error {
+	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
+	if err := coll.UpdateOne(
+		ctx, bson.M{"_id": userID, "deleted": true},
+		bson.M{
+			"$set":   bson.M{"deleted": false, "deleted_at": nil, "updated_at": time.Now()},
+			"$unset": bson.M{"deletion": ""},
+		},
+	); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.RestoreUser: failed", zap.Error(err), zap.String("userID", userID.Hex()))
+		return err
+	}
+	u.Core.Logger.Info("UserDaoImpl.RestoreUser: success", zap.String("userID", userID.Hex()))
+	prefix := config.UserCachePrefix
+	if err := u.Cache.Flush(ctx, &prefix); err != nil {
+		u.Core.Logger.Error("UserDaoImpl.RestoreUser: failed to flush cache", zap.Error(err))
+	} else {
+		u.Core.Logger.Info("UserDaoImpl.RestoreUser: cache flushed")
+	}
+	return nil
+}
+
 func (u *UserDaoImpl) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
 	coll := u.Core.Mongo.MongoClient.Database(u.Core.Mongo.DatabaseName).Collection(config.UserCollectionName)
 	if err := coll.RemoveId(ctx, userID); err != nil {
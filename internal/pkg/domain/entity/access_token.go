@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AccessTokenModel struct {
+	AccessTokenID primitive.ObjectID `json:"access_token_id" bson:"_id"`       // Mongo ObjectId
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`           // ID of the owner
+	Name          string             `json:"name" bson:"name"`                 // Name given by the owner
+	TokenHash     string             `json:"token_hash" bson:"token_hash"`     // SHA-256 of the token, the token itself is not stored
+	Scopes        []string           `json:"scopes" bson:"scopes"`             // Scopes, 'dataset:read' | 'dataset:write'
+	ExpiresAt     time.Time          `json:"expires_at" bson:"expires_at"`     // Expiry Time in ISO 8601
+	LastUsedAt    time.Time          `json:"last_used_at" bson:"last_used_at"` // Last Used Time in ISO 8601
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`     // Created Time in ISO 8601
+}
@@ -15,17 +15,18 @@ type UserCacheList struct {
 }
 
 type UserCache struct {
-	UserID       string    `json:"user_id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	Role         string    `json:"role"`
-	Organization string    `json:"organization"`
-	LastLogin    time.Time `json:"last_login"`
-	Deleted      bool      `json:"deleted"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	DeletedAt    time.Time `json:"deleted_at"`
+	UserID       string          `json:"user_id"`
+	Username     string          `json:"username"`
+	Email        string          `json:"email"`
+	Password     string          `json:"password"`
+	Role         string          `json:"role"`
+	Organization string          `json:"organization"`
+	LastLogin    time.Time       `json:"last_login"`
+	Suspension   SuspensionModel `json:"suspension"`
+	Deleted      bool            `json:"deleted"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DeletedAt    time.Time       `json:"deleted_at"`
 }
 
 type NoticeCacheList struct {
@@ -55,12 +56,23 @@ type DocumentationCache struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 type LoginLogCache struct {
-	UserIDHex string    `json:"user_id_hex"` // User ID in Hex
+	UserIDHex string    `json:"user_id_hex"` // User ID in Hex, empty for failed logins
+	Email     string    `json:"email"`       // Email of a failed login
 	IPAddress string    `json:"ip_address"`  // IP Address
 	UserAgent string    `json:"user_agent"`  // User Agent
+	Status    string    `json:"status"`      // Status, 'SUCCESS' | 'FAILURE', empty is treated as 'SUCCESS'
+	Reason    string    `json:"reason"`      // Reason of a failed login
 	CreatedAt time.Time `json:"created_at"`  // Created Time in ISO 8601
 }
 
+type LoginLockoutCache struct {
+	Kind      string    `json:"kind"`      // Kind, 'ACCOUNT' | 'IP'
+	Value     string    `json:"value"`     // Email of the account or IP address
+	Failures  int64     `json:"failures"`  // Failed logins which caused the lockout
+	LockedAt  time.Time `json:"locked_at"` // Locked Time in ISO 8601
+	ExpiresAt time.Time `json:"-"`         // Expiration Time, from the TTL of the cache key
+}
+
 type OperationLogCache struct {
 	UserIDHex   string    `json:"user_id_hex"`   // User ID in Hex
 	IPAddress   string    `json:"ip_address"`    // IP Address
@@ -15,11 +15,13 @@ type InstructionDataModel struct {
 		Input       string `json:"input" bson:"input"`             // Input
 		Output      string `json:"output" bson:"output"`           // Output
 	} `json:"row" bson:"row"`
-	Theme  string   `json:"theme" bson:"theme"`   // Theme
-	Source string   `json:"source" bson:"source"` // Source
-	Note   string   `json:"note" bson:"note"`     // Note (Optional)
-	Status struct { // Status
-		Code    string `json:"code" bson:"code"`       // Status Code, 'PENDING' | 'APPROVED' | 'REJECTED'
+	Theme      string                 `json:"theme" bson:"theme"`           // Theme
+	Source     string                 `json:"source" bson:"source"`         // Source
+	Note       string                 `json:"note" bson:"note"`             // Note (Optional)
+	Metadata   map[string]interface{} `json:"metadata" bson:"metadata"`     // Metadata fields defined by the theme template
+	Provenance Provenance             `json:"provenance" bson:"provenance"` // Provenance and licensing of the row data
+	Status     struct {               // Status
+		Code    string `json:"code" bson:"code"`       // Status Code, 'DRAFT' | 'PENDING' | 'APPROVED' | 'REJECTED'
 		Message string `json:"message" bson:"message"` // Status Error
 	} `json:"status" bson:"status"`
 	Deleted   bool      `json:"deleted" bson:"deleted"`       // Deleted Flag
@@ -27,3 +29,10 @@ type InstructionDataModel struct {
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"` // Updated Time in ISO 8601
 	DeletedAt time.Time `json:"deleted_at" bson:"deleted_at"` // Deleted Time in ISO 8601
 }
+
+type Provenance struct {
+	SourceType     string `json:"source_type" bson:"source_type"`         // Source Type, 'ORIGINAL' | 'WEB' | 'BOOK' | 'SYNTHETIC'
+	URL            string `json:"url" bson:"url"`                         // Source URL (Required for 'WEB')
+	License        string `json:"license" bson:"license"`                 // SPDX License Identifier
+	RightsAttested bool   `json:"rights_attested" bson:"rights_attested"` // Contributor attests to hold the rights
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InviteCodeModel struct {
+	InviteCodeID primitive.ObjectID `json:"invite_code_id" bson:"_id"`        // Mongo ObjectId
+	Code         string             `json:"code" bson:"code"`                 // Invite Code, redeemed on registration
+	Role         string             `json:"role" bson:"role"`                 // Role of the registered users, 'USER' | 'ADMIN'
+	Organization string             `json:"organization" bson:"organization"` // Organization of the registered users
+	MaxUses      int64              `json:"max_uses" bson:"max_uses"`         // Maximum number of redemptions
+	UsedCount    int64              `json:"used_count" bson:"used_count"`     // Number of redemptions
+	Redemptions  []Redemption       `json:"redemptions" bson:"redemptions"`   // Redemptions
+	CreatedBy    primitive.ObjectID `json:"created_by" bson:"created_by"`     // ID of the admin who created the code
+	ExpiresAt    time.Time          `json:"expires_at" bson:"expires_at"`     // Expiry Time in ISO 8601
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`     // Created Time in ISO 8601
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`     // Updated Time in ISO 8601
+}
+
+type Redemption struct {
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`         // ID of the registered user
+	Username   string             `json:"username" bson:"username"`       // Username (for space-time trade-off)
+	RedeemedAt time.Time          `json:"redeemed_at" bson:"redeemed_at"` // Redeemed Time in ISO 8601
+}
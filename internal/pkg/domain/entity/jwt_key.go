@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// JwtKeyModel is a key of the JWT key ring. A retired key no longer signs tokens and only verifies them until it
+// expires, when MongoDB removes it.
+type JwtKeyModel struct {
+	KeyID      string     `json:"key_id" bson:"_id"`                      // kid header of the tokens
+	PrivateKey []byte     `json:"-" bson:"private_key"`                   // ECDSA private key in SEC 1 DER form, encrypted
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`           // Created Time in ISO 8601
+	RetiredAt  *time.Time `json:"retired_at,omitempty" bson:"retired_at"` // Retired Time in ISO 8601
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" bson:"expires_at"` // Expiration Time of a retired key
+}
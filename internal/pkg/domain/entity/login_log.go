@@ -13,5 +13,7 @@ type LoginLogModel struct {
 	Email      string             `json:"email" bson:"email"`           // Email (for space-time trade-off)
 	IPAddress  string             `json:"ip_address" bson:"ip_address"` // IP Address
 	UserAgent  string             `json:"user_agent" bson:"user_agent"` // User Agent
+	Status     string             `json:"status" bson:"status"`         // Status: SUCCESS, FAILURE
+	Reason     string             `json:"reason" bson:"reason"`         // Reason of a failed login
 	CreatedAt  time.Time          `json:"created_at" bson:"created_at"` // Created Time in ISO 8601
 }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationModel is a tenant, e.g. a lab. Its members are the users whose organization is its name.
+type OrganizationModel struct {
+	OrganizationID primitive.ObjectID `json:"organization_id" bson:"_id"`     // Mongo ObjectId
+	Name           string             `json:"name" bson:"name"`               // Unique Name, the organization of its members
+	Description    string             `json:"description" bson:"description"` // Description
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`   // Created Time in ISO 8601
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`   // Updated Time in ISO 8601
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReAuditModel struct {
+	ReAuditID         primitive.ObjectID `json:"re_audit_id" bson:"_id"`                         // Mongo ObjectId
+	InstructionDataID primitive.ObjectID `json:"instruction_data_id" bson:"instruction_data_id"` // Instruction Data ID
+	ReviewerID        primitive.ObjectID `json:"reviewer_id" bson:"reviewer_id"`                 // ID of the reviewer who approved the instruction data
+	ReviewerName      string             `json:"reviewer_name" bson:"reviewer_name"`             // Reviewer Username (for space-time trade-off)
+	Theme             string             `json:"theme" bson:"theme"`                             // Theme of the instruction data when approved
+	AuditorID         primitive.ObjectID `json:"auditor_id" bson:"auditor_id"`                   // ID of the admin who re-audited the instruction data (NilObjectID while pending)
+	AuditorName       string             `json:"auditor_name" bson:"auditor_name"`               // Auditor Username (for space-time trade-off)
+	Status            string             `json:"status" bson:"status"`                           // Status, 'PENDING' | 'CONFIRMED' | 'OVERTURNED'
+	Message           string             `json:"message" bson:"message"`                         // Message (Optional)
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`                   // Sampled Time in ISO 8601
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`                   // Updated Time (i.e. the re-audit) in ISO 8601
+}
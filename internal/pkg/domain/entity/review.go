@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewModel struct {
+	ReviewID          primitive.ObjectID `json:"review_id" bson:"_id"`                           // Mongo ObjectId
+	InstructionDataID primitive.ObjectID `json:"instruction_data_id" bson:"instruction_data_id"` // Instruction Data ID
+	ReviewerID        primitive.ObjectID `json:"reviewer_id" bson:"reviewer_id"`                 // Reviewer (User) ID
+	ReviewerName      string             `json:"reviewer_name" bson:"reviewer_name"`             // Reviewer Username (for space-time trade-off)
+	Theme             string             `json:"theme" bson:"theme"`                             // Theme of the instruction data when reviewed
+	Decision          string             `json:"decision" bson:"decision"`                       // Decision, 'APPROVED' | 'REJECTED'
+	Message           string             `json:"message" bson:"message"`                         // Message (Optional)
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`                   // Created Time in ISO 8601
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`                   // Updated Time (i.e. the latest decision) in ISO 8601
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SessionModel is a login of a user on a device. The JWTs issued at the login and on its refreshes carry the session
+// ID, so that revoking the session invalidates them.
+type SessionModel struct {
+	SessionID      primitive.ObjectID `json:"session_id" bson:"_id"`            // Mongo ObjectId
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`           // User ID
+	IPAddress      string             `json:"ip_address" bson:"ip_address"`     // IP Address of the login
+	UserAgent      string             `json:"user_agent" bson:"user_agent"`     // User Agent of the device
+	RefreshTokenID string             `json:"-" bson:"refresh_token_id"`        // ID of the only valid refresh token
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`     // Created Time in ISO 8601
+	LastSeenAt     time.Time          `json:"last_seen_at" bson:"last_seen_at"` // Last Seen Time in ISO 8601
+	ExpiresAt      time.Time          `json:"expires_at" bson:"expires_at"`     // Expiration Time of the refresh token
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ThemeModel struct {
+	ThemeID     primitive.ObjectID `json:"theme_id" bson:"_id"`            // Mongo ObjectId
+	Name        string             `json:"name" bson:"name"`               // Theme Name, referenced by InstructionDataModel.Theme
+	Description string             `json:"description" bson:"description"` // Description
+	Schema      string             `json:"schema" bson:"schema"`           // JSON Schema of the metadata fields
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`   // Created Time in ISO 8601
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`   // Updated Time in ISO 8601
+}
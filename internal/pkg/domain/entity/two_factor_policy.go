@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TwoFactorPolicyModel struct {
+	TwoFactorPolicyID primitive.ObjectID `json:"two_factor_policy_id" bson:"_id"` // Mongo ObjectId
+	Role              string             `json:"role" bson:"role"`                // Role, 'USER' | 'ADMIN'
+	Enforced          bool               `json:"enforced" bson:"enforced"`        // Whether users of the role must use 2FA
+	UpdatedBy         primitive.ObjectID `json:"updated_by" bson:"updated_by"`    // ID of the admin who updated the policy
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`    // Updated Time in ISO 8601
+}
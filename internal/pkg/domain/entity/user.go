@@ -7,15 +7,58 @@ import (
 )
 
 type UserModel struct {
-	UserID       primitive.ObjectID `json:"user_id" bson:"_id"`               // Mongo ObjectId
-	Username     string             `json:"username" bson:"username"`         // Username
-	Email        string             `json:"email" bson:"email"`               // Email
-	Password     string             `json:"password" bson:"password"`         // Password crypt
-	Role         string             `json:"role" bson:"role"`                 // Role, 'USER' | 'ADMIN'
-	Organization string             `json:"organization" bson:"organization"` // Organization
-	LastLogin    time.Time          `json:"last_login" bson:"last_login"`     // Last Login Time in ISO 8601
-	Deleted      bool               `json:"deleted" bson:"deleted"`           // Deleted Flag
-	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`     // Created Time in ISO 8601
-	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`     // Updated Time in ISO 8601
-	DeletedAt    time.Time          `json:"deleted_at" bson:"deleted_at"`     // Deleted Time in ISO 8601
+	UserID   primitive.ObjectID `json:"user_id" bson:"_id"`       // Mongo ObjectId
+	Username string             `json:"username" bson:"username"` // Username
+	Email    string             `json:"email" bson:"email"`       // Email
+	Password string             `json:"password" bson:"password"` // Password crypt
+	// Crypts of the previous passwords, the most recent first, never cached
+	PasswordHistory   []string          `json:"-" bson:"password_history"`
+	PasswordChangedAt time.Time         `json:"password_changed_at" bson:"password_changed_at"` // Password Changed Time in ISO 8601
+	Role              string            `json:"role" bson:"role"`                               // Role, 'USER' | 'ADMIN'
+	Organization      string            `json:"organization" bson:"organization"`               // Organization
+	Avatar            string            `json:"avatar" bson:"avatar"`                           // Storage Name of the Avatar Image
+	LastLogin         time.Time         `json:"last_login" bson:"last_login"`                   // Last Login Time in ISO 8601
+	TwoFactor         TwoFactorModel    `json:"two_factor" bson:"two_factor"`                   // TOTP Two-Factor Authentication
+	OIDC              OIDCIdentityModel `json:"oidc" bson:"oidc"`                               // Linked OpenID Connect Identity
+	LDAP              LDAPIdentityModel `json:"ldap" bson:"ldap"`                               // Directory Entry of an LDAP User
+	Suspension        SuspensionModel   `json:"suspension" bson:"suspension"`                   // Suspension of the Account
+	TokensValidAfter  time.Time         `json:"-" bson:"tokens_valid_after"`                    // Tokens Issued Until Then Are Revoked
+	Deleted           bool              `json:"deleted" bson:"deleted"`                         // Deleted Flag
+	Deletion          DeletionModel     `json:"deletion" bson:"deletion"`                       // What to Restore With a Deleted User
+	CreatedAt         time.Time         `json:"created_at" bson:"created_at"`                   // Created Time in ISO 8601
+	UpdatedAt         time.Time         `json:"updated_at" bson:"updated_at"`                   // Updated Time in ISO 8601
+	DeletedAt         time.Time         `json:"deleted_at" bson:"deleted_at"`                   // Deleted Time in ISO 8601
+}
+
+type TwoFactorModel struct {
+	Enabled       bool      `json:"enabled" bson:"enabled"`       // Enabled Flag
+	Secret        string    `json:"-" bson:"secret"`              // TOTP Secret in Base32, Never Cached
+	RecoveryCodes []string  `json:"-" bson:"recovery_codes"`      // Bcrypt Hashes of the Unused Recovery Codes, Never Cached
+	EnabledAt     time.Time `json:"enabled_at" bson:"enabled_at"` // Enabled Time in ISO 8601
+}
+
+type SuspensionModel struct {
+	Suspended   bool               `json:"suspended" bson:"suspended"`       // Suspended Flag
+	Reason      string             `json:"reason" bson:"reason"`             // Reason of the Suspension
+	SuspendedBy primitive.ObjectID `json:"suspended_by" bson:"suspended_by"` // Admin ID
+	SuspendedAt time.Time          `json:"suspended_at" bson:"suspended_at"` // Suspended Time in ISO 8601
+	ExpiresAt   time.Time          `json:"expires_at" bson:"expires_at"`     // Expiry Time in ISO 8601, zero if it does not expire
+}
+
+type DeletionModel struct {
+	DeletedBy primitive.ObjectID `json:"deleted_by" bson:"deleted_by"` // Admin ID
+	Roles     []string           `json:"roles" bson:"roles"`           // Casbin Roles of the User
+	// IDs of the Organizations the User was an Organization Admin of
+	Organizations []string `json:"organizations" bson:"organizations"`
+}
+
+type OIDCIdentityModel struct {
+	Issuer   string    `json:"issuer" bson:"issuer"`       // Issuer Identifier of the Provider
+	Subject  string    `json:"subject" bson:"subject"`     // Subject Identifier at the Provider
+	LinkedAt time.Time `json:"linked_at" bson:"linked_at"` // Linked Time in ISO 8601
+}
+
+type LDAPIdentityModel struct {
+	DN       string    `json:"dn" bson:"dn"`               // Distinguished Name of the Directory Entry
+	LinkedAt time.Time `json:"linked_at" bson:"linked_at"` // Linked Time in ISO 8601
 }
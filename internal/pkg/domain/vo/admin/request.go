@@ -19,6 +19,35 @@ type (
 		CreateEndTime      *string `query:"createEndTime" validate:"omitnil,rfc3339"`
 	}
 
+	GetReviewAgreementRequest struct {
+		Theme     *string `query:"theme" validate:""`
+		StartTime *string `query:"startTime" validate:"omitnil,rfc3339,earlierThan=EndTime"`
+		EndTime   *string `query:"endTime" validate:"omitnil,rfc3339"`
+	}
+
+	GetReAuditListRequest struct {
+		Page       *int64  `query:"page" validate:"required,numeric,min=1"`
+		PageSize   *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+		Desc       *bool   `query:"desc" validate:"required"`
+		ReviewerID *string `query:"reviewerID" validate:"omitnil,mongodb"`
+		Theme      *string `query:"theme" validate:""`
+		Status     *string `query:"status" validate:"omitnil,reAuditStatus"`
+		StartTime  *string `query:"startTime" validate:"omitnil,rfc3339,earlierThan=EndTime"`
+		EndTime    *string `query:"endTime" validate:"omitnil,rfc3339"`
+	}
+
+	SubmitReAuditRequest struct {
+		ReAuditID *string `json:"re_audit_id" validate:"required,mongodb"`
+		Status    *string `json:"status" validate:"required,reAuditDecision"`
+		Message   *string `json:"message" validate:"omitnil,max=1000"`
+	}
+
+	GetReAuditStatisticRequest struct {
+		Theme     *string `query:"theme" validate:""`
+		StartTime *string `query:"startTime" validate:"omitnil,rfc3339,earlierThan=EndTime"`
+		EndTime   *string `query:"endTime" validate:"omitnil,rfc3339"`
+	}
+
 	GetInstructionDataRequest struct {
 		InstructionDataID *string `query:"instructionDataID" validate:"required,mongodb"`
 	}
@@ -35,6 +64,9 @@ type (
 		Theme           *string `query:"theme" validate:""`
 		Status          *string `query:"status" validate:"omitnil,instructionDataStatus"`
 		Query           *string `query:"query" validate:""`
+		Metadata        *string `query:"metadata" validate:"omitnil,json"`
+		SourceType      *string `query:"sourceType" validate:"omitnil,sourceType"`
+		License         *string `query:"license" validate:"omitnil,spdxLicense"`
 	}
 
 	ApproveInstructionDataRequest struct {
@@ -47,14 +79,15 @@ type (
 	}
 
 	UpdateInstructionDataRequest struct {
-		InstructionDataID *string `json:"instruction_data_id" validate:"required,mongodb"`
-		UserID            *string `json:"user_id" validate:"omitnil,mongodb"`
-		Instruction       *string `json:"instruction" validate:"omitnil,max=1000,min=1"`
-		Input             *string `json:"input" validate:"omitnil,max=1000,min=1"`
-		Output            *string `json:"output" validate:"omitnil,max=1000,min=1"`
-		Theme             *string `json:"theme" validate:""`
-		Source            *string `json:"source" validate:"omitnil,max=100"`
-		Note              *string `json:"note" validate:"omitnil,max=1000"`
+		InstructionDataID *string                `json:"instruction_data_id" validate:"required,mongodb"`
+		UserID            *string                `json:"user_id" validate:"omitnil,mongodb"`
+		Instruction       *string                `json:"instruction" validate:"omitnil,max=1000,min=1"`
+		Input             *string                `json:"input" validate:"omitnil,max=1000,min=1"`
+		Output            *string                `json:"output" validate:"omitnil,max=1000,min=1"`
+		Theme             *string                `json:"theme" validate:""`
+		Source            *string                `json:"source" validate:"omitnil,max=100"`
+		Note              *string                `json:"note" validate:"omitnil,max=1000"`
+		Metadata          map[string]interface{} `json:"metadata" validate:""`
 	}
 
 	ExportInstructionDataRequest struct {
@@ -66,12 +99,33 @@ type (
 		UpdateEndTime   *string `query:"updateEndTime" validate:"omitnil,rfc3339"`
 		Theme           *string `query:"theme" validate:""`
 		Status          *string `query:"status" validate:"omitnil,instructionDataStatus"`
+		Metadata        *string `query:"metadata" validate:"omitnil,json"`
+		SourceType      *string `query:"sourceType" validate:"omitnil,sourceType"`
+		License         *string `query:"license" validate:"omitnil,spdxLicense"`
+		PermissiveOnly  *bool   `query:"permissiveOnly" validate:""`
 	}
 
 	DeleteInstructionDataRequest struct {
 		InstructionDataID *string `query:"instructionDataID" validate:"required,mongodb"`
 	}
 
+	InsertThemeRequest struct {
+		Name        *string                `json:"name" validate:"required,max=100,min=1"`
+		Description *string                `json:"description" validate:"omitnil,max=1000"`
+		Schema      map[string]interface{} `json:"schema" validate:"required"`
+	}
+
+	UpdateThemeRequest struct {
+		ThemeID     *string                `json:"theme_id" validate:"required,mongodb"`
+		Name        *string                `json:"name" validate:"omitnil,max=100,min=1"`
+		Description *string                `json:"description" validate:"omitnil,max=1000"`
+		Schema      map[string]interface{} `json:"schema" validate:"omitempty"`
+	}
+
+	DeleteThemeRequest struct {
+		ThemeID *string `query:"themeID" validate:"required,mongodb"`
+	}
+
 	InsertNoticeRequest struct {
 		Title      *string `json:"title" validate:"required,max=100,min=1"`
 		Content    *string `json:"content" validate:"required,max=10000,min=1"`
@@ -92,10 +146,22 @@ type (
 	InsertUserRequest struct {
 		Username     *string `json:"username" validate:"required,min=3,max=20"`
 		Email        *string `json:"email" validate:"required,email,max=100"`
-		Password     *string `json:"password" validate:"required,min=8,max=20"`
+		Password     *string `json:"password" validate:"required,max=20"`
 		Organization *string `json:"organization" validate:"required,max=100"`
 	}
 
+	ImportUserRequest struct {
+		ValidateOnly *bool `form:"validateOnly" validate:"omitnil"`
+	}
+
+	// ImportUserRow is a row of the CSV file of an import, with username, email, role and organization columns.
+	ImportUserRow struct {
+		Username     *string `validate:"required,min=3,max=20"`
+		Email        *string `validate:"required,email,max=100"`
+		Role         *string `validate:"required,userRole"`
+		Organization *string `validate:"required,max=100"`
+	}
+
 	GetUserRequest struct {
 		UserID *string `query:"userID" validate:"required,mongodb"`
 	}
@@ -105,6 +171,7 @@ type (
 		PageSize           *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
 		Desc               *bool   `query:"desc" validate:"required"`
 		Role               *string `query:"role" validate:"omitnil,userRole"`
+		Suspended          *bool   `query:"suspended" validate:"omitnil"`
 		LastLoginStartTime *string `query:"lastLoginStartTime" validate:"omitnil,rfc3339,earlierThan=LastLoginEndTime"`
 		LastLoginEndTime   *string `query:"lastLoginEndTime" validate:"omitnil,rfc3339"`
 		CreateStartTime    *string `query:"createStartTime" validate:"omitnil,rfc3339,earlierThan=CreateEndTime"`
@@ -116,6 +183,7 @@ type (
 		UserID       *string `json:"user_id" validate:"required"`
 		Username     *string `json:"username" validate:"omitnil,min=3,max=20"`
 		Email        *string `json:"email" validate:"omitnil,email"`
+		Role         *string `json:"role" validate:"omitnil,userRole"`
 		Organization *string `json:"organization" validate:"omitnil,max=100"`
 	}
 
@@ -125,7 +193,79 @@ type (
 
 	ChangeUserPasswordRequest struct {
 		UserID      *string `json:"user_id" validate:"required,mongodb"`
-		NewPassword *string `json:"new_password" validate:"required,min=8,max=20"`
+		NewPassword *string `json:"new_password" validate:"required,max=20"`
+	}
+
+	DeleteUserSessionListRequest struct {
+		UserID *string `query:"userID" validate:"required,mongodb"`
+	}
+
+	// ImpersonateUserRequest is read-only unless ReadOnly is false.
+	ImpersonateUserRequest struct {
+		UserID   *string `json:"user_id" validate:"required,mongodb"`
+		ReadOnly *bool   `json:"read_only" validate:"omitnil"`
+	}
+
+	// SuspendUserRequest suspends the user until ExpiresAt if given, indefinitely otherwise.
+	SuspendUserRequest struct {
+		UserID    *string `json:"user_id" validate:"required,mongodb"`
+		Reason    *string `json:"reason" validate:"required,max=200"`
+		ExpiresAt *string `json:"expires_at" validate:"omitnil,rfc3339"`
+	}
+
+	ReactivateUserRequest struct {
+		UserID *string `json:"user_id" validate:"required,mongodb"`
+	}
+
+	ExportUserDataRequest struct {
+		UserID *string `query:"userID" validate:"required,mongodb"`
+	}
+
+	EraseUserRequest struct {
+		UserID *string `json:"user_id" validate:"required,mongodb"`
+	}
+
+	GetDeletedUserListRequest struct {
+		Page     *int64 `query:"page" validate:"required,numeric,min=1"`
+		PageSize *int64 `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+		Desc     *bool  `query:"desc" validate:"required"`
+	}
+
+	RestoreUserRequest struct {
+		UserID *string `json:"user_id" validate:"required,mongodb"`
+	}
+
+	InsertInviteCodeRequest struct {
+		Role         *string `json:"role" validate:"required,userRole"`
+		Organization *string `json:"organization" validate:"required,max=100"`
+		MaxUses      *int64  `json:"max_uses" validate:"required,min=1,max=10000"`
+		ExpiresAt    *string `json:"expires_at" validate:"required,rfc3339"`
+	}
+
+	GetInviteCodeRequest struct {
+		InviteCodeID *string `query:"inviteCodeID" validate:"required,mongodb"`
+	}
+
+	GetInviteCodeListRequest struct {
+		Page         *int64  `query:"page" validate:"required,numeric,min=1"`
+		PageSize     *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+		Desc         *bool   `query:"desc" validate:"required"`
+		Role         *string `query:"role" validate:"omitnil,userRole"`
+		Organization *string `query:"organization" validate:"omitnil,max=100"`
+	}
+
+	DeleteInviteCodeRequest struct {
+		InviteCodeID *string `query:"inviteCodeID" validate:"required,mongodb"`
+	}
+
+	UpdateTwoFactorPolicyRequest struct {
+		Role     *string `json:"role" validate:"required,userRole"`
+		Enforced *bool   `json:"enforced" validate:"required"`
+	}
+
+	DeleteLoginLockoutRequest struct {
+		Kind  *string `query:"kind" validate:"required,loginLockoutKind"`
+		Value *string `query:"value" validate:"required,max=320,min=1"`
 	}
 
 	InsertDocumentationRequest struct {
@@ -148,6 +288,7 @@ type (
 		PageSize        *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
 		Desc            *bool   `query:"desc" validate:"required"`
 		Query           *string `query:"query" validate:"omitnil,max=100"`
+		Status          *string `query:"status" validate:"omitnil,loginStatus"`
 		CreateStartTime *string `query:"createStartTime" validate:"omitnil,rfc3339,earlierThan=CreateEndTime"`
 		CreateEndTime   *string `query:"createEndTime" validate:"omitnil,rfc3339"`
 	}
@@ -163,4 +304,96 @@ type (
 		CreateStartTime *string `query:"createStartTime" validate:"omitnil,rfc3339,earlierThan=CreateEndTime"`
 		CreateEndTime   *string `query:"createEndTime" validate:"omitnil,rfc3339"`
 	}
+
+	InsertOrganizationRequest struct {
+		Name        *string `json:"name" validate:"required,max=100"`
+		Description *string `json:"description" validate:"omitnil,max=500"`
+	}
+
+	GetOrganizationRequest struct {
+		OrganizationID *string `query:"organizationID" validate:"required,mongodb"`
+	}
+
+	GetOrganizationListRequest struct {
+		Page     *int64  `query:"page" validate:"required,numeric,min=1"`
+		PageSize *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+		Desc     *bool   `query:"desc" validate:"required"`
+		Query    *string `query:"query" validate:"omitnil,max=100"`
+	}
+
+	UpdateOrganizationRequest struct {
+		OrganizationID *string `json:"organization_id" validate:"required,mongodb"`
+		Description    *string `json:"description" validate:"omitnil,max=500"`
+	}
+
+	DeleteOrganizationRequest struct {
+		OrganizationID *string `query:"organizationID" validate:"required,mongodb"`
+	}
+
+	GetOrganizationMemberListRequest struct {
+		OrganizationID *string `query:"organizationID" validate:"required,mongodb"`
+		Page           *int64  `query:"page" validate:"required,numeric,min=1"`
+		PageSize       *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+	}
+
+	InsertOrganizationMemberRequest struct {
+		OrganizationID *string `json:"organization_id" validate:"required,mongodb"`
+		UserID         *string `json:"user_id" validate:"required,mongodb"`
+	}
+
+	DeleteOrganizationMemberRequest struct {
+		OrganizationID *string `query:"organizationID" validate:"required,mongodb"`
+		UserID         *string `query:"userID" validate:"required,mongodb"`
+	}
+
+	InsertOrganizationAdminRequest struct {
+		OrganizationID *string `json:"organization_id" validate:"required,mongodb"`
+		UserID         *string `json:"user_id" validate:"required,mongodb"`
+	}
+
+	DeleteOrganizationAdminRequest struct {
+		OrganizationID *string `query:"organizationID" validate:"required,mongodb"`
+		UserID         *string `query:"userID" validate:"required,mongodb"`
+	}
+
+	GetPolicyListRequest struct {
+		Role *string `query:"role" validate:"omitnil,max=50"`
+	}
+
+	InsertPolicyRequest struct {
+		Role   *string `json:"role" validate:"required,max=50"`
+		Object *string `json:"object" validate:"required,policyObject"`
+		Action *string `json:"action" validate:"required,policyAction"`
+	}
+
+	DeletePolicyRequest struct {
+		Role   *string `query:"role" validate:"required,max=50"`
+		Object *string `query:"object" validate:"required,policyObject"`
+		Action *string `query:"action" validate:"required,policyAction"`
+	}
+
+	GetRoleGroupingListRequest struct {
+		UserID *string `query:"userID" validate:"omitnil,mongodb"`
+		Role   *string `query:"role" validate:"omitnil,max=50"`
+	}
+
+	InsertRoleGroupingRequest struct {
+		UserID *string `json:"user_id" validate:"required,mongodb"`
+		Role   *string `json:"role" validate:"required,max=50"`
+	}
+
+	DeleteRoleGroupingRequest struct {
+		UserID *string `query:"userID" validate:"required,mongodb"`
+		Role   *string `query:"role" validate:"required,max=50"`
+	}
+
+	GetUserPermissionRequest struct {
+		UserID *string `query:"userID" validate:"required,mongodb"`
+	}
+
+	CheckPermissionRequest struct {
+		UserID *string `query:"userID" validate:"required,mongodb"`
+		Object *string `query:"object" validate:"required,policyObject"`
+		Action *string `query:"action" validate:"required,policyAction"`
+	}
 )
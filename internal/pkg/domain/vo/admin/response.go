@@ -36,6 +36,85 @@ type (
 		UserStatisticList []*GetUserStatisticResponse `json:"user_statistic_list"`
 	}
 
+	GetReviewAgreementResponse struct {
+		ItemCount      int64                    `json:"item_count"`
+		Agreement      float64                  `json:"agreement"`
+		FleissKappa    *float64                 `json:"fleiss_kappa"`
+		ThemeAgreement []*ThemeAgreement        `json:"theme_agreement"`
+		ReviewerPairs  []*ReviewerPairAgreement `json:"reviewer_pairs"`
+		Reviewers      []*ReviewerConsensus     `json:"reviewers"`
+	}
+
+	ThemeAgreement struct {
+		Theme         string                   `json:"theme"`
+		ItemCount     int64                    `json:"item_count"`
+		Agreement     float64                  `json:"agreement"`
+		FleissKappa   *float64                 `json:"fleiss_kappa"`
+		ReviewerPairs []*ReviewerPairAgreement `json:"reviewer_pairs"`
+	}
+
+	ReviewerPairAgreement struct {
+		ReviewerAID   string   `json:"reviewer_a_id"`
+		ReviewerAName string   `json:"reviewer_a_name"`
+		ReviewerBID   string   `json:"reviewer_b_id"`
+		ReviewerBName string   `json:"reviewer_b_name"`
+		ItemCount     int64    `json:"item_count"`
+		Agreement     float64  `json:"agreement"`
+		CohenKappa    *float64 `json:"cohen_kappa"`
+	}
+
+	ReviewerConsensus struct {
+		ReviewerID       string  `json:"reviewer_id"`
+		ReviewerName     string  `json:"reviewer_name"`
+		ReviewCount      int64   `json:"review_count"`
+		ComparedCount    int64   `json:"compared_count"`
+		DisagreementRate float64 `json:"disagreement_rate"`
+		Flagged          bool    `json:"flagged"`
+	}
+
+	GetReAuditResponse struct {
+		ReAuditID         string `json:"re_audit_id"`
+		InstructionDataID string `json:"instruction_data_id"`
+		ReviewerID        string `json:"reviewer_id"`
+		ReviewerName      string `json:"reviewer_name"`
+		Theme             string `json:"theme"`
+		AuditorID         string `json:"auditor_id"`
+		AuditorName       string `json:"auditor_name"`
+		Status            string `json:"status"`
+		Message           string `json:"message"`
+		CreatedAt         string `json:"created_at"`
+		UpdatedAt         string `json:"updated_at"`
+	}
+
+	GetReAuditListResponse struct {
+		Total       int64                 `json:"total"`
+		ReAuditList []*GetReAuditResponse `json:"re_audit_list"`
+	}
+
+	GetReAuditStatisticResponse struct {
+		Data      ReAuditStatistic            `json:"data"`
+		Reviewers []*ReviewerReAuditStatistic `json:"reviewers"`
+		Themes    []*ThemeReAuditStatistic    `json:"themes"`
+	}
+
+	ReviewerReAuditStatistic struct {
+		ReviewerID   string           `json:"reviewer_id"`
+		ReviewerName string           `json:"reviewer_name"`
+		Data         ReAuditStatistic `json:"data"`
+	}
+
+	ThemeReAuditStatistic struct {
+		Theme string           `json:"theme"`
+		Data  ReAuditStatistic `json:"data"`
+	}
+
+	ReAuditStatistic struct {
+		SampledCount    int64   `json:"sampled_count"`
+		AuditedCount    int64   `json:"audited_count"`
+		OverturnedCount int64   `json:"overturned_count"`
+		ErrorRate       float64 `json:"error_rate"`
+	}
+
 	GetInstructionDataResponse struct {
 		InstructionDataID string `json:"instruction_data_id"`
 		UserID            string `json:"user_id"`
@@ -45,10 +124,12 @@ type (
 			Input       string `json:"input"`
 			Output      string `json:"output"`
 		} `json:"row"`
-		Theme  string `json:"theme"`
-		Source string `json:"source"`
-		Note   string `json:"note"`
-		Status struct {
+		Theme      string                 `json:"theme"`
+		Source     string                 `json:"source"`
+		Note       string                 `json:"note"`
+		Metadata   map[string]interface{} `json:"metadata"`
+		Provenance Provenance             `json:"provenance"`
+		Status     struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
 		} `json:"status"`
@@ -74,10 +155,12 @@ type (
 			Input       string `json:"input"`
 			Output      string `json:"output"`
 		} `json:"row"`
-		Theme  string `json:"theme"`
-		Source string `json:"source"`
-		Note   string `json:"note"`
-		Status struct {
+		Theme      string                 `json:"theme"`
+		Source     string                 `json:"source"`
+		Note       string                 `json:"note"`
+		Metadata   map[string]interface{} `json:"metadata"`
+		Provenance Provenance             `json:"provenance"`
+		Status     struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
 		} `json:"status"`
@@ -85,25 +168,74 @@ type (
 		UpdatedAt string `json:"updated_at"`
 	}
 
+	Provenance struct {
+		SourceType     string `json:"source_type"`
+		URL            string `json:"url"`
+		License        string `json:"license"`
+		RightsAttested bool   `json:"rights_attested"`
+	}
+
 	InstructionDataAlpacaList struct {
 		InstructionDataList []*InstructionDataAlpaca
 	}
 
 	InstructionDataAlpaca struct {
-		Institution string `json:"institution"`
-		Input       string `json:"input"`
-		Output      string `json:"output"`
+		Institution string                 `json:"institution"`
+		Input       string                 `json:"input"`
+		Output      string                 `json:"output"`
+		Metadata    map[string]interface{} `json:"metadata,omitempty"`
+		Provenance  *Provenance            `json:"provenance,omitempty"`
 	}
 
-	GetUserResponse struct {
-		UserID       string `json:"user_id"`
+	// ImportUserResult is a row of the result file of an import. The password is only set for created users, and
+	// only returned in the result file.
+	ImportUserResult struct {
+		Row          int    `json:"row"`
 		Username     string `json:"username"`
 		Email        string `json:"email"`
 		Role         string `json:"role"`
 		Organization string `json:"organization"`
-		LastLogin    string `json:"last_login"`
-		CreatedAt    string `json:"created_at"`
-		UpdatedAt    string `json:"updated_at"`
+		Action       string `json:"action"`
+		Status       string `json:"status"`
+		Message      string `json:"message"`
+		Password     string `json:"password"`
+	}
+
+	ImpersonateUserResponse struct {
+		AccessToken string `json:"access_token"`
+		ReadOnly    bool   `json:"read_only"`
+		ExpiresAt   string `json:"expires_at"`
+	}
+
+	GetUserResponse struct {
+		UserID       string              `json:"user_id"`
+		Username     string              `json:"username"`
+		Email        string              `json:"email"`
+		Role         string              `json:"role"`
+		Organization string              `json:"organization"`
+		Suspension   *SuspensionResponse `json:"suspension"`
+		LastLogin    string              `json:"last_login"`
+		CreatedAt    string              `json:"created_at"`
+		UpdatedAt    string              `json:"updated_at"`
+	}
+
+	// SuspensionResponse is the suspension of a user, whose ExpiresAt is empty if it does not expire.
+	SuspensionResponse struct {
+		Reason      string `json:"reason"`
+		SuspendedBy string `json:"suspended_by"`
+		SuspendedAt string `json:"suspended_at"`
+		ExpiresAt   string `json:"expires_at"`
+	}
+
+	// EraseUserResponse counts the records of an erased user, by what was done with them.
+	EraseUserResponse struct {
+		InstructionDataKept     int64 `json:"instruction_data_kept"`
+		InstructionDataDeleted  int64 `json:"instruction_data_deleted"`
+		ReviewsAnonymised       int64 `json:"reviews_anonymised"`
+		ReAuditsAnonymised      int64 `json:"re_audits_anonymised"`
+		LoginLogsAnonymised     int64 `json:"login_logs_anonymised"`
+		OperationLogsAnonymised int64 `json:"operation_logs_anonymised"`
+		AccessTokensDeleted     int64 `json:"access_tokens_deleted"`
 	}
 
 	GetUserListResponse struct {
@@ -111,6 +243,98 @@ type (
 		UserList []*GetUserResponse `json:"user_list"`
 	}
 
+	// GetDeletedUserResponse is a soft deleted user, which can be restored until PurgeAt.
+	GetDeletedUserResponse struct {
+		UserID       string `json:"user_id"`
+		Username     string `json:"username"`
+		Email        string `json:"email"`
+		Role         string `json:"role"`
+		Organization string `json:"organization"`
+		DeletedBy    string `json:"deleted_by"`
+		DeletedAt    string `json:"deleted_at"`
+		PurgeAt      string `json:"purge_at"`
+	}
+
+	GetDeletedUserListResponse struct {
+		Total    int64                     `json:"total"`
+		UserList []*GetDeletedUserResponse `json:"user_list"`
+	}
+
+	RestoreUserResponse struct {
+		InstructionDataRestored int64 `json:"instruction_data_restored"`
+	}
+
+	// PurgeUserResponse counts the records of a purged user, by what was done with them. The kept instruction data is
+	// the one reassigned or anonymised, depending on the purge policy.
+	// PurgeUserResponse is a user of a purge, whose Error is set if it failed to be purged.
+	PurgeUserResponse struct {
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+		Error    string `json:"error,omitempty"`
+		EraseUserResponse
+	}
+
+	// PurgeUserListResponse counts the users purged in Total and the users which failed to be purged in Failed.
+	PurgeUserListResponse struct {
+		Policy   string               `json:"policy"`
+		Total    int64                `json:"total"`
+		Failed   int64                `json:"failed"`
+		UserList []*PurgeUserResponse `json:"user_list"`
+	}
+
+	InsertInviteCodeResponse struct {
+		InviteCodeID string `json:"invite_code_id"`
+		Code         string `json:"code"`
+	}
+
+	GetInviteCodeResponse struct {
+		InviteCodeID string                `json:"invite_code_id"`
+		Code         string                `json:"code"`
+		Role         string                `json:"role"`
+		Organization string                `json:"organization"`
+		MaxUses      int64                 `json:"max_uses"`
+		UsedCount    int64                 `json:"used_count"`
+		Redemptions  []*RedemptionResponse `json:"redemptions"`
+		CreatedBy    string                `json:"created_by"`
+		ExpiresAt    string                `json:"expires_at"`
+		CreatedAt    string                `json:"created_at"`
+		UpdatedAt    string                `json:"updated_at"`
+	}
+
+	RedemptionResponse struct {
+		UserID     string `json:"user_id"`
+		Username   string `json:"username"`
+		RedeemedAt string `json:"redeemed_at"`
+	}
+
+	GetInviteCodeListResponse struct {
+		Total          int64                    `json:"total"`
+		InviteCodeList []*GetInviteCodeResponse `json:"invite_code_list"`
+	}
+
+	TwoFactorPolicyResponse struct {
+		Role      string `json:"role"`
+		Enforced  bool   `json:"enforced"`
+		UpdatedBy string `json:"updated_by,omitempty"`
+		UpdatedAt string `json:"updated_at,omitempty"`
+	}
+
+	GetTwoFactorPolicyListResponse struct {
+		PolicyList []*TwoFactorPolicyResponse `json:"policy_list"`
+	}
+
+	LoginLockoutResponse struct {
+		Kind      string `json:"kind"`
+		Value     string `json:"value"`
+		Failures  int64  `json:"failures"`
+		LockedAt  string `json:"locked_at"`
+		ExpiresAt string `json:"expires_at"`
+	}
+
+	GetLoginLockoutListResponse struct {
+		LockoutList []*LoginLockoutResponse `json:"lockout_list"`
+	}
+
 	GetLoginLogResponse struct {
 		LoginLogID string `json:"login_log_id"`
 		UserID     string `json:"user_id"`
@@ -118,6 +342,8 @@ type (
 		Email      string `json:"email"`
 		IPAddress  string `json:"ip_address"`
 		UserAgent  string `json:"user_agent"`
+		Status     string `json:"status"`
+		Reason     string `json:"reason"`
 		CreatedAt  string `json:"created_at"`
 	}
 
@@ -165,4 +391,69 @@ type (
 		Total        int64                  `json:"total"`
 		ErrorLogList []*GetErrorLogResponse `json:"error_log_list"`
 	}
+
+	InsertOrganizationResponse struct {
+		OrganizationID string `json:"organization_id"`
+	}
+
+	GetOrganizationResponse struct {
+		OrganizationID string   `json:"organization_id"`
+		Name           string   `json:"name"`
+		Description    string   `json:"description"`
+		MemberCount    int64    `json:"member_count"`
+		AdminIDList    []string `json:"admin_id_list"`
+		CreatedAt      string   `json:"created_at"`
+		UpdatedAt      string   `json:"updated_at"`
+	}
+
+	GetOrganizationListResponse struct {
+		Total            int64                      `json:"total"`
+		OrganizationList []*GetOrganizationResponse `json:"organization_list"`
+	}
+
+	GetOrganizationMemberResponse struct {
+		UserID    string `json:"user_id"`
+		Username  string `json:"username"`
+		Email     string `json:"email"`
+		Role      string `json:"role"`
+		OrgAdmin  bool   `json:"org_admin"`
+		LastLogin string `json:"last_login"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	GetOrganizationMemberListResponse struct {
+		Total      int64                            `json:"total"`
+		MemberList []*GetOrganizationMemberResponse `json:"member_list"`
+	}
+
+	PolicyResponse struct {
+		Role    string `json:"role"`
+		Object  string `json:"object"`
+		Action  string `json:"action"`
+		BuiltIn bool   `json:"built_in"`
+	}
+
+	GetPolicyListResponse struct {
+		PolicyList []*PolicyResponse `json:"policy_list"`
+	}
+
+	RoleGroupingResponse struct {
+		UserID string `json:"user_id"`
+		Role   string `json:"role"`
+	}
+
+	GetRoleGroupingListResponse struct {
+		RoleGroupingList []*RoleGroupingResponse `json:"role_grouping_list"`
+	}
+
+	GetUserPermissionResponse struct {
+		UserID         string            `json:"user_id"`
+		RoleList       []string          `json:"role_list"`
+		PermissionList []*PolicyResponse `json:"permission_list"`
+	}
+
+	CheckPermissionResponse struct {
+		Allowed       bool     `json:"allowed"`
+		MatchedPolicy []string `json:"matched_policy"`
+	}
 )
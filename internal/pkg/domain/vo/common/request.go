@@ -6,13 +6,85 @@ type (
 		Password *string `json:"password" validate:"required"`
 	}
 
+	RegisterRequest struct {
+		InviteCode *string `json:"invite_code" validate:"required,max=100"`
+		Username   *string `json:"username" validate:"required,min=3,max=20"`
+		Email      *string `json:"email" validate:"required,email,max=100"`
+		Password   *string `json:"password" validate:"required,max=20"`
+	}
+
+	ForgotPasswordRequest struct {
+		Email *string `json:"email" validate:"required,email,max=100"`
+	}
+
+	UpdateProfileRequest struct {
+		Username     *string `json:"username" validate:"omitnil,min=3,max=20"`
+		Organization *string `json:"organization" validate:"omitnil,max=100"`
+	}
+
+	ChangeEmailRequest struct {
+		Email *string `json:"email" validate:"required,email,max=100"`
+	}
+
+	ConfirmEmailRequest struct {
+		Token *string `json:"token" validate:"required,hexadecimal,len=64"`
+	}
+
+	ResetPasswordRequest struct {
+		Token       *string `json:"token" validate:"required,hexadecimal,len=64"`
+		NewPassword *string `json:"new_password" validate:"required,max=20"`
+	}
+
+	OIDCCallbackRequest struct {
+		Code  *string `json:"code" validate:"required"`
+		State *string `json:"state" validate:"required,hexadecimal,len=64"`
+	}
+
+	TwoFactorChallengeRequest struct {
+		ChallengeToken *string `json:"challenge_token" validate:"required,hexadecimal,len=64"`
+	}
+
+	ConfirmTwoFactorRequest struct {
+		ChallengeToken *string `json:"challenge_token" validate:"omitempty,hexadecimal,len=64"`
+		Code           *string `json:"code" validate:"required,numeric,len=6"`
+	}
+
+	VerifyTwoFactorRequest struct {
+		ChallengeToken *string `json:"challenge_token" validate:"required,hexadecimal,len=64"`
+		Code           *string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+		RecoveryCode   *string `json:"recovery_code" validate:"required_without=Code,omitempty,hexadecimal,len=10"`
+	}
+
+	DisableTwoFactorRequest struct {
+		Password *string `json:"password" validate:"required"`
+		Code     *string `json:"code" validate:"required,numeric,len=6"`
+	}
+
+	InsertAccessTokenRequest struct {
+		Name      *string  `json:"name" validate:"required,max=50"`
+		Scopes    []string `json:"scopes" validate:"required,min=1,unique,dive,accessTokenScope"`
+		ExpiresAt *string  `json:"expires_at" validate:"required,rfc3339"`
+	}
+
+	DeleteAccessTokenRequest struct {
+		AccessTokenID *string `query:"accessTokenID" validate:"required,mongodb"`
+	}
+
+	DeleteSessionRequest struct {
+		SessionID *string `query:"sessionID" validate:"required,mongodb"`
+	}
+
+	DeleteSessionListRequest struct {
+		ExceptCurrent *bool `query:"exceptCurrent" validate:"omitnil"`
+	}
+
 	RefreshTokenRequest struct {
 		RefreshToken *string `json:"refresh_token" validate:"required,jwt"`
 	}
 
 	ChangePasswordRequest struct {
 		OldPassword *string `json:"old_password" validate:"required"`
-		NewPassword *string `json:"new_password" validate:"required,min=8,max=20"`
+		NewPassword *string `json:"new_password" validate:"required,max=20"`
 	}
 
 	GetNoticeRequest struct {
@@ -27,6 +99,16 @@ type (
 		UpdateEndTime   *string `query:"updateEndTime" validate:"omitnil,rfc3339"`
 	}
 
+	GetThemeRequest struct {
+		ThemeID *string `query:"themeID" validate:"required,mongodb"`
+	}
+
+	GetThemeListRequest struct {
+		Page     *int64  `query:"page" validate:"required,numeric,min=1"`
+		PageSize *int64  `query:"pageSize" validate:"required,numeric,min=1,max=100"`
+		Query    *string `query:"query" validate:"omitnil,max=100"`
+	}
+
 	GetDocumentationRequest struct {
 		DocumentationID *string `query:"documentationID" validate:"required"`
 	}
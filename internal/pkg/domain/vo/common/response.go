@@ -5,7 +5,14 @@ type (
 		AccessToken  string  `json:"access_token"`
 		RefreshToken string  `json:"refresh_token"`
 		ExpiresIn    float64 `json:"expires_in"`
-		Meta         struct {
+		// Set instead of the tokens when a TOTP code is needed to complete the login
+		ChallengeToken     string `json:"challenge_token,omitempty"`
+		TwoFactorRequired  bool   `json:"two_factor_required"`
+		EnrollmentRequired bool   `json:"enrollment_required"`
+		// Set when the password is older than the maximum age of the password policy, the tokens then only allow
+		// changing it
+		PasswordExpired bool `json:"password_expired"`
+		Meta            struct {
 			UserID   string `json:"user_id"`
 			Username string `json:"username"`
 			Email    string `json:"email"`
@@ -13,6 +20,53 @@ type (
 		} `json:"meta"`
 	}
 
+	OIDCAuthorizeResponse struct {
+		AuthorizationURL string `json:"authorization_url"`
+	}
+
+	EnrollTwoFactorResponse struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioning_uri"`
+	}
+
+	ConfirmTwoFactorResponse struct {
+		RecoveryCodes []string       `json:"recovery_codes"`
+		Login         *LoginResponse `json:"login,omitempty"`
+	}
+
+	InsertAccessTokenResponse struct {
+		AccessTokenID string `json:"access_token_id"`
+		Token         string `json:"token"` // Only returned on creation
+	}
+
+	GetAccessTokenResponse struct {
+		AccessTokenID string   `json:"access_token_id"`
+		Name          string   `json:"name"`
+		Scopes        []string `json:"scopes"`
+		Expired       bool     `json:"expired"`
+		ExpiresAt     string   `json:"expires_at"`
+		LastUsedAt    string   `json:"last_used_at,omitempty"`
+		CreatedAt     string   `json:"created_at"`
+	}
+
+	GetAccessTokenListResponse struct {
+		AccessTokenList []*GetAccessTokenResponse `json:"access_token_list"`
+	}
+
+	GetSessionResponse struct {
+		SessionID  string `json:"session_id"`
+		IPAddress  string `json:"ip_address"`
+		UserAgent  string `json:"user_agent"`
+		Current    bool   `json:"current"`
+		CreatedAt  string `json:"created_at"`
+		LastSeenAt string `json:"last_seen_at"`
+		ExpiresAt  string `json:"expires_at"`
+	}
+
+	GetSessionListResponse struct {
+		SessionList []*GetSessionResponse `json:"session_list"`
+	}
+
 	RefreshTokenResponse struct {
 		AccessToken  string  `json:"access_token"`
 		RefreshToken string  `json:"refresh_token"`
@@ -66,11 +120,39 @@ type (
 	}
 
 	GetProfileResponse struct {
-		UserID       string `json:"user_id"`
-		Username     string `json:"username"`
-		Email        string `json:"email"`
-		Role         string `json:"role"`
-		Organization string `json:"organization"`
-		LastLogin    string `json:"last_login"`
+		UserID        string                `json:"user_id"`
+		Username      string                `json:"username"`
+		Email         string                `json:"email"`
+		Role          string                `json:"role"`
+		Organization  string                `json:"organization"`
+		Avatar        string                `json:"avatar"`
+		LastLogin     string                `json:"last_login"`
+		Impersonation *ProfileImpersonation `json:"impersonation,omitempty"`
+	}
+
+	UpdateAvatarResponse struct {
+		Avatar string `json:"avatar"`
+	}
+
+	// ProfileImpersonation is set on the profile when an admin is impersonating the user, for a banner to show it.
+	ProfileImpersonation struct {
+		ImpersonatorID       string `json:"impersonator_id"`
+		ImpersonatorUsername string `json:"impersonator_username"`
+		ReadOnly             bool   `json:"read_only"`
+		ExpiresAt            string `json:"expires_at"`
+	}
+
+	GetThemeResponse struct {
+		ThemeID     string                 `json:"theme_id"`
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Schema      map[string]interface{} `json:"schema"`
+		CreatedAt   string                 `json:"created_at"`
+		UpdatedAt   string                 `json:"updated_at"`
+	}
+
+	GetThemeListResponse struct {
+		Total     int64               `json:"total"`
+		ThemeList []*GetThemeResponse `json:"theme_list"`
 	}
 )
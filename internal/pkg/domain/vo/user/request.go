@@ -16,26 +16,54 @@ type (
 		UpdateStartTime *string `query:"updateStartTime" validate:"omitnil,rfc3339,earlierThan=UpdateEndTime"`
 		UpdateEndTime   *string `query:"updateEndTime" validate:"omitnil,rfc3339"`
 		Theme           *string `query:"theme" validate:""`
-		Status          *string `query:"status" validate:"omitnil,instructionDataStatus"`
+		Status          *string `query:"status" validate:"omitnil,userInstructionDataStatus"`
 	}
 
 	InsertInstructionDataRequest struct {
-		Instruction *string `json:"instruction" validate:"required,max=1000,min=1"`
-		Input       *string `json:"input" validate:"required,max=1000,min=1"`
-		Output      *string `json:"output" validate:"required,max=1000,min=1"`
-		Theme       *string `json:"theme" validate:""`
-		Source      *string `json:"source" validate:"required,max=100"`
-		Note        *string `json:"note" validate:"omitnil,max=1000"`
+		Instruction    *string                `json:"instruction" validate:"required,max=1000,min=1"`
+		Input          *string                `json:"input" validate:"required,max=1000,min=1"`
+		Output         *string                `json:"output" validate:"required,max=1000,min=1"`
+		Theme          *string                `json:"theme" validate:""`
+		Source         *string                `json:"source" validate:"required,max=100"`
+		Note           *string                `json:"note" validate:"omitnil,max=1000"`
+		Metadata       map[string]interface{} `json:"metadata" validate:""`
+		SourceType     *string                `json:"source_type" validate:"required,sourceType"`
+		SourceURL      *string                `json:"source_url" validate:"omitnil,url,max=2048"`
+		License        *string                `json:"license" validate:"required,spdxLicense"`
+		RightsAttested *bool                  `json:"rights_attested" validate:"required"`
 	}
 
-	UpdateInstructionDataRequest struct {
+	InsertInstructionDataDraftRequest struct {
+		Instruction    *string                `json:"instruction" validate:"omitnil,max=1000"`
+		Input          *string                `json:"input" validate:"omitnil,max=1000"`
+		Output         *string                `json:"output" validate:"omitnil,max=1000"`
+		Theme          *string                `json:"theme" validate:""`
+		Source         *string                `json:"source" validate:"omitnil,max=100"`
+		Note           *string                `json:"note" validate:"omitnil,max=1000"`
+		Metadata       map[string]interface{} `json:"metadata" validate:""`
+		SourceType     *string                `json:"source_type" validate:"omitnil,sourceType"`
+		SourceURL      *string                `json:"source_url" validate:"omitnil,url,max=2048"`
+		License        *string                `json:"license" validate:"omitnil,spdxLicense"`
+		RightsAttested *bool                  `json:"rights_attested" validate:""`
+	}
+
+	SubmitInstructionDataRequest struct {
 		InstructionDataID *string `json:"instruction_data_id" validate:"required,mongodb"`
-		Instruction       *string `json:"instruction" validate:"omitnil,max=1000,min=1"`
-		Input             *string `json:"input" validate:"omitnil,max=1000,min=1"`
-		Output            *string `json:"output" validate:"omitnil,max=1000,min=1"`
-		Theme             *string `json:"theme" validate:""`
-		Source            *string `json:"source" validate:"omitnil,max=1000"`
-		Note              *string `json:"note" validate:"omitnil,max=1000"`
+	}
+
+	UpdateInstructionDataRequest struct {
+		InstructionDataID *string                `json:"instruction_data_id" validate:"required,mongodb"`
+		Instruction       *string                `json:"instruction" validate:"omitnil,max=1000,min=1"`
+		Input             *string                `json:"input" validate:"omitnil,max=1000,min=1"`
+		Output            *string                `json:"output" validate:"omitnil,max=1000,min=1"`
+		Theme             *string                `json:"theme" validate:""`
+		Source            *string                `json:"source" validate:"omitnil,max=1000"`
+		Note              *string                `json:"note" validate:"omitnil,max=1000"`
+		Metadata          map[string]interface{} `json:"metadata" validate:""`
+		SourceType        *string                `json:"source_type" validate:"omitnil,sourceType"`
+		SourceURL         *string                `json:"source_url" validate:"omitnil,url,max=2048"`
+		License           *string                `json:"license" validate:"omitnil,spdxLicense"`
+		RightsAttested    *bool                  `json:"rights_attested" validate:""`
 	}
 
 	DeleteInstructionDataRequest struct {
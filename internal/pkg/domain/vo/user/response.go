@@ -26,10 +26,12 @@ type (
 			Input       string `json:"input"`
 			Output      string `json:"output"`
 		} `json:"row"`
-		Theme  string `json:"theme"`
-		Source string `json:"source"`
-		Note   string `json:"note"`
-		Status struct {
+		Theme      string                 `json:"theme"`
+		Source     string                 `json:"source"`
+		Note       string                 `json:"note"`
+		Metadata   map[string]interface{} `json:"metadata"`
+		Provenance Provenance             `json:"provenance"`
+		Status     struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
 		} `json:"status"`
@@ -37,6 +39,13 @@ type (
 		UpdatedAt string `json:"updated_at"`
 	}
 
+	Provenance struct {
+		SourceType     string `json:"source_type"`
+		URL            string `json:"url"`
+		License        string `json:"license"`
+		RightsAttested bool   `json:"rights_attested"`
+	}
+
 	GetInstructionDataListResponse struct {
 		Total               int64                         `json:"total"`
 		InstructionDataList []*GetInstructionDataResponse `json:"instruction_data_list"`
@@ -13,12 +13,14 @@ import (
 )
 
 type Middleware struct {
-	AuthMiddleware        *ware.AuthMiddleware
-	LoggingMiddleware     *ware.LoggingMiddleware
-	PrometheusMiddleware  *ware.PrometheusMiddleware
-	ContextMiddleware     *ware.ContextMiddleware
-	IdempotencyMiddleware *ware.IdempotencyMiddleware
-	Config                *config.Config
+	AuthMiddleware          *ware.AuthMiddleware
+	LoggingMiddleware       *ware.LoggingMiddleware
+	PrometheusMiddleware    *ware.PrometheusMiddleware
+	ContextMiddleware       *ware.ContextMiddleware
+	IdempotencyMiddleware   *ware.IdempotencyMiddleware
+	OrganizationMiddleware  *ware.OrganizationMiddleware
+	ImpersonationMiddleware *ware.ImpersonationMiddleware
+	Config                  *config.Config
 }
 
 func (m *Middleware) Register(app *fiber.App) error {
@@ -78,5 +80,8 @@ func (m *Middleware) Register(app *fiber.App) error {
 	// Register Context Middleware
 	m.ContextMiddleware.Register(app)
 
+	// Register Impersonation Middleware
+	m.ImpersonationMiddleware.Register(app)
+
 	return nil
 }
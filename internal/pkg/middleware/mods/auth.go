@@ -3,21 +3,35 @@ package mods
 import (
 	e "errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
 	"data-collection-hub-server/internal/pkg/dao"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
 	auth "data-collection-hub-server/pkg/jwt"
 	"data-collection-hub-server/pkg/utils/check"
 	"data-collection-hub-server/pkg/utils/crypt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// changePasswordPath is the only endpoint a user whose password has expired may call.
+const changePasswordPath = "/api/v1/change-password"
+
 type AuthMiddleware struct {
-	Jwt    *auth.Jwt
-	Cache  *dao.Cache
-	Config *config.Config
+	Jwt            *auth.Jwt
+	Cache          *dao.Cache
+	Config         *config.Config
+	AccessTokenDao daos.AccessTokenDao
+	SessionDao     daos.SessionDao
+	UserDao        daos.UserDao
 }
 
 func (a *AuthMiddleware) Register(app *fiber.App) {
@@ -39,11 +53,14 @@ func (a *AuthMiddleware) authMiddleware() fiber.Handler {
 			return errors.TokenInvalid(fmt.Errorf("token should be bearer token (start with 'Bearer ' or 'bearer ')"))
 		}
 		token = token[7:] // remove 'Bearer '
+		if strings.HasPrefix(token, config.AccessTokenPrefix) {
+			return a.accessTokenAuth(c, token)
+		}
 		blacklistKey := fmt.Sprintf("%s:%s", config.TokenBlacklistCachePrefix, crypt.MD5(token))
 		if ok, err := a.Cache.Get(c.Context(), blacklistKey); err == nil && *ok == config.CacheTrue {
 			return errors.TokenInvalid(fmt.Errorf("token has been revoked"))
 		}
-		sub, err := a.Jwt.VerifyAccessToken(token)
+		claims, err := a.Jwt.ParseAccessToken(token)
 		if err != nil {
 			var ve *jwt.ValidationError
 			if e.As(err, &ve) {
@@ -53,7 +70,175 @@ func (a *AuthMiddleware) authMiddleware() fiber.Handler {
 			}
 			return errors.TokenInvalid(fmt.Errorf("token invalid"))
 		}
-		c.Locals(config.UserIDKey, sub)
+		validAfter, err := a.SessionDao.GetUserTokensValidAfter(c.UserContext(), claims.Subject)
+		if err != nil {
+			return errors.ServerBusy(fmt.Errorf("failed to verify token"))
+		}
+		if claims.IssuedAtMillis() <= validAfter {
+			return errors.TokenInvalid(fmt.Errorf("token has been revoked, please log in again"))
+		}
+		if claims.SessionID != "" {
+			if err = a.sessionAuth(c, claims.SessionID); err != nil {
+				return err
+			}
+		}
+		if claims.Actor != nil {
+			if err = a.impersonationAuth(c, claims); err != nil {
+				return err
+			}
+		}
+		user, err := a.suspensionAuth(c, claims.Subject)
+		if err != nil {
+			return err
+		}
+		if err = a.passwordAuth(c, user, claims.Actor != nil); err != nil {
+			return err
+		}
+		c.Locals(config.UserIDKey, claims.Subject)
 		return c.Next()
 	}
 }
+
+// impersonationAuth rejects the impersonation token once the tokens of its admin have been revoked, and records the
+// impersonation of the request.
+func (a *AuthMiddleware) impersonationAuth(c *fiber.Ctx, claims *auth.Claims) error {
+	validAfter, err := a.SessionDao.GetUserTokensValidAfter(c.UserContext(), claims.Actor.Subject)
+	if err != nil {
+		return errors.ServerBusy(fmt.Errorf("failed to verify token"))
+	}
+	if claims.IssuedAtMillis() <= validAfter {
+		return errors.TokenInvalid(fmt.Errorf("token has been revoked, please log in again"))
+	}
+	c.Locals(
+		config.ImpersonationKey, &service.Impersonation{
+			ImpersonatorID: claims.Actor.Subject,
+			ReadOnly:       claims.ReadOnly,
+			ExpiresAt:      time.Unix(claims.ExpiresAt, 0),
+		},
+	)
+	return nil
+}
+
+// suspensionAuth rejects the requests of a suspended user, whose tokens are not revoked when the suspension expires.
+// Returns the user of the request.
+func (a *AuthMiddleware) suspensionAuth(c *fiber.Ctx, uid string) (*entity.UserModel, error) {
+	userID, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return nil, errors.TokenInvalid(fmt.Errorf("token invalid"))
+	}
+	user, err := a.UserDao.GetUserByID(c.UserContext(), userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.TokenInvalid(fmt.Errorf("user of the token not found"))
+		}
+		return nil, errors.ServerBusy(fmt.Errorf("failed to verify user"))
+	}
+	if err = service.CheckSuspension(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// passwordAuth only lets a user whose password has expired change it, until then every other request is rejected.
+// Impersonating admins are not held up by the password of the user.
+func (a *AuthMiddleware) passwordAuth(c *fiber.Ctx, user *entity.UserModel, impersonated bool) error {
+	if impersonated || !service.IsPasswordExpired(a.Config, user) {
+		return nil
+	}
+	if c.Method() == fiber.MethodPut && c.Path() == changePasswordPath {
+		return nil
+	}
+	return errors.PermissionDeny(fmt.Errorf("password expired, please change it"))
+}
+
+// sessionAuth rejects the access token once its session has been revoked, and records the session as seen.
+func (a *AuthMiddleware) sessionAuth(c *fiber.Ctx, sid string) error {
+	ctx := c.UserContext()
+	revoked, err := a.SessionDao.IsSessionRevoked(ctx, sid)
+	if err != nil {
+		return errors.ServerBusy(fmt.Errorf("failed to verify session"))
+	}
+	if revoked {
+		return errors.TokenInvalid(fmt.Errorf("session has been revoked"))
+	}
+	if sessionID, err := primitive.ObjectIDFromHex(sid); err == nil {
+		_ = a.SessionDao.UpdateSessionLastSeen( // failure is logged by the dao and should not reject the request
+			ctx, sessionID, a.Config.SessionConfig.LastSeenInterval,
+		)
+	}
+	c.Locals(config.SessionIDKey, sid)
+	return nil
+}
+
+// accessTokenAuth authenticates the request with a personal access token. A token only grants the endpoints
+// covered by its scopes, other endpoints still require a login. Tokens created before the tokens of the user were
+// revoked (e.g. by a password change) are rejected, and so are all of them while the password of the user has
+// expired.
+func (a *AuthMiddleware) accessTokenAuth(c *fiber.Ctx, token string) error {
+	ctx := c.UserContext()
+	accessToken, err := a.AccessTokenDao.GetAccessTokenByHash(ctx, crypt.SHA256(token))
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.TokenInvalid(fmt.Errorf("access token invalid or expired"))
+		}
+		return errors.ServerBusy(fmt.Errorf("failed to verify access token"))
+	}
+	scope := accessTokenScope(c.Method(), c.Path())
+	if scope == "" {
+		return errors.PermissionDeny(fmt.Errorf("access token is not allowed to access %s", c.Path()))
+	}
+	granted := false
+	for _, s := range accessToken.Scopes {
+		if s == scope {
+			granted = true
+			break
+		}
+	}
+	if !granted {
+		return errors.PermissionDeny(fmt.Errorf("access token lacks scope %s", scope))
+	}
+	validAfter, err := a.SessionDao.GetUserTokensValidAfter(ctx, accessToken.UserID.Hex())
+	if err != nil {
+		return errors.ServerBusy(fmt.Errorf("failed to verify access token"))
+	}
+	if accessToken.CreatedAt.UnixMilli() <= validAfter {
+		return errors.TokenInvalid(fmt.Errorf("access token has been revoked"))
+	}
+	user, err := a.suspensionAuth(c, accessToken.UserID.Hex())
+	if err != nil {
+		return err
+	}
+	if err = a.passwordAuth(c, user, false); err != nil {
+		return err
+	}
+	_ = a.AccessTokenDao.UpdateAccessTokenLastUsed( // failure is logged by the dao and should not reject the request
+		ctx, accessToken.AccessTokenID, a.Config.AccessTokenConfig.LastUsedInterval,
+	)
+	c.Locals(config.UserIDKey, accessToken.UserID.Hex())
+	return c.Next()
+}
+
+// accessTokenScope returns the scope required to call the endpoint with an access token, or "" if access tokens
+// cannot call it.
+func accessTokenScope(method, path string) string {
+	const prefix = "/api/v1"
+	path = strings.TrimPrefix(path, prefix)
+	switch {
+	case strings.HasPrefix(path, "/user/instruction-data"):
+		if method == fiber.MethodGet {
+			return config.AccessTokenScopeDatasetRead
+		}
+		if method == fiber.MethodPost || method == fiber.MethodPut || method == fiber.MethodDelete {
+			return config.AccessTokenScopeDatasetWrite
+		}
+	case path == "/user/data-statistic", strings.HasPrefix(path, "/theme"):
+		if method == fiber.MethodGet {
+			return config.AccessTokenScopeDatasetRead
+		}
+	case path == "/idempotency-token":
+		if method == fiber.MethodGet {
+			return config.AccessTokenScopeDatasetWrite
+		}
+	}
+	return ""
+}
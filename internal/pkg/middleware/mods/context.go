@@ -1,7 +1,10 @@
 package mods
 
 import (
+	"context"
+
 	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/service"
 	logging "data-collection-hub-server/pkg/zap"
 	"github.com/gofiber/fiber/v2"
 )
@@ -25,6 +28,14 @@ func (m *ContextMiddleware) contextMiddleware() fiber.Handler {
 		if ok {
 			ctx = m.Zap.SetUserIDInContext(ctx, userID)
 		}
+		if sid, ok := c.Locals(config.SessionIDKey).(string); ok {
+			ctx = context.WithValue(ctx, config.SessionIDKey, sid)
+		}
+		if impersonation, ok := c.Locals(config.ImpersonationKey).(*service.Impersonation); ok {
+			ctx = context.WithValue(ctx, config.ImpersonationKey, impersonation)
+		}
+		ctx = context.WithValue(ctx, config.IPAddressKey, c.IP())
+		ctx = context.WithValue(ctx, config.UserAgentKey, c.Get(fiber.HeaderUserAgent))
 		c.SetUserContext(ctx)
 		return c.Next()
 	}
@@ -0,0 +1,82 @@
+package mods
+
+import (
+	"fmt"
+	"strings"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/service"
+	sys "data-collection-hub-server/internal/pkg/service/sys/mods"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ImpersonationMiddleware struct {
+	LogsService sys.LogsService
+}
+
+func (m *ImpersonationMiddleware) Register(app *fiber.App) {
+	app.Use(m.impersonationMiddleware())
+}
+
+// impersonationMiddleware restricts the requests made under impersonation and records each of them in the operation
+// log, with the admin as the operator and the impersonated user as the entity.
+func (m *ImpersonationMiddleware) impersonationMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		impersonation := service.ImpersonationOf(ctx)
+		if impersonation == nil {
+			return c.Next()
+		}
+		var err error
+		if reason := impersonationDenied(impersonation, c.Method(), c.Path()); reason != "" {
+			err = errors.PermissionDeny(fmt.Errorf("%s", reason))
+		} else {
+			err = c.Next()
+		}
+
+		var (
+			operatorID, _ = primitive.ObjectIDFromHex(impersonation.ImpersonatorID)
+			userIDHex, _  = ctx.Value(config.UserIDKey).(string)
+			userID, _     = primitive.ObjectIDFromHex(userIDHex)
+			ipAddr        = c.IP()
+			userAgent     = c.Get(fiber.HeaderUserAgent)
+			operation     = config.OperationTypeImpersonate
+			entityType    = config.EntityTypeImpersonation
+			description   = fmt.Sprintf("%s %s as user %s", c.Method(), c.Path(), userIDHex)
+			status        = config.OperationStatusSuccess
+		)
+		if err != nil {
+			description = fmt.Sprintf("%s: %v", description, err)
+			status = config.OperationStatusFailure
+		} else if c.Response().StatusCode() >= fiber.StatusBadRequest {
+			description = fmt.Sprintf("%s: status %d", description, c.Response().StatusCode())
+			status = config.OperationStatusFailure
+		}
+		_ = m.LogsService.CacheOperationLog(
+			ctx, &operatorID, &userID, &ipAddr, &userAgent, &operation, &entityType, &description, &status,
+		)
+		return err
+	}
+}
+
+// impersonationDenied returns why the request cannot be made under the impersonation, or "" if it can. Read-only
+// impersonations only read, and no impersonation changes the credentials of the user.
+func impersonationDenied(impersonation *service.Impersonation, method, path string) string {
+	if method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions {
+		return ""
+	}
+	if impersonation.ReadOnly {
+		return "impersonation is read-only"
+	}
+	path = strings.TrimPrefix(path, "/api/v1")
+	for _, prefix := range []string{
+		"/change-password", "/profile/email", "/two-factor", "/access-token", "/session", "/auth",
+	} {
+		if strings.HasPrefix(path, prefix) {
+			return fmt.Sprintf("%s is not allowed under impersonation", path)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OrganizationMiddleware struct {
+	Enforcer        *casbin.Enforcer
+	OrganizationDao daos.OrganizationDao
+}
+
+// OrganizationMiddleware scopes the request of a user holding the ORG_ADMIN role to the organization of its ORG_ADMIN
+// grouping, even if another role grants the permission too; an ORG_ADMIN without an organization is denied. Admins,
+// and users granted the permission by other roles only (e.g. REVIEWER), are not scoped.
+func (m *OrganizationMiddleware) OrganizationMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals(config.UserIDKey).(string)
+		if !ok {
+			return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+		}
+		isAdmin, err := m.Enforcer.HasRoleForUser(userID, config.UserRoleAdmin)
+		if err != nil {
+			return errors.ServerBusy(fmt.Errorf("failed to get roles of user"))
+		}
+		if isAdmin {
+			return c.Next()
+		}
+		isOrgAdmin, err := m.Enforcer.HasRoleForUser(userID, config.UserRoleOrgAdmin)
+		if err != nil {
+			return errors.ServerBusy(fmt.Errorf("failed to get roles of user"))
+		}
+		organizationID, err := service.OrganizationAdminDomain(m.Enforcer, userID)
+		if e.Is(err, service.ErrMultipleOrganizationAdminDomains) {
+			return errors.PermissionDeny(fmt.Errorf("organization admin of more than one organization"))
+		} else if err != nil {
+			return errors.ServerBusy(fmt.Errorf("failed to get roles of user"))
+		}
+		if organizationID == primitive.NilObjectID {
+			if isOrgAdmin {
+				return errors.PermissionDeny(fmt.Errorf("organization admin without an organization"))
+			}
+			return c.Next()
+		}
+		ctx := c.UserContext()
+		organization, err := m.OrganizationDao.GetOrganizationByID(ctx, organizationID)
+		if err != nil {
+			return errors.PermissionDeny(fmt.Errorf("organization of the user not found"))
+		}
+		c.SetUserContext(context.WithValue(ctx, config.OrganizationKey, organization.Name))
+		return c.Next()
+	}
+}
@@ -12,7 +12,10 @@ type Router struct {
 	RouterV1 *router.Router
 }
 
-func (r *Router) RegisterRouter(app *fiber.App, casbin *casbin.Middleware, idempotencyMiddleware fiber.Handler) {
+func (r *Router) RegisterRouter(
+	app *fiber.App, casbin *casbin.Middleware, idempotencyMiddleware, organizationMiddleware fiber.Handler,
+) {
+	app.Get("/.well-known/jwks.json", r.RouterV1.ApiV1.CommonApi.JWKSApi.GetJWKS)
 	group := app.Group(prefix)
-	r.RouterV1.RegisterRouter(&group, casbin, idempotencyMiddleware)
+	r.RouterV1.RegisterRouter(&group, casbin, idempotencyMiddleware, organizationMiddleware)
 }
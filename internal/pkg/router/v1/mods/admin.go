@@ -3,7 +3,7 @@ package mods
 import (
 	"data-collection-hub-server/internal/pkg/api/v1/admin"
 	"data-collection-hub-server/internal/pkg/config"
-	"github.com/gofiber/contrib/casbin"
+	casbinmw "github.com/gofiber/contrib/casbin"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -13,54 +13,86 @@ type AdminRouter struct{}
 
 // RegisterAdminRouter registers the admin router.
 func (a *AdminRouter) RegisterAdminRouter(
-	app fiber.Router, api *admin.Admin, casbin *casbin.Middleware, idempotencyMiddleware fiber.Handler,
+	app fiber.Router, api *admin.Admin, casbin *casbinmw.Middleware,
+	idempotencyMiddleware, organizationMiddleware fiber.Handler,
 ) {
 	group := app.Group(adminPrefix)
+	// Organization admins are let through with their requests scoped to their organization by organizationMiddleware.
+	orgAdmin := casbin.RequiresRoles(
+		[]string{config.UserRoleAdmin, config.UserRoleOrgAdmin}, casbinmw.WithValidationRule(casbinmw.AtLeastOneRule),
+	)
+	// Routes open to fine-grained roles check the permission, granted by casbin policies, instead of the role.
+	statisticRead := casbin.RequiresPermissions(
+		[]string{config.PolicyObjectStatistic + ":" + config.PolicyActionRead},
+	)
+	instructionDataRead := casbin.RequiresPermissions(
+		[]string{config.PolicyObjectInstructionData + ":" + config.PolicyActionRead},
+	)
+	instructionDataReview := casbin.RequiresPermissions(
+		[]string{config.PolicyObjectInstructionData + ":" + config.PolicyActionReview},
+	)
+	instructionDataExport := casbin.RequiresPermissions(
+		[]string{config.PolicyObjectInstructionData + ":" + config.PolicyActionExport},
+	)
 
 	group.Get(
 		"/data-statistic",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		statisticRead,
+		organizationMiddleware,
 		api.StatisticApi.GetDataStatistic,
 	)
 	group.Get(
 		"/user-statistic",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		statisticRead,
+		organizationMiddleware,
 		api.StatisticApi.GetUserStatistic,
 	)
 	group.Get(
 		"/user-statistic/list",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		statisticRead,
+		organizationMiddleware,
 		api.StatisticApi.GetUserStatisticList,
 	)
+	group.Get(
+		"/review-agreement",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.StatisticApi.GetReviewAgreement,
+	)
 
 	group.Get(
 		"/instruction-data",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataRead,
+		organizationMiddleware,
 		api.DataAuditApi.GetInstructionData,
 	)
 	group.Get(
 		"/instruction-data/list",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataRead,
+		organizationMiddleware,
 		api.DataAuditApi.GetInstructionDataList,
 	)
 	group.Put(
 		"instruction-data/approve",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataReview,
+		organizationMiddleware,
 		api.DataAuditApi.ApproveInstructionData,
 	)
 	group.Put(
 		"/instruction-data/reject",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataReview,
+		organizationMiddleware,
 		api.DataAuditApi.RejectInstructionData,
 	)
 	group.Get(
 		"/instruction-data/export",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataExport,
+		organizationMiddleware,
 		api.DataAuditApi.ExportInstructionData,
 	)
 	group.Get(
 		"/instruction-data/export/alpaca",
-		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		instructionDataExport,
+		organizationMiddleware,
 		api.DataAuditApi.ExportInstructionDataAsAlpaca,
 	)
 	group.Put(
@@ -75,6 +107,22 @@ func (a *AdminRouter) RegisterAdminRouter(
 		api.DataAuditApi.DeleteInstructionData,
 	)
 
+	group.Get(
+		"/re-audit/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ReAuditApi.GetReAuditList,
+	)
+	group.Get(
+		"/re-audit/statistic",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ReAuditApi.GetReAuditStatistic,
+	)
+	group.Put(
+		"/re-audit",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ReAuditApi.SubmitReAudit,
+	)
+
 	group.Post(
 		"/notice",
 		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
@@ -91,6 +139,22 @@ func (a *AdminRouter) RegisterAdminRouter(
 		api.NoticeApi.DeleteNotice,
 	)
 
+	group.Post(
+		"/theme",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ThemeApi.InsertTheme,
+	)
+	group.Put(
+		"/theme",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ThemeApi.UpdateTheme,
+	)
+	group.Delete(
+		"/theme",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.ThemeApi.DeleteTheme,
+	)
+
 	group.Post(
 		"/user",
 		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
@@ -106,6 +170,11 @@ func (a *AdminRouter) RegisterAdminRouter(
 		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
 		api.UserApi.GetUserList,
 	)
+	group.Post(
+		"/user/import",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.ImportUser,
+	)
 
 	group.Put(
 		"/user",
@@ -122,6 +191,94 @@ func (a *AdminRouter) RegisterAdminRouter(
 		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
 		api.UserApi.ChangeUserPassword,
 	)
+	group.Delete(
+		"/user/session/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.DeleteUserSessionList,
+	)
+	group.Put(
+		"/user/suspend",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.SuspendUser,
+	)
+	group.Put(
+		"/user/reactivate",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.ReactivateUser,
+	)
+	group.Get(
+		"/user/export",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.ExportUserData,
+	)
+	group.Post(
+		"/user/erase",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.EraseUser,
+	)
+	group.Get(
+		"/user/deleted/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.GetDeletedUserList,
+	)
+	group.Put(
+		"/user/restore",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.RestoreUser,
+	)
+	group.Post(
+		"/user/purge",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.PurgeUserList,
+	)
+	group.Post(
+		"/user/impersonate",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.UserApi.ImpersonateUser,
+	)
+
+	group.Post(
+		"/invite-code",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.InviteCodeApi.InsertInviteCode,
+	)
+	group.Get(
+		"/invite-code",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.InviteCodeApi.GetInviteCode,
+	)
+	group.Get(
+		"/invite-code/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.InviteCodeApi.GetInviteCodeList,
+	)
+	group.Delete(
+		"/invite-code",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.InviteCodeApi.DeleteInviteCode,
+	)
+
+	group.Get(
+		"/two-factor-policy/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.TwoFactorPolicyApi.GetTwoFactorPolicyList,
+	)
+	group.Put(
+		"/two-factor-policy",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.TwoFactorPolicyApi.UpdateTwoFactorPolicy,
+	)
+
+	group.Get(
+		"/login-lockout/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.LoginLockoutApi.GetLoginLockoutList,
+	)
+	group.Delete(
+		"/login-lockout",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.LoginLockoutApi.DeleteLoginLockout,
+	)
 
 	group.Post(
 		"/documentation",
@@ -149,4 +306,98 @@ func (a *AdminRouter) RegisterAdminRouter(
 		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
 		api.LogsApi.GetOperationLogList,
 	)
+
+	group.Post(
+		"/organization",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.InsertOrganization,
+	)
+	group.Get(
+		"/organization",
+		orgAdmin,
+		organizationMiddleware,
+		api.OrganizationApi.GetOrganization,
+	)
+	group.Get(
+		"/organization/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.GetOrganizationList,
+	)
+	group.Put(
+		"/organization",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.UpdateOrganization,
+	)
+	group.Delete(
+		"/organization",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.DeleteOrganization,
+	)
+	group.Get(
+		"/organization/member/list",
+		orgAdmin,
+		organizationMiddleware,
+		api.OrganizationApi.GetOrganizationMemberList,
+	)
+	group.Post(
+		"/organization/member",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.InsertOrganizationMember,
+	)
+	group.Delete(
+		"/organization/member",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.DeleteOrganizationMember,
+	)
+	group.Post(
+		"/organization/admin",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.InsertOrganizationAdmin,
+	)
+	group.Delete(
+		"/organization/admin",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.OrganizationApi.DeleteOrganizationAdmin,
+	)
+
+	group.Get(
+		"/policy/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.GetPolicyList,
+	)
+	group.Post(
+		"/policy",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.InsertPolicy,
+	)
+	group.Delete(
+		"/policy",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.DeletePolicy,
+	)
+	group.Get(
+		"/role-grouping/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.GetRoleGroupingList,
+	)
+	group.Post(
+		"/role-grouping",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.InsertRoleGrouping,
+	)
+	group.Delete(
+		"/role-grouping",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.DeleteRoleGrouping,
+	)
+	group.Get(
+		"/permission/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.GetUserPermission,
+	)
+	group.Get(
+		"/permission/check",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin}),
+		api.PolicyApi.CheckPermission,
+	)
 }
@@ -28,17 +28,119 @@ func (c *CommonRouter) RegisterCommonRouter(
 		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
 		api.ProfileApi.GetProfile,
 	)
+	app.Put(
+		"/profile",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.ProfileApi.UpdateProfile,
+	)
+	app.Post(
+		"/profile/email",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.ProfileApi.ChangeEmail,
+	)
+	app.Post(
+		"/profile/email/confirm",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.ProfileApi.ConfirmEmail,
+	)
+	app.Put(
+		"/profile/avatar",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.ProfileApi.UpdateAvatar,
+	)
+	app.Get(
+		"/profile/export",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.ProfileApi.ExportPersonalData,
+	)
 	app.Put(
 		"/change-password",
 		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
 		api.AuthApi.ChangePassword,
 	)
+	app.Post(
+		"/two-factor/enroll",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.TwoFactorApi.EnrollTwoFactor,
+	)
+	app.Post(
+		"/two-factor/confirm",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.TwoFactorApi.ConfirmTwoFactor,
+	)
+	app.Post(
+		"/two-factor/disable",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.TwoFactorApi.DisableTwoFactor,
+	)
+	app.Post(
+		"/access-token",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.AccessTokenApi.InsertAccessToken,
+	)
+	app.Get(
+		"/access-token/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.AccessTokenApi.GetAccessTokenList,
+	)
+	app.Delete(
+		"/access-token",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.AccessTokenApi.DeleteAccessToken,
+	)
+	app.Get(
+		"/session/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.SessionApi.GetSessionList,
+	)
+	app.Delete(
+		"/session",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.SessionApi.DeleteSession,
+	)
+	app.Delete(
+		"/session/list",
+		casbin.RequiresRoles([]string{config.UserRoleAdmin, config.UserRoleUser}),
+		api.SessionApi.DeleteSessionList,
+	)
 
 	authGroup := app.Group("/auth")
 	authGroup.Post(
 		"/login",
 		api.AuthApi.Login,
 	)
+	authGroup.Post(
+		"/register",
+		api.AuthApi.Register,
+	)
+	authGroup.Post(
+		"/forgot-password",
+		api.AuthApi.ForgotPassword,
+	)
+	authGroup.Post(
+		"/reset-password",
+		api.AuthApi.ResetPassword,
+	)
+	authGroup.Post(
+		"/two-factor/verify",
+		api.TwoFactorApi.VerifyTwoFactor,
+	)
+	authGroup.Post(
+		"/two-factor/enroll",
+		api.TwoFactorApi.EnrollTwoFactorWithChallenge,
+	)
+	authGroup.Post(
+		"/two-factor/confirm",
+		api.TwoFactorApi.ConfirmTwoFactorWithChallenge,
+	)
+	authGroup.Get(
+		"/oidc/authorize",
+		api.OIDCApi.Authorize,
+	)
+	authGroup.Post(
+		"/oidc/callback",
+		api.OIDCApi.Callback,
+	)
 	authGroup.Get(
 		"/logout",
 		api.AuthApi.Logout,
@@ -58,6 +160,16 @@ func (c *CommonRouter) RegisterCommonRouter(
 		api.NoticeApi.GetNoticeList,
 	)
 
+	themeGroup := app.Group("/theme")
+	themeGroup.Get(
+		"/",
+		api.ThemeApi.GetTheme,
+	)
+	themeGroup.Get(
+		"/list",
+		api.ThemeApi.GetThemeList,
+	)
+
 	documentationGroup := app.Group("/documentation")
 	documentationGroup.Get(
 		"/",
@@ -41,6 +41,17 @@ func (u *UserRouter) RegisterUserRouter(
 		idempotencyMiddleware,
 		api.DatasetApi.InsertInstructionData,
 	)
+	group.Post(
+		"/instruction-data/draft",
+		casbin.RequiresRoles([]string{config.UserRoleUser}),
+		idempotencyMiddleware,
+		api.DatasetApi.InsertInstructionDataDraft,
+	)
+	group.Post(
+		"/instruction-data/submit",
+		casbin.RequiresRoles([]string{config.UserRoleUser}),
+		api.DatasetApi.SubmitInstructionData,
+	)
 	group.Put(
 		"/instruction-data",
 		casbin.RequiresRoles([]string{config.UserRoleUser}),
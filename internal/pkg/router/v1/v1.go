@@ -16,15 +16,19 @@ type Router struct {
 	UserRouter   *mods.UserRouter
 }
 
-func (a *Router) RegisterRouter(router *fiber.Router, casbin *casbin.Middleware, idempotencyMiddleware fiber.Handler) {
-	a.registerV1Router(router, casbin, idempotencyMiddleware)
+func (a *Router) RegisterRouter(
+	router *fiber.Router, casbin *casbin.Middleware, idempotencyMiddleware, organizationMiddleware fiber.Handler,
+) {
+	a.registerV1Router(router, casbin, idempotencyMiddleware, organizationMiddleware)
 }
 
 func (a *Router) registerV1Router(
-	router *fiber.Router, casbin *casbin.Middleware, idempotencyMiddleware fiber.Handler,
+	router *fiber.Router, casbin *casbin.Middleware, idempotencyMiddleware, organizationMiddleware fiber.Handler,
 ) {
 	v1Router := (*router).Group(v1Prefix)
-	a.AdminRouter.RegisterAdminRouter(v1Router, a.ApiV1.AdminApi, casbin, idempotencyMiddleware)
+	a.AdminRouter.RegisterAdminRouter(
+		v1Router, a.ApiV1.AdminApi, casbin, idempotencyMiddleware, organizationMiddleware,
+	)
 	a.CommonRouter.RegisterCommonRouter(v1Router, a.ApiV1.CommonApi, casbin)
 	a.UserRouter.RegisterUserRouter(v1Router, a.ApiV1.UserApi, casbin, idempotencyMiddleware)
 }
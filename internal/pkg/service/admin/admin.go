@@ -5,10 +5,17 @@ import (
 )
 
 type Admin struct {
-	DataAuditService     mods.DataAuditService
-	DocumentationService mods.DocumentationService
-	LogsService          mods.LogsService
-	NoticeService        mods.NoticeService
-	StatisticService     mods.StatisticService
-	UserService          mods.UserService
+	DataAuditService       mods.DataAuditService
+	DocumentationService   mods.DocumentationService
+	InviteCodeService      mods.InviteCodeService
+	LoginLockoutService    mods.LoginLockoutService
+	LogsService            mods.LogsService
+	NoticeService          mods.NoticeService
+	OrganizationService    mods.OrganizationService
+	PolicyService          mods.PolicyService
+	ReAuditService         mods.ReAuditService
+	StatisticService       mods.StatisticService
+	ThemeService           mods.ThemeService
+	TwoFactorPolicyService mods.TwoFactorPolicyService
+	UserService            mods.UserService
 }
@@ -8,9 +8,11 @@ import (
 
 	"data-collection-hub-server/internal/pkg/config"
 	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/internal/pkg/domain/vo/admin"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/spdx"
 	"github.com/qiniu/qmgo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -23,23 +25,23 @@ type DataAuditService interface {
 	GetInstructionDataList(
 		ctx context.Context, page, pageSize *int64, desc *bool, userID *primitive.ObjectID,
 		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-		theme, status, query *string,
+		theme, status, query *string, metadata map[string]interface{}, sourceType, license *string,
 	) (*admin.GetInstructionDataListResponse, error)
 	ApproveInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error
 	RejectInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID, message *string) error
 	UpdateInstructionData(
 		ctx context.Context, instructionDataID, userID *primitive.ObjectID,
-		instruction, input, output, theme, source, note *string,
+		instruction, input, output, theme, source, note *string, metadata map[string]interface{},
 	) error
 	ExportInstructionData(
 		ctx context.Context, desc *bool, userID *primitive.ObjectID,
 		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-		theme, status *string,
+		theme, status *string, metadata map[string]interface{}, sourceType, license *string, permissiveOnly *bool,
 	) (*admin.InstructionDataList, error)
 	ExportInstructionDataAsAlpaca(
 		ctx context.Context, desc *bool, userID *primitive.ObjectID,
 		createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-		theme, status *string,
+		theme, status *string, metadata map[string]interface{}, sourceType, license *string, permissiveOnly *bool,
 	) (*admin.InstructionDataAlpacaList, error)
 	DeleteInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error
 }
@@ -47,12 +49,18 @@ type DataAuditService interface {
 type DataAuditServiceImpl struct {
 	core               *service.Core
 	instructionDataDao dao.InstructionDataDao
+	themeDao           dao.ThemeDao
+	reviewDao          dao.ReviewDao
 }
 
-func NewDataAuditService(core *service.Core, instructionDataDao dao.InstructionDataDao) DataAuditService {
+func NewDataAuditService(
+	core *service.Core, instructionDataDao dao.InstructionDataDao, themeDao dao.ThemeDao, reviewDao dao.ReviewDao,
+) DataAuditService {
 	return &DataAuditServiceImpl{
 		core:               core,
 		instructionDataDao: instructionDataDao,
+		themeDao:           themeDao,
+		reviewDao:          reviewDao,
 	}
 }
 
@@ -71,6 +79,9 @@ func (d DataAuditServiceImpl) GetInstructionData(
 			)
 		}
 	}
+	if instructionData.Status.Code == config.InstructionDataStatusDraft {
+		return nil, errors.NotFound(fmt.Errorf("instruction data (id: %s) not found", instructionDataID.Hex()))
+	}
 	return &admin.GetInstructionDataResponse{
 		InstructionDataID: instructionDataID.Hex(),
 		UserID:            instructionData.UserID.Hex(),
@@ -88,9 +99,11 @@ func (d DataAuditServiceImpl) GetInstructionData(
 			Input:       instructionData.Row.Input,
 			Output:      instructionData.Row.Output,
 		}),
-		Theme:  instructionData.Theme,
-		Source: instructionData.Source,
-		Note:   instructionData.Note,
+		Theme:      instructionData.Theme,
+		Source:     instructionData.Source,
+		Note:       instructionData.Note,
+		Metadata:   instructionData.Metadata,
+		Provenance: admin.Provenance(instructionData.Provenance),
 		Status: struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
@@ -109,12 +122,13 @@ func (d DataAuditServiceImpl) GetInstructionData(
 func (d DataAuditServiceImpl) GetInstructionDataList(
 	ctx context.Context, page, pageSize *int64, desc *bool, userID *primitive.ObjectID,
 	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-	theme, status, query *string,
+	theme, status, query *string, metadata map[string]interface{}, sourceType, license *string,
 ) (*admin.GetInstructionDataListResponse, error) {
 	offset := (*page - 1) * *pageSize
 	instructionDataList, count, err := d.instructionDataDao.GetInstructionDataList(
 		ctx, offset, *pageSize, *desc, userID, theme, status,
-		createStartTime, createEndTime, updateStartTime, updateEndTime, query,
+		createStartTime, createEndTime, updateStartTime, updateEndTime, query, metadata, sourceType,
+		licenseFilter(license, nil),
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get instruction data list")) // TODO: Should contain more situation e.g. sometime it's caused by not found or the input is illegal, not only operation failed
@@ -140,9 +154,11 @@ func (d DataAuditServiceImpl) GetInstructionDataList(
 					Input:       instructionData.Row.Input,
 					Output:      instructionData.Row.Output,
 				}),
-				Theme:  instructionData.Theme,
-				Source: instructionData.Source,
-				Note:   instructionData.Note,
+				Theme:      instructionData.Theme,
+				Source:     instructionData.Source,
+				Note:       instructionData.Note,
+				Metadata:   instructionData.Metadata,
+				Provenance: admin.Provenance(instructionData.Provenance),
 				Status: struct {
 					Code    string `json:"code"`
 					Message string `json:"message"`
@@ -169,11 +185,18 @@ func (d DataAuditServiceImpl) ApproveInstructionData(ctx context.Context, instru
 	status := config.InstructionDataStatusApproved
 	message := ""
 
-	err := d.instructionDataDao.UpdateInstructionData(
+	instructionData, err := d.getSubmitted(ctx, instructionDataID)
+	if err != nil {
+		return err
+	}
+	if err = d.recordReview(ctx, instructionData, status, message); err != nil {
+		return err
+	}
+	err = d.instructionDataDao.UpdateInstructionData(
 		ctx,
 		*instructionDataID,
 		nil, nil, nil, nil, nil, nil, nil,
-		&status, &message,
+		&status, &message, nil, nil,
 	)
 	if err != nil {
 		if e.Is(err, qmgo.ErrNoSuchDocuments) {
@@ -193,11 +216,18 @@ func (d DataAuditServiceImpl) RejectInstructionData(
 	ctx context.Context, instructionDataID *primitive.ObjectID, message *string,
 ) error {
 	status := config.InstructionDataStatusRejected
-	err := d.instructionDataDao.UpdateInstructionData(
+	instructionData, err := d.getSubmitted(ctx, instructionDataID)
+	if err != nil {
+		return err
+	}
+	if err = d.recordReview(ctx, instructionData, status, *message); err != nil {
+		return err
+	}
+	err = d.instructionDataDao.UpdateInstructionData(
 		ctx,
 		*instructionDataID,
 		nil, nil, nil, nil, nil, nil, nil,
-		&status, message,
+		&status, message, nil, nil,
 	)
 	if err != nil {
 		if e.Is(err, qmgo.ErrNoSuchDocuments) {
@@ -215,12 +245,28 @@ func (d DataAuditServiceImpl) RejectInstructionData(
 
 func (d DataAuditServiceImpl) UpdateInstructionData(
 	ctx context.Context, instructionDataID, userID *primitive.ObjectID,
-	instruction, input, output, theme, source, note *string,
+	instruction, input, output, theme, source, note *string, metadata map[string]interface{},
 ) error {
-	err := d.instructionDataDao.UpdateInstructionData(
+	instructionData, err := d.getSubmitted(ctx, instructionDataID)
+	if err != nil {
+		return err
+	}
+	if theme != nil || metadata != nil {
+		t, m := instructionData.Theme, instructionData.Metadata
+		if theme != nil {
+			t = *theme
+		}
+		if metadata != nil {
+			m = metadata
+		}
+		if err = service.ValidateMetadata(ctx, d.themeDao, t, m); err != nil {
+			return err
+		}
+	}
+	err = d.instructionDataDao.UpdateInstructionData(
 		ctx,
 		*instructionDataID,
-		userID, instruction, input, output, theme, source, note, nil, nil,
+		userID, instruction, input, output, theme, source, note, nil, nil, metadata, nil,
 	)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
@@ -239,11 +285,12 @@ func (d DataAuditServiceImpl) UpdateInstructionData(
 func (d DataAuditServiceImpl) ExportInstructionData(
 	ctx context.Context, desc *bool, userID *primitive.ObjectID,
 	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-	theme, status *string,
+	theme, status *string, metadata map[string]interface{}, sourceType, license *string, permissiveOnly *bool,
 ) (*admin.InstructionDataList, error) {
 	var instructionDataList []*admin.InstructionData
 	_instructionDataList, _, err := d.instructionDataDao.GetInstructionDataList(
 		ctx, 0, 0, *desc, userID, theme, status, createStartTime, createEndTime, updateStartTime, updateEndTime, nil,
+		metadata, sourceType, licenseFilter(license, permissiveOnly),
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get instruction data list"))
@@ -268,9 +315,11 @@ func (d DataAuditServiceImpl) ExportInstructionData(
 					Input:       instructionData.Row.Input,
 					Output:      instructionData.Row.Output,
 				}),
-				Theme:  instructionData.Theme,
-				Source: instructionData.Source,
-				Note:   instructionData.Note,
+				Theme:      instructionData.Theme,
+				Source:     instructionData.Source,
+				Note:       instructionData.Note,
+				Metadata:   instructionData.Metadata,
+				Provenance: admin.Provenance(instructionData.Provenance),
 				Status: struct {
 					Code    string `json:"code"`
 					Message string `json:"message"`
@@ -294,11 +343,12 @@ func (d DataAuditServiceImpl) ExportInstructionData(
 func (d DataAuditServiceImpl) ExportInstructionDataAsAlpaca(
 	ctx context.Context, desc *bool, userID *primitive.ObjectID,
 	createStartTime, createEndTime, updateStartTime, updateEndTime *time.Time,
-	theme, status *string,
+	theme, status *string, metadata map[string]interface{}, sourceType, license *string, permissiveOnly *bool,
 ) (*admin.InstructionDataAlpacaList, error) {
 	var instructionDataList []*admin.InstructionDataAlpaca
 	_instructionDataList, _, err := d.instructionDataDao.GetInstructionDataList(
 		ctx, 0, 0, *desc, userID, theme, status, createStartTime, createEndTime, updateStartTime, updateEndTime, nil,
+		metadata, sourceType, licenseFilter(license, permissiveOnly),
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get instruction data list"))
@@ -310,6 +360,8 @@ func (d DataAuditServiceImpl) ExportInstructionDataAsAlpaca(
 				Institution: instructionData.Row.Instruction,
 				Input:       instructionData.Row.Input,
 				Output:      instructionData.Row.Output,
+				Metadata:    instructionData.Metadata,
+				Provenance:  (*admin.Provenance)(&instructionData.Provenance),
 			},
 		)
 	}
@@ -328,3 +380,66 @@ func (d DataAuditServiceImpl) DeleteInstructionData(ctx context.Context, instruc
 	}
 	return nil
 }
+
+// getSubmitted returns the instruction data if it has been submitted, drafts are treated as not found.
+func (d DataAuditServiceImpl) getSubmitted(
+	ctx context.Context, instructionDataID *primitive.ObjectID,
+) (*entity.InstructionDataModel, error) {
+	instructionData, err := d.instructionDataDao.GetInstructionDataByID(ctx, *instructionDataID)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("instruction data (id: %s) not found", instructionDataID.Hex()))
+		}
+		return nil, errors.OperationFailed(
+			fmt.Errorf("failed to get instruction data (id: %s)", instructionDataID.Hex()),
+		)
+	}
+	if instructionData.Status.Code == config.InstructionDataStatusDraft {
+		return nil, errors.NotFound(fmt.Errorf("instruction data (id: %s) not found", instructionDataID.Hex()))
+	}
+	return instructionData, nil
+}
+
+// recordReview records the decision of the current admin on the instruction data. Every reviewer keeps one decision
+// per record, so that the agreement between reviewers can be measured, while the status of the record follows the
+// latest decision.
+func (d DataAuditServiceImpl) recordReview(
+	ctx context.Context, instructionData *entity.InstructionDataModel, decision, message string,
+) error {
+	reviewerIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	reviewerID, err := primitive.ObjectIDFromHex(reviewerIDHex)
+	if err != nil {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	err = d.reviewDao.UpsertReview(
+		ctx, instructionData.InstructionDataID, reviewerID, instructionData.Theme, decision, message,
+	)
+	if err != nil {
+		return errors.OperationFailed(
+			fmt.Errorf("failed to record review of instruction data (id: %s)", instructionData.InstructionDataID.Hex()),
+		)
+	}
+	return nil
+}
+
+// licenseFilter returns the licenses the instruction data is filtered by, nil means no filter. With permissiveOnly,
+// only the permissive licenses are kept, so that records with a copyleft, non-commercial or unknown license are
+// excluded.
+func licenseFilter(license *string, permissiveOnly *bool) []string {
+	if permissiveOnly == nil || !*permissiveOnly {
+		if license == nil {
+			return nil
+		}
+		return []string{*license}
+	}
+	if license == nil {
+		return spdx.PermissiveLicenses()
+	}
+	if spdx.IsPermissive(*license) {
+		return []string{*license}
+	}
+	return []string{}
+}
@@ -0,0 +1,141 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type InviteCodeService interface {
+	InsertInviteCode(
+		ctx context.Context, role, organization *string, maxUses *int64, expiresAt *time.Time,
+	) (*admin.InsertInviteCodeResponse, error)
+	GetInviteCode(ctx context.Context, inviteCodeID *primitive.ObjectID) (*admin.GetInviteCodeResponse, error)
+	GetInviteCodeList(
+		ctx context.Context, page, pageSize *int64, desc *bool, role, organization *string,
+	) (*admin.GetInviteCodeListResponse, error)
+	DeleteInviteCode(ctx context.Context, inviteCodeID *primitive.ObjectID) error
+}
+
+type InviteCodeServiceImpl struct {
+	core          *service.Core
+	inviteCodeDao dao.InviteCodeDao
+}
+
+func NewInviteCodeService(core *service.Core, inviteCodeDao dao.InviteCodeDao) InviteCodeService {
+	return &InviteCodeServiceImpl{
+		core:          core,
+		inviteCodeDao: inviteCodeDao,
+	}
+}
+
+// InsertInviteCode generates a new invite code, users registered with it get the given role and organization.
+func (i InviteCodeServiceImpl) InsertInviteCode(
+	ctx context.Context, role, organization *string, maxUses *int64, expiresAt *time.Time,
+) (*admin.InsertInviteCodeResponse, error) {
+	createdBy, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, errors.InvalidRequest(fmt.Errorf("expiry time should be in the future"))
+	}
+	code, err := crypt.RandomToken(8)
+	if err != nil {
+		i.core.Logger.Error("failed to generate invite code", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate invite code"))
+	}
+	inviteCodeID, err := i.inviteCodeDao.InsertInviteCode(
+		ctx, code, *role, *organization, *maxUses, *expiresAt, createdBy,
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.DuplicateKeyError(fmt.Errorf("invite code already exists, please retry"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to insert invite code"))
+	}
+	return &admin.InsertInviteCodeResponse{
+		InviteCodeID: inviteCodeID.Hex(),
+		Code:         code,
+	}, nil
+}
+
+func (i InviteCodeServiceImpl) GetInviteCode(
+	ctx context.Context, inviteCodeID *primitive.ObjectID,
+) (*admin.GetInviteCodeResponse, error) {
+	inviteCode, err := i.inviteCodeDao.GetInviteCodeByID(ctx, *inviteCodeID)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("invite code (id: %s) not found", inviteCodeID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get invite code (id: %s)", inviteCodeID.Hex()))
+	}
+	return inviteCodeResponse(inviteCode), nil
+}
+
+func (i InviteCodeServiceImpl) GetInviteCodeList(
+	ctx context.Context, page, pageSize *int64, desc *bool, role, organization *string,
+) (*admin.GetInviteCodeListResponse, error) {
+	offset := (*page - 1) * *pageSize
+	inviteCodeList, count, err := i.inviteCodeDao.GetInviteCodeList(ctx, offset, *pageSize, *desc, role, organization)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get invite code list"))
+	}
+	resp := make([]*admin.GetInviteCodeResponse, 0, len(inviteCodeList))
+	for _, inviteCode := range inviteCodeList {
+		resp = append(resp, inviteCodeResponse(&inviteCode))
+	}
+	return &admin.GetInviteCodeListResponse{
+		Total:          *count,
+		InviteCodeList: resp,
+	}, nil
+}
+
+// DeleteInviteCode deletes the invite code, users already registered with it are kept.
+func (i InviteCodeServiceImpl) DeleteInviteCode(ctx context.Context, inviteCodeID *primitive.ObjectID) error {
+	if err := i.inviteCodeDao.DeleteInviteCode(ctx, *inviteCodeID); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("invite code (id: %s) not found", inviteCodeID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to delete invite code (id: %s)", inviteCodeID.Hex()))
+	}
+	return nil
+}
+
+func inviteCodeResponse(inviteCode *entity.InviteCodeModel) *admin.GetInviteCodeResponse {
+	redemptions := make([]*admin.RedemptionResponse, 0, len(inviteCode.Redemptions))
+	for _, redemption := range inviteCode.Redemptions {
+		redemptions = append(
+			redemptions, &admin.RedemptionResponse{
+				UserID:     redemption.UserID.Hex(),
+				Username:   redemption.Username,
+				RedeemedAt: redemption.RedeemedAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &admin.GetInviteCodeResponse{
+		InviteCodeID: inviteCode.InviteCodeID.Hex(),
+		Code:         inviteCode.Code,
+		Role:         inviteCode.Role,
+		Organization: inviteCode.Organization,
+		MaxUses:      inviteCode.MaxUses,
+		UsedCount:    inviteCode.UsedCount,
+		Redemptions:  redemptions,
+		CreatedBy:    inviteCode.CreatedBy.Hex(),
+		ExpiresAt:    inviteCode.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:    inviteCode.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    inviteCode.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,66 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+)
+
+type LoginLockoutService interface {
+	GetLoginLockoutList(ctx context.Context) (*admin.GetLoginLockoutListResponse, error)
+	DeleteLoginLockout(ctx context.Context, kind, value *string) error
+}
+
+type LoginLockoutServiceImpl struct {
+	core            *service.Core
+	loginAttemptDao dao.LoginAttemptDao
+}
+
+func NewLoginLockoutService(core *service.Core, loginAttemptDao dao.LoginAttemptDao) LoginLockoutService {
+	return &LoginLockoutServiceImpl{
+		core:            core,
+		loginAttemptDao: loginAttemptDao,
+	}
+}
+
+// GetLoginLockoutList returns the accounts and IP addresses currently locked after too many failed logins, the most
+// recent lockouts first.
+func (l LoginLockoutServiceImpl) GetLoginLockoutList(ctx context.Context) (*admin.GetLoginLockoutListResponse, error) {
+	lockoutList, err := l.loginAttemptDao.GetLoginLockoutList(ctx)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get login lockout list"))
+	}
+	sort.Slice(
+		lockoutList, func(i, j int) bool {
+			return lockoutList[i].LockedAt.After(lockoutList[j].LockedAt)
+		},
+	)
+	resp := make([]*admin.LoginLockoutResponse, 0, len(lockoutList))
+	for _, lockout := range lockoutList {
+		resp = append(
+			resp, &admin.LoginLockoutResponse{
+				Kind:      lockout.Kind,
+				Value:     lockout.Value,
+				Failures:  lockout.Failures,
+				LockedAt:  lockout.LockedAt.Format(time.RFC3339),
+				ExpiresAt: lockout.ExpiresAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &admin.GetLoginLockoutListResponse{LockoutList: resp}, nil
+}
+
+// DeleteLoginLockout unlocks the account or IP address and clears its failed logins and delay, whether it is
+// currently locked or not.
+func (l LoginLockoutServiceImpl) DeleteLoginLockout(ctx context.Context, kind, value *string) error {
+	if err := l.loginAttemptDao.DeleteLoginLockout(ctx, *kind, *value); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to delete login lockout (%s: %s)", *kind, *value))
+	}
+	return nil
+}
@@ -13,7 +13,7 @@ import (
 
 type LogsService interface {
 	GetLoginLogList(
-		ctx context.Context, page, pageSize *int64, desc *bool, query *string,
+		ctx context.Context, page, pageSize *int64, desc *bool, query, status *string,
 		createStartTime, createEndTime *time.Time,
 	) (*admin.GetLoginLogListResponse, error)
 	GetOperationLogList(
@@ -39,12 +39,13 @@ func NewLogsService(
 }
 
 func (l LogsServiceImpl) GetLoginLogList(
-	ctx context.Context, page, pageSize *int64, desc *bool, query *string, createStartTime, createEndTime *time.Time,
+	ctx context.Context, page, pageSize *int64, desc *bool, query, status *string,
+	createStartTime, createEndTime *time.Time,
 ) (*admin.GetLoginLogListResponse, error) {
 	l.loginLogDao.SyncLoginLog(ctx) // Sync log before querying
 	offset := (*page - 1) * *pageSize
 	loginLogs, total, err := l.loginLogDao.GetLoginLogList(
-		ctx, offset, *pageSize, *desc, createStartTime, createEndTime, nil, nil, nil, query,
+		ctx, offset, *pageSize, *desc, createStartTime, createEndTime, nil, nil, nil, status, query,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get login log list"))
@@ -59,6 +60,8 @@ func (l LogsServiceImpl) GetLoginLogList(
 				Email:      loginLog.Email,
 				IPAddress:  loginLog.IPAddress,
 				UserAgent:  loginLog.UserAgent,
+				Status:     loginLog.Status,
+				Reason:     loginLog.Reason,
 				CreatedAt:  loginLog.CreatedAt.Format(time.RFC3339),
 			},
 		)
@@ -0,0 +1,393 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type OrganizationService interface {
+	InsertOrganization(ctx context.Context, name, description *string) (*admin.InsertOrganizationResponse, error)
+	GetOrganization(ctx context.Context, organizationID *primitive.ObjectID) (*admin.GetOrganizationResponse, error)
+	GetOrganizationList(
+		ctx context.Context, page, pageSize *int64, desc *bool, query *string,
+	) (*admin.GetOrganizationListResponse, error)
+	UpdateOrganization(ctx context.Context, organizationID *primitive.ObjectID, description *string) error
+	DeleteOrganization(ctx context.Context, organizationID *primitive.ObjectID) error
+	GetOrganizationMemberList(
+		ctx context.Context, organizationID *primitive.ObjectID, page, pageSize *int64,
+	) (*admin.GetOrganizationMemberListResponse, error)
+	InsertOrganizationMember(ctx context.Context, organizationID, userID *primitive.ObjectID) error
+	DeleteOrganizationMember(ctx context.Context, organizationID, userID *primitive.ObjectID) error
+	InsertOrganizationAdmin(ctx context.Context, organizationID, userID *primitive.ObjectID) error
+	DeleteOrganizationAdmin(ctx context.Context, organizationID, userID *primitive.ObjectID) error
+}
+
+type OrganizationServiceImpl struct {
+	core            *service.Core
+	organizationDao dao.OrganizationDao
+	userDao         dao.UserDao
+	enforcer        *casbin.Enforcer
+}
+
+func NewOrganizationService(
+	core *service.Core, organizationDao dao.OrganizationDao, userDao dao.UserDao, enforcer *casbin.Enforcer,
+) OrganizationService {
+	return &OrganizationServiceImpl{
+		core:            core,
+		organizationDao: organizationDao,
+		userDao:         userDao,
+		enforcer:        enforcer,
+	}
+}
+
+func (o OrganizationServiceImpl) InsertOrganization(
+	ctx context.Context, name, description *string,
+) (*admin.InsertOrganizationResponse, error) {
+	var desc string
+	if description != nil {
+		desc = *description
+	}
+	organizationID, err := o.organizationDao.InsertOrganization(ctx, *name, desc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.DuplicateKeyError(fmt.Errorf("organization with name %s already exists", *name))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to insert organization"))
+	}
+	return &admin.InsertOrganizationResponse{OrganizationID: organizationID.Hex()}, nil
+}
+
+// GetOrganization returns the organization with its member count and admins. Organization admins only see their own.
+func (o OrganizationServiceImpl) GetOrganization(
+	ctx context.Context, organizationID *primitive.ObjectID,
+) (*admin.GetOrganizationResponse, error) {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return o.organizationResponse(ctx, organization)
+}
+
+func (o OrganizationServiceImpl) GetOrganizationList(
+	ctx context.Context, page, pageSize *int64, desc *bool, query *string,
+) (*admin.GetOrganizationListResponse, error) {
+	offset := (*page - 1) * *pageSize
+	organizationList, count, err := o.organizationDao.GetOrganizationList(ctx, offset, *pageSize, *desc, query)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get organization list"))
+	}
+	resp := make([]*admin.GetOrganizationResponse, 0, len(organizationList))
+	for _, organization := range organizationList {
+		organizationResp, err := o.organizationResponse(ctx, &organization)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, organizationResp)
+	}
+	return &admin.GetOrganizationListResponse{
+		Total:            *count,
+		OrganizationList: resp,
+	}, nil
+}
+
+func (o OrganizationServiceImpl) UpdateOrganization(
+	ctx context.Context, organizationID *primitive.ObjectID, description *string,
+) error {
+	if err := o.organizationDao.UpdateOrganization(ctx, *organizationID, description); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("organization (id: %s) not found", organizationID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to update organization (id: %s)", organizationID.Hex()))
+	}
+	return nil
+}
+
+// DeleteOrganization deletes an organization without members.
+func (o OrganizationServiceImpl) DeleteOrganization(ctx context.Context, organizationID *primitive.ObjectID) error {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	memberCount, err := o.userDao.CountUser(ctx, &organization.Name, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to count members of organization"))
+	}
+	if *memberCount > 0 {
+		return errors.InvalidRequest(
+			fmt.Errorf("organization (id: %s) still has %d members", organizationID.Hex(), *memberCount),
+		)
+	}
+	adminIDs, err := o.adminIDList(organizationID)
+	if err != nil {
+		return err
+	}
+	for _, adminID := range adminIDs {
+		if err = RemoveOrganizationAdmin(o.enforcer, adminID); err != nil {
+			o.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+			return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+		}
+	}
+	if err = o.organizationDao.DeleteOrganization(ctx, *organizationID); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("organization (id: %s) not found", organizationID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to delete organization (id: %s)", organizationID.Hex()))
+	}
+	return nil
+}
+
+// GetOrganizationMemberList returns the members of the organization. Organization admins only see their own.
+func (o OrganizationServiceImpl) GetOrganizationMemberList(
+	ctx context.Context, organizationID *primitive.ObjectID, page, pageSize *int64,
+) (*admin.GetOrganizationMemberListResponse, error) {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	offset := (*page - 1) * *pageSize
+	users, count, err := o.userDao.GetUserList(
+		ctx, offset, *pageSize, false, &organization.Name, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get members of organization"))
+	}
+	adminIDs, err := o.adminIDList(organizationID)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*admin.GetOrganizationMemberResponse, 0, len(users))
+	for _, user := range users {
+		var orgAdmin bool
+		for _, adminID := range adminIDs {
+			orgAdmin = orgAdmin || adminID == user.UserID.Hex()
+		}
+		resp = append(
+			resp, &admin.GetOrganizationMemberResponse{
+				UserID:    user.UserID.Hex(),
+				Username:  user.Username,
+				Email:     user.Email,
+				Role:      user.Role,
+				OrgAdmin:  orgAdmin,
+				LastLogin: user.LastLogin.Format(time.RFC3339),
+				CreatedAt: user.CreatedAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &admin.GetOrganizationMemberListResponse{
+		Total:      *count,
+		MemberList: resp,
+	}, nil
+}
+
+// InsertOrganizationMember moves the user into the organization. A user leaving an organization it administers loses
+// its organization admin role.
+func (o OrganizationServiceImpl) InsertOrganizationMember(
+	ctx context.Context, organizationID, userID *primitive.ObjectID,
+) error {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	user, err := o.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Organization == organization.Name {
+		return nil
+	}
+	if err = RemoveOrganizationAdmin(o.enforcer, userID.Hex()); err != nil {
+		o.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+	}
+	return o.updateUserOrganization(ctx, userID, organization.Name)
+}
+
+// DeleteOrganizationMember removes the user from the organization, and its organization admin role with it.
+func (o OrganizationServiceImpl) DeleteOrganizationMember(
+	ctx context.Context, organizationID, userID *primitive.ObjectID,
+) error {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	user, err := o.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Organization != organization.Name {
+		return errors.NotFound(
+			fmt.Errorf("user (id: %s) is not a member of organization (id: %s)", userID.Hex(), organizationID.Hex()),
+		)
+	}
+	if err = RemoveOrganizationAdmin(o.enforcer, userID.Hex()); err != nil {
+		o.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+	}
+	return o.updateUserOrganization(ctx, userID, "")
+}
+
+// InsertOrganizationAdmin makes a member of the organization an organization admin: it can review, export and see
+// statistics of the data of the organization. A user is an organization admin of one organization at most.
+func (o OrganizationServiceImpl) InsertOrganizationAdmin(
+	ctx context.Context, organizationID, userID *primitive.ObjectID,
+) error {
+	organization, err := o.getOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	user, err := o.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Organization != organization.Name {
+		return errors.InvalidRequest(
+			fmt.Errorf("user (id: %s) is not a member of organization (id: %s)", userID.Hex(), organizationID.Hex()),
+		)
+	}
+	adminOrganizationID, err := service.OrganizationAdminDomain(o.enforcer, userID.Hex())
+	if err != nil {
+		o.core.Logger.Error("failed to get organization admin role of user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to get organization admin role of user"))
+	}
+	if adminOrganizationID != primitive.NilObjectID && adminOrganizationID != *organizationID {
+		return errors.InvalidRequest(
+			fmt.Errorf("user (id: %s) is already an admin of another organization", userID.Hex()),
+		)
+	}
+	if _, err = o.enforcer.AddNamedGroupingPolicy(
+		"g2", userID.Hex(), config.UserRoleOrgAdmin, organizationID.Hex(),
+	); err != nil {
+		o.core.Logger.Error("failed to create organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to create organization admin role for user"))
+	}
+	if _, err = o.enforcer.AddRoleForUser(userID.Hex(), config.UserRoleOrgAdmin); err != nil {
+		o.core.Logger.Error("failed to create organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to create organization admin role for user"))
+	}
+	return nil
+}
+
+func (o OrganizationServiceImpl) DeleteOrganizationAdmin(
+	ctx context.Context, organizationID, userID *primitive.ObjectID,
+) error {
+	if _, err := o.getOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+	isAdmin, err := o.enforcer.HasNamedGroupingPolicy(
+		"g2", userID.Hex(), config.UserRoleOrgAdmin, organizationID.Hex(),
+	)
+	if err != nil {
+		o.core.Logger.Error("failed to get organization admin role of user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to get organization admin role of user"))
+	}
+	if !isAdmin {
+		return errors.NotFound(
+			fmt.Errorf("user (id: %s) is not an admin of organization (id: %s)", userID.Hex(), organizationID.Hex()),
+		)
+	}
+	if err = RemoveOrganizationAdmin(o.enforcer, userID.Hex()); err != nil {
+		o.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+	}
+	return nil
+}
+
+// RemoveOrganizationAdmin removes the organization admin role of the user, in whichever organization it holds it.
+func RemoveOrganizationAdmin(enforcer *casbin.Enforcer, userID string) error {
+	if _, err := enforcer.RemoveFilteredNamedGroupingPolicy("g2", 0, userID, config.UserRoleOrgAdmin); err != nil {
+		return err
+	}
+	_, err := enforcer.DeleteRoleForUser(userID, config.UserRoleOrgAdmin)
+	return err
+}
+
+// getOrganization returns the organization, which must be the one of the request for organization admins.
+func (o OrganizationServiceImpl) getOrganization(
+	ctx context.Context, organizationID *primitive.ObjectID,
+) (*entity.OrganizationModel, error) {
+	organization, err := o.organizationDao.GetOrganizationByID(ctx, *organizationID)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("organization (id: %s) not found", organizationID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get organization (id: %s)", organizationID.Hex()))
+	}
+	if scope := service.OrganizationScope(ctx); scope != nil && *scope != organization.Name {
+		return nil, errors.NotFound(fmt.Errorf("organization (id: %s) not found", organizationID.Hex()))
+	}
+	return organization, nil
+}
+
+func (o OrganizationServiceImpl) getUser(ctx context.Context, userID *primitive.ObjectID) (*entity.UserModel, error) {
+	user, err := o.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	return user, nil
+}
+
+func (o OrganizationServiceImpl) updateUserOrganization(
+	ctx context.Context, userID *primitive.ObjectID, organization string,
+) error {
+	if err := o.userDao.UpdateUser(ctx, *userID, nil, nil, nil, nil, &organization); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", userID.Hex()))
+	}
+	return nil
+}
+
+func (o OrganizationServiceImpl) adminIDList(organizationID *primitive.ObjectID) ([]string, error) {
+	groupings, err := o.enforcer.GetFilteredNamedGroupingPolicy(
+		"g2", 1, config.UserRoleOrgAdmin, organizationID.Hex(),
+	)
+	if err != nil {
+		o.core.Logger.Error("failed to get organization admins", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to get organization admins"))
+	}
+	adminIDs := make([]string, 0, len(groupings))
+	for _, grouping := range groupings {
+		adminIDs = append(adminIDs, grouping[0])
+	}
+	return adminIDs, nil
+}
+
+func (o OrganizationServiceImpl) organizationResponse(
+	ctx context.Context, organization *entity.OrganizationModel,
+) (*admin.GetOrganizationResponse, error) {
+	memberCount, err := o.userDao.CountUser(ctx, &organization.Name, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to count members of organization"))
+	}
+	adminIDs, err := o.adminIDList(&organization.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.GetOrganizationResponse{
+		OrganizationID: organization.OrganizationID.Hex(),
+		Name:           organization.Name,
+		Description:    organization.Description,
+		MemberCount:    *memberCount,
+		AdminIDList:    adminIDs,
+		CreatedAt:      organization.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      organization.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
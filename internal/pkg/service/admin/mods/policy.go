@@ -0,0 +1,234 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/casbin/casbin/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type PolicyService interface {
+	GetPolicyList(ctx context.Context, role *string) (*admin.GetPolicyListResponse, error)
+	InsertPolicy(ctx context.Context, role, object, action *string) error
+	DeletePolicy(ctx context.Context, role, object, action *string) error
+	GetRoleGroupingList(ctx context.Context, userID, role *string) (*admin.GetRoleGroupingListResponse, error)
+	InsertRoleGrouping(ctx context.Context, userID *primitive.ObjectID, role *string) error
+	DeleteRoleGrouping(ctx context.Context, userID *primitive.ObjectID, role *string) error
+	GetUserPermission(ctx context.Context, userID *primitive.ObjectID) (*admin.GetUserPermissionResponse, error)
+	CheckPermission(
+		ctx context.Context, userID *primitive.ObjectID, object, action *string,
+	) (*admin.CheckPermissionResponse, error)
+}
+
+type PolicyServiceImpl struct {
+	core     *service.Core
+	userDao  dao.UserDao
+	enforcer *casbin.Enforcer
+}
+
+func NewPolicyService(core *service.Core, userDao dao.UserDao, enforcer *casbin.Enforcer) PolicyService {
+	return &PolicyServiceImpl{
+		core:     core,
+		userDao:  userDao,
+		enforcer: enforcer,
+	}
+}
+
+// GetPolicyList returns the casbin policies, of the role if given.
+func (p PolicyServiceImpl) GetPolicyList(ctx context.Context, role *string) (*admin.GetPolicyListResponse, error) {
+	var (
+		policies [][]string
+		err      error
+	)
+	if role != nil {
+		policies, err = p.enforcer.GetFilteredPolicy(0, *role)
+	} else {
+		policies, err = p.enforcer.GetPolicy()
+	}
+	if err != nil {
+		p.core.Logger.Error("failed to get policies", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to get policies"))
+	}
+	return &admin.GetPolicyListResponse{PolicyList: policyResponseList(policies)}, nil
+}
+
+// InsertPolicy grants the permission to act on the object to the role. The role does not need to exist: granting a
+// permission to a new role creates a custom role.
+func (p PolicyServiceImpl) InsertPolicy(ctx context.Context, role, object, action *string) error {
+	ok, err := p.enforcer.AddPolicy(*role, *object, *action)
+	if err != nil {
+		p.core.Logger.Error("failed to create policy", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to create policy"))
+	}
+	if !ok {
+		return errors.DuplicateKeyError(fmt.Errorf("policy (%s, %s, %s) already exists", *role, *object, *action))
+	}
+	return nil
+}
+
+// DeletePolicy revokes the permission from the role. The default policies of the built-in roles cannot be revoked.
+func (p PolicyServiceImpl) DeletePolicy(ctx context.Context, role, object, action *string) error {
+	if service.IsDefaultPolicy(*role, *object, *action) {
+		return errors.InvalidRequest(
+			fmt.Errorf("policy (%s, %s, %s) is built in and cannot be deleted", *role, *object, *action),
+		)
+	}
+	ok, err := p.enforcer.RemovePolicy(*role, *object, *action)
+	if err != nil {
+		p.core.Logger.Error("failed to delete policy", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete policy"))
+	}
+	if !ok {
+		return errors.NotFound(fmt.Errorf("policy (%s, %s, %s) not found", *role, *object, *action))
+	}
+	return nil
+}
+
+// GetRoleGroupingList returns the roles granted to users, filtered by user and role if given.
+func (p PolicyServiceImpl) GetRoleGroupingList(
+	ctx context.Context, userID, role *string,
+) (*admin.GetRoleGroupingListResponse, error) {
+	var user, r string
+	if userID != nil {
+		user = *userID
+	}
+	if role != nil {
+		r = *role
+	}
+	groupings, err := p.enforcer.GetFilteredGroupingPolicy(0, user, r)
+	if err != nil {
+		p.core.Logger.Error("failed to get role groupings", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to get role groupings"))
+	}
+	resp := make([]*admin.RoleGroupingResponse, 0, len(groupings))
+	for _, grouping := range groupings {
+		resp = append(resp, &admin.RoleGroupingResponse{UserID: grouping[0], Role: grouping[1]})
+	}
+	return &admin.GetRoleGroupingListResponse{RoleGroupingList: resp}, nil
+}
+
+// InsertRoleGrouping grants a fine-grained or custom role to the user, on top of its role. Custom roles must have
+// policies, so that a user can not be made to inherit the roles of another user by granting its ID as a role.
+func (p PolicyServiceImpl) InsertRoleGrouping(ctx context.Context, userID *primitive.ObjectID, role *string) error {
+	if err := checkGrantableRole(*role); err != nil {
+		return err
+	}
+	if err := p.checkKnownRole(*role); err != nil {
+		return err
+	}
+	if _, err := p.userDao.GetUserByID(ctx, *userID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	ok, err := p.enforcer.AddRoleForUser(userID.Hex(), *role)
+	if err != nil {
+		p.core.Logger.Error("failed to create role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to create role for user"))
+	}
+	if !ok {
+		return errors.DuplicateKeyError(fmt.Errorf("user (id: %s) already has role %s", userID.Hex(), *role))
+	}
+	return nil
+}
+
+// DeleteRoleGrouping revokes a fine-grained or custom role from the user.
+func (p PolicyServiceImpl) DeleteRoleGrouping(ctx context.Context, userID *primitive.ObjectID, role *string) error {
+	if err := checkGrantableRole(*role); err != nil {
+		return err
+	}
+	ok, err := p.enforcer.DeleteRoleForUser(userID.Hex(), *role)
+	if err != nil {
+		p.core.Logger.Error("failed to delete role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete role for user"))
+	}
+	if !ok {
+		return errors.NotFound(fmt.Errorf("user (id: %s) does not have role %s", userID.Hex(), *role))
+	}
+	return nil
+}
+
+// GetUserPermission returns the roles of the user, including inherited ones, and the permissions they grant.
+func (p PolicyServiceImpl) GetUserPermission(
+	ctx context.Context, userID *primitive.ObjectID,
+) (*admin.GetUserPermissionResponse, error) {
+	roles, err := p.enforcer.GetImplicitRolesForUser(userID.Hex())
+	if err != nil {
+		p.core.Logger.Error("failed to get roles of user", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to get roles of user"))
+	}
+	permissions, err := p.enforcer.GetImplicitPermissionsForUser(userID.Hex())
+	if err != nil {
+		p.core.Logger.Error("failed to get permissions of user", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to get permissions of user"))
+	}
+	return &admin.GetUserPermissionResponse{
+		UserID:         userID.Hex(),
+		RoleList:       roles,
+		PermissionList: policyResponseList(permissions),
+	}, nil
+}
+
+// CheckPermission dry-runs the access check of the user acting on the object, returning the policy allowing it.
+func (p PolicyServiceImpl) CheckPermission(
+	ctx context.Context, userID *primitive.ObjectID, object, action *string,
+) (*admin.CheckPermissionResponse, error) {
+	allowed, explain, err := p.enforcer.EnforceEx(userID.Hex(), *object, *action)
+	if err != nil {
+		p.core.Logger.Error("failed to check permission", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to check permission"))
+	}
+	return &admin.CheckPermissionResponse{Allowed: allowed, MatchedPolicy: explain}, nil
+}
+
+// checkGrantableRole rejects the roles managed by the user and organization APIs.
+func checkGrantableRole(role string) error {
+	switch role {
+	case config.UserRoleUser, config.UserRoleAdmin, config.UserRoleOrgAdmin:
+		return errors.InvalidRequest(fmt.Errorf("role %s is managed through the user and organization APIs", role))
+	default:
+		return nil
+	}
+}
+
+// checkKnownRole rejects the roles which are neither fine-grained roles nor custom roles with policies.
+func (p PolicyServiceImpl) checkKnownRole(role string) error {
+	switch role {
+	case config.UserRoleReviewer, config.UserRoleExporter, config.UserRoleViewer:
+		return nil
+	}
+	policies, err := p.enforcer.GetFilteredPolicy(0, role)
+	if err != nil {
+		p.core.Logger.Error("failed to get policies of role", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to get policies of role"))
+	}
+	if len(policies) == 0 {
+		return errors.InvalidRequest(fmt.Errorf("role %s has no policies", role))
+	}
+	return nil
+}
+
+func policyResponseList(policies [][]string) []*admin.PolicyResponse {
+	resp := make([]*admin.PolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		resp = append(
+			resp, &admin.PolicyResponse{
+				Role:    policy[0],
+				Object:  policy[1],
+				Action:  policy[2],
+				BuiltIn: service.IsDefaultPolicy(policy[0], policy[1], policy[2]),
+			},
+		)
+	}
+	return resp
+}
@@ -0,0 +1,195 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReAuditService interface {
+	GetReAuditList(
+		ctx context.Context, page, pageSize *int64, desc *bool, reviewerID *primitive.ObjectID,
+		theme, status *string, startTime, endTime *time.Time,
+	) (*admin.GetReAuditListResponse, error)
+	SubmitReAudit(ctx context.Context, reAuditID *primitive.ObjectID, status, message *string) error
+	GetReAuditStatistic(
+		ctx context.Context, theme *string, startTime, endTime *time.Time,
+	) (*admin.GetReAuditStatisticResponse, error)
+}
+
+type ReAuditServiceImpl struct {
+	core       *service.Core
+	reAuditDao dao.ReAuditDao
+}
+
+func NewReAuditService(core *service.Core, reAuditDao dao.ReAuditDao) ReAuditService {
+	return &ReAuditServiceImpl{
+		core:       core,
+		reAuditDao: reAuditDao,
+	}
+}
+
+// GetReAuditList returns the re-audit queue of the current admin, records approved by the admin themselves are left
+// out so that every record is re-audited by a different admin.
+func (r ReAuditServiceImpl) GetReAuditList(
+	ctx context.Context, page, pageSize *int64, desc *bool, reviewerID *primitive.ObjectID,
+	theme, status *string, startTime, endTime *time.Time,
+) (*admin.GetReAuditListResponse, error) {
+	auditorID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	offset := (*page - 1) * *pageSize
+	reAuditList, count, err := r.reAuditDao.GetReAuditList(
+		ctx, offset, *pageSize, *desc, reviewerID, &auditorID, theme, status, startTime, endTime,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get re-audit list"))
+	}
+
+	resp := make([]*admin.GetReAuditResponse, 0, len(reAuditList))
+	for _, reAudit := range reAuditList {
+		resp = append(
+			resp, &admin.GetReAuditResponse{
+				ReAuditID:         reAudit.ReAuditID.Hex(),
+				InstructionDataID: reAudit.InstructionDataID.Hex(),
+				ReviewerID:        reAudit.ReviewerID.Hex(),
+				ReviewerName:      reAudit.ReviewerName,
+				Theme:             reAudit.Theme,
+				AuditorID:         reAuditAuditorID(&reAudit),
+				AuditorName:       reAudit.AuditorName,
+				Status:            reAudit.Status,
+				Message:           reAudit.Message,
+				CreatedAt:         reAudit.CreatedAt.Format(time.RFC3339),
+				UpdatedAt:         reAudit.UpdatedAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &admin.GetReAuditListResponse{
+		Total:       *count,
+		ReAuditList: resp,
+	}, nil
+}
+
+// SubmitReAudit records whether the current admin confirms or overturns the original approval. Admins cannot
+// re-audit their own approvals, and a re-audit can only be submitted once.
+func (r ReAuditServiceImpl) SubmitReAudit(
+	ctx context.Context, reAuditID *primitive.ObjectID, status, message *string,
+) error {
+	auditorID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return err
+	}
+	reAudit, err := r.reAuditDao.GetReAuditByID(ctx, *reAuditID)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("re-audit (id: %s) not found", reAuditID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get re-audit (id: %s)", reAuditID.Hex()))
+	}
+	if reAudit.ReviewerID == auditorID {
+		return errors.PermissionDeny(fmt.Errorf("cannot re-audit your own approval (id: %s)", reAuditID.Hex()))
+	}
+	if reAudit.Status != config.ReAuditStatusPending {
+		return errors.InvalidRequest(fmt.Errorf("re-audit (id: %s) has already been submitted", reAuditID.Hex()))
+	}
+	var m string
+	if message != nil {
+		m = *message
+	}
+	if err = r.reAuditDao.UpdateReAudit(ctx, *reAuditID, auditorID, *status, m); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.InvalidRequest(fmt.Errorf("re-audit (id: %s) has already been submitted", reAuditID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to submit re-audit (id: %s)", reAuditID.Hex()))
+	}
+	return nil
+}
+
+// GetReAuditStatistic estimates the error rate of the reviewers from the records sampled in the given time range, as
+// the share of the re-audited records whose approval was overturned.
+func (r ReAuditServiceImpl) GetReAuditStatistic(
+	ctx context.Context, theme *string, startTime, endTime *time.Time,
+) (*admin.GetReAuditStatisticResponse, error) {
+	reAuditList, _, err := r.reAuditDao.GetReAuditList(
+		ctx, 0, 0, false, nil, nil, theme, nil, startTime, endTime,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get re-audit list"))
+	}
+
+	var (
+		total          admin.ReAuditStatistic
+		reviewerStats  = map[string]*admin.ReviewerReAuditStatistic{}
+		themeStats     = map[string]*admin.ThemeReAuditStatistic{}
+		reviewerIDList []string
+		themeList      []string
+	)
+	for _, reAudit := range reAuditList {
+		reviewerID := reAudit.ReviewerID.Hex()
+		if _, ok := reviewerStats[reviewerID]; !ok {
+			reviewerStats[reviewerID] = &admin.ReviewerReAuditStatistic{
+				ReviewerID:   reviewerID,
+				ReviewerName: reAudit.ReviewerName,
+			}
+			reviewerIDList = append(reviewerIDList, reviewerID)
+		}
+		if _, ok := themeStats[reAudit.Theme]; !ok {
+			themeStats[reAudit.Theme] = &admin.ThemeReAuditStatistic{Theme: reAudit.Theme}
+			themeList = append(themeList, reAudit.Theme)
+		}
+		countReAudit(&total, reAudit.Status)
+		countReAudit(&reviewerStats[reviewerID].Data, reAudit.Status)
+		countReAudit(&themeStats[reAudit.Theme].Data, reAudit.Status)
+	}
+	sort.Strings(reviewerIDList)
+	sort.Strings(themeList)
+
+	reviewers := make([]*admin.ReviewerReAuditStatistic, 0, len(reviewerIDList))
+	for _, reviewerID := range reviewerIDList {
+		reviewers = append(reviewers, reviewerStats[reviewerID])
+	}
+	themes := make([]*admin.ThemeReAuditStatistic, 0, len(themeList))
+	for _, t := range themeList {
+		themes = append(themes, themeStats[t])
+	}
+	return &admin.GetReAuditStatisticResponse{
+		Data:      total,
+		Reviewers: reviewers,
+		Themes:    themes,
+	}, nil
+}
+
+// countReAudit adds a sampled record with the given status to the statistic and updates the estimated error rate.
+func countReAudit(statistic *admin.ReAuditStatistic, status string) {
+	statistic.SampledCount++
+	switch status {
+	case config.ReAuditStatusConfirmed:
+		statistic.AuditedCount++
+	case config.ReAuditStatusOverturned:
+		statistic.AuditedCount++
+		statistic.OverturnedCount++
+	}
+	if statistic.AuditedCount > 0 {
+		statistic.ErrorRate = float64(statistic.OverturnedCount) / float64(statistic.AuditedCount)
+	}
+}
+
+// reAuditAuditorID returns the hex ID of the auditor, empty while the re-audit is pending.
+func reAuditAuditorID(reAudit *entity.ReAuditModel) string {
+	if reAudit.AuditorID.IsZero() {
+		return ""
+	}
+	return reAudit.AuditorID.Hex()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	e "errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
@@ -11,6 +12,7 @@ import (
 	"data-collection-hub-server/internal/pkg/domain/vo/admin"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/agreement"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -22,21 +24,26 @@ type StatisticService interface {
 		ctx context.Context, page, pageSize *int64,
 		loginStartTime, loginEndTime, createdBefore, createdAfter *time.Time,
 	) (*admin.GetUserStatisticListResponse, error)
+	GetReviewAgreement(
+		ctx context.Context, theme *string, startTime, endTime *time.Time,
+	) (*admin.GetReviewAgreementResponse, error)
 }
 
 type StatisticServiceImpl struct {
 	core               *service.Core
 	instructionDataDao dao.InstructionDataDao
 	userDao            dao.UserDao
+	reviewDao          dao.ReviewDao
 }
 
 func NewStatisticService(
-	core *service.Core, instructionDataDao dao.InstructionDataDao, userDao dao.UserDao,
+	core *service.Core, instructionDataDao dao.InstructionDataDao, userDao dao.UserDao, reviewDao dao.ReviewDao,
 ) StatisticService {
 	return &StatisticServiceImpl{
 		core:               core,
 		instructionDataDao: instructionDataDao,
 		userDao:            userDao,
+		reviewDao:          reviewDao,
 	}
 }
 
@@ -52,21 +59,21 @@ func (s StatisticServiceImpl) GetDataStatistic(
 
 	total, err := s.instructionDataDao.CountInstructionData(
 		ctx, nil, nil, nil, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to count instruction data"))
 	}
 
 	pendingCount, err := s.instructionDataDao.CountInstructionData(
-		ctx, nil, nil, &pendingStatus, nil, nil, nil, nil,
+		ctx, nil, nil, &pendingStatus, nil, nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to count instruction data with status %s", pendingStatus))
 	}
 
 	approvedCount, err := s.instructionDataDao.CountInstructionData(
-		ctx, nil, nil, &approvedStatus, nil, nil, nil, nil,
+		ctx, nil, nil, &approvedStatus, nil, nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -78,7 +85,7 @@ func (s StatisticServiceImpl) GetDataStatistic(
 
 	rejectedCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, nil, nil, &rejectedStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -111,7 +118,7 @@ func (s StatisticServiceImpl) GetDataStatistic(
 		end := startDate.AddDate(0, 0, i+1)
 		_total, err := s.instructionDataDao.CountInstructionData(
 			ctx, nil, nil, nil, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -124,7 +131,7 @@ func (s StatisticServiceImpl) GetDataStatistic(
 
 		_pendingCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, nil, nil, &pendingStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -137,7 +144,7 @@ func (s StatisticServiceImpl) GetDataStatistic(
 
 		_approvedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, nil, nil, &approvedStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -150,7 +157,7 @@ func (s StatisticServiceImpl) GetDataStatistic(
 
 		_rejectedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, nil, nil, &rejectedStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -205,9 +212,12 @@ func (s StatisticServiceImpl) GetUserStatistic(
 		}
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get user with id %s", userID.Hex()))
 	}
+	if scope := service.OrganizationScope(ctx); scope != nil && *scope != user.Organization {
+		return nil, errors.NotFound(fmt.Errorf("user with id %s not found", userID.Hex()))
+	}
 	total, err := s.instructionDataDao.CountInstructionData(
 		ctx, userID, nil, nil, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to count instruction data for user %s", userID.Hex()))
@@ -215,7 +225,7 @@ func (s StatisticServiceImpl) GetUserStatistic(
 
 	pendingCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, userID, nil, &pendingStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -227,7 +237,7 @@ func (s StatisticServiceImpl) GetUserStatistic(
 
 	approvedCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, userID, nil, &approvedStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -239,7 +249,7 @@ func (s StatisticServiceImpl) GetUserStatistic(
 
 	rejectedCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, userID, nil, &rejectedStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -268,7 +278,7 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 	rejectedStatus := config.InstructionDataStatusRejected
 	offset := (*page - 1) * *pageSize
 	users, count, err := s.userDao.GetUserList(
-		ctx, offset, *pageSize, false, nil, nil, createdBefore, createdAfter,
+		ctx, offset, *pageSize, false, service.OrganizationScope(ctx), nil, nil, createdBefore, createdAfter,
 		nil, nil, loginBefore, loginAfter, nil,
 	)
 	if err != nil {
@@ -278,7 +288,7 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 	for _, user := range users {
 		total, err := s.instructionDataDao.CountInstructionData(
 			ctx, &user.UserID, nil, nil, nil,
-			nil, nil, nil,
+			nil, nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -290,7 +300,7 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 
 		pendingCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &user.UserID, nil, &pendingStatus, nil,
-			nil, nil, nil,
+			nil, nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -302,7 +312,7 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 
 		approvedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &user.UserID, nil, &approvedStatus, nil,
-			nil, nil, nil,
+			nil, nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -314,7 +324,7 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 
 		rejectedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &user.UserID, nil, &rejectedStatus, nil,
-			nil, nil, nil,
+			nil, nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -342,3 +352,102 @@ func (s StatisticServiceImpl) GetUserStatisticList(
 		UserStatisticList: resp,
 	}, nil
 }
+
+// GetReviewAgreement measures the consistency of the reviewers on the records reviewed by more than one of them, with
+// the decisions made in the given time range, overall and per theme. Reviewers who disagree with the consensus of the
+// other reviewers more often than the configured threshold are flagged.
+func (s StatisticServiceImpl) GetReviewAgreement(
+	ctx context.Context, theme *string, startTime, endTime *time.Time,
+) (*admin.GetReviewAgreementResponse, error) {
+	reviews, _, err := s.reviewDao.GetReviewList(ctx, 0, 0, false, nil, nil, theme, nil, startTime, endTime)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get review list"))
+	}
+
+	var (
+		ratings      = agreement.Ratings{}
+		themeRatings = map[string]agreement.Ratings{}
+		names        = map[string]string{}
+	)
+	for _, review := range reviews {
+		item, reviewer := review.InstructionDataID.Hex(), review.ReviewerID.Hex()
+		names[reviewer] = review.ReviewerName
+		if ratings[item] == nil {
+			ratings[item] = map[string]string{}
+		}
+		ratings[item][reviewer] = review.Decision
+		if themeRatings[review.Theme] == nil {
+			themeRatings[review.Theme] = agreement.Ratings{}
+		}
+		if themeRatings[review.Theme][item] == nil {
+			themeRatings[review.Theme][item] = map[string]string{}
+		}
+		themeRatings[review.Theme][item][reviewer] = review.Decision
+	}
+
+	itemCount, observed, fleissKappa := agreement.Fleiss(ratings)
+	themes := make([]string, 0, len(themeRatings))
+	for t := range themeRatings {
+		themes = append(themes, t)
+	}
+	sort.Strings(themes)
+	themeAgreement := make([]*admin.ThemeAgreement, 0, len(themes))
+	for _, t := range themes {
+		_itemCount, _observed, _fleissKappa := agreement.Fleiss(themeRatings[t])
+		themeAgreement = append(
+			themeAgreement, &admin.ThemeAgreement{
+				Theme:         t,
+				ItemCount:     _itemCount,
+				Agreement:     _observed,
+				FleissKappa:   _fleissKappa,
+				ReviewerPairs: reviewerPairAgreement(themeRatings[t], names),
+			},
+		)
+	}
+
+	raters := agreement.Consensus(ratings)
+	reviewers := make([]*admin.ReviewerConsensus, 0, len(raters))
+	for _, rater := range raters {
+		reviewers = append(
+			reviewers, &admin.ReviewerConsensus{
+				ReviewerID:       rater.Rater,
+				ReviewerName:     names[rater.Rater],
+				ReviewCount:      rater.Items,
+				ComparedCount:    rater.Compared,
+				DisagreementRate: rater.Disagreement,
+				Flagged: rater.Compared >= s.core.Config.ReviewConfig.OutlierMinItems &&
+					rater.Disagreement > s.core.Config.ReviewConfig.OutlierThreshold,
+			},
+		)
+	}
+
+	return &admin.GetReviewAgreementResponse{
+		ItemCount:      itemCount,
+		Agreement:      observed,
+		FleissKappa:    fleissKappa,
+		ThemeAgreement: themeAgreement,
+		ReviewerPairs:  reviewerPairAgreement(ratings, names),
+		Reviewers:      reviewers,
+	}, nil
+}
+
+// reviewerPairAgreement returns the agreement and Cohen's kappa of every pair of reviewers who reviewed the same
+// records, named after names.
+func reviewerPairAgreement(ratings agreement.Ratings, names map[string]string) []*admin.ReviewerPairAgreement {
+	pairs := agreement.Pairwise(ratings)
+	reviewerPairs := make([]*admin.ReviewerPairAgreement, 0, len(pairs))
+	for _, pair := range pairs {
+		reviewerPairs = append(
+			reviewerPairs, &admin.ReviewerPairAgreement{
+				ReviewerAID:   pair.RaterA,
+				ReviewerAName: names[pair.RaterA],
+				ReviewerBID:   pair.RaterB,
+				ReviewerBName: names[pair.RaterB],
+				ItemCount:     pair.Items,
+				Agreement:     pair.Agreement,
+				CohenKappa:    pair.Kappa,
+			},
+		)
+	}
+	return reviewerPairs
+}
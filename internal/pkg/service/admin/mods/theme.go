@@ -0,0 +1,89 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ThemeService interface {
+	InsertTheme(ctx context.Context, name, description, schema *string) (string, error)
+	UpdateTheme(ctx context.Context, themeID *primitive.ObjectID, name, description, schema *string) error
+	DeleteTheme(ctx context.Context, themeID *primitive.ObjectID) error
+}
+
+type ThemeServiceImpl struct {
+	core     *service.Core
+	themeDao dao.ThemeDao
+}
+
+func NewThemeService(core *service.Core, themeDao dao.ThemeDao) ThemeService {
+	return &ThemeServiceImpl{
+		core:     core,
+		themeDao: themeDao,
+	}
+}
+
+// InsertTheme inserts a new theme with the JSON Schema of its metadata fields. Returns the theme ID if successful.
+func (t ThemeServiceImpl) InsertTheme(ctx context.Context, name, description, metadataSchema *string) (string, error) {
+	if _, err := schema.Compile(*metadataSchema); err != nil {
+		return "", errors.InvalidRequest(fmt.Errorf("invalid metadata schema: %s", err))
+	}
+	var d string
+	if description != nil {
+		d = *description
+	}
+	themeID, err := t.themeDao.InsertTheme(ctx, *name, d, *metadataSchema)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", errors.DuplicateKeyError(fmt.Errorf("theme with name %s already exists", *name))
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to insert theme"))
+	}
+	return themeID.Hex(), nil
+}
+
+// UpdateTheme updates the theme. Records already stored keep their metadata, the new schema only applies to
+// submissions and updates made afterwards. Records refer to their theme by name, so a theme cannot be renamed.
+func (t ThemeServiceImpl) UpdateTheme(
+	ctx context.Context, themeID *primitive.ObjectID, name, description, metadataSchema *string,
+) error {
+	if metadataSchema != nil {
+		if _, err := schema.Compile(*metadataSchema); err != nil {
+			return errors.InvalidRequest(fmt.Errorf("invalid metadata schema: %s", err))
+		}
+	}
+	theme, err := t.themeDao.GetThemeByID(ctx, *themeID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("theme (id: %s) not found", themeID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get theme (id: %s)", themeID.Hex()))
+	}
+	if name != nil && *name != theme.Name {
+		return errors.InvalidRequest(
+			fmt.Errorf("theme %s cannot be renamed, its records refer to it by name", theme.Name),
+		)
+	}
+	if err = t.themeDao.UpdateTheme(ctx, *themeID, nil, description, metadataSchema); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to update theme (id: %s)", themeID.Hex()))
+	}
+	return nil
+}
+
+func (t ThemeServiceImpl) DeleteTheme(ctx context.Context, themeID *primitive.ObjectID) error {
+	if err := t.themeDao.DeleteTheme(ctx, *themeID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("theme (id: %s) not found", themeID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to delete theme (id: %s)", themeID.Hex()))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+)
+
+type TwoFactorPolicyService interface {
+	GetTwoFactorPolicyList(ctx context.Context) (*admin.GetTwoFactorPolicyListResponse, error)
+	UpdateTwoFactorPolicy(ctx context.Context, role *string, enforced *bool) error
+}
+
+type TwoFactorPolicyServiceImpl struct {
+	core               *service.Core
+	twoFactorPolicyDao dao.TwoFactorPolicyDao
+}
+
+func NewTwoFactorPolicyService(core *service.Core, twoFactorPolicyDao dao.TwoFactorPolicyDao) TwoFactorPolicyService {
+	return &TwoFactorPolicyServiceImpl{
+		core:               core,
+		twoFactorPolicyDao: twoFactorPolicyDao,
+	}
+}
+
+// GetTwoFactorPolicyList returns the 2FA policy of every role, roles without a policy are not enforced.
+func (t TwoFactorPolicyServiceImpl) GetTwoFactorPolicyList(
+	ctx context.Context,
+) (*admin.GetTwoFactorPolicyListResponse, error) {
+	policyList, err := t.twoFactorPolicyDao.GetTwoFactorPolicyList(ctx)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get two-factor policy list"))
+	}
+	resp := make([]*admin.TwoFactorPolicyResponse, 0, 2)
+	for _, role := range []string{config.UserRoleAdmin, config.UserRoleUser} {
+		policyResp := &admin.TwoFactorPolicyResponse{Role: role}
+		for _, policy := range policyList {
+			if policy.Role == role {
+				policyResp.Enforced = policy.Enforced
+				policyResp.UpdatedBy = policy.UpdatedBy.Hex()
+				policyResp.UpdatedAt = policy.UpdatedAt.Format(time.RFC3339)
+			}
+		}
+		resp = append(resp, policyResp)
+	}
+	return &admin.GetTwoFactorPolicyListResponse{PolicyList: resp}, nil
+}
+
+// UpdateTwoFactorPolicy sets whether users of the role must use 2FA. Users of an enforced role who have not enrolled
+// are asked to enroll on their next login.
+func (t TwoFactorPolicyServiceImpl) UpdateTwoFactorPolicy(ctx context.Context, role *string, enforced *bool) error {
+	updatedBy, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if err = t.twoFactorPolicyDao.UpsertTwoFactorPolicy(ctx, *role, *enforced, updatedBy); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to update two-factor policy of role %s", *role))
+	}
+	return nil
+}
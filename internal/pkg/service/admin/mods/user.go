@@ -8,9 +8,12 @@ import (
 
 	"data-collection-hub-server/internal/pkg/config"
 	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/internal/pkg/domain/vo/admin"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/storage"
 	"data-collection-hub-server/pkg/utils/crypt"
 	"github.com/casbin/casbin/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,27 +25,63 @@ type UserService interface {
 	InsertUser(ctx context.Context, username, email, password, organization *string) (string, error)
 	GetUser(ctx context.Context, userID *primitive.ObjectID) (*admin.GetUserResponse, error)
 	GetUserList(
-		ctx context.Context, page, pageSize *int64, desc *bool, role *string,
+		ctx context.Context, page, pageSize *int64, desc *bool, role *string, suspended *bool,
 		lastLoginBefore, lastLoginAfter, createdBefore, createdAfter *time.Time, query *string,
 	) (*admin.GetUserListResponse, error)
-	UpdateUser(ctx context.Context, userID *primitive.ObjectID, username, email, organization *string) error
+	UpdateUser(ctx context.Context, userID *primitive.ObjectID, username, email, role, organization *string) error
 	DeleteUser(ctx context.Context, userID *primitive.ObjectID) error
 	ChangeUserPassword(ctx context.Context, userID *primitive.ObjectID, newPassword *string) error
+	DeleteUserSessionList(ctx context.Context, userID *primitive.ObjectID) (int64, error)
+	SuspendUser(ctx context.Context, userID *primitive.ObjectID, reason *string, expiresAt *time.Time) error
+	ReactivateUser(ctx context.Context, userID *primitive.ObjectID) error
+	ImpersonateUser(
+		ctx context.Context, userID *primitive.ObjectID, readOnly bool,
+	) (*admin.ImpersonateUserResponse, error)
+	ImportUser(ctx context.Context, row *admin.ImportUserRow, validateOnly bool) (*admin.ImportUserResult, error)
+	ExportUserData(ctx context.Context, userID *primitive.ObjectID) ([]byte, error)
+	EraseUser(ctx context.Context, userID *primitive.ObjectID) (*admin.EraseUserResponse, error)
+	GetDeletedUserList(
+		ctx context.Context, page, pageSize *int64, desc *bool,
+	) (*admin.GetDeletedUserListResponse, error)
+	RestoreUser(ctx context.Context, userID *primitive.ObjectID) (*admin.RestoreUserResponse, error)
+	PurgeUserList(ctx context.Context) (*admin.PurgeUserListResponse, error)
 }
 
 // UserServiceImpl implements the UserService.
 type UserServiceImpl struct {
-	core     *service.Core
-	userDao  dao.UserDao
-	enforcer *casbin.Enforcer
+	core               *service.Core
+	userDao            dao.UserDao
+	sessionDao         dao.SessionDao
+	instructionDataDao dao.InstructionDataDao
+	reviewDao          dao.ReviewDao
+	reAuditDao         dao.ReAuditDao
+	loginLogDao        dao.LoginLogDao
+	operationLogDao    dao.OperationLogDao
+	accessTokenDao     dao.AccessTokenDao
+	enforcer           *casbin.Enforcer
+	jwt                *jwt.Jwt
+	storage            storage.Storage
 }
 
 // NewUserService is a wire provider function that returns a UserServiceImpl.
-func NewUserService(core *service.Core, userDao dao.UserDao, enforcer *casbin.Enforcer) UserService {
+func NewUserService(
+	core *service.Core, userDao dao.UserDao, sessionDao dao.SessionDao, instructionDataDao dao.InstructionDataDao,
+	reviewDao dao.ReviewDao, reAuditDao dao.ReAuditDao, loginLogDao dao.LoginLogDao, operationLogDao dao.OperationLogDao,
+	accessTokenDao dao.AccessTokenDao, enforcer *casbin.Enforcer, jwt *jwt.Jwt, storage storage.Storage,
+) UserService {
 	return &UserServiceImpl{
-		core:     core,
-		userDao:  userDao,
-		enforcer: enforcer,
+		core:               core,
+		userDao:            userDao,
+		sessionDao:         sessionDao,
+		instructionDataDao: instructionDataDao,
+		reviewDao:          reviewDao,
+		reAuditDao:         reAuditDao,
+		loginLogDao:        loginLogDao,
+		operationLogDao:    operationLogDao,
+		accessTokenDao:     accessTokenDao,
+		enforcer:           enforcer,
+		jwt:                jwt,
+		storage:            storage,
 	}
 }
 
@@ -51,6 +90,9 @@ func NewUserService(core *service.Core, userDao dao.UserDao, enforcer *casbin.En
 func (u UserServiceImpl) InsertUser(
 	ctx context.Context, username, email, password, organization *string,
 ) (string, error) {
+	if err := service.ValidatePassword(u.core.Config, "password", *password, *username, *email); err != nil {
+		return "", err
+	}
 	passwordHash, err := crypt.Hash(*password)
 	if err != nil {
 		u.core.Logger.Error("failed to hash password", zap.Error(err))
@@ -89,6 +131,7 @@ func (u UserServiceImpl) GetUser(ctx context.Context, userID *primitive.ObjectID
 		Email:        user.Email,
 		Role:         user.Role,
 		Organization: user.Organization,
+		Suspension:   suspensionResponse(user),
 		LastLogin:    user.LastLogin.Format(time.RFC3339),
 		CreatedAt:    user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
@@ -98,12 +141,12 @@ func (u UserServiceImpl) GetUser(ctx context.Context, userID *primitive.ObjectID
 // GetUserList retrieves a list of users based on the query parameters.
 // Returns the list of users if successful.
 func (u UserServiceImpl) GetUserList(
-	ctx context.Context, page, pageSize *int64, desc *bool, role *string,
+	ctx context.Context, page, pageSize *int64, desc *bool, role *string, suspended *bool,
 	lastLoginBefore, lastLoginAfter, createdBefore, createdAfter *time.Time, query *string,
 ) (*admin.GetUserListResponse, error) {
 	offset := (*page - 1) * *pageSize
 	users, count, err := u.userDao.GetUserList(
-		ctx, offset, *pageSize, *desc, nil, role, createdBefore, createdAfter,
+		ctx, offset, *pageSize, *desc, nil, role, suspended, createdBefore, createdAfter,
 		nil, nil, lastLoginBefore, lastLoginAfter, query,
 	)
 	if err != nil {
@@ -118,6 +161,7 @@ func (u UserServiceImpl) GetUserList(
 				Email:        user.Email,
 				Role:         user.Role,
 				Organization: user.Organization,
+				Suspension:   suspensionResponse(&user),
 				LastLogin:    user.LastLogin.Format(time.RFC3339),
 				CreatedAt:    user.CreatedAt.Format(time.RFC3339),
 				UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
@@ -130,28 +174,94 @@ func (u UserServiceImpl) GetUserList(
 	}, nil
 }
 
-// UpdateUser updates a user's information.
+// UpdateUser updates a user's information. A role change is applied to casbin as well, and a user losing the admin
+// role has all of its tokens revoked. A user leaving its organization loses its organization admin role.
 // Returns nil if successful.
 func (u UserServiceImpl) UpdateUser(
-	ctx context.Context, userID *primitive.ObjectID, username, email, organization *string,
+	ctx context.Context, userID *primitive.ObjectID, username, email, role, organization *string,
 ) error {
-	err := u.userDao.UpdateUser(ctx, *userID, username, email, nil, nil, organization)
+	var (
+		oldRole           string
+		downgrade         bool
+		leaveOrganization bool
+	)
+	if role != nil || organization != nil {
+		user, err := u.userDao.GetUserByID(ctx, *userID)
+		if err != nil {
+			if e.Is(err, mongo.ErrNoDocuments) {
+				return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+			}
+			return errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+		}
+		if role != nil && user.Role == *role {
+			role = nil
+		} else if role != nil {
+			oldRole = user.Role
+			downgrade = user.Role == config.UserRoleAdmin
+		}
+		leaveOrganization = organization != nil && user.Organization != *organization
+	}
+	err := u.userDao.UpdateUser(ctx, *userID, username, email, nil, role, organization)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return errors.DuplicateKeyError(fmt.Errorf("user with email %s already exists", *email))
+			return errors.DuplicateKeyError(fmt.Errorf("user with the username or email already exists"))
 		} else if e.Is(err, mongo.ErrNoDocuments) {
 			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
 		} else {
 			return errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", userID.Hex()))
 		}
 	}
+	if leaveOrganization {
+		if err = RemoveOrganizationAdmin(u.enforcer, userID.Hex()); err != nil {
+			u.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+			return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+		}
+	}
+	if role == nil {
+		return nil
+	}
+	if _, err = u.enforcer.DeleteRoleForUser(userID.Hex(), oldRole); err != nil {
+		u.core.Logger.Error("failed to delete role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to update role for user"))
+	}
+	if _, err = u.enforcer.AddRoleForUser(userID.Hex(), *role); err != nil {
+		u.core.Logger.Error("failed to create role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to update role for user"))
+	}
+	if downgrade {
+		if err = u.sessionDao.RevokeUserTokens(ctx, *userID); err != nil {
+			return errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()))
+		}
+	}
 	return nil
 }
 
-// DeleteUser deletes a user by user ID.
+// DeleteUser soft deletes a user by user ID, along with its instruction data, and revokes all of its tokens. Its
+// casbin roles are removed and recorded with it, so that RestoreUser brings the user back as it was until the user is
+// purged.
 // Returns nil if successful.
 func (u UserServiceImpl) DeleteUser(ctx context.Context, userID *primitive.ObjectID) error {
-	err := u.userDao.DeleteUser(ctx, *userID)
+	var deletion entity.DeletionModel
+	if adminID, ok := ctx.Value(config.UserIDKey).(string); ok {
+		deletion.DeletedBy, _ = primitive.ObjectIDFromHex(adminID)
+	}
+	roles, err := u.enforcer.GetRolesForUser(userID.Hex())
+	if err != nil {
+		u.core.Logger.Error("failed to get roles for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to get roles for user"))
+	}
+	deletion.Roles = roles
+	groupings, err := u.enforcer.GetFilteredNamedGroupingPolicy("g2", 0, userID.Hex(), config.UserRoleOrgAdmin)
+	if err != nil {
+		u.core.Logger.Error("failed to get organization admin roles for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to get organization admin roles for user"))
+	}
+	for _, grouping := range groupings {
+		deletion.Organizations = append(deletion.Organizations, grouping[2])
+	}
+	// mongodb keeps milliseconds, the deletion time must match exactly to restore the instruction data
+	deletedAt := time.Now().Truncate(time.Millisecond)
+	err = u.userDao.SoftDeleteUser(ctx, *userID, deletedAt, deletion)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
 			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
@@ -159,20 +269,47 @@ func (u UserServiceImpl) DeleteUser(ctx context.Context, userID *primitive.Objec
 			return errors.OperationFailed(fmt.Errorf("failed to delete user (id: %s)", userID.Hex()))
 		}
 	}
+	if _, err = u.instructionDataDao.SoftDeleteUserInstructionDataList(ctx, *userID, deletedAt); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to delete instruction data of user (id: %s)", userID.Hex()))
+	}
+	if err = RemoveOrganizationAdmin(u.enforcer, userID.Hex()); err != nil {
+		u.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+	}
+	if _, err = u.enforcer.DeleteRolesForUser(userID.Hex()); err != nil {
+		u.core.Logger.Error("failed to delete roles for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to delete roles for user"))
+	}
+	if err = u.sessionDao.RevokeUserTokens(ctx, *userID); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()))
+	}
 	return nil
 }
 
-// ChangeUserPassword changes a user's password.
+// ChangeUserPassword changes a user's password, following the password policy, and revokes all of its tokens.
 // Returns nil if successful.
 func (u UserServiceImpl) ChangeUserPassword(
 	ctx context.Context, userID *primitive.ObjectID, newPassword *string,
 ) error {
+	user, err := u.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	passwordHistory, err := service.ValidatePasswordChange(
+		ctx, u.core.Config, u.userDao, "new_password", *newPassword, user,
+	)
+	if err != nil {
+		return err
+	}
 	newPasswordHash, err := crypt.Hash(*newPassword)
 	if err != nil {
 		u.core.Logger.Error("failed to hash password", zap.Error(err))
 		return errors.ServiceError(fmt.Errorf("failed to hash password"))
 	}
-	err = u.userDao.UpdateUser(ctx, *userID, nil, nil, &newPasswordHash, nil, nil)
+	err = u.userDao.UpdateUserPassword(ctx, *userID, newPasswordHash, passwordHistory)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
 			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
@@ -180,5 +317,458 @@ func (u UserServiceImpl) ChangeUserPassword(
 			return errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", userID.Hex()))
 		}
 	}
+	if err = u.sessionDao.RevokeUserTokens(ctx, *userID); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()))
+	}
 	return nil
 }
+
+// DeleteUserSessionList logs a user out of all devices by revoking all of its sessions.
+// Returns the number of revoked sessions.
+func (u UserServiceImpl) DeleteUserSessionList(ctx context.Context, userID *primitive.ObjectID) (int64, error) {
+	if _, err := u.userDao.GetUserByID(ctx, *userID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return 0, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return 0, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	count, err := u.sessionDao.DeleteSessionList(ctx, *userID, nil)
+	if err != nil {
+		return 0, errors.OperationFailed(fmt.Errorf("failed to delete sessions of user (id: %s)", userID.Hex()))
+	}
+	return count, nil
+}
+
+// SuspendUser suspends a user, until expiresAt if given, and revokes all of its tokens. The data of the user is kept,
+// and counts in the statistics. Admins cannot suspend themselves.
+// Returns nil if successful.
+func (u UserServiceImpl) SuspendUser(
+	ctx context.Context, userID *primitive.ObjectID, reason *string, expiresAt *time.Time,
+) error {
+	adminID, err := primitive.ObjectIDFromHex(ctx.Value(config.UserIDKey).(string))
+	if err != nil {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	if adminID == *userID {
+		return errors.InvalidRequest(fmt.Errorf("cannot suspend yourself"))
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errors.InvalidRequest(fmt.Errorf("expiry time should be in the future"))
+	}
+	if _, err = u.userDao.GetUserByID(ctx, *userID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	if err = u.userDao.SuspendUser(ctx, *userID, *reason, expiresAt, adminID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to suspend user (id: %s)", userID.Hex()))
+	}
+	if err = u.sessionDao.RevokeUserTokens(ctx, *userID); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()))
+	}
+	return nil
+}
+
+// ReactivateUser lifts the suspension of a user.
+// Returns nil if successful.
+func (u UserServiceImpl) ReactivateUser(ctx context.Context, userID *primitive.ObjectID) error {
+	user, err := u.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	if !service.IsSuspended(user, time.Now()) {
+		return errors.InvalidRequest(fmt.Errorf("user (id: %s) is not suspended", userID.Hex()))
+	}
+	if err = u.userDao.ReactivateUser(ctx, *userID); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to reactivate user (id: %s)", userID.Hex()))
+	}
+	return nil
+}
+
+// ImpersonateUser issues to the admin of the context a short-lived access token of the user, carrying the admin as
+// its actor. Admins cannot be impersonated.
+// Returns the token, read-only if readOnly.
+func (u UserServiceImpl) ImpersonateUser(
+	ctx context.Context, userID *primitive.ObjectID, readOnly bool,
+) (*admin.ImpersonateUserResponse, error) {
+	adminID, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return nil, errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	if adminID == userID.Hex() {
+		return nil, errors.InvalidRequest(fmt.Errorf("cannot impersonate yourself"))
+	}
+	user, err := u.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	if user.Role == config.UserRoleAdmin {
+		return nil, errors.PermissionDeny(fmt.Errorf("admin (id: %s) cannot be impersonated", userID.Hex()))
+	}
+	duration := u.core.Config.ImpersonationConfig.TokenDuration
+	accessToken, err := u.jwt.GenerateImpersonationToken(userID.Hex(), adminID, readOnly, duration)
+	if err != nil {
+		u.core.Logger.Error("failed to generate impersonation token", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate impersonation token"))
+	}
+	return &admin.ImpersonateUserResponse{
+		AccessToken: accessToken,
+		ReadOnly:    readOnly,
+		ExpiresAt:   time.Now().Add(duration).Format(time.RFC3339),
+	}, nil
+}
+
+// ImportUser creates the user of a row of an import, with a random initial password, or updates the username, role
+// and organization of the user with the email of the row. With validateOnly, the row is only checked.
+// Returns the result of the row, with the initial password of a created user.
+func (u UserServiceImpl) ImportUser(
+	ctx context.Context, row *admin.ImportUserRow, validateOnly bool,
+) (*admin.ImportUserResult, error) {
+	result := &admin.ImportUserResult{
+		Username:     *row.Username,
+		Email:        *row.Email,
+		Role:         *row.Role,
+		Organization: *row.Organization,
+	}
+	user, err := u.userDao.GetUserByEmail(ctx, *row.Email)
+	if err != nil && !e.Is(err, mongo.ErrNoDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user with email %s", *row.Email))
+	}
+	// The username must not be taken by another user
+	other, err := u.userDao.GetUserByUsername(ctx, *row.Username)
+	if err != nil && !e.Is(err, mongo.ErrNoDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user with username %s", *row.Username))
+	}
+	if other != nil && (user == nil || other.UserID != user.UserID) {
+		return nil, errors.DuplicateKeyError(fmt.Errorf("user with username %s already exists", *row.Username))
+	}
+
+	if user != nil {
+		result.Action = config.OperationTypeUpdate
+		if validateOnly {
+			return result, nil
+		}
+		if err = u.UpdateUser(ctx, &user.UserID, row.Username, nil, row.Role, row.Organization); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	result.Action = config.OperationTypeCreate
+	if validateOnly {
+		return result, nil
+	}
+	password, err := service.GeneratePassword(u.core.Config, *row.Username, *row.Email)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := crypt.Hash(password)
+	if err != nil {
+		u.core.Logger.Error("failed to hash password", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to hash password"))
+	}
+	userID, err := u.userDao.InsertUser(ctx, *row.Username, *row.Email, passwordHash, *row.Role, *row.Organization)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.DuplicateKeyError(fmt.Errorf("user with email %s already exists", *row.Email))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to insert user"))
+	}
+	if _, err = u.enforcer.AddRoleForUser(userID.Hex(), *row.Role); err != nil {
+		u.core.Logger.Error("failed to create role for user", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to create role for user"))
+	}
+	result.Password = password
+	return result, nil
+}
+
+// ExportUserData returns a zip archive of the personal data held about a user, to answer its data access request.
+func (u UserServiceImpl) ExportUserData(ctx context.Context, userID *primitive.ObjectID) ([]byte, error) {
+	user, err := u.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	return service.ExportPersonalData(
+		ctx, user, u.storage, u.instructionDataDao, u.reviewDao, u.reAuditDao, u.loginLogDao, u.operationLogDao,
+	)
+}
+
+// EraseUser erases a user and its personal data. The approved instruction data of the user is kept, attributed to a
+// random pseudonymous ID and username which nothing links back to the user, and its other instruction data is
+// deleted. The copies of its username and email in the login and operation logs are replaced with the pseudonym.
+// Admins cannot erase themselves.
+// Returns the number of records kept, deleted and anonymised.
+func (u UserServiceImpl) EraseUser(ctx context.Context, userID *primitive.ObjectID) (*admin.EraseUserResponse, error) {
+	if adminID, ok := ctx.Value(config.UserIDKey).(string); !ok || adminID == userID.Hex() {
+		return nil, errors.InvalidRequest(fmt.Errorf("cannot erase yourself"))
+	}
+	user, err := u.userDao.GetUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	// The logs still in the cache would be written with the username and email after the erasure
+	u.loginLogDao.SyncLoginLog(ctx)
+	u.operationLogDao.SyncOperationLog(ctx)
+	return u.purgeUser(ctx, user, config.UserPurgePolicyAnonymise, nil)
+}
+
+// GetDeletedUserList retrieves the soft deleted users, which can be restored until they are purged.
+// Returns the list of deleted users if successful.
+func (u UserServiceImpl) GetDeletedUserList(
+	ctx context.Context, page, pageSize *int64, desc *bool,
+) (*admin.GetDeletedUserListResponse, error) {
+	offset := (*page - 1) * *pageSize
+	users, count, err := u.userDao.GetDeletedUserList(ctx, offset, *pageSize, *desc, nil)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get deleted user list"))
+	}
+	resp := make([]*admin.GetDeletedUserResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(
+			resp, &admin.GetDeletedUserResponse{
+				UserID:       user.UserID.Hex(),
+				Username:     user.Username,
+				Email:        user.Email,
+				Role:         user.Role,
+				Organization: user.Organization,
+				DeletedBy:    user.Deletion.DeletedBy.Hex(),
+				DeletedAt:    user.DeletedAt.Format(time.RFC3339),
+				PurgeAt:      user.DeletedAt.Add(u.core.Config.UserRetentionConfig.Period).Format(time.RFC3339),
+			},
+		)
+	}
+	return &admin.GetDeletedUserListResponse{
+		Total:    *count,
+		UserList: resp,
+	}, nil
+}
+
+// RestoreUser undoes the soft deletion of a user. Its casbin roles are granted back, and the instruction data deleted
+// along with it is visible again.
+// Returns the number of instruction data restored.
+func (u UserServiceImpl) RestoreUser(
+	ctx context.Context, userID *primitive.ObjectID,
+) (*admin.RestoreUserResponse, error) {
+	user, err := u.userDao.GetDeletedUserByID(ctx, *userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("deleted user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get deleted user (id: %s)", userID.Hex()))
+	}
+	if err = u.userDao.RestoreUser(ctx, *userID); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to restore user (id: %s)", userID.Hex()))
+	}
+	roles := user.Deletion.Roles
+	if len(roles) == 0 {
+		// the roles of the users deleted without them being recorded
+		roles = []string{user.Role}
+	}
+	for _, role := range roles {
+		if _, err = u.enforcer.AddRoleForUser(userID.Hex(), role); err != nil {
+			u.core.Logger.Error("failed to create role for user", zap.Error(err))
+			return nil, errors.ServiceError(fmt.Errorf("failed to restore roles for user"))
+		}
+	}
+	for _, organizationID := range user.Deletion.Organizations {
+		if _, err = u.enforcer.AddNamedGroupingPolicy(
+			"g2", userID.Hex(), config.UserRoleOrgAdmin, organizationID,
+		); err != nil {
+			u.core.Logger.Error("failed to create organization admin role for user", zap.Error(err))
+			return nil, errors.ServiceError(fmt.Errorf("failed to restore organization admin roles for user"))
+		}
+	}
+	restored, err := u.instructionDataDao.RestoreUserInstructionDataList(ctx, *userID, user.DeletedAt)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to restore instruction data of user"))
+	}
+	return &admin.RestoreUserResponse{InstructionDataRestored: *restored}, nil
+}
+
+// PurgeUserList hard deletes the users deleted for longer than the retention period. What is done with their
+// instruction data follows the purge policy: it is deleted, reassigned to the configured user, or anonymised as on
+// erasure. Their login and operation logs are anonymised whatever the policy. A user which fails to be purged is
+// reported with the error and left for the next purge, the others are purged regardless.
+// Returns the number of records of each purged user, by what was done with them.
+func (u UserServiceImpl) PurgeUserList(ctx context.Context) (*admin.PurgeUserListResponse, error) {
+	var (
+		retention  = u.core.Config.UserRetentionConfig
+		reassignTo *entity.UserModel
+	)
+	switch retention.PurgePolicy {
+	case config.UserPurgePolicyDelete, config.UserPurgePolicyAnonymise:
+	case config.UserPurgePolicyReassign:
+		toUserID, err := primitive.ObjectIDFromHex(retention.ReassignTo)
+		if err != nil {
+			return nil, errors.ServiceError(fmt.Errorf("invalid user to reassign the data of purged users to"))
+		}
+		if reassignTo, err = u.userDao.GetUserByID(ctx, toUserID); err != nil {
+			return nil, errors.OperationFailed(
+				fmt.Errorf("failed to get user (id: %s) to reassign the data of purged users to", toUserID.Hex()),
+			)
+		}
+	default:
+		return nil, errors.ServiceError(fmt.Errorf("unknown purge policy %s", retention.PurgePolicy))
+	}
+	deletedBefore := time.Now().Add(-retention.Period)
+	users, _, err := u.userDao.GetDeletedUserList(ctx, 0, 0, false, &deletedBefore)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get deleted user list"))
+	}
+	resp := &admin.PurgeUserListResponse{
+		Policy:   retention.PurgePolicy,
+		UserList: make([]*admin.PurgeUserResponse, 0, len(users)),
+	}
+	if len(users) == 0 {
+		return resp, nil
+	}
+	// The logs still in the cache would be written with the usernames and emails after the purge
+	u.loginLogDao.SyncLoginLog(ctx)
+	u.operationLogDao.SyncOperationLog(ctx)
+	for i := range users {
+		user := &users[i]
+		purged := &admin.PurgeUserResponse{UserID: user.UserID.Hex(), Username: user.Username}
+		result, err := u.purgeUser(ctx, user, retention.PurgePolicy, reassignTo)
+		if err != nil {
+			// The user is still soft deleted, the next purge picks it up again
+			u.core.Logger.Error(
+				"failed to purge user", zap.Error(err),
+				zap.String("userID", user.UserID.Hex()), zap.String("policy", retention.PurgePolicy),
+			)
+			purged.Error = err.Error()
+			resp.Failed++
+		} else {
+			purged.EraseUserResponse = *result
+			resp.Total++
+		}
+		resp.UserList = append(resp.UserList, purged)
+	}
+	return resp, nil
+}
+
+// purgeUser hard deletes the user and revokes all of its tokens. Its instruction data is deleted, reassigned to
+// reassignTo, or anonymised by attributing the approved data to a random pseudonym and deleting the rest, following
+// the policy. The instruction data of a deleted user which was deleted along with it is dealt with as visible. The
+// copies of its ID, username and email in the reviews, re-audits, login and operation logs are replaced with the
+// pseudonym, and its access tokens are deleted.
+// The steps are not run in a transaction. Each of them only affects the records still attributed to the user, and the
+// user itself is deleted last, so a purge which fails half way is finished by running it again.
+func (u UserServiceImpl) purgeUser(
+	ctx context.Context, user *entity.UserModel, policy string, reassignTo *entity.UserModel,
+) (*admin.EraseUserResponse, error) {
+	var (
+		userID      = user.UserID
+		pseudonymID = primitive.NewObjectID()
+		pseudonym   = fmt.Sprintf("erased-%s", pseudonymID.Hex())
+		resp        = &admin.EraseUserResponse{}
+		kept        *int64
+		err         error
+	)
+	if err = u.sessionDao.RevokeUserTokens(ctx, userID); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user"))
+	}
+	accessTokens, err := u.accessTokenDao.DeleteAccessTokenList(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to delete access tokens of user"))
+	}
+	if user.Deleted {
+		if _, err = u.instructionDataDao.RestoreUserInstructionDataList(ctx, userID, user.DeletedAt); err != nil {
+			return nil, errors.OperationFailed(fmt.Errorf("failed to restore instruction data of user"))
+		}
+	}
+	switch policy {
+	case config.UserPurgePolicyAnonymise:
+		kept, err = u.instructionDataDao.PseudonymizeInstructionDataList(ctx, userID, pseudonymID, pseudonym)
+		if err != nil {
+			return nil, errors.OperationFailed(fmt.Errorf("failed to pseudonymize instruction data of user"))
+		}
+	case config.UserPurgePolicyReassign:
+		kept, err = u.instructionDataDao.ReassignInstructionDataList(
+			ctx, userID, reassignTo.UserID, reassignTo.Username,
+		)
+		if err != nil {
+			return nil, errors.OperationFailed(fmt.Errorf("failed to reassign instruction data of user"))
+		}
+	default:
+		kept = new(int64)
+	}
+	deleted, err := u.instructionDataDao.DeleteInstructionDataList(
+		ctx, &userID, nil, nil, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to delete instruction data of user"))
+	}
+	reviews, err := u.reviewDao.PseudonymizeReviewList(ctx, userID, pseudonymID, pseudonym)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to anonymise reviews of user"))
+	}
+	reAudits, err := u.reAuditDao.PseudonymizeReAuditList(ctx, userID, pseudonymID, pseudonym)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to anonymise re-audits of user"))
+	}
+	loginLogs, err := u.loginLogDao.PseudonymizeLoginLogList(ctx, userID, user.Email, pseudonymID, pseudonym)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to anonymise login logs of user"))
+	}
+	operationLogs, err := u.operationLogDao.PseudonymizeOperationLogList(ctx, userID, pseudonymID, pseudonym)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to anonymise operation logs of user"))
+	}
+	if err = RemoveOrganizationAdmin(u.enforcer, userID.Hex()); err != nil {
+		u.core.Logger.Error("failed to delete organization admin role for user", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to delete organization admin role for user"))
+	}
+	if _, err = u.enforcer.DeleteRolesForUser(userID.Hex()); err != nil {
+		u.core.Logger.Error("failed to delete roles for user", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to delete roles for user"))
+	}
+	if user.Avatar != "" {
+		if err = u.storage.Delete(ctx, user.Avatar); err != nil {
+			u.core.Logger.Error("failed to delete avatar", zap.Error(err), zap.String("avatar", user.Avatar))
+			return nil, errors.ServiceError(fmt.Errorf("failed to delete avatar of user"))
+		}
+	}
+	if err = u.userDao.DeleteUser(ctx, userID); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to delete user (id: %s)", userID.Hex()))
+	}
+	resp.InstructionDataKept, resp.InstructionDataDeleted = *kept, *deleted
+	resp.ReviewsAnonymised, resp.ReAuditsAnonymised = *reviews, *reAudits
+	resp.LoginLogsAnonymised, resp.OperationLogsAnonymised = *loginLogs, *operationLogs
+	resp.AccessTokensDeleted = *accessTokens
+	return resp, nil
+}
+
+// suspensionResponse returns the suspension of the user, nil if it is not suspended.
+func suspensionResponse(user *entity.UserModel) *admin.SuspensionResponse {
+	if !service.IsSuspended(user, time.Now()) {
+		return nil
+	}
+	resp := &admin.SuspensionResponse{
+		Reason:      user.Suspension.Reason,
+		SuspendedBy: user.Suspension.SuspendedBy.Hex(),
+		SuspendedAt: user.Suspension.SuspendedAt.Format(time.RFC3339),
+	}
+	if !user.Suspension.ExpiresAt.IsZero() {
+		resp.ExpiresAt = user.Suspension.ExpiresAt.Format(time.RFC3339)
+	}
+	return resp
+}
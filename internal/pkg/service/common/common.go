@@ -9,4 +9,10 @@ type Common struct {
 	DocumentationService mods.DocumentationService
 	NoticeService        mods.NoticeService
 	ProfileService       mods.ProfileService
+	ThemeService         mods.ThemeService
+	TwoFactorService     mods.TwoFactorService
+	AccessTokenService   mods.AccessTokenService
+	OIDCService          mods.OIDCService
+	SessionService       mods.SessionService
+	JWKSService          mods.JWKSService
 }
@@ -0,0 +1,134 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type AccessTokenService interface {
+	InsertAccessToken(
+		ctx context.Context, name *string, scopes []string, expiresAt *time.Time,
+	) (*common.InsertAccessTokenResponse, error)
+	GetAccessTokenList(ctx context.Context) (*common.GetAccessTokenListResponse, error)
+	DeleteAccessToken(ctx context.Context, accessTokenID *primitive.ObjectID) error
+}
+
+type accessTokenServiceImpl struct {
+	core           *service.Core
+	accessTokenDao daos.AccessTokenDao
+}
+
+func NewAccessTokenService(core *service.Core, accessTokenDao daos.AccessTokenDao) AccessTokenService {
+	return &accessTokenServiceImpl{
+		core:           core,
+		accessTokenDao: accessTokenDao,
+	}
+}
+
+// InsertAccessToken creates a personal access token of the current user. The token is only returned here, the
+// server keeps its SHA-256.
+func (a accessTokenServiceImpl) InsertAccessToken(
+	ctx context.Context, name *string, scopes []string, expiresAt *time.Time,
+) (*common.InsertAccessTokenResponse, error) {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, errors.InvalidRequest(fmt.Errorf("expiry time should be in the future"))
+	}
+	if expiresAt.After(time.Now().Add(a.core.Config.AccessTokenConfig.MaxTTL)) {
+		return nil, errors.InvalidRequest(
+			fmt.Errorf("expiry time should be within %s", a.core.Config.AccessTokenConfig.MaxTTL),
+		)
+	}
+	count, err := a.accessTokenDao.CountAccessToken(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to count access tokens"))
+	}
+	if *count >= a.core.Config.AccessTokenConfig.MaxPerUser {
+		return nil, errors.InvalidRequest(
+			fmt.Errorf(
+				"at most %d access tokens are allowed, please revoke unused ones",
+				a.core.Config.AccessTokenConfig.MaxPerUser,
+			),
+		)
+	}
+	secret, err := crypt.RandomToken(32)
+	if err != nil {
+		a.core.Logger.Error("failed to generate access token", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate access token"))
+	}
+	token := config.AccessTokenPrefix + secret
+	accessTokenID, err := a.accessTokenDao.InsertAccessToken(
+		ctx, userID, *name, crypt.SHA256(token), scopes, *expiresAt,
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.DuplicateKeyError(fmt.Errorf("access token already exists, please retry"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to insert access token"))
+	}
+	return &common.InsertAccessTokenResponse{
+		AccessTokenID: accessTokenID.Hex(),
+		Token:         token,
+	}, nil
+}
+
+func (a accessTokenServiceImpl) GetAccessTokenList(ctx context.Context) (*common.GetAccessTokenListResponse, error) {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accessTokenList, err := a.accessTokenDao.GetAccessTokenList(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get access token list"))
+	}
+	resp := make([]*common.GetAccessTokenResponse, 0, len(accessTokenList))
+	for _, accessToken := range accessTokenList {
+		var lastUsedAt string
+		if !accessToken.LastUsedAt.IsZero() {
+			lastUsedAt = accessToken.LastUsedAt.Format(time.RFC3339)
+		}
+		resp = append(
+			resp, &common.GetAccessTokenResponse{
+				AccessTokenID: accessToken.AccessTokenID.Hex(),
+				Name:          accessToken.Name,
+				Scopes:        accessToken.Scopes,
+				Expired:       !accessToken.ExpiresAt.After(time.Now()),
+				ExpiresAt:     accessToken.ExpiresAt.Format(time.RFC3339),
+				LastUsedAt:    lastUsedAt,
+				CreatedAt:     accessToken.CreatedAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &common.GetAccessTokenListResponse{AccessTokenList: resp}, nil
+}
+
+// DeleteAccessToken revokes the access token, only tokens of the current user can be revoked.
+func (a accessTokenServiceImpl) DeleteAccessToken(ctx context.Context, accessTokenID *primitive.ObjectID) error {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if err = a.accessTokenDao.DeleteAccessToken(ctx, userID, *accessTokenID); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("access token (id: %s) not found", accessTokenID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to delete access token (id: %s)", accessTokenID.Hex()))
+	}
+	return nil
+}
@@ -4,62 +4,142 @@ import (
 	"context"
 	e "errors"
 	"fmt"
+	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
 	"data-collection-hub-server/internal/pkg/dao"
 	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/internal/pkg/domain/vo/common"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
 	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/mailer"
 	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/casbin/casbin/v2"
+	"github.com/qiniu/qmgo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
 type AuthService interface {
-	Login(ctx context.Context, email, password *string) (*common.LoginResponse, error)
+	Login(ctx context.Context, email, password, ipAddress *string) (*common.LoginResponse, error)
+	Register(ctx context.Context, inviteCode, username, email, password *string) (string, error)
 	RefreshToken(ctx context.Context, refreshToken *string) (*common.RefreshTokenResponse, error)
 	Logout(ctx context.Context, accessToken *string) error
 	ChangePassword(ctx context.Context, oldPassword, newPassword *string) error
+	ForgotPassword(ctx context.Context, email *string) (string, error)
+	ResetPassword(ctx context.Context, token, newPassword *string) (string, error)
 }
 
 type authServiceImpl struct {
-	core    *service.Core
-	cache   *dao.Cache
-	userDao daos.UserDao
-	jwt     *jwt.Jwt
+	core               *service.Core
+	cache              *dao.Cache
+	userDao            daos.UserDao
+	inviteCodeDao      daos.InviteCodeDao
+	twoFactorPolicyDao daos.TwoFactorPolicyDao
+	jwt                *jwt.Jwt
+	enforcer           *casbin.Enforcer
+	mailer             mailer.Mailer
+	authenticators     *AuthenticatorChain
+	loginAttemptDao    daos.LoginAttemptDao
+	sessionDao         daos.SessionDao
+	operationLogDao    daos.OperationLogDao
 }
 
-func NewAuthService(core *service.Core, userDao daos.UserDao, cache *dao.Cache, jwt *jwt.Jwt) AuthService {
+func NewAuthService(
+	core *service.Core, userDao daos.UserDao, inviteCodeDao daos.InviteCodeDao,
+	twoFactorPolicyDao daos.TwoFactorPolicyDao, cache *dao.Cache, jwt *jwt.Jwt, enforcer *casbin.Enforcer,
+	mailer mailer.Mailer, authenticators *AuthenticatorChain, loginAttemptDao daos.LoginAttemptDao,
+	sessionDao daos.SessionDao, operationLogDao daos.OperationLogDao,
+) AuthService {
 	return &authServiceImpl{
-		core:    core,
-		cache:   cache,
-		userDao: userDao,
-		jwt:     jwt,
+		core:               core,
+		cache:              cache,
+		userDao:            userDao,
+		inviteCodeDao:      inviteCodeDao,
+		twoFactorPolicyDao: twoFactorPolicyDao,
+		jwt:                jwt,
+		enforcer:           enforcer,
+		mailer:             mailer,
+		authenticators:     authenticators,
+		loginAttemptDao:    loginAttemptDao,
+		sessionDao:         sessionDao,
+		operationLogDao:    operationLogDao,
 	}
 }
 
-func (a authServiceImpl) Login(ctx context.Context, email, password *string) (*common.LoginResponse, error) {
-	user, err := a.userDao.GetUserByEmail(ctx, *email)
+// Login checks the password of the user with the authenticator chain. When the user has enabled 2FA or 2FA is
+// enforced for the role of the user, a challenge token to be completed with TwoFactorService is returned instead of
+// the JWTs. Wrong credentials are counted per account and per IP address, which are delayed and then locked as
+// failures add up.
+func (a authServiceImpl) Login(ctx context.Context, email, password, ipAddress *string) (*common.LoginResponse, error) {
+	if err := checkLoginAllowed(ctx, a.loginAttemptDao, *email, *ipAddress); err != nil {
+		return nil, err
+	}
+	user, err := a.authenticators.Authenticate(ctx, *email, *password)
 	if err != nil {
-		return nil, errors.AuthFailed(fmt.Errorf("user not exist or password wrong"))
+		var appErr *errors.AppError
+		if e.As(err, &appErr) && appErr.Code() == errors.CodeAuthFailed {
+			if lockErr := recordLoginFailure(ctx, a.core, a.loginAttemptDao, *email, *ipAddress); lockErr != nil {
+				return nil, lockErr
+			}
+		}
+		return nil, err
 	}
-	if !crypt.Compare(*password, user.Password) {
-		return nil, errors.AuthFailed(fmt.Errorf("user not exist or password wrong"))
+	_ = a.loginAttemptDao.ResetLoginFailure(ctx, config.LoginLockoutKindAccount, *email)
+	if err = service.CheckSuspension(user); err != nil {
+		return nil, err
 	}
-	accessToken, err := a.jwt.GenerateAccessToken(user.UserID.Hex())
+	enforced, err := twoFactorEnforced(ctx, a.twoFactorPolicyDao, user.Role)
 	if err != nil {
-		a.core.Logger.Error("failed to generate access token", zap.Error(err))
+		return nil, err
+	}
+	if !user.TwoFactor.Enabled && !enforced {
+		return issueLoginResponse(ctx, a.core, a.jwt, a.userDao, a.sessionDao, user)
+	}
+	challengeToken, err := newTwoFactorChallenge(ctx, a.core, a.cache, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &common.LoginResponse{
+		ChallengeToken:     challengeToken,
+		TwoFactorRequired:  true,
+		EnrollmentRequired: !user.TwoFactor.Enabled,
+	}
+	resp.Meta.UserID = user.UserID.Hex()
+	resp.Meta.Username = user.Username
+	resp.Meta.Email = user.Email
+	resp.Meta.Role = user.Role
+	return resp, nil
+}
+
+// issueLoginResponse starts a session on the device of the request, generates the JWTs of the session and updates
+// the last login time of the user. Suspended users are refused.
+func issueLoginResponse(
+	ctx context.Context, core *service.Core, jwt *jwt.Jwt, userDao daos.UserDao, sessionDao daos.SessionDao,
+	user *entity.UserModel,
+) (*common.LoginResponse, error) {
+	if err := service.CheckSuspension(user); err != nil {
+		return nil, err
+	}
+	refreshTokenID := primitive.NewObjectID().Hex()
+	sessionID, err := newSession(ctx, core, sessionDao, user.UserID, refreshTokenID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := jwt.GenerateAccessToken(user.UserID.Hex(), sessionID.Hex())
+	if err != nil {
+		core.Logger.Error("failed to generate access token", zap.Error(err))
 		return nil, errors.ServiceError(fmt.Errorf("failed to generate access token"))
 	}
-	refreshToken, err := a.jwt.GenerateRefreshToken(user.UserID.Hex())
+	refreshToken, err := jwt.GenerateRefreshToken(user.UserID.Hex(), sessionID.Hex(), refreshTokenID)
 	if err != nil {
-		a.core.Logger.Error("failed to generate refresh token", zap.Error(err))
+		core.Logger.Error("failed to generate refresh token", zap.Error(err))
 		return nil, errors.ServiceError(fmt.Errorf("failed to generate refresh token"))
 	}
-	err = a.userDao.UpdateUserLastLogin(ctx, user.UserID)
+	err = userDao.UpdateUserLastLogin(ctx, user.UserID)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
 			return nil, errors.DuplicateKeyError(fmt.Errorf("user last login already updated"))
@@ -73,38 +153,105 @@ func (a authServiceImpl) Login(ctx context.Context, email, password *string) (*c
 			)
 		}
 	}
-	return &common.LoginResponse{
+	resp := &common.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresIn:    a.core.Config.JWTConfig.TokenDuration.Seconds(),
-		Meta: struct {
-			UserID   string `json:"user_id"`
-			Username string `json:"username"`
-			Email    string `json:"email"`
-			Role     string `json:"role"`
-		}(struct {
-			UserID   string
-			Username string
-			Email    string
-			Role     string
-		}{
-			UserID:   user.UserID.Hex(),
-			Username: user.Username,
-			Email:    user.Email,
-			Role:     user.Role,
-		}),
-	}, nil
+		ExpiresIn:    core.Config.JWTConfig.TokenDuration.Seconds(),
+	}
+	resp.Meta.UserID = user.UserID.Hex()
+	resp.Meta.Username = user.Username
+	resp.Meta.Email = user.Email
+	resp.Meta.Role = user.Role
+	resp.PasswordExpired = service.IsPasswordExpired(core.Config, user)
+	return resp, nil
+}
+
+// Register redeems the invite code and creates a user with the role and organization of the code.
+// Returns the user ID if successful.
+func (a authServiceImpl) Register(
+	ctx context.Context, inviteCode, username, email, password *string,
+) (string, error) {
+	code, err := a.inviteCodeDao.RedeemInviteCode(ctx, *inviteCode)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return "", errors.AuthFailed(fmt.Errorf("invite code invalid, expired or used up"))
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to redeem invite code"))
+	}
+	userID, err := a.insertInvitedUser(ctx, code, username, email, password)
+	if err != nil {
+		if releaseErr := a.inviteCodeDao.ReleaseInviteCode(ctx, code.InviteCodeID); releaseErr != nil {
+			a.core.Logger.Error("failed to release invite code", zap.Error(releaseErr))
+		}
+		return "", err
+	}
+	if err = a.inviteCodeDao.InsertRedemption(ctx, code.InviteCodeID, userID, *username); err != nil {
+		a.core.Logger.Error("failed to record redemption of invite code", zap.Error(err))
+	}
+	return userID.Hex(), nil
+}
+
+// insertInvitedUser inserts the user registered with the invite code and adds the casbin grouping of its role.
+func (a authServiceImpl) insertInvitedUser(
+	ctx context.Context, code *entity.InviteCodeModel, username, email, password *string,
+) (primitive.ObjectID, error) {
+	if err := service.ValidatePassword(a.core.Config, "password", *password, *username, *email); err != nil {
+		return primitive.NilObjectID, err
+	}
+	passwordHash, err := crypt.Hash(*password)
+	if err != nil {
+		a.core.Logger.Error("failed to hash password", zap.Error(err))
+		return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to hash password"))
+	}
+	userID, err := a.userDao.InsertUser(ctx, *username, *email, passwordHash, code.Role, code.Organization)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, errors.DuplicateKeyError(
+				fmt.Errorf("user with email %s already exists", *email),
+			)
+		}
+		return primitive.NilObjectID, errors.OperationFailed(fmt.Errorf("failed to insert user"))
+	}
+	if _, err = a.enforcer.AddRoleForUser(userID.Hex(), code.Role); err != nil {
+		a.core.Logger.Error("failed to create role for user", zap.Error(err))
+		if err = a.userDao.DeleteUser(ctx, userID); err != nil {
+			a.core.Logger.Error("failed to delete user without role", zap.Error(err))
+		}
+		return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to create role for user"))
+	}
+	return userID, nil
 }
 
+// RefreshToken issues new JWTs for the session of the refresh token and extends the session. Refresh tokens are
+// rotated: each one can be used once, and the session only accepts the last one issued. A refresh token used again
+// was either stolen or used by a thief first, so the whole session is revoked. The refresh token is also rejected once
+// its session has been revoked or has expired, or while the user is suspended.
 func (a authServiceImpl) RefreshToken(ctx context.Context, refreshToken *string) (*common.RefreshTokenResponse, error) {
-	userIDHex, err := a.jwt.VerifyRefreshToken(*refreshToken)
+	claims, err := a.jwt.ParseRefreshToken(*refreshToken)
 	if err != nil {
 		return nil, errors.TokenInvalid(fmt.Errorf("refresh token invalid"))
 	}
-	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
 	if err != nil {
 		return nil, errors.NotAuthorized(fmt.Errorf("user id invalid"))
 	}
+	sessionID, err := primitive.ObjectIDFromHex(claims.SessionID)
+	if err != nil {
+		return nil, errors.TokenInvalid(fmt.Errorf("refresh token has no session, please log in again"))
+	}
+	session, err := a.sessionDao.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return nil, errors.TokenInvalid(fmt.Errorf("session has been revoked or expired"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get session (id: %s)", sessionID.Hex()))
+	}
+	if session.UserID != userID {
+		return nil, errors.TokenInvalid(fmt.Errorf("refresh token invalid"))
+	}
+	if claims.Id != session.RefreshTokenID {
+		return nil, a.revokeReusedSession(ctx, session)
+	}
 	user, err := a.userDao.GetUserByID(ctx, userID)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
@@ -113,16 +260,28 @@ func (a authServiceImpl) RefreshToken(ctx context.Context, refreshToken *string)
 			return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
 		}
 	}
-	accessToken, err := a.jwt.GenerateAccessToken(userID.Hex())
+	if err = service.CheckSuspension(user); err != nil {
+		return nil, err
+	}
+	accessToken, err := a.jwt.GenerateAccessToken(userID.Hex(), sessionID.Hex())
 	if err != nil {
 		a.core.Logger.Error("failed to generate access token", zap.Error(err))
 		return nil, errors.ServiceError(fmt.Errorf("failed to generate access token"))
 	}
-	newRefreshToken, err := a.jwt.GenerateRefreshToken(userID.Hex())
+	newRefreshTokenID := primitive.NewObjectID().Hex()
+	newRefreshToken, err := a.jwt.GenerateRefreshToken(userID.Hex(), sessionID.Hex(), newRefreshTokenID)
 	if err != nil {
 		a.core.Logger.Error("failed to generate refresh token", zap.Error(err))
 		return nil, errors.ServiceError(fmt.Errorf("failed to generate refresh token"))
 	}
+	if err = a.sessionDao.RotateSessionRefreshToken(
+		ctx, sessionID, claims.Id, newRefreshTokenID, time.Now().Add(a.core.Config.JWTConfig.RefreshDuration),
+	); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) { // rotated by a concurrent refresh with the same token
+			return nil, a.revokeReusedSession(ctx, session)
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to update session (id: %s)", sessionID.Hex()))
+	}
 	return &common.RefreshTokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
@@ -146,6 +305,32 @@ func (a authServiceImpl) RefreshToken(ctx context.Context, refreshToken *string)
 	}, nil
 }
 
+// revokeReusedSession revokes the session a consumed refresh token was presented for, and records the event in the
+// operation log as a possible theft of the token.
+func (a authServiceImpl) revokeReusedSession(ctx context.Context, session *entity.SessionModel) error {
+	ipAddress, _ := ctx.Value(config.IPAddressKey).(string)
+	userAgent, _ := ctx.Value(config.UserAgentKey).(string)
+	a.core.Logger.Warn(
+		"refresh token reused, possible theft", zap.String("userID", session.UserID.Hex()),
+		zap.String("sessionID", session.SessionID.Hex()), zap.String("ipAddress", ipAddress),
+	)
+	if err := a.sessionDao.DeleteSession(ctx, session.UserID, session.SessionID); err != nil &&
+		!e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return errors.OperationFailed(fmt.Errorf("failed to revoke session (id: %s)", session.SessionID.Hex()))
+	}
+	description := fmt.Sprintf(
+		"Refresh token reused from %s, session %s revoked as possible theft", ipAddress, session.SessionID.Hex(),
+	)
+	if err := a.operationLogDao.CacheOperationLog(
+		ctx, session.UserID, session.SessionID, ipAddress, userAgent, config.OperationTypeDelete,
+		config.EntityTypeSession, description, config.OperationStatusFailure,
+	); err != nil {
+		a.core.Logger.Error("failed to log refresh token reuse", zap.Error(err))
+	}
+	return errors.TokenInvalid(fmt.Errorf("refresh token already used, session revoked, please log in again"))
+}
+
+// Logout blacklists the access token and ends its session, so that the refresh token of the session is rejected too.
 func (a authServiceImpl) Logout(ctx context.Context, accessToken *string) error {
 	if err := a.cache.Set(
 		ctx, fmt.Sprintf("%s:%s", config.TokenBlacklistCachePrefix, crypt.MD5(*accessToken)), config.CacheTrue,
@@ -153,9 +338,27 @@ func (a authServiceImpl) Logout(ctx context.Context, accessToken *string) error
 	); err != nil {
 		return errors.OperationFailed(fmt.Errorf("failed to blacklist token"))
 	}
+	// logout skips the auth middleware, the token may be expired but its session is ended all the same
+	claims, err := a.jwt.ParseExpiredAccessToken(*accessToken)
+	if err != nil || claims.SessionID == "" {
+		return nil
+	}
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
+	if err != nil {
+		return nil
+	}
+	sessionID, err := primitive.ObjectIDFromHex(claims.SessionID)
+	if err != nil {
+		return nil
+	}
+	if err = a.sessionDao.DeleteSession(ctx, userID, sessionID); err != nil && !e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return errors.OperationFailed(fmt.Errorf("failed to delete session (id: %s)", sessionID.Hex()))
+	}
 	return nil
 }
 
+// ChangePassword sets a new password for the current user and revokes all of their tokens, so every device has to
+// log in again.
 func (a authServiceImpl) ChangePassword(ctx context.Context, oldPassword, newPassword *string) error {
 	var (
 		userIDHex string
@@ -179,23 +382,114 @@ func (a authServiceImpl) ChangePassword(ctx context.Context, oldPassword, newPas
 	if !crypt.Compare(*oldPassword, user.Password) {
 		return errors.AuthFailed(fmt.Errorf("old password wrong"))
 	}
+	passwordHistory, err := service.ValidatePasswordChange(
+		ctx, a.core.Config, a.userDao, "new_password", *newPassword, user,
+	)
+	if err != nil {
+		return err
+	}
 	hashedPassword, err := crypt.Hash(*newPassword)
 	if err != nil {
 		a.core.Logger.Error("failed to hash password", zap.Error(err))
 		return errors.ServiceError(fmt.Errorf("failed to hash password"))
 	}
-	if err = a.userDao.UpdateUser(ctx, userID, nil, nil, &hashedPassword, nil, nil); err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return errors.DuplicateKeyError(
-				fmt.Errorf(
-					"user with username %s or email %s already exists", user.Username, user.Email,
-				),
-			)
-		} else if e.Is(err, mongo.ErrNoDocuments) {
+	if err = a.userDao.UpdateUserPassword(ctx, userID, hashedPassword, passwordHistory); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
 			return errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
 		} else {
 			return errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", userID.Hex()))
 		}
 	}
+	if err = a.sessionDao.RevokeUserTokens(ctx, userID); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()))
+	}
 	return nil
 }
+
+// ForgotPassword mails a single-use reset token to the user with the given email. The token is kept in the cache
+// until it is used or expires. Returns the user ID, or an empty string if no user has the email, in which case
+// nothing is sent and no error is returned. An error returned with a user ID happened past the lookup of the email,
+// the caller answers it as a success so that registered emails can not be probed.
+func (a authServiceImpl) ForgotPassword(ctx context.Context, email *string) (string, error) {
+	user, err := a.userDao.GetUserByEmail(ctx, *email)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return "", nil
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to get user by email"))
+	}
+	token, err := crypt.RandomToken(32)
+	if err != nil {
+		a.core.Logger.Error("failed to generate reset token", zap.Error(err))
+		return user.UserID.Hex(), errors.ServiceError(fmt.Errorf("failed to generate reset token"))
+	}
+	if err = a.cache.Set(
+		ctx, fmt.Sprintf("%s:%s", config.PasswordResetCachePrefix, crypt.MD5(token)), user.UserID.Hex(),
+		&a.core.Config.PasswordResetConfig.TokenTTL,
+	); err != nil {
+		return user.UserID.Hex(), errors.OperationFailed(fmt.Errorf("failed to save reset token"))
+	}
+	body := fmt.Sprintf(
+		"Hi %s,\n\nA password reset was requested for your account. Open the link below to set a new password, "+
+			"it is valid for %s and can be used once:\n\n%s\n\nIf you did not request it, please ignore this mail.\n",
+		user.Username, a.core.Config.PasswordResetConfig.TokenTTL,
+		fmt.Sprintf(a.core.Config.PasswordResetConfig.ResetURL, token),
+	)
+	if err = a.mailer.Send(ctx, user.Email, "Password reset", body); err != nil {
+		a.core.Logger.Error("failed to send reset mail", zap.Error(err), zap.String("userID", user.UserID.Hex()))
+		return user.UserID.Hex(), errors.ServiceError(fmt.Errorf("failed to send reset mail"))
+	}
+	return user.UserID.Hex(), nil
+}
+
+// ResetPassword sets the password of the user the reset token was issued to. The token is consumed once the password
+// is updated, so that a password rejected by the policy can be retried with the same link, and all tokens of the
+// user are revoked. Returns the user ID if the token is valid.
+func (a authServiceImpl) ResetPassword(ctx context.Context, token, newPassword *string) (string, error) {
+	tokenKey := fmt.Sprintf("%s:%s", config.PasswordResetCachePrefix, crypt.MD5(*token))
+	userIDHex, err := a.cache.Get(ctx, tokenKey)
+	if err != nil {
+		if e.Is(err, a.cache.Nil) {
+			return "", errors.AuthFailed(fmt.Errorf("reset token invalid or expired"))
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to get reset token"))
+	}
+	userID, err := primitive.ObjectIDFromHex(*userIDHex)
+	if err != nil {
+		return "", errors.AuthFailed(fmt.Errorf("reset token invalid or expired"))
+	}
+	user, err := a.userDao.GetUserByID(ctx, userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return userID.Hex(), errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return userID.Hex(), errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	passwordHistory, err := service.ValidatePasswordChange(
+		ctx, a.core.Config, a.userDao, "new_password", *newPassword, user,
+	)
+	if err != nil {
+		return userID.Hex(), err
+	}
+	hashedPassword, err := crypt.Hash(*newPassword)
+	if err != nil {
+		a.core.Logger.Error("failed to hash password", zap.Error(err))
+		return userID.Hex(), errors.ServiceError(fmt.Errorf("failed to hash password"))
+	}
+	if err = a.userDao.UpdateUserPassword(ctx, userID, hashedPassword, passwordHistory); err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return userID.Hex(), errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return userID.Hex(), errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", userID.Hex()))
+	}
+	if err = a.cache.Delete(ctx, tokenKey); err != nil {
+		a.core.Logger.Error("failed to delete reset token", zap.Error(err), zap.String("userID", userID.Hex()))
+		return userID.Hex(), errors.OperationFailed(fmt.Errorf("failed to delete reset token"))
+	}
+	if err = a.sessionDao.RevokeUserTokens(ctx, userID); err != nil {
+		return userID.Hex(), errors.OperationFailed(
+			fmt.Errorf("failed to revoke tokens of user (id: %s)", userID.Hex()),
+		)
+	}
+	return userID.Hex(), nil
+}
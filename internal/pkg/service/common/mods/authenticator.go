@@ -0,0 +1,232 @@
+package mods
+
+import (
+	"context"
+	"crypto/tls"
+	e "errors"
+	"fmt"
+	"strings"
+
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/ldap"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/casbin/casbin/v2"
+	"github.com/qiniu/qmgo"
+	"go.uber.org/zap"
+)
+
+const (
+	AuthenticatorLocal = "local"
+	AuthenticatorLDAP  = "ldap"
+)
+
+var (
+	// errUnknownUser is returned by an authenticator whose identity source does not know the email.
+	errUnknownUser = e.New("unknown user")
+	// errWrongPassword is returned by an authenticator which knows the email but rejects the password.
+	errWrongPassword = e.New("wrong password")
+)
+
+// Authenticator checks the credentials of a login against one identity source.
+type Authenticator interface {
+	Name() string
+	Authenticate(ctx context.Context, email, password string) (*entity.UserModel, error)
+}
+
+// AuthenticatorChain tries its authenticators in order until one accepts the credentials.
+type AuthenticatorChain struct {
+	core           *service.Core
+	authenticators []Authenticator
+}
+
+// NewAuthenticatorChain builds the chain configured in AuthenticatorConfig.
+func NewAuthenticatorChain(
+	core *service.Core, userDao daos.UserDao, sessionDao daos.SessionDao, enforcer *casbin.Enforcer,
+) (*AuthenticatorChain, error) {
+	chain := &AuthenticatorChain{core: core}
+	for _, name := range core.Config.AuthenticatorConfig.Chain {
+		switch name {
+		case AuthenticatorLocal:
+			chain.authenticators = append(chain.authenticators, &localAuthenticator{userDao: userDao})
+		case AuthenticatorLDAP:
+			chain.authenticators = append(
+				chain.authenticators,
+				&ldapAuthenticator{core: core, userDao: userDao, sessionDao: sessionDao, enforcer: enforcer},
+			)
+		default:
+			return nil, fmt.Errorf("unknown authenticator %s", name)
+		}
+	}
+	if len(chain.authenticators) == 0 {
+		return nil, fmt.Errorf("no authenticator configured")
+	}
+	return chain, nil
+}
+
+// Authenticate returns the user accepted by the first authenticator. Rejections by one authenticator fall through
+// to the next, so that e.g. directory users without a local password can still log in. Errors from pkg/errors
+// (such as a user outside of the allowed groups) are returned at once.
+func (c *AuthenticatorChain) Authenticate(ctx context.Context, email, password string) (*entity.UserModel, error) {
+	var unavailable bool
+	for _, authenticator := range c.authenticators {
+		user, err := authenticator.Authenticate(ctx, email, password)
+		if err == nil {
+			return user, nil
+		}
+		var appErr *errors.AppError
+		switch {
+		case e.Is(err, errUnknownUser), e.Is(err, errWrongPassword):
+			continue
+		case e.As(err, &appErr):
+			return nil, err
+		default:
+			c.core.Logger.Error(
+				"authenticator failed", zap.String("authenticator", authenticator.Name()), zap.Error(err),
+			)
+			unavailable = true
+		}
+	}
+	if unavailable {
+		return nil, errors.ServerBusy(fmt.Errorf("authentication backend unavailable, please try again later"))
+	}
+	return nil, errors.AuthFailed(fmt.Errorf("user not exist or password wrong"))
+}
+
+// localAuthenticator checks the password stored in the user collection.
+type localAuthenticator struct {
+	userDao daos.UserDao
+}
+
+func (l *localAuthenticator) Name() string {
+	return AuthenticatorLocal
+}
+
+func (l *localAuthenticator) Authenticate(ctx context.Context, email, password string) (*entity.UserModel, error) {
+	user, err := l.userDao.GetUserByEmail(ctx, email)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return nil, errUnknownUser
+		}
+		return nil, err
+	}
+	if !crypt.Compare(password, user.Password) {
+		return nil, errWrongPassword
+	}
+	return user, nil
+}
+
+// ldapAuthenticator finds the entry of the email in the directory and binds as it with the password. Users are
+// provisioned on their first successful bind and matched on the DN of their entry afterwards, and their role follows
+// the group membership if groups are configured. Accounts not provisioned through LDAP are never signed in.
+type ldapAuthenticator struct {
+	core       *service.Core
+	userDao    daos.UserDao
+	sessionDao daos.SessionDao
+	enforcer   *casbin.Enforcer
+}
+
+func (l *ldapAuthenticator) Name() string {
+	return AuthenticatorLDAP
+}
+
+func (l *ldapAuthenticator) Authenticate(ctx context.Context, email, password string) (*entity.UserModel, error) {
+	cfg := l.core.Config.LDAPConfig
+	if password == "" {
+		return nil, errWrongPassword
+	}
+	entry, err := l.bind(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	role, err := mapGroupsToRole(entry.GetAll(cfg.GroupAttribute), cfg.AdminGroups, cfg.UserGroups, strings.EqualFold)
+	if err != nil {
+		return nil, err
+	}
+	// only accounts provisioned through LDAP are signed in, so that a directory entry can not take over a local
+	// account (or change its role) by carrying its email
+	user, err := l.userDao.GetUserByLDAPDN(ctx, entry.DN)
+	if err == nil {
+		if role != "" && role != user.Role {
+			if err = syncUserRole(ctx, l.core, l.userDao, l.sessionDao, l.enforcer, user, role); err != nil {
+				return nil, err
+			}
+		}
+		return user, nil
+	} else if !e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user"))
+	}
+
+	if mail := entry.Get(cfg.EmailAttribute); mail != "" {
+		email = mail
+	}
+	if _, err = l.userDao.GetUserByEmail(ctx, email); err == nil {
+		l.core.Logger.Warn(
+			"directory entry carries the email of an account not provisioned through LDAP",
+			zap.String("dn", entry.DN), zap.String("email", email),
+		)
+		return nil, errUnknownUser
+	} else if !e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user"))
+	}
+	organization := entry.Get(cfg.OrganizationAttribute)
+	if organization == "" {
+		organization = cfg.DefaultOrganization
+	}
+	userID, err := provisionExternalUser(
+		ctx, l.core, l.userDao, l.enforcer, entry.Get(cfg.UsernameAttribute), email, organization, role,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.userDao.LinkUserLDAP(ctx, userID, entry.DN); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to link user"))
+	}
+	user, err = l.userDao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	return user, nil
+}
+
+// bind searches the entry of the email, with the service account if configured, and binds as it.
+func (l *ldapAuthenticator) bind(ctx context.Context, email, password string) (*ldap.Entry, error) {
+	cfg := l.core.Config.LDAPConfig
+	var tlsConfig *tls.Config
+	if cfg.InsecureSkipVerify {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- opt-in for test directories
+	}
+	conn, err := ldap.Dial(ctx, cfg.URL, cfg.Timeout, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if cfg.BindDN != "" {
+		if err = conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("service account bind failed: %w", err)
+		}
+	}
+	entries, err := conn.Search(
+		cfg.BaseDN, fmt.Sprintf(cfg.UserFilter, ldap.EscapeFilter(email)),
+		[]string{cfg.UsernameAttribute, cfg.EmailAttribute, cfg.OrganizationAttribute, cfg.GroupAttribute}, 2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	switch len(entries) {
+	case 0:
+		return nil, errUnknownUser
+	case 1:
+	default:
+		return nil, fmt.Errorf("more than one entry matches %s", email)
+	}
+	if err = conn.Bind(entries[0].DN, password); err != nil {
+		if ldap.IsInvalidCredentials(err) {
+			return nil, errWrongPassword
+		}
+		return nil, err
+	}
+	return entries[0], nil
+}
@@ -0,0 +1,121 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"data-collection-hub-server/internal/pkg/config"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/casbin/casbin/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// provisionExternalUser inserts a user authenticated by an external identity source (OIDC provider, LDAP directory)
+// for the first time, with an unusable random password, and adds the casbin grouping of its role. The username is
+// derived from the email if empty and suffixed if already taken; the role defaults to USER.
+func provisionExternalUser(
+	ctx context.Context, core *service.Core, userDao daos.UserDao, enforcer *casbin.Enforcer,
+	username, email, organization, role string,
+) (primitive.ObjectID, error) {
+	if username == "" {
+		username = strings.SplitN(email, "@", 2)[0]
+	}
+	if _, err := userDao.GetUserByUsername(ctx, username); err == nil {
+		suffix, err := crypt.RandomToken(3)
+		if err != nil {
+			return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to generate username"))
+		}
+		username = fmt.Sprintf("%s-%s", username, suffix)
+	}
+	if role == "" {
+		role = config.UserRoleUser
+	}
+	password, err := crypt.RandomToken(32)
+	if err != nil {
+		return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to generate password"))
+	}
+	passwordHash, err := crypt.Hash(password)
+	if err != nil {
+		core.Logger.Error("failed to hash password", zap.Error(err))
+		return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to hash password"))
+	}
+	userID, err := userDao.InsertUser(ctx, username, email, passwordHash, role, organization)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, errors.DuplicateKeyError(
+				fmt.Errorf("user with email %s already exists", email),
+			)
+		}
+		return primitive.NilObjectID, errors.OperationFailed(fmt.Errorf("failed to insert user"))
+	}
+	if _, err = enforcer.AddRoleForUser(userID.Hex(), role); err != nil {
+		core.Logger.Error("failed to create role for user", zap.Error(err))
+		if err = userDao.DeleteUser(ctx, userID); err != nil {
+			core.Logger.Error("failed to delete user without role", zap.Error(err))
+		}
+		return primitive.NilObjectID, errors.ServiceError(fmt.Errorf("failed to create role for user"))
+	}
+	return userID, nil
+}
+
+// syncUserRole updates the role of the user and its casbin grouping to the role granted by the external identity
+// source. Only the grouping of the previous role is replaced, the other roles granted by admins are kept. The tokens
+// of a user which is no longer an admin are revoked, as admin UpdateUser does.
+func syncUserRole(
+	ctx context.Context, core *service.Core, userDao daos.UserDao, sessionDao daos.SessionDao,
+	enforcer *casbin.Enforcer, user *entity.UserModel, role string,
+) error {
+	if err := userDao.UpdateUser(ctx, user.UserID, nil, nil, nil, &role, nil); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to update role of user (id: %s)", user.UserID.Hex()))
+	}
+	if _, err := enforcer.DeleteRoleForUser(user.UserID.Hex(), user.Role); err != nil {
+		core.Logger.Error("failed to delete role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to update role of user"))
+	}
+	if _, err := enforcer.AddRoleForUser(user.UserID.Hex(), role); err != nil {
+		core.Logger.Error("failed to create role for user", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to update role of user"))
+	}
+	if user.Role == config.UserRoleAdmin && role != config.UserRoleAdmin {
+		if err := sessionDao.RevokeUserTokens(ctx, user.UserID); err != nil {
+			return errors.OperationFailed(
+				fmt.Errorf("failed to revoke tokens of user (id: %s)", user.UserID.Hex()),
+			)
+		}
+	}
+	user.Role = role
+	return nil
+}
+
+// mapGroupsToRole maps the groups of a user to a role. The role is "" if no groups are configured, i.e. roles are
+// not managed by the external identity source.
+func mapGroupsToRole(groups, adminGroups, userGroups []string, equal func(a, b string) bool) (string, error) {
+	if len(adminGroups) == 0 && len(userGroups) == 0 {
+		return "", nil
+	}
+	if containsAny(groups, adminGroups, equal) {
+		return config.UserRoleAdmin, nil
+	}
+	if containsAny(groups, userGroups, equal) {
+		return config.UserRoleUser, nil
+	}
+	return "", errors.PermissionDeny(fmt.Errorf("not a member of any group allowed to sign in"))
+}
+
+func containsAny(list, candidates []string, equal func(a, b string) bool) bool {
+	for _, item := range list {
+		for _, candidate := range candidates {
+			if equal(item, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
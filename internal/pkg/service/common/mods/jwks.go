@@ -0,0 +1,29 @@
+package mods
+
+import (
+	"context"
+
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/jwt"
+)
+
+type JWKSService interface {
+	GetJWKS(ctx context.Context) *jwt.JSONWebKeySet
+}
+
+type jwksServiceImpl struct {
+	core *service.Core
+	jwt  *jwt.Jwt
+}
+
+func NewJWKSService(core *service.Core, jwt *jwt.Jwt) JWKSService {
+	return &jwksServiceImpl{
+		core: core,
+		jwt:  jwt,
+	}
+}
+
+// GetJWKS returns the public keys verifying the JWTs, including the retired keys whose tokens may still be valid.
+func (j jwksServiceImpl) GetJWKS(_ context.Context) *jwt.JSONWebKeySet {
+	return j.jwt.JSONWebKeySet()
+}
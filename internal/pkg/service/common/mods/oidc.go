@@ -0,0 +1,208 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/oidc"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/casbin/casbin/v2"
+	"github.com/goccy/go-json"
+	"github.com/qiniu/qmgo"
+	"go.uber.org/zap"
+)
+
+type OIDCService interface {
+	Authorize(ctx context.Context) (*common.OIDCAuthorizeResponse, error)
+	Callback(ctx context.Context, code, state *string) (*common.LoginResponse, error)
+}
+
+type oidcServiceImpl struct {
+	core       *service.Core
+	cache      *dao.Cache
+	userDao    daos.UserDao
+	jwt        *jwt.Jwt
+	enforcer   *casbin.Enforcer
+	provider   *oidc.Provider
+	sessionDao daos.SessionDao
+}
+
+// oidcState is kept in the cache between the redirect to the provider and the callback.
+type oidcState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func NewOIDCService(
+	core *service.Core, userDao daos.UserDao, cache *dao.Cache, jwt *jwt.Jwt, enforcer *casbin.Enforcer,
+	provider *oidc.Provider, sessionDao daos.SessionDao,
+) OIDCService {
+	return &oidcServiceImpl{
+		core:       core,
+		cache:      cache,
+		userDao:    userDao,
+		jwt:        jwt,
+		enforcer:   enforcer,
+		provider:   provider,
+		sessionDao: sessionDao,
+	}
+}
+
+// Authorize starts the authorization code flow, returning the URL of the provider the user should be sent to.
+func (o oidcServiceImpl) Authorize(ctx context.Context) (*common.OIDCAuthorizeResponse, error) {
+	if o.provider == nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("single sign-on is not enabled"))
+	}
+	state, err := crypt.RandomToken(32)
+	if err != nil {
+		o.core.Logger.Error("failed to generate state", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate state"))
+	}
+	nonce, err := crypt.RandomToken(16)
+	if err != nil {
+		o.core.Logger.Error("failed to generate nonce", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate nonce"))
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		o.core.Logger.Error("failed to generate code verifier", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate code verifier"))
+	}
+	authorizationURL, err := o.provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		o.core.Logger.Error("failed to build authorization url", zap.Error(err))
+		return nil, errors.ServerBusy(fmt.Errorf("identity provider unavailable"))
+	}
+	stateJSON, _ := json.Marshal(oidcState{Nonce: nonce, CodeVerifier: codeVerifier})
+	if err = o.cache.Set(
+		ctx, fmt.Sprintf("%s:%s", config.OIDCStateCachePrefix, state), string(stateJSON),
+		&o.core.Config.OIDCConfig.StateTTL,
+	); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to store state"))
+	}
+	return &common.OIDCAuthorizeResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+// Callback completes the authorization code flow. The user is found by the subject of the ID token, or linked by
+// email on the first sign-in, or provisioned if there is no user with the email. If roles are managed by the
+// provider, the role of the user is synchronized with the groups on every sign-in. Two-factor authentication is
+// left to the provider.
+func (o oidcServiceImpl) Callback(ctx context.Context, code, state *string) (*common.LoginResponse, error) {
+	if o.provider == nil {
+		return nil, errors.InvalidRequest(fmt.Errorf("single sign-on is not enabled"))
+	}
+	stateJSON, err := o.cache.GetDelete(ctx, fmt.Sprintf("%s:%s", config.OIDCStateCachePrefix, *state))
+	if err != nil {
+		if e.Is(err, dao.CacheNil{}) {
+			return nil, errors.AuthFailed(fmt.Errorf("sign-in state invalid or expired, please sign in again"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get state"))
+	}
+	var s oidcState
+	if err = json.Unmarshal([]byte(*stateJSON), &s); err != nil {
+		return nil, errors.ServiceError(fmt.Errorf("failed to parse state"))
+	}
+	rawIDToken, err := o.provider.Exchange(ctx, *code, s.CodeVerifier)
+	if err != nil {
+		o.core.Logger.Warn("failed to redeem authorization code", zap.Error(err))
+		return nil, errors.AuthFailed(fmt.Errorf("failed to redeem authorization code"))
+	}
+	claims, err := o.provider.VerifyIDToken(ctx, rawIDToken, s.Nonce)
+	if err != nil {
+		o.core.Logger.Warn("failed to verify id token", zap.Error(err))
+		return nil, errors.AuthFailed(fmt.Errorf("id token invalid"))
+	}
+	role, err := o.mapRole(claims)
+	if err != nil {
+		return nil, err
+	}
+	user, err := o.resolveUser(ctx, claims, role)
+	if err != nil {
+		return nil, err
+	}
+	if role != "" && role != user.Role {
+		if err = syncUserRole(ctx, o.core, o.userDao, o.sessionDao, o.enforcer, user, role); err != nil {
+			return nil, err
+		}
+	}
+	return issueLoginResponse(ctx, o.core, o.jwt, o.userDao, o.sessionDao, user)
+}
+
+// mapRole maps the groups of the user to a role. The role is "" if roles are not managed by the provider.
+func (o oidcServiceImpl) mapRole(claims oidc.Claims) (string, error) {
+	cfg := o.core.Config.OIDCConfig
+	groups, _ := claims.Strings(cfg.GroupsClaim)
+	return mapGroupsToRole(
+		groups, cfg.AdminGroups, cfg.UserGroups, func(a, b string) bool { return a == b },
+	)
+}
+
+func (o oidcServiceImpl) resolveUser(ctx context.Context, claims oidc.Claims, role string) (*entity.UserModel, error) {
+	issuer, subject := o.provider.Issuer(), claims.String("sub")
+	user, err := o.userDao.GetUserByOIDCSubject(ctx, issuer, subject)
+	if err == nil {
+		return user, nil
+	} else if !e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user"))
+	}
+
+	email := claims.String(o.core.Config.OIDCConfig.EmailClaim)
+	if email == "" {
+		return nil, errors.AuthFailed(fmt.Errorf("id token has no email"))
+	}
+	// an email is only trusted when the provider states it is verified, a missing claim is not enough
+	if verified, ok := claims.Bool("email_verified"); !ok || !verified {
+		return nil, errors.AuthFailed(fmt.Errorf("email %s is not verified by the identity provider", email))
+	}
+	user, err = o.userDao.GetUserByEmail(ctx, email)
+	if err == nil {
+		if err = o.userDao.LinkUserOIDC(ctx, user.UserID, issuer, subject); err != nil {
+			if e.Is(err, qmgo.ErrNoSuchDocuments) {
+				return nil, errors.AuthFailed(fmt.Errorf("user with email %s is linked to another identity", email))
+			}
+			return nil, errors.OperationFailed(fmt.Errorf("failed to link user"))
+		}
+		return user, nil
+	} else if !e.Is(err, qmgo.ErrNoSuchDocuments) {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user"))
+	}
+
+	if !o.core.Config.OIDCConfig.AutoProvision {
+		return nil, errors.AuthFailed(fmt.Errorf("no user with email %s, please ask an administrator", email))
+	}
+	return o.provisionUser(ctx, claims, email, role)
+}
+
+// provisionUser inserts the user signing in for the first time and links it to the subject.
+func (o oidcServiceImpl) provisionUser(
+	ctx context.Context, claims oidc.Claims, email, role string,
+) (*entity.UserModel, error) {
+	cfg := o.core.Config.OIDCConfig
+	organization := claims.String(cfg.OrganizationClaim)
+	if organization == "" {
+		organization = cfg.DefaultOrganization
+	}
+	userID, err := provisionExternalUser(
+		ctx, o.core, o.userDao, o.enforcer, claims.String(cfg.UsernameClaim), email, organization, role,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.userDao.LinkUserOIDC(ctx, userID, o.provider.Issuer(), claims.String("sub")); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to link user"))
+	}
+	user, err := o.userDao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	return user, nil
+}
@@ -2,52 +2,327 @@ package mods
 
 import (
 	"context"
+	e "errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
-	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/dao"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/internal/pkg/domain/vo/common"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/mailer"
+	"data-collection-hub-server/pkg/storage"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/goccy/go-json"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 )
 
 type ProfileService interface {
 	GetProfile(ctx context.Context) (*common.GetProfileResponse, error)
+	UpdateProfile(ctx context.Context, username, organization *string) error
+	ChangeEmail(ctx context.Context, email *string) error
+	ConfirmEmail(ctx context.Context, token *string) (string, error)
+	UpdateAvatar(ctx context.Context, data []byte) (*common.UpdateAvatarResponse, error)
+	ExportPersonalData(ctx context.Context) ([]byte, error)
 }
 
 type profileServiceImpl struct {
-	core    *service.Core
-	userDao dao.UserDao
+	core               *service.Core
+	userDao            daos.UserDao
+	organizationDao    daos.OrganizationDao
+	instructionDataDao daos.InstructionDataDao
+	reviewDao          daos.ReviewDao
+	reAuditDao         daos.ReAuditDao
+	loginLogDao        daos.LoginLogDao
+	operationLogDao    daos.OperationLogDao
+	cache              *dao.Cache
+	mailer             mailer.Mailer
+	storage            storage.Storage
 }
 
-func NewProfileService(core *service.Core, userDao dao.UserDao) ProfileService {
+func NewProfileService(
+	core *service.Core, userDao daos.UserDao, organizationDao daos.OrganizationDao,
+	instructionDataDao daos.InstructionDataDao, reviewDao daos.ReviewDao, reAuditDao daos.ReAuditDao,
+	loginLogDao daos.LoginLogDao, operationLogDao daos.OperationLogDao, cache *dao.Cache, mailer mailer.Mailer,
+	storage storage.Storage,
+) ProfileService {
 	return &profileServiceImpl{
-		core:    core,
-		userDao: userDao,
+		core:               core,
+		userDao:            userDao,
+		organizationDao:    organizationDao,
+		instructionDataDao: instructionDataDao,
+		reviewDao:          reviewDao,
+		reAuditDao:         reAuditDao,
+		loginLogDao:        loginLogDao,
+		operationLogDao:    operationLogDao,
+		cache:              cache,
+		mailer:             mailer,
+		storage:            storage,
 	}
 }
 
+// emailChange is the pending email change a confirmation token is issued for, kept in the cache.
+type emailChange struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// avatarExtensions are the image types accepted as avatars, by the content type sniffed from the image.
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
 func (p profileServiceImpl) GetProfile(ctx context.Context) (*common.GetProfileResponse, error) {
-	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var avatar string
+	if user.Avatar != "" {
+		avatar = p.storage.URL(user.Avatar)
+	}
+	return &common.GetProfileResponse{
+		UserID:        user.UserID.Hex(),
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          user.Role,
+		Organization:  user.Organization,
+		Avatar:        avatar,
+		LastLogin:     user.LastLogin.Format(time.RFC3339),
+		Impersonation: p.profileImpersonation(ctx),
+	}, nil
+}
+
+// UpdateProfile changes the username and organization of the current user. The username must not be taken. The
+// members of the organizations managed as tenants are managed by the admins, so the user can neither join nor leave
+// one of them.
+func (p profileServiceImpl) UpdateProfile(ctx context.Context, username, organization *string) error {
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if username != nil && *username == user.Username {
+		username = nil
+	}
+	if organization != nil && *organization == user.Organization {
+		organization = nil
+	}
+	if username == nil && organization == nil {
+		return nil
+	}
+	if username != nil {
+		if _, err = p.userDao.GetUserByUsername(ctx, *username); err == nil {
+			return errors.DuplicateKeyError(fmt.Errorf("username %s is already taken", *username))
+		} else if !e.Is(err, mongo.ErrNoDocuments) {
+			return errors.OperationFailed(fmt.Errorf("failed to get user by username"))
+		}
+	}
+	if organization != nil {
+		for _, name := range []string{user.Organization, *organization} {
+			if err = p.checkSelfManagedOrganization(ctx, name); err != nil {
+				return err
+			}
+		}
+	}
+	if err = p.userDao.UpdateUser(ctx, user.UserID, username, nil, nil, nil, organization); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.DuplicateKeyError(fmt.Errorf("username %s is already taken", *username))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", user.UserID.Hex()))
+	}
+	return nil
+}
+
+// ChangeEmail mails a single-use confirmation token to the new email of the current user. The email is only changed
+// once the token is confirmed, and must not be taken.
+func (p profileServiceImpl) ChangeEmail(ctx context.Context, email *string) error {
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if *email == user.Email {
+		return errors.InvalidRequest(fmt.Errorf("email is not changed"))
+	}
+	if err = p.checkEmailAvailable(ctx, *email); err != nil {
+		return err
+	}
+	token, err := crypt.RandomToken(32)
+	if err != nil {
+		p.core.Logger.Error("failed to generate email change token", zap.Error(err))
+		return errors.ServiceError(fmt.Errorf("failed to generate email change token"))
+	}
+	change, _ := json.Marshal(emailChange{UserID: user.UserID.Hex(), Email: *email})
+	ttl := p.core.Config.ProfileConfig.EmailChangeTokenTTL
+	if err = p.cache.Set(
+		ctx, fmt.Sprintf("%s:%s", config.EmailChangeCachePrefix, crypt.MD5(token)), string(change), &ttl,
+	); err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to save email change token"))
+	}
+	body := fmt.Sprintf(
+		"Hi %s,\n\nA change of the email of your account to this address was requested. Open the link below to "+
+			"confirm it, it is valid for %s and can be used once:\n\n%s\n\nIf you did not request it, please ignore "+
+			"this mail.\n",
+		user.Username, ttl, fmt.Sprintf(p.core.Config.ProfileConfig.EmailChangeURL, token),
+	)
+	if err = p.mailer.Send(ctx, *email, "Confirm your new email", body); err != nil {
+		p.core.Logger.Error(
+			"failed to send email change mail", zap.Error(err), zap.String("userID", user.UserID.Hex()),
+		)
+		return errors.ServiceError(fmt.Errorf("failed to send email change mail"))
+	}
+	return nil
+}
+
+// ConfirmEmail changes the email of the current user to the one the confirmation token was issued for, and notifies
+// the previous email. The token is consumed whether or not the change succeeds. Returns the previous email.
+func (p profileServiceImpl) ConfirmEmail(ctx context.Context, token *string) (string, error) {
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	value, err := p.cache.GetDelete(ctx, fmt.Sprintf("%s:%s", config.EmailChangeCachePrefix, crypt.MD5(*token)))
+	if err != nil {
+		if e.Is(err, p.cache.Nil) {
+			return "", errors.InvalidRequest(fmt.Errorf("email change token invalid or expired"))
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to get email change token"))
+	}
+	var change emailChange
+	if err = json.Unmarshal([]byte(*value), &change); err != nil || change.UserID != user.UserID.Hex() {
+		return "", errors.InvalidRequest(fmt.Errorf("email change token invalid or expired"))
+	}
+	if err = p.checkEmailAvailable(ctx, change.Email); err != nil {
+		return "", err
+	}
+	if err = p.userDao.UpdateUser(ctx, user.UserID, nil, &change.Email, nil, nil, nil); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", errors.DuplicateKeyError(fmt.Errorf("email %s is already taken", change.Email))
+		}
+		return "", errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", user.UserID.Hex()))
+	}
+	body := fmt.Sprintf(
+		"Hi %s,\n\nThe email of your account was changed to %s. If you did not change it, please contact an "+
+			"admin.\n",
+		user.Username, change.Email,
+	)
+	if err = p.mailer.Send(ctx, user.Email, "Your email was changed", body); err != nil {
+		// The change is done, failing to notify the previous email should not report it as failed
+		p.core.Logger.Error(
+			"failed to send email changed mail", zap.Error(err), zap.String("userID", user.UserID.Hex()),
+		)
+	}
+	return user.Email, nil
+}
+
+// UpdateAvatar stores the image as the avatar of the current user, replacing the previous one.
+// Returns the URL of the avatar.
+func (p profileServiceImpl) UpdateAvatar(ctx context.Context, data []byte) (*common.UpdateAvatarResponse, error) {
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize := p.core.Config.ProfileConfig.AvatarMaxSize; int64(len(data)) > maxSize {
+		return nil, errors.InvalidRequest(fmt.Errorf("avatar should not exceed %d bytes", maxSize))
+	}
+	ext, ok := avatarExtensions[http.DetectContentType(data)]
 	if !ok {
-		return nil, errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+		return nil, errors.InvalidRequest(fmt.Errorf("avatar should be a PNG, JPEG, GIF or WebP image"))
+	}
+	suffix, err := crypt.RandomToken(8)
+	if err != nil {
+		p.core.Logger.Error("failed to generate avatar name", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate avatar name"))
+	}
+	// A new name for each upload, so that caches never serve the previous avatar
+	name := fmt.Sprintf("avatar/%s-%s%s", user.UserID.Hex(), suffix, ext)
+	if err = p.storage.Put(ctx, name, data); err != nil {
+		p.core.Logger.Error("failed to store avatar", zap.Error(err), zap.String("userID", user.UserID.Hex()))
+		return nil, errors.ServiceError(fmt.Errorf("failed to store avatar"))
+	}
+	if err = p.userDao.UpdateUserAvatar(ctx, user.UserID, name); err != nil {
+		_ = p.storage.Delete(ctx, name)
+		return nil, errors.OperationFailed(fmt.Errorf("failed to update user (id: %s)", user.UserID.Hex()))
+	}
+	if user.Avatar != "" {
+		if err = p.storage.Delete(ctx, user.Avatar); err != nil {
+			p.core.Logger.Error(
+				"failed to delete previous avatar", zap.Error(err), zap.String("avatar", user.Avatar),
+			)
+		}
+	}
+	return &common.UpdateAvatarResponse{Avatar: p.storage.URL(name)}, nil
+}
+
+// ExportPersonalData returns a zip archive of the personal data held about the current user.
+func (p profileServiceImpl) ExportPersonalData(ctx context.Context) ([]byte, error) {
+	user, err := p.currentUser(ctx)
+	if err != nil {
+		return nil, err
 	}
-	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	return service.ExportPersonalData(
+		ctx, user, p.storage, p.instructionDataDao, p.reviewDao, p.reAuditDao, p.loginLogDao, p.operationLogDao,
+	)
+}
+
+func (p profileServiceImpl) currentUser(ctx context.Context) (*entity.UserModel, error) {
+	userID, err := service.CurrentUserID(ctx)
 	if err != nil {
-		return nil, errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+		return nil, err
 	}
 	user, err := p.userDao.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, errors.NotAuthorized(fmt.Errorf("user not exist"))
 	}
-	return &common.GetProfileResponse{
-		UserID:       user.UserID.Hex(),
-		Username:     user.Username,
-		Email:        user.Email,
-		Role:         user.Role,
-		Organization: user.Organization,
-		LastLogin:    user.LastLogin.Format(time.RFC3339),
-	}, nil
+	return user, nil
+}
+
+// checkEmailAvailable rejects the email if another user has it.
+func (p profileServiceImpl) checkEmailAvailable(ctx context.Context, email string) error {
+	if _, err := p.userDao.GetUserByEmail(ctx, email); err == nil {
+		return errors.DuplicateKeyError(fmt.Errorf("email %s is already taken", email))
+	} else if !e.Is(err, mongo.ErrNoDocuments) {
+		return errors.OperationFailed(fmt.Errorf("failed to get user by email"))
+	}
+	return nil
+}
+
+// checkSelfManagedOrganization rejects the organization if it is managed as a tenant.
+func (p profileServiceImpl) checkSelfManagedOrganization(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := p.organizationDao.GetOrganizationByName(ctx, name); err == nil {
+		return errors.PermissionDeny(fmt.Errorf("members of organization %s are managed by its admins", name))
+	} else if !e.Is(err, mongo.ErrNoDocuments) {
+		return errors.OperationFailed(fmt.Errorf("failed to get organization %s", name))
+	}
+	return nil
+}
+
+// profileImpersonation returns the impersonation banner of the request, nil if it is not impersonated.
+func (p profileServiceImpl) profileImpersonation(ctx context.Context) *common.ProfileImpersonation {
+	impersonation := service.ImpersonationOf(ctx)
+	if impersonation == nil {
+		return nil
+	}
+	resp := &common.ProfileImpersonation{
+		ImpersonatorID: impersonation.ImpersonatorID,
+		ReadOnly:       impersonation.ReadOnly,
+		ExpiresAt:      impersonation.ExpiresAt.Format(time.RFC3339),
+	}
+	if impersonatorID, err := primitive.ObjectIDFromHex(impersonation.ImpersonatorID); err == nil {
+		if impersonator, err := p.userDao.GetUserByID(ctx, impersonatorID); err == nil {
+			resp.ImpersonatorUsername = impersonator.Username
+		}
+	}
+	return resp
 }
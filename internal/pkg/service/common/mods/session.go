@@ -0,0 +1,120 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionService interface {
+	GetSessionList(ctx context.Context) (*common.GetSessionListResponse, error)
+	DeleteSession(ctx context.Context, sessionID *primitive.ObjectID) error
+	DeleteSessionList(ctx context.Context, exceptCurrent bool) (int64, error)
+}
+
+type sessionServiceImpl struct {
+	core       *service.Core
+	sessionDao daos.SessionDao
+}
+
+func NewSessionService(core *service.Core, sessionDao daos.SessionDao) SessionService {
+	return &sessionServiceImpl{
+		core:       core,
+		sessionDao: sessionDao,
+	}
+}
+
+// GetSessionList returns the active sessions of the current user, the most recently seen first.
+func (s sessionServiceImpl) GetSessionList(ctx context.Context) (*common.GetSessionListResponse, error) {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessionList, err := s.sessionDao.GetSessionList(ctx, userID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get session list"))
+	}
+	currentSessionID, _ := ctx.Value(config.SessionIDKey).(string)
+	resp := make([]*common.GetSessionResponse, 0, len(sessionList))
+	for _, session := range sessionList {
+		resp = append(
+			resp, &common.GetSessionResponse{
+				SessionID:  session.SessionID.Hex(),
+				IPAddress:  session.IPAddress,
+				UserAgent:  session.UserAgent,
+				Current:    session.SessionID.Hex() == currentSessionID,
+				CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+				LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+				ExpiresAt:  session.ExpiresAt.Format(time.RFC3339),
+			},
+		)
+	}
+	return &common.GetSessionListResponse{SessionList: resp}, nil
+}
+
+// DeleteSession revokes a session of the current user. Its tokens stop working at once.
+func (s sessionServiceImpl) DeleteSession(ctx context.Context, sessionID *primitive.ObjectID) error {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if err = s.sessionDao.DeleteSession(ctx, userID, *sessionID); err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return errors.NotFound(fmt.Errorf("session (id: %s) not found", sessionID.Hex()))
+		}
+		return errors.OperationFailed(fmt.Errorf("failed to delete session (id: %s)", sessionID.Hex()))
+	}
+	return nil
+}
+
+// DeleteSessionList revokes the sessions of the current user, except the current one if exceptCurrent is set.
+// Returns the number of revoked sessions.
+func (s sessionServiceImpl) DeleteSessionList(ctx context.Context, exceptCurrent bool) (int64, error) {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var exceptSessionID *primitive.ObjectID
+	if exceptCurrent {
+		currentSessionID, ok := ctx.Value(config.SessionIDKey).(string)
+		if !ok {
+			return 0, errors.InvalidRequest(fmt.Errorf("current request is not made within a session"))
+		}
+		sessionID, err := primitive.ObjectIDFromHex(currentSessionID)
+		if err != nil {
+			return 0, errors.InvalidRequest(fmt.Errorf("current request is not made within a session"))
+		}
+		exceptSessionID = &sessionID
+	}
+	count, err := s.sessionDao.DeleteSessionList(ctx, userID, exceptSessionID)
+	if err != nil {
+		return 0, errors.OperationFailed(fmt.Errorf("failed to delete session list"))
+	}
+	return count, nil
+}
+
+// newSession creates the session of a login, on the device found in the context, lasting as long as its first
+// refresh token.
+func newSession(
+	ctx context.Context, core *service.Core, sessionDao daos.SessionDao, userID primitive.ObjectID,
+	refreshTokenID string,
+) (primitive.ObjectID, error) {
+	ipAddress, _ := ctx.Value(config.IPAddressKey).(string)
+	userAgent, _ := ctx.Value(config.UserAgentKey).(string)
+	sessionID, err := sessionDao.InsertSession(
+		ctx, userID, ipAddress, userAgent, refreshTokenID, time.Now().Add(core.Config.JWTConfig.RefreshDuration),
+	)
+	if err != nil {
+		return primitive.NilObjectID, errors.OperationFailed(fmt.Errorf("failed to create session"))
+	}
+	return sessionID, nil
+}
@@ -0,0 +1,76 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"github.com/goccy/go-json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ThemeService interface {
+	GetTheme(ctx context.Context, themeID *primitive.ObjectID) (*common.GetThemeResponse, error)
+	GetThemeList(ctx context.Context, page, pageSize *int64, query *string) (*common.GetThemeListResponse, error)
+}
+
+type themeServiceImpl struct {
+	core     *service.Core
+	themeDao dao.ThemeDao
+}
+
+func NewThemeService(core *service.Core, themeDao dao.ThemeDao) ThemeService {
+	return &themeServiceImpl{
+		core:     core,
+		themeDao: themeDao,
+	}
+}
+
+func (t themeServiceImpl) GetTheme(ctx context.Context, themeID *primitive.ObjectID) (*common.GetThemeResponse, error) {
+	theme, err := t.themeDao.GetThemeByID(ctx, *themeID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("theme (id: %s) not found", themeID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get theme (id: %s)", themeID.Hex()))
+	}
+	return toThemeResponse(theme), nil
+}
+
+func (t themeServiceImpl) GetThemeList(
+	ctx context.Context, page, pageSize *int64, query *string,
+) (*common.GetThemeListResponse, error) {
+	offset := (*page - 1) * *pageSize
+	themes, count, err := t.themeDao.GetThemeList(ctx, offset, *pageSize, false, query)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get theme list"))
+	}
+	resp := make([]*common.GetThemeResponse, 0, len(themes))
+	for i := range themes {
+		resp = append(resp, toThemeResponse(&themes[i]))
+	}
+	return &common.GetThemeListResponse{
+		Total:     *count,
+		ThemeList: resp,
+	}, nil
+}
+
+func toThemeResponse(theme *entity.ThemeModel) *common.GetThemeResponse {
+	var metadataSchema map[string]interface{}
+	_ = json.Unmarshal([]byte(theme.Schema), &metadataSchema) // The schema has been validated on insert
+	return &common.GetThemeResponse{
+		ThemeID:     theme.ThemeID.Hex(),
+		Name:        theme.Name,
+		Description: theme.Description,
+		Schema:      metadataSchema,
+		CreatedAt:   theme.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   theme.UpdatedAt.Format(time.RFC3339),
+	}
+}
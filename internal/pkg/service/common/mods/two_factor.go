@@ -0,0 +1,403 @@
+package mods
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	daos "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/internal/pkg/domain/vo/common"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// recoveryCodeBytes is the number of random bytes of a recovery code, written in hex.
+const recoveryCodeBytes = 10
+
+type TwoFactorService interface {
+	Enroll(ctx context.Context, challengeToken *string) (*common.EnrollTwoFactorResponse, error)
+	Confirm(ctx context.Context, challengeToken, code *string) (*common.ConfirmTwoFactorResponse, error)
+	Verify(ctx context.Context, challengeToken, code, recoveryCode *string) (*common.LoginResponse, error)
+	Disable(ctx context.Context, password, code *string) error
+}
+
+type twoFactorServiceImpl struct {
+	core               *service.Core
+	cache              *dao.Cache
+	userDao            daos.UserDao
+	twoFactorPolicyDao daos.TwoFactorPolicyDao
+	jwt                *jwt.Jwt
+	sessionDao         daos.SessionDao
+}
+
+func NewTwoFactorService(
+	core *service.Core, userDao daos.UserDao, twoFactorPolicyDao daos.TwoFactorPolicyDao, cache *dao.Cache,
+	jwt *jwt.Jwt, sessionDao daos.SessionDao,
+) TwoFactorService {
+	return &twoFactorServiceImpl{
+		core:               core,
+		cache:              cache,
+		userDao:            userDao,
+		twoFactorPolicyDao: twoFactorPolicyDao,
+		jwt:                jwt,
+		sessionDao:         sessionDao,
+	}
+}
+
+// Enroll generates a pending TOTP secret for the user, which is enabled once confirmed with a code. The user is
+// the one of the login challenge if given, otherwise the current user.
+func (t twoFactorServiceImpl) Enroll(
+	ctx context.Context, challengeToken *string,
+) (*common.EnrollTwoFactorResponse, error) {
+	var (
+		user *entity.UserModel
+		err  error
+	)
+	if challengeToken != nil {
+		user, err = t.peekChallenge(ctx, *challengeToken)
+	} else {
+		user, err = t.currentUser(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor.Enabled {
+		return nil, errors.InvalidRequest(fmt.Errorf("two-factor authentication already enabled"))
+	}
+	secret, err := crypt.GenerateTOTPSecret()
+	if err != nil {
+		t.core.Logger.Error("failed to generate TOTP secret", zap.Error(err))
+		return nil, errors.ServiceError(fmt.Errorf("failed to generate TOTP secret"))
+	}
+	if err = t.cache.Set(
+		ctx, enrollmentKey(user.UserID), secret, &t.core.Config.TwoFactorConfig.EnrollmentTTL,
+	); err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to save pending enrollment"))
+	}
+	return &common.EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: crypt.TOTPProvisioningURI(t.core.Config.TwoFactorConfig.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables the pending TOTP secret of the user if the code matches, and returns the recovery codes. With a
+// login challenge, the login is completed as well.
+func (t twoFactorServiceImpl) Confirm(
+	ctx context.Context, challengeToken, code *string,
+) (*common.ConfirmTwoFactorResponse, error) {
+	var (
+		user     *entity.UserModel
+		attempts int
+		err      error
+	)
+	if challengeToken != nil {
+		user, attempts, err = t.takeChallenge(ctx, *challengeToken)
+	} else {
+		user, err = t.currentUser(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor.Enabled {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts-1)
+		return nil, errors.InvalidRequest(fmt.Errorf("two-factor authentication already enabled"))
+	}
+	secret, err := t.cache.Get(ctx, enrollmentKey(user.UserID))
+	if err != nil {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts)
+		if e.Is(err, t.cache.Nil) {
+			return nil, errors.InvalidRequest(fmt.Errorf("no pending enrollment, please enroll first"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get pending enrollment"))
+	}
+	if !t.checkCode(ctx, user.UserID, *secret, *code) {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts)
+		return nil, errors.AuthFailed(fmt.Errorf("code wrong"))
+	}
+
+	recoveryCodes := make([]string, 0, t.core.Config.TwoFactorConfig.RecoveryCodeCount)
+	recoveryCodeHashes := make([]string, 0, t.core.Config.TwoFactorConfig.RecoveryCodeCount)
+	for i := 0; i < t.core.Config.TwoFactorConfig.RecoveryCodeCount; i++ {
+		recoveryCode, err := crypt.RandomToken(recoveryCodeBytes)
+		if err != nil {
+			t.core.Logger.Error("failed to generate recovery code", zap.Error(err))
+			return nil, errors.ServiceError(fmt.Errorf("failed to generate recovery code"))
+		}
+		recoveryCodeHash, err := crypt.Hash(recoveryCode)
+		if err != nil {
+			t.core.Logger.Error("failed to hash recovery code", zap.Error(err))
+			return nil, errors.ServiceError(fmt.Errorf("failed to hash recovery code"))
+		}
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		recoveryCodeHashes = append(recoveryCodeHashes, recoveryCodeHash)
+	}
+	if err = t.userDao.EnableUserTwoFactor(ctx, user.UserID, *secret, recoveryCodeHashes); err != nil {
+		return nil, errors.OperationFailed(
+			fmt.Errorf("failed to enable two-factor authentication (id: %s)", user.UserID.Hex()),
+		)
+	}
+	if err = t.cache.Delete(ctx, enrollmentKey(user.UserID)); err != nil {
+		t.core.Logger.Error("failed to delete pending enrollment", zap.Error(err))
+	}
+
+	resp := &common.ConfirmTwoFactorResponse{RecoveryCodes: recoveryCodes}
+	if challengeToken != nil {
+		if resp.Login, err = issueLoginResponse(ctx, t.core, t.jwt, t.userDao, t.sessionDao, user); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Verify completes the login challenge with a TOTP code or an unused recovery code. After too many wrong codes the
+// challenge is dropped and the password has to be entered again.
+func (t twoFactorServiceImpl) Verify(
+	ctx context.Context, challengeToken, code, recoveryCode *string,
+) (*common.LoginResponse, error) {
+	user, attempts, err := t.takeChallenge(ctx, *challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactor.Enabled {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts-1)
+		return nil, errors.InvalidRequest(fmt.Errorf("two-factor authentication not enabled, please enroll first"))
+	}
+	twoFactor, err := t.getTwoFactor(ctx, user.UserID)
+	if err != nil {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts-1)
+		return nil, err
+	}
+	if code != nil {
+		if !t.checkCode(ctx, user.UserID, twoFactor.Secret, *code) {
+			t.putBackChallenge(ctx, challengeToken, user.UserID, attempts)
+			return nil, errors.AuthFailed(fmt.Errorf("code wrong"))
+		}
+	} else if err = t.useRecoveryCode(ctx, user.UserID, twoFactor, *recoveryCode); err != nil {
+		t.putBackChallenge(ctx, challengeToken, user.UserID, attempts)
+		return nil, err
+	}
+	return issueLoginResponse(ctx, t.core, t.jwt, t.userDao, t.sessionDao, user)
+}
+
+// Disable disables 2FA of the current user, unless it is enforced for the role of the user.
+func (t twoFactorServiceImpl) Disable(ctx context.Context, password, code *string) error {
+	user, err := t.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactor.Enabled {
+		return errors.InvalidRequest(fmt.Errorf("two-factor authentication not enabled"))
+	}
+	enforced, err := twoFactorEnforced(ctx, t.twoFactorPolicyDao, user.Role)
+	if err != nil {
+		return err
+	}
+	if enforced {
+		return errors.PermissionDeny(fmt.Errorf("two-factor authentication is enforced for role %s", user.Role))
+	}
+	if !crypt.Compare(*password, user.Password) {
+		return errors.AuthFailed(fmt.Errorf("password wrong"))
+	}
+	twoFactor, err := t.getTwoFactor(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	if !t.checkCode(ctx, user.UserID, twoFactor.Secret, *code) {
+		return errors.AuthFailed(fmt.Errorf("code wrong"))
+	}
+	if err = t.userDao.DisableUserTwoFactor(ctx, user.UserID); err != nil {
+		return errors.OperationFailed(
+			fmt.Errorf("failed to disable two-factor authentication (id: %s)", user.UserID.Hex()),
+		)
+	}
+	return nil
+}
+
+func (t twoFactorServiceImpl) currentUser(ctx context.Context) (*entity.UserModel, error) {
+	userID, err := service.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.getUser(ctx, userID)
+}
+
+func (t twoFactorServiceImpl) getUser(ctx context.Context, userID primitive.ObjectID) (*entity.UserModel, error) {
+	user, err := t.userDao.GetUserByID(ctx, userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get user (id: %s)", userID.Hex()))
+	}
+	return user, nil
+}
+
+// peekChallenge returns the user of the login challenge without consuming it.
+func (t twoFactorServiceImpl) peekChallenge(ctx context.Context, challengeToken string) (*entity.UserModel, error) {
+	value, err := t.cache.Get(ctx, challengeKey(challengeToken))
+	if err != nil {
+		if e.Is(err, t.cache.Nil) {
+			return nil, errors.AuthFailed(fmt.Errorf("challenge token invalid or expired"))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get challenge token"))
+	}
+	userID, _, err := parseChallenge(*value)
+	if err != nil {
+		return nil, errors.AuthFailed(fmt.Errorf("challenge token invalid or expired"))
+	}
+	return t.getUser(ctx, userID)
+}
+
+// takeChallenge consumes the login challenge and returns its user and the number of attempts made so far,
+// including this one. Use putBackChallenge to allow another attempt.
+func (t twoFactorServiceImpl) takeChallenge(
+	ctx context.Context, challengeToken string,
+) (*entity.UserModel, int, error) {
+	value, err := t.cache.GetDelete(ctx, challengeKey(challengeToken))
+	if err != nil {
+		if e.Is(err, t.cache.Nil) {
+			return nil, 0, errors.AuthFailed(fmt.Errorf("challenge token invalid or expired"))
+		}
+		return nil, 0, errors.OperationFailed(fmt.Errorf("failed to get challenge token"))
+	}
+	userID, attempts, err := parseChallenge(*value)
+	if err != nil {
+		return nil, 0, errors.AuthFailed(fmt.Errorf("challenge token invalid or expired"))
+	}
+	user, err := t.getUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return user, attempts + 1, nil
+}
+
+// putBackChallenge restores a challenge taken by takeChallenge, unless it has run out of attempts.
+func (t twoFactorServiceImpl) putBackChallenge(
+	ctx context.Context, challengeToken *string, userID primitive.ObjectID, attempts int,
+) {
+	if challengeToken == nil || attempts >= t.core.Config.TwoFactorConfig.ChallengeMaxAttempts {
+		return
+	}
+	if err := t.cache.Set(
+		ctx, challengeKey(*challengeToken), fmt.Sprintf("%s:%d", userID.Hex(), attempts),
+		&t.core.Config.TwoFactorConfig.ChallengeTTL,
+	); err != nil {
+		t.core.Logger.Error("failed to put back challenge token", zap.Error(err))
+	}
+}
+
+// checkCode validates the TOTP code and rejects codes already used by the user within the accepted time steps.
+func (t twoFactorServiceImpl) checkCode(ctx context.Context, userID primitive.ObjectID, secret, code string) bool {
+	if !crypt.ValidateTOTP(secret, code, time.Now(), t.core.Config.TwoFactorConfig.Skew) {
+		return false
+	}
+	key := fmt.Sprintf("%s:used:%s:%s", config.TwoFactorCachePrefix, userID.Hex(), code)
+	ttl := time.Duration(2*t.core.Config.TwoFactorConfig.Skew+1) * 30 * time.Second
+	// marking the code used and checking it was not are one step, so that concurrent requests can not share a code
+	unused, err := t.cache.SetNX(ctx, key, config.CacheTrue, &ttl)
+	if err != nil {
+		t.core.Logger.Error("failed to mark code as used", zap.Error(err))
+		return false
+	}
+	return unused
+}
+
+// getTwoFactor returns the TOTP secret and recovery codes of the user, read from the database as they are not cached.
+func (t twoFactorServiceImpl) getTwoFactor(
+	ctx context.Context, userID primitive.ObjectID,
+) (*entity.TwoFactorModel, error) {
+	twoFactor, err := t.userDao.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", userID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get two-factor authentication (id: %s)", userID.Hex()))
+	}
+	return twoFactor, nil
+}
+
+// useRecoveryCode consumes the recovery code if it matches one of the unused recovery codes of the user. A code used
+// by a concurrent request is not found any more.
+func (t twoFactorServiceImpl) useRecoveryCode(
+	ctx context.Context, userID primitive.ObjectID, twoFactor *entity.TwoFactorModel, recoveryCode string,
+) error {
+	for _, recoveryCodeHash := range twoFactor.RecoveryCodes {
+		if !crypt.Compare(recoveryCode, recoveryCodeHash) {
+			continue
+		}
+		if err := t.userDao.UseUserRecoveryCode(ctx, userID, recoveryCodeHash); err != nil {
+			if e.Is(err, qmgo.ErrNoSuchDocuments) {
+				return errors.AuthFailed(fmt.Errorf("recovery code wrong or used"))
+			}
+			return errors.OperationFailed(fmt.Errorf("failed to use recovery code"))
+		}
+		return nil
+	}
+	return errors.AuthFailed(fmt.Errorf("recovery code wrong or used"))
+}
+
+// newTwoFactorChallenge issues the challenge token which completes the login of the user with a TOTP code.
+func newTwoFactorChallenge(
+	ctx context.Context, core *service.Core, cache *dao.Cache, userID primitive.ObjectID,
+) (string, error) {
+	challengeToken, err := crypt.RandomToken(32)
+	if err != nil {
+		core.Logger.Error("failed to generate challenge token", zap.Error(err))
+		return "", errors.ServiceError(fmt.Errorf("failed to generate challenge token"))
+	}
+	if err = cache.Set(
+		ctx, challengeKey(challengeToken), fmt.Sprintf("%s:%d", userID.Hex(), 0),
+		&core.Config.TwoFactorConfig.ChallengeTTL,
+	); err != nil {
+		return "", errors.OperationFailed(fmt.Errorf("failed to save challenge token"))
+	}
+	return challengeToken, nil
+}
+
+// twoFactorEnforced reports whether 2FA is enforced for the role. Roles without a policy are not enforced.
+func twoFactorEnforced(ctx context.Context, twoFactorPolicyDao daos.TwoFactorPolicyDao, role string) (bool, error) {
+	policy, err := twoFactorPolicyDao.GetTwoFactorPolicyByRole(ctx, role)
+	if err != nil {
+		if e.Is(err, qmgo.ErrNoSuchDocuments) {
+			return false, nil
+		}
+		return false, errors.OperationFailed(fmt.Errorf("failed to get two-factor policy of role %s", role))
+	}
+	return policy.Enforced, nil
+}
+
+func challengeKey(challengeToken string) string {
+	return fmt.Sprintf("%s:challenge:%s", config.TwoFactorCachePrefix, crypt.MD5(challengeToken))
+}
+
+func enrollmentKey(userID primitive.ObjectID) string {
+	return fmt.Sprintf("%s:enrollment:%s", config.TwoFactorCachePrefix, userID.Hex())
+}
+
+func parseChallenge(value string) (primitive.ObjectID, int, error) {
+	userIDHex, attemptsStr, ok := strings.Cut(value, ":")
+	if !ok {
+		return primitive.NilObjectID, 0, fmt.Errorf("malformed challenge %s", value)
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return primitive.NilObjectID, 0, err
+	}
+	attempts, err := strconv.Atoi(attemptsStr)
+	if err != nil {
+		return primitive.NilObjectID, 0, err
+	}
+	return userID, attempts, nil
+}
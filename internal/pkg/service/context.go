@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CurrentUserID returns the ID of the user who sent the request, set in the context by the auth middleware.
+func CurrentUserID(ctx context.Context) (primitive.ObjectID, error) {
+	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return primitive.NilObjectID, errors.NotAuthorized(fmt.Errorf("user id not found in context"))
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return primitive.NilObjectID, errors.NotAuthorized(fmt.Errorf("user id invalid"))
+	}
+	return userID, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+)
+
+// Impersonation is the impersonation of the user of a request by an admin, read from the claims of its token.
+type Impersonation struct {
+	ImpersonatorID string
+	ReadOnly       bool
+	ExpiresAt      time.Time
+}
+
+// ImpersonationOf returns the impersonation the request is made under, nil if it is not impersonated.
+func ImpersonationOf(ctx context.Context) *Impersonation {
+	if impersonation, ok := ctx.Value(config.ImpersonationKey).(*Impersonation); ok {
+		return impersonation
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"github.com/casbin/casbin/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrMultipleOrganizationAdminDomains is returned for a user who is an organization admin of more than one
+// organization, which organization admins can not be.
+var ErrMultipleOrganizationAdminDomains = e.New("organization admin of more than one organization")
+
+// OrganizationAdminDomain returns the ID of the organization the user is an organization admin of, the domain of its
+// ORG_ADMIN grouping, or primitive.NilObjectID if the user is not an organization admin. The casbin matcher does not
+// enforce the domain, callers scope the requests of the user to the organization themselves.
+func OrganizationAdminDomain(enforcer *casbin.Enforcer, userID string) (primitive.ObjectID, error) {
+	groupings, err := enforcer.GetFilteredNamedGroupingPolicy("g2", 0, userID, config.UserRoleOrgAdmin)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	domain := primitive.NilObjectID
+	for _, grouping := range groupings {
+		organizationID, err := primitive.ObjectIDFromHex(grouping[2])
+		if err != nil {
+			return primitive.NilObjectID, fmt.Errorf("invalid organization admin domain %q", grouping[2])
+		}
+		if domain != primitive.NilObjectID && domain != organizationID {
+			return primitive.NilObjectID, ErrMultipleOrganizationAdminDomains
+		}
+		domain = organizationID
+	}
+	return domain, nil
+}
+
+// OrganizationScope returns the organization the request is restricted to, nil if it is not restricted.
+func OrganizationScope(ctx context.Context) *string {
+	if organization, ok := ctx.Value(config.OrganizationKey).(string); ok {
+		return &organization
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/pkg/utils/password"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	commonPasswords     = make(map[string]map[string]struct{}) // by file, loaded once
+	commonPasswordsLock sync.Mutex
+)
+
+// ValidatePassword validates a new password of the user against the password policy. The error names the request
+// field of the password.
+func ValidatePassword(cfg *config.Config, field, newPassword, username, email string) error {
+	policyConfig := cfg.PasswordPolicyConfig
+	common, err := loadCommonPasswords(policyConfig.CommonPasswordFile)
+	if err != nil {
+		return errors.ServiceError(fmt.Errorf("failed to load common passwords"))
+	}
+	policy := password.Policy{
+		MinLength:       policyConfig.MinLength,
+		RequireUpper:    policyConfig.RequireUpper,
+		RequireLower:    policyConfig.RequireLower,
+		RequireDigit:    policyConfig.RequireDigit,
+		RequireSpecial:  policyConfig.RequireSpecial,
+		CommonPasswords: common,
+	}
+	if err = policy.Validate(newPassword, username, email); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("failed to validate request[field: %s] %s", field, err.Error()))
+	}
+	return nil
+}
+
+// GeneratePassword returns a random initial password of the user following the password policy, at least 16
+// characters long.
+func GeneratePassword(cfg *config.Config, username, email string) (string, error) {
+	for attempt := 0; attempt < 10; attempt++ {
+		generated, err := password.Generate(max(cfg.PasswordPolicyConfig.MinLength, 16))
+		if err != nil {
+			return "", errors.ServiceError(fmt.Errorf("failed to generate password"))
+		}
+		if err = ValidatePassword(cfg, "password", generated, username, email); err == nil {
+			return generated, nil
+		}
+	}
+	return "", errors.ServiceError(fmt.Errorf("failed to generate a password following the password policy"))
+}
+
+// ValidatePasswordChange validates a new password of the user against the password policy, and rejects the current
+// password and the previous ones kept in the history, which is read from the database as it is not cached. Returns
+// the history to store along with the new password.
+func ValidatePasswordChange(
+	ctx context.Context, cfg *config.Config, userDao dao.UserDao, field, newPassword string, user *entity.UserModel,
+) ([]string, error) {
+	if err := ValidatePassword(cfg, field, newPassword, user.Username, user.Email); err != nil {
+		return nil, err
+	}
+	historySize := cfg.PasswordPolicyConfig.HistorySize
+	if historySize <= 0 {
+		return nil, nil
+	}
+	passwordHistory, err := userDao.GetUserPasswordHistory(ctx, user.UserID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NotFound(fmt.Errorf("user (id: %s) not found", user.UserID.Hex()))
+		}
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get password history of user"))
+	}
+	recent := append([]string{user.Password}, passwordHistory...)
+	if len(recent) > historySize {
+		recent = recent[:historySize]
+	}
+	for _, passwordHash := range recent {
+		if crypt.Compare(newPassword, passwordHash) {
+			return nil, errors.InvalidRequest(
+				fmt.Errorf(
+					"failed to validate request[field: %s] must not be one of the last %d passwords", field,
+					historySize,
+				),
+			)
+		}
+	}
+	return recent[:min(len(recent), historySize-1)], nil
+}
+
+// IsPasswordExpired reports whether the password of the user is older than the maximum age of the password policy.
+// Users created before passwords were dated count from their creation. The passwords of users signing in through an
+// external identity source (OIDC provider, LDAP directory) never expire, as they are not used.
+func IsPasswordExpired(cfg *config.Config, user *entity.UserModel) bool {
+	maxAge := cfg.PasswordPolicyConfig.MaxAge
+	if maxAge <= 0 || user.OIDC.Subject != "" || user.LDAP.DN != "" {
+		return false
+	}
+	changedAt := user.PasswordChangedAt
+	if changedAt.IsZero() {
+		changedAt = user.CreatedAt
+	}
+	return !changedAt.IsZero() && time.Since(changedAt) > maxAge
+}
+
+func loadCommonPasswords(path string) (map[string]struct{}, error) {
+	commonPasswordsLock.Lock()
+	defer commonPasswordsLock.Unlock()
+	if passwords, ok := commonPasswords[path]; ok {
+		return passwords, nil
+	}
+	passwords, err := password.CommonPasswords(path)
+	if err != nil {
+		return nil, err
+	}
+	commonPasswords[path] = passwords
+	return passwords, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/storage"
+	"github.com/goccy/go-json"
+)
+
+// personalDataProfile is the profile of the user in its personal data archive. Credentials are left out.
+type personalDataProfile struct {
+	UserID            string `json:"user_id"`
+	Username          string `json:"username"`
+	Email             string `json:"email"`
+	Role              string `json:"role"`
+	Organization      string `json:"organization"`
+	Avatar            string `json:"avatar"`
+	TwoFactorEnabled  bool   `json:"two_factor_enabled"`
+	OIDCIssuer        string `json:"oidc_issuer"`
+	LastLogin         string `json:"last_login"`
+	PasswordChangedAt string `json:"password_changed_at"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// ExportPersonalData returns a zip archive of the personal data held about the user: its profile, instruction data
+// including drafts, review decisions, re-audit records it approved or re-audited, login logs and operation logs,
+// each as a JSON file.
+func ExportPersonalData(
+	ctx context.Context, user *entity.UserModel, storage storage.Storage, instructionDataDao dao.InstructionDataDao,
+	reviewDao dao.ReviewDao, reAuditDao dao.ReAuditDao, loginLogDao dao.LoginLogDao, operationLogDao dao.OperationLogDao,
+) ([]byte, error) {
+	profile := personalDataProfile{
+		UserID:            user.UserID.Hex(),
+		Username:          user.Username,
+		Email:             user.Email,
+		Role:              user.Role,
+		Organization:      user.Organization,
+		TwoFactorEnabled:  user.TwoFactor.Enabled,
+		OIDCIssuer:        user.OIDC.Issuer,
+		LastLogin:         user.LastLogin.Format(time.RFC3339),
+		PasswordChangedAt: user.PasswordChangedAt.Format(time.RFC3339),
+		CreatedAt:         user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         user.UpdatedAt.Format(time.RFC3339),
+	}
+	if user.Avatar != "" {
+		profile.Avatar = storage.URL(user.Avatar)
+	}
+	instructionDataList, _, err := instructionDataDao.GetInstructionDataList(
+		ctx, 0, 0, false, &user.UserID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get instruction data list"))
+	}
+	// Drafts are only listed on demand
+	draftStatus := config.InstructionDataStatusDraft
+	draftList, _, err := instructionDataDao.GetInstructionDataList(
+		ctx, 0, 0, false, &user.UserID, nil, &draftStatus, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get draft list"))
+	}
+	instructionDataList = append(instructionDataList, draftList...)
+	reviewList, _, err := reviewDao.GetReviewList(ctx, 0, 0, false, nil, &user.UserID, nil, nil, nil, nil)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get review list"))
+	}
+	reAuditList, err := reAuditDao.GetUserReAuditList(ctx, user.UserID)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get re-audit list"))
+	}
+	loginLogList, _, err := loginLogDao.GetLoginLogList(
+		ctx, 0, 0, false, nil, nil, &user.UserID, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get login log list"))
+	}
+	operationLogList, _, err := operationLogDao.GetOperationLogList(
+		ctx, 0, 0, false, nil, nil, &user.UserID, nil, nil, nil, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, errors.OperationFailed(fmt.Errorf("failed to get operation log list"))
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", profile},
+		{"instruction_data.json", instructionDataList},
+		{"review.json", reviewList},
+		{"re_audit.json", reAuditList},
+		{"login_log.json", loginLogList},
+		{"operation_log.json", operationLogList},
+	} {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, errors.ServiceError(fmt.Errorf("failed to marshal %s", file.name))
+		}
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, errors.ServiceError(fmt.Errorf("failed to write %s", file.name))
+		}
+		if _, err = w.Write(data); err != nil {
+			return nil, errors.ServiceError(fmt.Errorf("failed to write %s", file.name))
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return nil, errors.ServiceError(fmt.Errorf("failed to write personal data archive"))
+	}
+	return buf.Bytes(), nil
+}
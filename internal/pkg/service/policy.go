@@ -0,0 +1,40 @@
+package service
+
+import (
+	"data-collection-hub-server/internal/pkg/config"
+	"github.com/casbin/casbin/v2"
+)
+
+// DefaultPolicies are the permissions of the built-in roles, as casbin policies of role, object and action. They are
+// restored on startup and cannot be removed through the policy API.
+var DefaultPolicies = [][]string{
+	{config.UserRoleAdmin, config.PolicyObjectStatistic, config.PolicyActionRead},
+	{config.UserRoleAdmin, config.PolicyObjectInstructionData, config.PolicyActionRead},
+	{config.UserRoleAdmin, config.PolicyObjectInstructionData, config.PolicyActionReview},
+	{config.UserRoleAdmin, config.PolicyObjectInstructionData, config.PolicyActionExport},
+	{config.UserRoleOrgAdmin, config.PolicyObjectStatistic, config.PolicyActionRead},
+	{config.UserRoleOrgAdmin, config.PolicyObjectInstructionData, config.PolicyActionRead},
+	{config.UserRoleOrgAdmin, config.PolicyObjectInstructionData, config.PolicyActionReview},
+	{config.UserRoleOrgAdmin, config.PolicyObjectInstructionData, config.PolicyActionExport},
+	{config.UserRoleReviewer, config.PolicyObjectInstructionData, config.PolicyActionRead},
+	{config.UserRoleReviewer, config.PolicyObjectInstructionData, config.PolicyActionReview},
+	{config.UserRoleExporter, config.PolicyObjectInstructionData, config.PolicyActionRead},
+	{config.UserRoleExporter, config.PolicyObjectInstructionData, config.PolicyActionExport},
+	{config.UserRoleViewer, config.PolicyObjectStatistic, config.PolicyActionRead},
+}
+
+// SeedDefaultPolicies adds the default policies missing from the enforcer.
+func SeedDefaultPolicies(enforcer *casbin.Enforcer) error {
+	_, err := enforcer.AddPoliciesEx(DefaultPolicies)
+	return err
+}
+
+// IsDefaultPolicy reports whether the policy is one of the default policies.
+func IsDefaultPolicy(role, object, action string) bool {
+	for _, policy := range DefaultPolicies {
+		if policy[0] == role && policy[1] == object && policy[2] == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/spdx"
+)
+
+// ValidateProvenance validates the provenance of a submission. The source type and license must be known, web sources
+// must carry their URL, and the contributor must attest to hold the rights.
+func ValidateProvenance(provenance *entity.Provenance) error {
+	switch provenance.SourceType {
+	case config.SourceTypeOriginal, config.SourceTypeWeb, config.SourceTypeBook, config.SourceTypeSynthetic:
+	default:
+		return errors.InvalidRequest(fmt.Errorf("invalid source type %q", provenance.SourceType))
+	}
+	if provenance.SourceType == config.SourceTypeWeb && provenance.URL == "" {
+		return errors.InvalidRequest(fmt.Errorf("source url is required for web sources"))
+	}
+	if provenance.URL != "" {
+		if u, err := url.ParseRequestURI(provenance.URL); err != nil || u.Host == "" {
+			return errors.InvalidRequest(fmt.Errorf("invalid source url %q", provenance.URL))
+		}
+	}
+	if !spdx.IsValid(provenance.License) {
+		return errors.InvalidRequest(fmt.Errorf("license %q is not a supported SPDX identifier", provenance.License))
+	}
+	if !provenance.RightsAttested {
+		return errors.InvalidRequest(fmt.Errorf("the contributor must attest to hold the rights of the data"))
+	}
+	return nil
+}
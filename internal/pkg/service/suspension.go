@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/errors"
+)
+
+// IsSuspended reports whether the user is suspended at the time. A suspension past its expiry is lifted.
+func IsSuspended(user *entity.UserModel, now time.Time) bool {
+	suspension := user.Suspension
+	return suspension.Suspended && (suspension.ExpiresAt.IsZero() || now.Before(suspension.ExpiresAt))
+}
+
+// CheckSuspension returns an error with the reason and expiry of the suspension if the user is suspended.
+func CheckSuspension(user *entity.UserModel) error {
+	if !IsSuspended(user, time.Now()) {
+		return nil
+	}
+	if user.Suspension.ExpiresAt.IsZero() {
+		return errors.Suspended(fmt.Errorf("account is suspended: %s", user.Suspension.Reason))
+	}
+	return errors.Suspended(
+		fmt.Errorf(
+			"account is suspended until %s: %s",
+			user.Suspension.ExpiresAt.Format(time.RFC3339), user.Suspension.Reason,
+		),
+	)
+}
@@ -15,6 +15,7 @@ type LogsService interface {
 		ctx context.Context, userID *primitive.ObjectID, ipAddress, userAgent *string,
 	) error
 	CacheLoginLog(ctx context.Context, userID *primitive.ObjectID, ipAddress, userAgent *string) error
+	CacheFailedLoginLog(ctx context.Context, email, ipAddress, userAgent, reason *string) error
 	InsertOperationLog(
 		ctx context.Context, userID, entityID *primitive.ObjectID,
 		ipAddress, userAgent, operation, entityType, description, status *string,
@@ -62,6 +63,17 @@ func (l logsServiceImpl) CacheLoginLog(
 	return nil
 }
 
+// CacheFailedLoginLog caches a failed login with its reason, so that attacks show up in the login log.
+func (l logsServiceImpl) CacheFailedLoginLog(
+	ctx context.Context, email, ipAddress, userAgent, reason *string,
+) error {
+	err := l.loginLogDao.CacheFailedLoginLog(ctx, *email, *ipAddress, *userAgent, *reason)
+	if err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to cache failed login log"))
+	}
+	return nil
+}
+
 func (l logsServiceImpl) InsertOperationLog(
 	ctx context.Context, userID, entityID *primitive.ObjectID,
 	ipAddress, userAgent, operation, entityType, description, status *string,
@@ -80,9 +92,9 @@ func (l logsServiceImpl) CacheOperationLog(
 	ctx context.Context, userID, entityID *primitive.ObjectID, ipAddress, userAgent *string,
 	operation, entityType, description, status *string,
 ) error {
-	var _entityID primitive.ObjectID
-	if entityID == nil {
-		_entityID = primitive.NilObjectID
+	_entityID := primitive.NilObjectID
+	if entityID != nil {
+		_entityID = *entityID
 	}
 	err := l.operationLogDao.CacheOperationLog(
 		ctx, *userID, _entityID, *ipAddress, *userAgent, *operation, *entityType, *description, *status,
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+
+	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ValidateMetadata validates the metadata against the template of the theme. Themes without a template only accept
+// empty metadata.
+func ValidateMetadata(ctx context.Context, themeDao dao.ThemeDao, theme string, metadata map[string]interface{}) error {
+	themeModel, err := themeDao.GetThemeByName(ctx, theme)
+	if err != nil {
+		if !e.Is(err, mongo.ErrNoDocuments) {
+			return errors.OperationFailed(fmt.Errorf("failed to get theme %s", theme))
+		}
+		if len(metadata) > 0 {
+			return errors.InvalidRequest(fmt.Errorf("theme %s does not define any metadata field", theme))
+		}
+		return nil
+	}
+	if err = schema.Validate(themeModel.Schema, metadata); err != nil {
+		return errors.InvalidRequest(fmt.Errorf("metadata does not match the template of theme %s: %s", theme, err))
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"data-collection-hub-server/internal/pkg/config"
 	dao "data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/domain/entity"
 	"data-collection-hub-server/internal/pkg/domain/vo/user"
 	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/errors"
@@ -17,8 +18,14 @@ import (
 
 type DatasetService interface {
 	InsertInstructionData(
-		ctx context.Context, Instruction, Input, Output, Theme, Source, Note *string,
+		ctx context.Context, Instruction, Input, Output, Theme, Source, Note *string, metadata map[string]interface{},
+		sourceType, sourceURL, license *string, rightsAttested *bool,
 	) (string, error)
+	InsertInstructionDataDraft(
+		ctx context.Context, Instruction, Input, Output, Theme, Source, Note *string, metadata map[string]interface{},
+		sourceType, sourceURL, license *string, rightsAttested *bool,
+	) (string, error)
+	SubmitInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error
 	GetInstructionData(ctx context.Context, instructionDataID primitive.ObjectID) (
 		*user.GetInstructionDataResponse, error,
 	)
@@ -27,7 +34,8 @@ type DatasetService interface {
 	) (*user.GetInstructionDataListResponse, error)
 	UpdateInstructionData(
 		ctx context.Context, instructionDataID *primitive.ObjectID,
-		Instruction, Input, Output, Theme, Source, Note *string,
+		Instruction, Input, Output, Theme, Source, Note *string, metadata map[string]interface{},
+		sourceType, sourceURL, license *string, rightsAttested *bool,
 	) error
 	DeleteInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error
 }
@@ -36,21 +44,25 @@ type datasetServiceImpl struct {
 	core               *service.Core
 	instructionDataDao dao.InstructionDataDao
 	userDao            dao.UserDao
+	themeDao           dao.ThemeDao
 	operationLogDao    dao.OperationLogDao
 }
 
 func NewDatasetService(
-	core *service.Core, instructionDataDao dao.InstructionDataDao, operationLogDao dao.OperationLogDao,
+	core *service.Core, instructionDataDao dao.InstructionDataDao, themeDao dao.ThemeDao,
+	operationLogDao dao.OperationLogDao,
 ) DatasetService {
 	return &datasetServiceImpl{
 		core:               core,
 		instructionDataDao: instructionDataDao,
+		themeDao:           themeDao,
 		operationLogDao:    operationLogDao,
 	}
 }
 
 func (d datasetServiceImpl) InsertInstructionData(
-	ctx context.Context, instruction, input, output, theme, source, note *string,
+	ctx context.Context, instruction, input, output, theme, source, note *string, metadata map[string]interface{},
+	sourceType, sourceURL, license *string, rightsAttested *bool,
 ) (string, error) {
 	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
 	if !ok {
@@ -72,8 +84,16 @@ func (d datasetServiceImpl) InsertInstructionData(
 	} else {
 		n = *note
 	}
+	if err = service.ValidateMetadata(ctx, d.themeDao, t, metadata); err != nil {
+		return "", err
+	}
+	provenance := mergeProvenance(entity.Provenance{}, sourceType, sourceURL, license, rightsAttested)
+	if err = service.ValidateProvenance(&provenance); err != nil {
+		return "", err
+	}
 	instructionDataID, err := d.instructionDataDao.InsertInstructionData(
-		ctx, userID, *instruction, *input, *output, t, *source, n, config.InstructionDataStatusPending, "",
+		ctx, userID, *instruction, *input, *output, t, *source, n, config.InstructionDataStatusPending, "", metadata,
+		&provenance,
 	)
 	if err != nil {
 		return "", errors.OperationFailed(fmt.Errorf("failed to insert instruction data"))
@@ -81,6 +101,104 @@ func (d datasetServiceImpl) InsertInstructionData(
 	return instructionDataID.Hex(), nil
 }
 
+// InsertInstructionDataDraft saves an incomplete instruction data as a draft, which stays invisible to the reviewers
+// until it is submitted.
+func (d datasetServiceImpl) InsertInstructionDataDraft(
+	ctx context.Context, instruction, input, output, theme, source, note *string, metadata map[string]interface{},
+	sourceType, sourceURL, license *string, rightsAttested *bool,
+) (string, error) {
+	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return "", errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return "", errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+
+	var i, in, o, t, s, n string
+	if instruction != nil {
+		i = *instruction
+	}
+	if input != nil {
+		in = *input
+	}
+	if output != nil {
+		o = *output
+	}
+	if theme == nil {
+		t = "Default"
+	} else {
+		t = *theme
+	}
+	if source != nil {
+		s = *source
+	}
+	if note != nil {
+		n = *note
+	}
+	provenance := mergeProvenance(entity.Provenance{}, sourceType, sourceURL, license, rightsAttested)
+	instructionDataID, err := d.instructionDataDao.InsertInstructionData(
+		ctx, userID, i, in, o, t, s, n, config.InstructionDataStatusDraft, "", metadata, &provenance,
+	)
+	if err != nil {
+		return "", errors.OperationFailed(fmt.Errorf("failed to insert instruction data draft"))
+	}
+	return instructionDataID.Hex(), nil
+}
+
+// SubmitInstructionData submits a draft of the current user for review. The draft must be complete, i.e. the
+// instruction, input, output and source are all filled, the metadata must match the template of the theme, and the
+// provenance must be complete.
+func (d datasetServiceImpl) SubmitInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error {
+	userIDHex, ok := ctx.Value(config.UserIDKey).(string)
+	if !ok {
+		return errors.NotAuthorized(fmt.Errorf("user is not authorized"))
+	}
+	instructionData, err := d.instructionDataDao.GetInstructionDataByID(ctx, *instructionDataID)
+	if err != nil {
+		if e.Is(err, mongo.ErrNoDocuments) {
+			return errors.NotFound(fmt.Errorf("instruction data (id: %s) not found", instructionDataID.Hex()))
+		} else {
+			return errors.OperationFailed(
+				fmt.Errorf("failed to get instruction data (id: %s)", instructionDataID.Hex()),
+			)
+		}
+	}
+	if instructionData.UserID.Hex() != userIDHex {
+		return errors.NotFound(fmt.Errorf("instruction data (id: %s) not found", instructionDataID.Hex()))
+	}
+	if instructionData.Status.Code != config.InstructionDataStatusDraft {
+		return errors.PermissionDeny(
+			fmt.Errorf("instruction data (id: %s) is not in draft status", instructionDataID.Hex()),
+		)
+	}
+	if instructionData.Row.Instruction == "" || instructionData.Row.Input == "" ||
+		instructionData.Row.Output == "" || instructionData.Source == "" {
+		return errors.InvalidRequest(
+			fmt.Errorf(
+				"instruction data (id: %s) is incomplete, instruction, input, output and source are required",
+				instructionDataID.Hex(),
+			),
+		)
+	}
+	if err = service.ValidateMetadata(ctx, d.themeDao, instructionData.Theme, instructionData.Metadata); err != nil {
+		return err
+	}
+	if err = service.ValidateProvenance(&instructionData.Provenance); err != nil {
+		return err
+	}
+
+	statusCode := config.InstructionDataStatusPending
+	err = d.instructionDataDao.UpdateInstructionData(
+		ctx, *instructionDataID, nil, nil, nil, nil, nil, nil, nil, &statusCode, nil, nil, nil,
+	)
+	if err != nil {
+		return errors.OperationFailed(fmt.Errorf("failed to submit instruction data (id: %s)", instructionDataID.Hex()))
+	}
+	return nil
+}
+
 func (d datasetServiceImpl) GetInstructionData(
 	ctx context.Context, instructionDataID primitive.ObjectID,
 ) (*user.GetInstructionDataResponse, error) {
@@ -105,9 +223,11 @@ func (d datasetServiceImpl) GetInstructionData(
 			Input:       instructionData.Row.Input,
 			Output:      instructionData.Row.Output,
 		},
-		Theme:  instructionData.Theme,
-		Source: instructionData.Source,
-		Note:   instructionData.Note,
+		Theme:      instructionData.Theme,
+		Source:     instructionData.Source,
+		Note:       instructionData.Note,
+		Metadata:   instructionData.Metadata,
+		Provenance: user.Provenance(instructionData.Provenance),
 		Status: struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
@@ -134,7 +254,7 @@ func (d datasetServiceImpl) GetInstructionDataList(
 	}
 	instructionDataList, count, err := d.instructionDataDao.GetInstructionDataList(
 		ctx, offset, *pageSize, false, &userID, theme, status,
-		nil, nil, updateBefore, updateAfter, nil,
+		nil, nil, updateBefore, updateAfter, nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to get instruction data list"))
@@ -153,9 +273,11 @@ func (d datasetServiceImpl) GetInstructionDataList(
 					Input:       instructionData.Row.Input,
 					Output:      instructionData.Row.Output,
 				},
-				Theme:  instructionData.Theme,
-				Source: instructionData.Source,
-				Note:   instructionData.Note,
+				Theme:      instructionData.Theme,
+				Source:     instructionData.Source,
+				Note:       instructionData.Note,
+				Metadata:   instructionData.Metadata,
+				Provenance: user.Provenance(instructionData.Provenance),
 				Status: struct {
 					Code    string `json:"code"`
 					Message string `json:"message"`
@@ -176,8 +298,10 @@ func (d datasetServiceImpl) GetInstructionDataList(
 
 func (d datasetServiceImpl) UpdateInstructionData(
 	ctx context.Context, instructionDataID *primitive.ObjectID, instruction, input, output, theme, source, note *string,
+	metadata map[string]interface{}, sourceType, sourceURL, license *string, rightsAttested *bool,
 ) error {
-	// Check if the instruction data exists and is in pending status (only pending status can be updated by the user)
+	// Check if the instruction data exists and is in draft or pending status (only drafts and pending data can be
+	// updated by the user)
 	instructionData, err := d.instructionDataDao.GetInstructionDataByID(ctx, *instructionDataID)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
@@ -188,14 +312,38 @@ func (d datasetServiceImpl) UpdateInstructionData(
 			)
 		}
 	}
-	if instructionData.Status.Code != config.InstructionDataStatusPending {
+	if instructionData.Status.Code != config.InstructionDataStatusDraft &&
+		instructionData.Status.Code != config.InstructionDataStatusPending {
 		return errors.PermissionDeny(
-			fmt.Errorf("instruction data (id: %s) is not in pending status", instructionDataID.Hex()),
+			fmt.Errorf("instruction data (id: %s) is not in draft or pending status", instructionDataID.Hex()),
 		)
 	}
+	// Drafts are validated on submission
+	if instructionData.Status.Code == config.InstructionDataStatusPending && (theme != nil || metadata != nil) {
+		t, m := instructionData.Theme, instructionData.Metadata
+		if theme != nil {
+			t = *theme
+		}
+		if metadata != nil {
+			m = metadata
+		}
+		if err = service.ValidateMetadata(ctx, d.themeDao, t, m); err != nil {
+			return err
+		}
+	}
+	var provenance *entity.Provenance
+	if sourceType != nil || sourceURL != nil || license != nil || rightsAttested != nil {
+		p := mergeProvenance(instructionData.Provenance, sourceType, sourceURL, license, rightsAttested)
+		if instructionData.Status.Code == config.InstructionDataStatusPending {
+			if err = service.ValidateProvenance(&p); err != nil {
+				return err
+			}
+		}
+		provenance = &p
+	}
 
 	err = d.instructionDataDao.UpdateInstructionData(
-		ctx, *instructionDataID, nil, instruction, input, output, theme, source, note, nil, nil,
+		ctx, *instructionDataID, nil, instruction, input, output, theme, source, note, nil, nil, metadata, provenance,
 	)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
@@ -212,7 +360,8 @@ func (d datasetServiceImpl) UpdateInstructionData(
 }
 
 func (d datasetServiceImpl) DeleteInstructionData(ctx context.Context, instructionDataID *primitive.ObjectID) error {
-	// Check if the instruction data exists and is in pending status (only pending status can be deleted by the user)
+	// Check if the instruction data exists and is in draft or pending status (only drafts and pending data can be
+	// deleted by the user)
 	instructionData, err := d.instructionDataDao.GetInstructionDataByID(ctx, *instructionDataID)
 	if err != nil {
 		if e.Is(err, mongo.ErrNoDocuments) {
@@ -223,9 +372,10 @@ func (d datasetServiceImpl) DeleteInstructionData(ctx context.Context, instructi
 			)
 		}
 	}
-	if instructionData.Status.Code != config.InstructionDataStatusPending {
+	if instructionData.Status.Code != config.InstructionDataStatusDraft &&
+		instructionData.Status.Code != config.InstructionDataStatusPending {
 		return errors.PermissionDeny(
-			fmt.Errorf("instruction data (id: %s) is not in pending status", instructionDataID.Hex()),
+			fmt.Errorf("instruction data (id: %s) is not in draft or pending status", instructionDataID.Hex()),
 		)
 	}
 
@@ -235,3 +385,22 @@ func (d datasetServiceImpl) DeleteInstructionData(ctx context.Context, instructi
 	}
 	return nil
 }
+
+// mergeProvenance overrides the given provenance with the fields that are not nil.
+func mergeProvenance(
+	provenance entity.Provenance, sourceType, sourceURL, license *string, rightsAttested *bool,
+) entity.Provenance {
+	if sourceType != nil {
+		provenance.SourceType = *sourceType
+	}
+	if sourceURL != nil {
+		provenance.URL = *sourceURL
+	}
+	if license != nil {
+		provenance.License = *license
+	}
+	if rightsAttested != nil {
+		provenance.RightsAttested = *rightsAttested
+	}
+	return provenance
+}
@@ -48,7 +48,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 	}
 	total, err := s.instructionDataDao.CountInstructionData(
 		ctx, &userID, nil, nil, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to count instruction data"))
@@ -56,7 +56,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 	pendingCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, &userID, nil, &pendingStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(fmt.Errorf("failed to count instruction data with status %s", pendingStatus))
@@ -64,7 +64,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 	approvedCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, &userID, nil, &approvedStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -76,7 +76,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 	rejectedCount, err := s.instructionDataDao.CountInstructionData(
 		ctx, &userID, nil, &rejectedStatus, nil,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	if err != nil {
 		return nil, errors.OperationFailed(
@@ -110,7 +110,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 		end := startDate.Add(time.Duration(i+1) * time.Hour * 24)
 		_total, err := s.instructionDataDao.CountInstructionData(
 			ctx, &userID, nil, nil, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -123,7 +123,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 		_pendingCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &userID, nil, &pendingStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -136,7 +136,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 		_approvedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &userID, nil, &approvedStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
@@ -149,7 +149,7 @@ func (s statisticServiceImpl) GetDataStatistic(
 
 		_rejectedCount, err := s.instructionDataDao.CountInstructionData(
 			ctx, &userID, nil, &rejectedStatus, &start, &end,
-			nil, nil,
+			nil, nil, nil,
 		)
 		if err != nil {
 			return nil, errors.OperationFailed(
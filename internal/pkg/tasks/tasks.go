@@ -2,27 +2,38 @@ package tasks
 
 import (
 	"context"
+	"math"
+	"math/rand"
+	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
 	"data-collection-hub-server/internal/pkg/dao/mods"
+	admin "data-collection-hub-server/internal/pkg/service/admin/mods"
 	"data-collection-hub-server/pkg/cron"
 	"data-collection-hub-server/pkg/jwt"
 	logging "data-collection-hub-server/pkg/zap"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
 type Tasks struct {
-	cron            *cron.Cron
-	config          *config.Config
-	loginLogDao     mods.LoginLogDao
-	operationLogDao mods.OperationLogDao
-	jwt             *jwt.Jwt
-	logger          *zap.Logger
+	cron               *cron.Cron
+	config             *config.Config
+	loginLogDao        mods.LoginLogDao
+	operationLogDao    mods.OperationLogDao
+	instructionDataDao mods.InstructionDataDao
+	reviewDao          mods.ReviewDao
+	reAuditDao         mods.ReAuditDao
+	jwtKeyDao          mods.JwtKeyDao
+	userService        admin.UserService
+	jwt                *jwt.Jwt
+	logger             *zap.Logger
 }
 
 func New(
 	ctx context.Context, config *config.Config, loginLogDao mods.LoginLogDao, operationLogDao mods.OperationLogDao,
-	jwt *jwt.Jwt, zap *logging.Zap,
+	instructionDataDao mods.InstructionDataDao, reviewDao mods.ReviewDao, reAuditDao mods.ReAuditDao,
+	jwtKeyDao mods.JwtKeyDao, userService admin.UserService, jwt *jwt.Jwt, zap *logging.Zap,
 ) (*Tasks, error) {
 	ctx = zap.SetTagInContext(ctx, logging.CronTag)
 	logger, err := zap.GetLogger(ctx)
@@ -30,12 +41,17 @@ func New(
 		return nil, err
 	}
 	return &Tasks{
-		cron:            cron.New(ctx),
-		config:          config,
-		loginLogDao:     loginLogDao,
-		operationLogDao: operationLogDao,
-		jwt:             jwt,
-		logger:          logger,
+		cron:               cron.New(ctx),
+		config:             config,
+		loginLogDao:        loginLogDao,
+		operationLogDao:    operationLogDao,
+		instructionDataDao: instructionDataDao,
+		reviewDao:          reviewDao,
+		reAuditDao:         reAuditDao,
+		jwtKeyDao:          jwtKeyDao,
+		userService:        userService,
+		jwt:                jwt,
+		logger:             logger,
 	}, nil
 }
 
@@ -45,11 +61,118 @@ func (t *Tasks) syncLogs() {
 	t.operationLogDao.SyncOperationLog(t.cron.Context())
 }
 
+// updateKey rotates the JWT key ring. The new key signs the tokens from now on, while the retired keys keep
+// verifying the tokens they signed until these expire. The ring is reloaded from the database, so that the keys of
+// the other instances are picked up too; between rotations, the other instances reload their ring when they see a
+// token of the new key.
 func (t *Tasks) updateKey() {
+	ctx := t.cron.Context()
 	t.logger.Info("Updating JWT key")
-	if err := t.jwt.UpdateKey(); err != nil {
+	key, err := jwt.GenerateKey()
+	if err != nil {
+		t.logger.Error("Failed to generate JWT key", zap.Error(err))
+		return
+	}
+	if err = t.jwtKeyDao.InsertJwtKey(ctx, key); err != nil {
+		t.logger.Error("Failed to save JWT key", zap.Error(err))
+		return
+	}
+	if _, err = t.jwtKeyDao.RetireJwtKeyList(
+		ctx, key.ID, time.Now().Add(t.config.JWTConfig.RefreshDuration),
+	); err != nil {
+		t.logger.Error("Failed to retire JWT keys", zap.Error(err))
+	}
+	keys, err := t.jwtKeyDao.GetJwtKeyList(ctx)
+	if err != nil {
+		t.logger.Error("Failed to load JWT keys", zap.Error(err))
+		return
+	}
+	if err = t.jwt.SetKeys(keys); err != nil {
 		t.logger.Error("Failed to update JWT key", zap.Error(err))
+		return
+	}
+	t.logger.Info("Updated JWT key", zap.String("keyID", t.jwt.SigningKeyID()), zap.Int("keys", len(keys)))
+}
+
+// cleanDrafts soft deletes drafts which have not been updated within the configured retention.
+func (t *Tasks) cleanDrafts() {
+	var (
+		draftStatus = config.InstructionDataStatusDraft
+		updateStart = time.Unix(0, 0)
+		updateEnd   = time.Now().Add(-t.config.TasksConfig.DraftRetention)
+	)
+	t.logger.Info("Cleaning abandoned drafts", zap.Time("updatedBefore", updateEnd))
+	count, err := t.instructionDataDao.SoftDeleteInstructionDataList(
+		t.cron.Context(), nil, nil, &draftStatus, nil, nil, &updateStart, &updateEnd,
+	)
+	if err != nil {
+		t.logger.Error("Failed to clean abandoned drafts", zap.Error(err))
+		return
 	}
+	t.logger.Info("Cleaned abandoned drafts", zap.Int64("count", *count))
+}
+
+// sampleReAudits draws the configured fraction of the records approved within the re-audit window, per reviewer and
+// theme, into the re-audit queue. Every group with at least one approval contributes at least one record.
+func (t *Tasks) sampleReAudits() {
+	var (
+		ctx            = t.cron.Context()
+		approvedStatus = config.InstructionDataStatusApproved
+		endTime        = time.Now()
+		startTime      = endTime.Add(-t.config.ReviewConfig.ReAuditWindow)
+		sampleRate     = t.config.ReviewConfig.ReAuditSampleRate
+	)
+	if sampleRate <= 0 {
+		return
+	}
+	t.logger.Info("Sampling approved instruction data for re-audit", zap.Time("approvedAfter", startTime))
+	reviews, _, err := t.reviewDao.GetReviewList(
+		ctx, 0, 0, false, nil, nil, nil, &approvedStatus, &startTime, &endTime,
+	)
+	if err != nil {
+		t.logger.Error("Failed to get approved reviews", zap.Error(err))
+		return
+	}
+
+	type group struct{ reviewerID, theme string }
+	groups := map[group][]int{}
+	for i, review := range reviews {
+		g := group{review.ReviewerID.Hex(), review.Theme}
+		groups[g] = append(groups[g], i)
+	}
+	var count int64
+	for _, indices := range groups {
+		n := int(math.Ceil(float64(len(indices)) * math.Min(sampleRate, 1)))
+		rand.Shuffle(len(indices), func(i, j int) { indices[i], indices[j] = indices[j], indices[i] })
+		for _, i := range indices[:n] {
+			review := reviews[i]
+			_, err = t.reAuditDao.InsertReAudit(
+				ctx, review.InstructionDataID, review.ReviewerID, review.ReviewerName, review.Theme,
+			)
+			if err != nil {
+				if !mongo.IsDuplicateKeyError(err) {
+					t.logger.Error("Failed to insert re-audit", zap.Error(err))
+				}
+				continue
+			}
+			count++
+		}
+	}
+	t.logger.Info("Sampled approved instruction data for re-audit", zap.Int64("count", count))
+}
+
+// purgeUsers hard deletes the users deleted for longer than the configured retention, following the purge policy.
+func (t *Tasks) purgeUsers() {
+	t.logger.Info(
+		"Purging deleted users", zap.String("policy", t.config.UserRetentionConfig.PurgePolicy),
+		zap.Duration("retention", t.config.UserRetentionConfig.Period),
+	)
+	resp, err := t.userService.PurgeUserList(t.cron.Context())
+	if err != nil {
+		t.logger.Error("Failed to purge deleted users", zap.Error(err))
+		return
+	}
+	t.logger.Info("Purged deleted users", zap.Int64("count", resp.Total), zap.Int64("failed", resp.Failed))
 }
 
 func (t *Tasks) Start() error {
@@ -64,6 +187,24 @@ func (t *Tasks) Start() error {
 		return err
 	}
 	t.logger.Info("Added update key task", zap.Int("id", int(updateKeyID)))
+	cleanDraftsID, err := t.cron.AddFunc(t.config.TasksConfig.CleanDraftsSpec, t.cleanDrafts)
+	if err != nil {
+		t.logger.Error("Failed to add clean drafts task", zap.Error(err))
+		return err
+	}
+	t.logger.Info("Added clean drafts task", zap.Int("id", int(cleanDraftsID)))
+	reAuditID, err := t.cron.AddFunc(t.config.TasksConfig.ReAuditSpec, t.sampleReAudits)
+	if err != nil {
+		t.logger.Error("Failed to add re-audit task", zap.Error(err))
+		return err
+	}
+	t.logger.Info("Added re-audit task", zap.Int("id", int(reAuditID)))
+	purgeUsersID, err := t.cron.AddFunc(t.config.TasksConfig.PurgeUsersSpec, t.purgeUsers)
+	if err != nil {
+		t.logger.Error("Failed to add purge users task", zap.Error(err))
+		return err
+	}
+	t.logger.Info("Added purge users task", zap.Int("id", int(purgeUsersID)))
 	t.logger.Info("Starting tasks")
 	t.cron.Start()
 	return nil
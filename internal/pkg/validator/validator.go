@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/spdx"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -43,6 +44,42 @@ func instructionDataStatus(fl validator.FieldLevel) bool {
 	}
 }
 
+func userInstructionDataStatus(fl validator.FieldLevel) bool {
+	if fl.Field().String() == config.InstructionDataStatusDraft {
+		return true
+	}
+	return instructionDataStatus(fl)
+}
+
+func sourceType(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.SourceTypeOriginal, config.SourceTypeWeb, config.SourceTypeBook, config.SourceTypeSynthetic:
+		return true
+	default:
+		return false
+	}
+}
+
+func spdxLicense(fl validator.FieldLevel) bool {
+	return spdx.IsValid(fl.Field().String())
+}
+
+func reAuditDecision(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.ReAuditStatusConfirmed, config.ReAuditStatusOverturned:
+		return true
+	default:
+		return false
+	}
+}
+
+func reAuditStatus(fl validator.FieldLevel) bool {
+	if fl.Field().String() == config.ReAuditStatusPending {
+		return true
+	}
+	return reAuditDecision(fl)
+}
+
 func noticeType(fl validator.FieldLevel) bool {
 	switch fl.Field().String() {
 	case config.NoticeTypeUrgent, config.NoticeTypeNormal:
@@ -61,9 +98,37 @@ func userRole(fl validator.FieldLevel) bool {
 	}
 }
 
+func policyObject(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.PolicyObjectInstructionData, config.PolicyObjectStatistic:
+		return true
+	default:
+		return false
+	}
+}
+
+func policyAction(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.PolicyActionRead, config.PolicyActionReview, config.PolicyActionExport:
+		return true
+	default:
+		return false
+	}
+}
+
+func accessTokenScope(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.AccessTokenScopeDatasetRead, config.AccessTokenScopeDatasetWrite:
+		return true
+	default:
+		return false
+	}
+}
+
 func operationType(fl validator.FieldLevel) bool {
 	switch fl.Field().String() {
-	case config.OperationTypeCreate, config.OperationTypeUpdate, config.OperationTypeDelete:
+	case config.OperationTypeCreate, config.OperationTypeUpdate, config.OperationTypeDelete,
+		config.OperationTypeImpersonate, config.OperationTypeExport:
 		return true
 	default:
 		return false
@@ -72,7 +137,11 @@ func operationType(fl validator.FieldLevel) bool {
 
 func entityType(fl validator.FieldLevel) bool {
 	switch fl.Field().String() {
-	case config.EntityTypeDocumentation, config.EntityTypeNotice, config.EntityTypeInstruction, config.EntityTypeUser:
+	case config.EntityTypeDocumentation, config.EntityTypeNotice, config.EntityTypeInstruction, config.EntityTypeUser,
+		config.EntityTypeTheme, config.EntityTypeReAudit, config.EntityTypeInviteCode,
+		config.EntityTypeTwoFactorPolicy, config.EntityTypeAccessToken, config.EntityTypeLoginLockout,
+		config.EntityTypeSession, config.EntityTypeOrganization, config.EntityTypePolicy,
+		config.EntityTypeImpersonation:
 		return true
 	default:
 		return false
@@ -88,6 +157,24 @@ func operationStatus(fl validator.FieldLevel) bool {
 	}
 }
 
+func loginStatus(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.LoginStatusSuccess, config.LoginStatusFailure:
+		return true
+	default:
+		return false
+	}
+}
+
+func loginLockoutKind(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case config.LoginLockoutKindAccount, config.LoginLockoutKindIP:
+		return true
+	default:
+		return false
+	}
+}
+
 func NewValidator() (*validator.Validate, error) {
 	var err error
 	once.Do(
@@ -104,12 +191,38 @@ func NewValidator() (*validator.Validate, error) {
 			); err != nil {
 				return
 			}
+			if err = validate.RegisterValidation(
+				"userInstructionDataStatus", userInstructionDataStatus,
+			); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("sourceType", sourceType); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("spdxLicense", spdxLicense); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("reAuditDecision", reAuditDecision); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("reAuditStatus", reAuditStatus); err != nil {
+				return
+			}
 			if err = validate.RegisterValidation("noticeType", noticeType); err != nil {
 				return
 			}
 			if err = validate.RegisterValidation("userRole", userRole); err != nil {
 				return
 			}
+			if err = validate.RegisterValidation("policyObject", policyObject); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("policyAction", policyAction); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("accessTokenScope", accessTokenScope); err != nil {
+				return
+			}
 			if err = validate.RegisterValidation("operationType", operationType); err != nil {
 				return
 			}
@@ -119,6 +232,12 @@ func NewValidator() (*validator.Validate, error) {
 			if err = validate.RegisterValidation("operationStatus", operationStatus); err != nil {
 				return
 			}
+			if err = validate.RegisterValidation("loginStatus", loginStatus); err != nil {
+				return
+			}
+			if err = validate.RegisterValidation("loginLockoutKind", loginLockoutKind); err != nil {
+				return
+			}
 			validateInstance = validate
 		},
 	)
@@ -2,15 +2,18 @@ package wire
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"fmt"
 
 	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao/mods"
+	"data-collection-hub-server/internal/pkg/service"
 	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/pkg/mailer"
 	"data-collection-hub-server/pkg/mongo"
+	"data-collection-hub-server/pkg/oidc"
 	"data-collection-hub-server/pkg/prometheus"
 	"data-collection-hub-server/pkg/redis"
+	"data-collection-hub-server/pkg/storage"
 	logging "data-collection-hub-server/pkg/zap"
 	"github.com/casbin/casbin/v2"
 	mongodbadapter "github.com/casbin/mongodb-adapter/v3"
@@ -45,21 +48,71 @@ func InitializeZap(config *config.Config) (*logging.Zap, error) {
 	return z, nil
 }
 
-// InitializeJwt initializes jwt injection with config.
-func InitializeJwt(config *config.Config) (*jwt.Jwt, error) {
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// InitializeJwt initializes jwt injection with config and the persisted key ring, generating the first key if the
+// ring is empty. The ring is reloaded from the database for tokens signed by keys of other instances.
+func InitializeJwt(ctx context.Context, config *config.Config, jwtKeyDao mods.JwtKeyDao) (*jwt.Jwt, error) {
+	keys, err := jwtKeyDao.GetJwtKeyList(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if len(keys) == 0 {
+		key, err := jwt.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		if err = jwtKeyDao.InsertJwtKey(ctx, key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
 	j, err := jwt.New(
-		privateKey, config.JWTConfig.TokenDuration, config.JWTConfig.RefreshDuration, config.JWTConfig.RefreshBuffer,
+		keys, config.JWTConfig.TokenDuration, config.JWTConfig.RefreshDuration, config.JWTConfig.RefreshBuffer,
 	)
 	if err != nil {
 		return nil, err
 	}
+	j.SetKeyLoader(
+		func() ([]*jwt.Key, error) { return jwtKeyDao.GetJwtKeyList(ctx) }, config.JWTConfig.KeyReloadInterval,
+	)
 	return j, nil
 }
 
+// InitializeMailer initializes mailer injection with config.
+func InitializeMailer(config *config.Config) (mailer.Mailer, error) {
+	switch config.MailerConfig.Driver {
+	case "smtp":
+		return mailer.NewSMTPMailer(
+			config.MailerConfig.SMTPHost, config.MailerConfig.SMTPPort, config.MailerConfig.SMTPUsername,
+			config.MailerConfig.SMTPPassword, config.MailerConfig.From,
+		), nil
+	case "file":
+		return mailer.NewFileMailer(config.MailerConfig.FilePath, config.MailerConfig.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %s", config.MailerConfig.Driver)
+	}
+}
+
+// InitializeStorage initializes the storage of uploaded files with config.
+func InitializeStorage(config *config.Config) (storage.Storage, error) {
+	switch config.StorageConfig.Driver {
+	case "local":
+		return storage.NewLocalStorage(config.StorageConfig.LocalDir, config.StorageConfig.LocalURLPrefix)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %s", config.StorageConfig.Driver)
+	}
+}
+
+// InitializeOIDC initializes the OpenID Connect provider injection with config, nil if single sign-on is disabled.
+func InitializeOIDC(config *config.Config) *oidc.Provider {
+	if !config.OIDCConfig.Enabled {
+		return nil
+	}
+	return oidc.New(
+		config.OIDCConfig.Issuer, config.OIDCConfig.ClientID, config.OIDCConfig.ClientSecret,
+		config.OIDCConfig.RedirectURL, config.OIDCConfig.Scopes, config.OIDCConfig.Timeout,
+	)
+}
+
 // InitializePrometheus initializes prometheus injection with config.
 func InitializePrometheus(config *config.Config) *prometheus.Prometheus {
 	return prometheus.New(
@@ -67,7 +120,7 @@ func InitializePrometheus(config *config.Config) *prometheus.Prometheus {
 	)
 }
 
-// InitializeCasbinEnforcer initializes casbin enforcer injection with config.
+// InitializeCasbinEnforcer initializes casbin enforcer injection with config, and seeds the default policies.
 func InitializeCasbinEnforcer(config *config.Config) (*casbin.Enforcer, error) {
 	adapter, err := mongodbadapter.NewAdapter(config.CasbinConfig.PolicyAdapterUrl)
 	if err != nil {
@@ -77,5 +130,8 @@ func InitializeCasbinEnforcer(config *config.Config) (*casbin.Enforcer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = service.SeedDefaultPolicies(e); err != nil {
+		return nil, err
+	}
 	return e, nil
 }
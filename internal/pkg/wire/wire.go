@@ -51,6 +51,12 @@ var (
 		wire.Struct(new(commonapis.DocumentationApi), "*"),
 		wire.Struct(new(commonapis.NoticeApi), "*"),
 		wire.Struct(new(commonapis.IdempotencyApi), "*"),
+		wire.Struct(new(commonapis.ThemeApi), "*"),
+		wire.Struct(new(commonapis.TwoFactorApi), "*"),
+		wire.Struct(new(commonapis.AccessTokenApi), "*"),
+		wire.Struct(new(commonapis.OIDCApi), "*"),
+		wire.Struct(new(commonapis.SessionApi), "*"),
+		wire.Struct(new(commonapis.JWKSApi), "*"),
 		wire.Struct(new(userapis.DatasetApi), "*"),
 		wire.Struct(new(userapis.StatisticApi), "*"),
 		wire.Struct(new(adminapis.UserApi), "*"),
@@ -59,6 +65,13 @@ var (
 		wire.Struct(new(adminapis.StatisticApi), "*"),
 		wire.Struct(new(adminapis.LogsApi), "*"),
 		wire.Struct(new(adminapis.DataAuditApi), "*"),
+		wire.Struct(new(adminapis.ThemeApi), "*"),
+		wire.Struct(new(adminapis.ReAuditApi), "*"),
+		wire.Struct(new(adminapis.InviteCodeApi), "*"),
+		wire.Struct(new(adminapis.TwoFactorPolicyApi), "*"),
+		wire.Struct(new(adminapis.LoginLockoutApi), "*"),
+		wire.Struct(new(adminapis.OrganizationApi), "*"),
+		wire.Struct(new(adminapis.PolicyApi), "*"),
 		wire.Struct(new(commonapi.Common), "*"),
 		wire.Struct(new(userapi.User), "*"),
 		wire.Struct(new(adminapi.Admin), "*"),
@@ -81,11 +94,25 @@ var (
 		adminservices.NewNoticeService,
 		adminservices.NewDocumentationService,
 		adminservices.NewLogsService,
+		adminservices.NewThemeService,
+		adminservices.NewReAuditService,
+		adminservices.NewInviteCodeService,
+		adminservices.NewTwoFactorPolicyService,
+		adminservices.NewLoginLockoutService,
+		adminservices.NewOrganizationService,
+		adminservices.NewPolicyService,
 		commonservices.NewAuthService,
+		commonservices.NewAuthenticatorChain,
 		commonservices.NewProfileService,
 		commonservices.NewDocumentationService,
 		commonservices.NewNoticeService,
 		commonservices.NewIdempotencyService,
+		commonservices.NewThemeService,
+		commonservices.NewTwoFactorService,
+		commonservices.NewAccessTokenService,
+		commonservices.NewOIDCService,
+		commonservices.NewSessionService,
+		commonservices.NewJWKSService,
 		userservices.NewDatasetService,
 		userservices.NewStatisticService,
 		sysservices.NewLogsService,
@@ -100,6 +127,16 @@ var (
 		daos.NewLoginLogDao,
 		daos.NewOperationLogDao,
 		daos.NewDocumentationDao,
+		daos.NewThemeDao,
+		daos.NewReviewDao,
+		daos.NewReAuditDao,
+		daos.NewInviteCodeDao,
+		daos.NewTwoFactorPolicyDao,
+		daos.NewAccessTokenDao,
+		daos.NewLoginAttemptDao,
+		daos.NewSessionDao,
+		daos.NewJwtKeyDao,
+		daos.NewOrganizationDao,
 	)
 
 	MiddlewareProviderSet = wire.NewSet(
@@ -108,6 +145,8 @@ var (
 		wire.Struct(new(wares.AuthMiddleware), "*"),
 		wire.Struct(new(wares.ContextMiddleware), "*"),
 		wire.Struct(new(wares.IdempotencyMiddleware), "*"),
+		wire.Struct(new(wares.OrganizationMiddleware), "*"),
+		wire.Struct(new(wares.ImpersonationMiddleware), "*"),
 		wire.Struct(new(middleware.Middleware), "*"),
 	)
 
@@ -126,6 +165,9 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 		InitializeJwt,
 		InitializePrometheus,
 		InitializeCasbinEnforcer,
+		InitializeMailer,
+		InitializeStorage,
+		InitializeOIDC,
 		DaoProviderSet,
 		ServiceProviderSet,
 		ValidatorProviderSet,
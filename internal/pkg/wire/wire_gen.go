@@ -72,7 +72,15 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 	if err != nil {
 		return nil, err
 	}
-	dataAuditService := mods2.NewDataAuditService(core, instructionDataDao)
+	themeDao, err := mods.NewThemeDao(ctx, daoCore, cache)
+	if err != nil {
+		return nil, err
+	}
+	reviewDao, err := mods.NewReviewDao(ctx, daoCore, userDao)
+	if err != nil {
+		return nil, err
+	}
+	dataAuditService := mods2.NewDataAuditService(core, instructionDataDao, themeDao, reviewDao)
 	loginLogDao, err := mods.NewLoginLogDao(ctx, daoCore, cache, userDao)
 	if err != nil {
 		return nil, err
@@ -91,7 +99,7 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 		LogsService:      logsService,
 		Validator:        validate,
 	}
-	statisticService := mods2.NewStatisticService(core, instructionDataDao, userDao)
+	statisticService := mods2.NewStatisticService(core, instructionDataDao, userDao, reviewDao)
 	statisticApi := &mods4.StatisticApi{
 		StatisticService: statisticService,
 		Validator:        validate,
@@ -100,7 +108,31 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 	if err != nil {
 		return nil, err
 	}
-	userService := mods2.NewUserService(core, userDao, enforcer)
+	sessionDao, err := mods.NewSessionDao(ctx, daoCore, cache)
+	if err != nil {
+		return nil, err
+	}
+	jwtKeyDao, err := mods.NewJwtKeyDao(ctx, daoCore)
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := InitializeJwt(ctx, configConfig, jwtKeyDao)
+	if err != nil {
+		return nil, err
+	}
+	storageStorage, err := InitializeStorage(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	reAuditDao, err := mods.NewReAuditDao(ctx, daoCore, userDao)
+	if err != nil {
+		return nil, err
+	}
+	accessTokenDao, err := mods.NewAccessTokenDao(ctx, daoCore)
+	if err != nil {
+		return nil, err
+	}
+	userService := mods2.NewUserService(core, userDao, sessionDao, instructionDataDao, reviewDao, reAuditDao, loginLogDao, operationLogDao, accessTokenDao, enforcer, jwt, storageStorage)
 	userApi := &mods4.UserApi{
 		UserService: userService,
 		LogsService: logsService,
@@ -131,27 +163,95 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 		LogsService: modsLogsService,
 		Validator:   validate,
 	}
+	themeService := mods2.NewThemeService(core, themeDao)
+	themeApi := &mods4.ThemeApi{
+		ThemeService: themeService,
+		LogsService:  logsService,
+		Validator:    validate,
+	}
+	reAuditService := mods2.NewReAuditService(core, reAuditDao)
+	reAuditApi := &mods4.ReAuditApi{
+		ReAuditService: reAuditService,
+		LogsService:    logsService,
+		Validator:      validate,
+	}
+	inviteCodeDao, err := mods.NewInviteCodeDao(ctx, daoCore)
+	if err != nil {
+		return nil, err
+	}
+	inviteCodeService := mods2.NewInviteCodeService(core, inviteCodeDao)
+	inviteCodeApi := &mods4.InviteCodeApi{
+		InviteCodeService: inviteCodeService,
+		LogsService:       logsService,
+		Validator:         validate,
+	}
+	twoFactorPolicyDao, err := mods.NewTwoFactorPolicyDao(ctx, daoCore)
+	if err != nil {
+		return nil, err
+	}
+	twoFactorPolicyService := mods2.NewTwoFactorPolicyService(core, twoFactorPolicyDao)
+	twoFactorPolicyApi := &mods4.TwoFactorPolicyApi{
+		TwoFactorPolicyService: twoFactorPolicyService,
+		LogsService:            logsService,
+		Validator:              validate,
+	}
+	loginAttemptDao := mods.NewLoginAttemptDao(daoCore, cache)
+	loginLockoutService := mods2.NewLoginLockoutService(core, loginAttemptDao)
+	loginLockoutApi := &mods4.LoginLockoutApi{
+		LoginLockoutService: loginLockoutService,
+		LogsService:         logsService,
+		Validator:           validate,
+	}
+	organizationDao, err := mods.NewOrganizationDao(ctx, daoCore)
+	if err != nil {
+		return nil, err
+	}
+	organizationService := mods2.NewOrganizationService(core, organizationDao, userDao, enforcer)
+	organizationApi := &mods4.OrganizationApi{
+		OrganizationService: organizationService,
+		LogsService:         logsService,
+		Validator:           validate,
+	}
+	policyService := mods2.NewPolicyService(core, userDao, enforcer)
+	policyApi := &mods4.PolicyApi{
+		PolicyService: policyService,
+		LogsService:   logsService,
+		Validator:     validate,
+	}
 	adminAdmin := &admin.Admin{
-		DataAuditApi:     dataAuditApi,
-		StatisticApi:     statisticApi,
-		UserApi:          userApi,
-		NoticeApi:        noticeApi,
-		DocumentationApi: documentationApi,
-		LogsApi:          logsApi,
-	}
-	jwt, err := InitializeJwt(configConfig)
+		DataAuditApi:       dataAuditApi,
+		StatisticApi:       statisticApi,
+		UserApi:            userApi,
+		NoticeApi:          noticeApi,
+		DocumentationApi:   documentationApi,
+		LogsApi:            logsApi,
+		ThemeApi:           themeApi,
+		ReAuditApi:         reAuditApi,
+		InviteCodeApi:      inviteCodeApi,
+		TwoFactorPolicyApi: twoFactorPolicyApi,
+		LoginLockoutApi:    loginLockoutApi,
+		OrganizationApi:    organizationApi,
+		PolicyApi:          policyApi,
+	}
+	mailerMailer, err := InitializeMailer(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	authenticatorChain, err := mods5.NewAuthenticatorChain(core, userDao, sessionDao, enforcer)
 	if err != nil {
 		return nil, err
 	}
-	authService := mods5.NewAuthService(core, userDao, cache, jwt)
+	authService := mods5.NewAuthService(core, userDao, inviteCodeDao, twoFactorPolicyDao, cache, jwt, enforcer, mailerMailer, authenticatorChain, loginAttemptDao, sessionDao, operationLogDao)
 	authApi := &mods6.AuthApi{
 		AuthService: authService,
 		LogsService: logsService,
 		Validator:   validate,
 	}
-	profileService := mods5.NewProfileService(core, userDao)
+	profileService := mods5.NewProfileService(core, userDao, organizationDao, instructionDataDao, reviewDao, reAuditDao, loginLogDao, operationLogDao, cache, mailerMailer, storageStorage)
 	profileApi := &mods6.ProfileApi{
 		ProfileService: profileService,
+		LogsService:    logsService,
+		Validator:      validate,
 	}
 	modsDocumentationService := mods5.NewDocumentationService(core, documentationDao)
 	modsDocumentationApi := &mods6.DocumentationApi{
@@ -167,14 +267,54 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 	idempotencyApi := &mods6.IdempotencyApi{
 		IdempotencyService: idempotencyService,
 	}
+	modsThemeService := mods5.NewThemeService(core, themeDao)
+	modsThemeApi := &mods6.ThemeApi{
+		ThemeService: modsThemeService,
+		Validator:    validate,
+	}
+	twoFactorService := mods5.NewTwoFactorService(core, userDao, twoFactorPolicyDao, cache, jwt, sessionDao)
+	twoFactorApi := &mods6.TwoFactorApi{
+		TwoFactorService: twoFactorService,
+		LogsService:      logsService,
+		Validator:        validate,
+	}
+	accessTokenService := mods5.NewAccessTokenService(core, accessTokenDao)
+	accessTokenApi := &mods6.AccessTokenApi{
+		AccessTokenService: accessTokenService,
+		LogsService:        logsService,
+		Validator:          validate,
+	}
+	provider := InitializeOIDC(configConfig)
+	oidcService := mods5.NewOIDCService(core, userDao, cache, jwt, enforcer, provider, sessionDao)
+	oidcApi := &mods6.OIDCApi{
+		OIDCService: oidcService,
+		LogsService: logsService,
+		Validator:   validate,
+	}
+	sessionService := mods5.NewSessionService(core, sessionDao)
+	sessionApi := &mods6.SessionApi{
+		SessionService: sessionService,
+		LogsService:    logsService,
+		Validator:      validate,
+	}
+	jwksService := mods5.NewJWKSService(core, jwt)
+	jwksApi := &mods6.JWKSApi{
+		JWKSService: jwksService,
+	}
 	commonCommon := &common.Common{
 		AuthApi:          authApi,
 		ProfileApi:       profileApi,
 		DocumentationApi: modsDocumentationApi,
 		NoticeApi:        modsNoticeApi,
 		IdempotencyApi:   idempotencyApi,
-	}
-	datasetService := mods7.NewDatasetService(core, instructionDataDao, operationLogDao)
+		ThemeApi:         modsThemeApi,
+		TwoFactorApi:     twoFactorApi,
+		AccessTokenApi:   accessTokenApi,
+		OIDCApi:          oidcApi,
+		SessionApi:       sessionApi,
+		JWKSApi:          jwksApi,
+	}
+	datasetService := mods7.NewDatasetService(core, instructionDataDao, themeDao, operationLogDao)
 	datasetApi := &mods8.DatasetApi{
 		DatasetService: datasetService,
 		LogsService:    logsService,
@@ -209,9 +349,12 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 		RouterV1: routerRouter,
 	}
 	authMiddleware := &mods10.AuthMiddleware{
-		Jwt:    jwt,
-		Cache:  cache,
-		Config: configConfig,
+		Jwt:            jwt,
+		Cache:          cache,
+		Config:         configConfig,
+		AccessTokenDao: accessTokenDao,
+		SessionDao:     sessionDao,
+		UserDao:        userDao,
 	}
 	loggingMiddleware := &mods10.LoggingMiddleware{
 		Zap: zap,
@@ -228,19 +371,28 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 		IdempotencyService: idempotencyService,
 		Config:             configConfig,
 	}
+	organizationMiddleware := &mods10.OrganizationMiddleware{
+		Enforcer:        enforcer,
+		OrganizationDao: organizationDao,
+	}
+	impersonationMiddleware := &mods10.ImpersonationMiddleware{
+		LogsService: logsService,
+	}
 	middlewareMiddleware := &middleware.Middleware{
-		AuthMiddleware:        authMiddleware,
-		LoggingMiddleware:     loggingMiddleware,
-		PrometheusMiddleware:  prometheusMiddleware,
-		ContextMiddleware:     contextMiddleware,
-		IdempotencyMiddleware: idempotencyMiddleware,
-		Config:                configConfig,
-	}
-	tasksTasks, err := tasks.New(ctx, configConfig, loginLogDao, operationLogDao, jwt, zap)
+		AuthMiddleware:          authMiddleware,
+		LoggingMiddleware:       loggingMiddleware,
+		PrometheusMiddleware:    prometheusMiddleware,
+		ContextMiddleware:       contextMiddleware,
+		IdempotencyMiddleware:   idempotencyMiddleware,
+		OrganizationMiddleware:  organizationMiddleware,
+		ImpersonationMiddleware: impersonationMiddleware,
+		Config:                  configConfig,
+	}
+	tasksTasks, err := tasks.New(ctx, configConfig, loginLogDao, operationLogDao, instructionDataDao, reviewDao, reAuditDao, jwtKeyDao, userService, jwt, zap)
 	if err != nil {
 		return nil, err
 	}
-	appApp, err := app.New(ctx, zap, configConfig, router3, middlewareMiddleware, tasksTasks, mongo, redis)
+	appApp, err := app.New(ctx, zap, configConfig, router3, middlewareMiddleware, tasksTasks, mongo, redis, enforcer)
 	if err != nil {
 		return nil, err
 	}
@@ -252,15 +404,15 @@ func InitializeApp(ctx context.Context) (*app.App, error) {
 var (
 	RouterProviderSet = wire.NewSet(wire.Struct(new(mods9.AdminRouter), "*"), wire.Struct(new(mods9.UserRouter), "*"), wire.Struct(new(mods9.CommonRouter), "*"), wire.Struct(new(router.Router), "*"), wire.Struct(new(router2.Router), "*"))
 
-	ApiProviderSet = wire.NewSet(wire.Struct(new(mods6.AuthApi), "*"), wire.Struct(new(mods6.ProfileApi), "*"), wire.Struct(new(mods6.DocumentationApi), "*"), wire.Struct(new(mods6.NoticeApi), "*"), wire.Struct(new(mods6.IdempotencyApi), "*"), wire.Struct(new(mods8.DatasetApi), "*"), wire.Struct(new(mods8.StatisticApi), "*"), wire.Struct(new(mods4.UserApi), "*"), wire.Struct(new(mods4.DocumentationApi), "*"), wire.Struct(new(mods4.NoticeApi), "*"), wire.Struct(new(mods4.StatisticApi), "*"), wire.Struct(new(mods4.LogsApi), "*"), wire.Struct(new(mods4.DataAuditApi), "*"), wire.Struct(new(common.Common), "*"), wire.Struct(new(user.User), "*"), wire.Struct(new(admin.Admin), "*"), wire.Struct(new(api.Api), "*"))
+	ApiProviderSet = wire.NewSet(wire.Struct(new(mods6.AuthApi), "*"), wire.Struct(new(mods6.ProfileApi), "*"), wire.Struct(new(mods6.DocumentationApi), "*"), wire.Struct(new(mods6.NoticeApi), "*"), wire.Struct(new(mods6.IdempotencyApi), "*"), wire.Struct(new(mods6.ThemeApi), "*"), wire.Struct(new(mods6.TwoFactorApi), "*"), wire.Struct(new(mods6.AccessTokenApi), "*"), wire.Struct(new(mods6.OIDCApi), "*"), wire.Struct(new(mods6.SessionApi), "*"), wire.Struct(new(mods6.JWKSApi), "*"), wire.Struct(new(mods8.DatasetApi), "*"), wire.Struct(new(mods8.StatisticApi), "*"), wire.Struct(new(mods4.UserApi), "*"), wire.Struct(new(mods4.DocumentationApi), "*"), wire.Struct(new(mods4.NoticeApi), "*"), wire.Struct(new(mods4.StatisticApi), "*"), wire.Struct(new(mods4.LogsApi), "*"), wire.Struct(new(mods4.DataAuditApi), "*"), wire.Struct(new(mods4.ThemeApi), "*"), wire.Struct(new(mods4.ReAuditApi), "*"), wire.Struct(new(mods4.InviteCodeApi), "*"), wire.Struct(new(mods4.TwoFactorPolicyApi), "*"), wire.Struct(new(mods4.LoginLockoutApi), "*"), wire.Struct(new(mods4.OrganizationApi), "*"), wire.Struct(new(mods4.PolicyApi), "*"), wire.Struct(new(common.Common), "*"), wire.Struct(new(user.User), "*"), wire.Struct(new(admin.Admin), "*"), wire.Struct(new(api.Api), "*"))
 
 	ValidatorProviderSet = wire.NewSet(validator.NewValidator)
 
-	ServiceProviderSet = wire.NewSet(service.NewCore, wire.Struct(new(admin2.Admin), "*"), wire.Struct(new(user2.User), "*"), wire.Struct(new(common2.Common), "*"), wire.Struct(new(sys.Sys), "*"), mods2.NewDataAuditService, mods2.NewStatisticService, mods2.NewUserService, mods2.NewNoticeService, mods2.NewDocumentationService, mods2.NewLogsService, mods5.NewAuthService, mods5.NewProfileService, mods5.NewDocumentationService, mods5.NewNoticeService, mods5.NewIdempotencyService, mods7.NewDatasetService, mods7.NewStatisticService, mods3.NewLogsService)
+	ServiceProviderSet = wire.NewSet(service.NewCore, wire.Struct(new(admin2.Admin), "*"), wire.Struct(new(user2.User), "*"), wire.Struct(new(common2.Common), "*"), wire.Struct(new(sys.Sys), "*"), mods2.NewDataAuditService, mods2.NewStatisticService, mods2.NewUserService, mods2.NewNoticeService, mods2.NewDocumentationService, mods2.NewLogsService, mods2.NewThemeService, mods2.NewReAuditService, mods2.NewInviteCodeService, mods2.NewTwoFactorPolicyService, mods2.NewLoginLockoutService, mods2.NewOrganizationService, mods2.NewPolicyService, mods5.NewAuthService, mods5.NewAuthenticatorChain, mods5.NewProfileService, mods5.NewDocumentationService, mods5.NewNoticeService, mods5.NewIdempotencyService, mods5.NewThemeService, mods5.NewTwoFactorService, mods5.NewAccessTokenService, mods5.NewOIDCService, mods5.NewSessionService, mods5.NewJWKSService, mods7.NewDatasetService, mods7.NewStatisticService, mods3.NewLogsService)
 
-	DaoProviderSet = wire.NewSet(dao.NewCore, dao.NewCache, mods.NewUserDao, mods.NewInstructionDataDao, mods.NewNoticeDao, mods.NewLoginLogDao, mods.NewOperationLogDao, mods.NewDocumentationDao)
+	DaoProviderSet = wire.NewSet(dao.NewCore, dao.NewCache, mods.NewUserDao, mods.NewInstructionDataDao, mods.NewNoticeDao, mods.NewLoginLogDao, mods.NewOperationLogDao, mods.NewDocumentationDao, mods.NewThemeDao, mods.NewReviewDao, mods.NewReAuditDao, mods.NewInviteCodeDao, mods.NewTwoFactorPolicyDao, mods.NewAccessTokenDao, mods.NewLoginAttemptDao, mods.NewSessionDao, mods.NewJwtKeyDao, mods.NewOrganizationDao)
 
-	MiddlewareProviderSet = wire.NewSet(wire.Struct(new(mods10.LoggingMiddleware), "*"), wire.Struct(new(mods10.PrometheusMiddleware), "*"), wire.Struct(new(mods10.AuthMiddleware), "*"), wire.Struct(new(mods10.ContextMiddleware), "*"), wire.Struct(new(mods10.IdempotencyMiddleware), "*"), wire.Struct(new(middleware.Middleware), "*"))
+	MiddlewareProviderSet = wire.NewSet(wire.Struct(new(mods10.LoggingMiddleware), "*"), wire.Struct(new(mods10.PrometheusMiddleware), "*"), wire.Struct(new(mods10.AuthMiddleware), "*"), wire.Struct(new(mods10.ContextMiddleware), "*"), wire.Struct(new(mods10.IdempotencyMiddleware), "*"), wire.Struct(new(mods10.OrganizationMiddleware), "*"), wire.Struct(new(mods10.ImpersonationMiddleware), "*"), wire.Struct(new(middleware.Middleware), "*"))
 
 	SchedulerProviderSet = wire.NewSet(tasks.New)
 )
@@ -4,12 +4,14 @@ const ( // Business error code
 	MessageSuccess = "success"
 	CodeSuccess    = 200
 
-	CodeNotAuthorized  = 1001
-	CodeAuthFailed     = 1002
-	CodeTokenInvalid   = 1003
-	CodeTokenExpired   = 1004
-	CodeTokenMissed    = 1005
-	CodePermissionDeny = 1006
+	CodeNotAuthorized   = 1001
+	CodeAuthFailed      = 1002
+	CodeTokenInvalid    = 1003
+	CodeTokenExpired    = 1004
+	CodeTokenMissed     = 1005
+	CodePermissionDeny  = 1006
+	CodeTooManyAttempts = 1007
+	CodeSuspended       = 1008
 
 	CodeInvalidRequest = 2001
 	CodeIdempotency    = 2002
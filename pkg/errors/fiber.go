@@ -28,6 +28,14 @@ func PermissionDeny(err error) *AppError {
 	return NewAppErrorWithCause(CodePermissionDeny, fiber.StatusForbidden, "Permission deny", err)
 }
 
+func TooManyAttempts(err error) *AppError {
+	return NewAppErrorWithCause(CodeTooManyAttempts, fiber.StatusTooManyRequests, "Too many attempts", err)
+}
+
+func Suspended(err error) *AppError {
+	return NewAppErrorWithCause(CodeSuspended, fiber.StatusForbidden, "Account suspended", err)
+}
+
 func InvalidRequest(err error) *AppError {
 	return NewAppErrorWithCause(CodeInvalidRequest, fiber.StatusBadRequest, "Invalid request", err)
 }
@@ -1,9 +1,6 @@
 package jwt
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"fmt"
 	"sync"
 	"time"
@@ -21,28 +18,58 @@ var (
 	once        sync.Once
 )
 
+// Claims are the claims of the tokens. SessionID links the tokens to the server-side session they were issued for,
+// which is also the family of the refresh tokens rotated from the one issued at login. The ID of a refresh token tells
+// it apart from the others of its family. Actor is set on the tokens issued to an admin impersonating the subject,
+// which are read-only if ReadOnly is set.
+type Claims struct {
+	jwt.StandardClaims
+	IssuedAtMilli int64  `json:"iat_ms,omitempty"` // iat in unix milliseconds, as iat has whole seconds only
+	SessionID     string `json:"sid,omitempty"`
+	Actor         *Actor `json:"act,omitempty"`
+	ReadOnly      bool   `json:"read_only,omitempty"`
+}
+
+// IssuedAtMillis returns the issue time of the token in unix milliseconds, to the second for tokens without iat_ms.
+func (c *Claims) IssuedAtMillis() int64 {
+	if c.IssuedAtMilli != 0 {
+		return c.IssuedAtMilli
+	}
+	return c.IssuedAt * 1000
+}
+
+// Actor is the party acting on behalf of the subject of a token, as in the act claim of RFC 8693.
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
 type Jwt struct {
-	privateKey      *ecdsa.PrivateKey
-	publicKey       *ecdsa.PublicKey
+	mu              sync.RWMutex
+	signingKey      *Key
+	keys            map[string]*Key // signing and retired keys by ID, all of them verify tokens
 	tokenDuration   time.Duration
 	refreshDuration time.Duration
 	refreshBuffer   time.Duration
+
+	reloadMu       sync.Mutex
+	loadKeys       func() ([]*Key, error) // loads the ring for tokens of unknown keys, nil if not set
+	reloadInterval time.Duration
+	reloadedAt     time.Time
 }
 
-func New(
-	privateKey *ecdsa.PrivateKey, tokenDuration, refreshDuration time.Duration,
-	refreshBuffer time.Duration,
-) (*Jwt, error) {
+// New creates the JWT singleton with the key ring. The newest key signs the tokens and the others only verify them.
+func New(keys []*Key, tokenDuration, refreshDuration, refreshBuffer time.Duration) (*Jwt, error) {
 	var err error
 	once.Do(
 		func() {
 			j := &Jwt{
-				privateKey:      privateKey,
-				publicKey:       &privateKey.PublicKey,
 				tokenDuration:   tokenDuration,
 				refreshDuration: refreshDuration,
 				refreshBuffer:   refreshBuffer,
 			}
+			if err = j.SetKeys(keys); err != nil {
+				return
+			}
 			if err = j.checkJWT(); err == nil {
 				jwtInstance = j
 			}
@@ -51,37 +78,57 @@ func New(
 	return jwtInstance, err
 }
 
-func Update(privateKey *ecdsa.PrivateKey, tokenDuration, refreshDuration, refreshBuffer time.Duration) error {
-	var err error
-	if privateKey == nil {
-		privateKey = jwtInstance.privateKey
-	}
-	jwtInstance = &Jwt{
-		privateKey:      privateKey,
-		publicKey:       &privateKey.PublicKey,
+// Update changes the durations of the JWT singleton, keeping its keys.
+func Update(tokenDuration, refreshDuration, refreshBuffer time.Duration) error {
+	j := &Jwt{
+		signingKey:      jwtInstance.signingKey,
 		tokenDuration:   tokenDuration,
 		refreshDuration: refreshDuration,
 		refreshBuffer:   refreshBuffer,
 	}
-	if err = jwtInstance.checkJWT(); err != nil {
+	if err := j.checkJWT(); err != nil {
 		return err
 	}
+	jwtInstance.mu.Lock()
+	defer jwtInstance.mu.Unlock()
+	jwtInstance.tokenDuration = tokenDuration
+	jwtInstance.refreshDuration = refreshDuration
+	jwtInstance.refreshBuffer = refreshBuffer
 	return nil
 }
 
-func (j *Jwt) UpdateKey() error {
-	// XXX: can be chosen from elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	if err != nil {
-		return err
+// SetKeys replaces the key ring. The newest key signs the tokens and the others only verify them.
+func (j *Jwt) SetKeys(keys []*Key) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("key ring is empty")
 	}
-	j.privateKey = privateKey
-	j.publicKey = &privateKey.PublicKey
+	ring := make(map[string]*Key, len(keys))
+	signingKey := keys[0]
+	for _, key := range keys {
+		if key.ID == "" || key.PrivateKey == nil {
+			return fmt.Errorf("key ID or private key is empty")
+		}
+		ring[key.ID] = key
+		if key.CreatedAt.After(signingKey.CreatedAt) {
+			signingKey = key
+		}
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.signingKey = signingKey
+	j.keys = ring
 	return nil
 }
 
+// SigningKeyID returns the ID of the key signing the tokens.
+func (j *Jwt) SigningKeyID() string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.signingKey.ID
+}
+
 func (j *Jwt) checkJWT() error {
-	if j.privateKey == nil {
+	if j.signingKey == nil {
 		return fmt.Errorf("private key is nil")
 	}
 	if j.tokenDuration == 0 {
@@ -100,43 +147,119 @@ func (j *Jwt) checkJWT() error {
 	return nil
 }
 
-func (j *Jwt) GenerateAccessToken(subject string) (string, error) {
-	if subject == "" {
-		return "", fmt.Errorf("subject is empty") // TODO: CHANGE ERROR TYPE
+// SetKeyLoader sets the loader of the key ring, called when a token names a key which is not in the ring, such as a
+// key generated by another instance. Reloads are at least interval apart.
+func (j *Jwt) SetKeyLoader(load func() ([]*Key, error), interval time.Duration) {
+	j.reloadMu.Lock()
+	defer j.reloadMu.Unlock()
+	j.loadKeys = load
+	j.reloadInterval = interval
+	j.reloadedAt = time.Time{}
+}
+
+// keyFunc returns the public key of the ring the token names in its kid header. The ring is reloaded once if the key
+// is unknown.
+func (j *Jwt) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
 	}
-	token := jwt.NewWithClaims(
-		jwt.SigningMethodES256, &jwt.StandardClaims{
-			Subject:   subject,
-			Audience:  AccessAudience,
-			IssuedAt:  time.Now().Unix(),
-			ExpiresAt: time.Now().Add(j.tokenDuration).Unix(),
-			NotBefore: time.Now().Unix(),
-		},
-	)
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.key(kid)
+	if !ok {
+		j.reloadKeys()
+		if key, ok = j.key(kid); !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+	}
+	return &key.PrivateKey.PublicKey, nil
+}
+
+func (j *Jwt) key(kid string) (*Key, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	key, ok := j.keys[kid]
+	return key, ok
+}
 
-	tokenString, err := token.SignedString(j.privateKey)
+// reloadKeys replaces the ring with the one of the loader, unless it was reloaded within the reload interval.
+func (j *Jwt) reloadKeys() {
+	j.reloadMu.Lock()
+	defer j.reloadMu.Unlock()
+	if j.loadKeys == nil || time.Since(j.reloadedAt) < j.reloadInterval {
+		return
+	}
+	j.reloadedAt = time.Now()
+	keys, err := j.loadKeys()
 	if err != nil {
-		return "", err
+		return
 	}
+	_ = j.SetKeys(keys) // an empty ring is kept out
+}
 
-	return tokenString, nil
+func (j *Jwt) GenerateAccessToken(subject, sessionID string) (string, error) {
+	return j.generateToken(subject, sessionID, "", AccessAudience)
 }
 
-func (j *Jwt) GenerateRefreshToken(subject string) (string, error) {
-	if subject == "" {
-		return "", fmt.Errorf("subject is empty") // TODO: CHANGE ERROR TYPE
+// GenerateRefreshToken generates a refresh token of the subject with the token ID, linked to the session if
+// sessionID is not empty.
+func (j *Jwt) GenerateRefreshToken(subject, sessionID, tokenID string) (string, error) {
+	return j.generateToken(subject, sessionID, tokenID, RefreshAudience)
+}
+
+// GenerateImpersonationToken generates an access token of the subject acting as the actor, valid for the duration.
+// It is not linked to a session and cannot be refreshed.
+func (j *Jwt) GenerateImpersonationToken(
+	subject, actor string, readOnly bool, duration time.Duration,
+) (string, error) {
+	if actor == "" {
+		return "", fmt.Errorf("actor is empty")
+	}
+	if duration <= 0 {
+		return "", fmt.Errorf("duration should be positive")
+	}
+	return j.signToken(
+		subject, duration, &Claims{
+			StandardClaims: jwt.StandardClaims{Audience: AccessAudience},
+			Actor:          &Actor{Subject: actor},
+			ReadOnly:       readOnly,
+		},
+	)
+}
+
+func (j *Jwt) generateToken(subject, sessionID, tokenID, audience string) (string, error) {
+	j.mu.RLock()
+	duration := j.tokenDuration
+	if audience == RefreshAudience {
+		duration = j.refreshDuration
 	}
-	token := jwt.NewWithClaims(
-		jwt.SigningMethodES256, &jwt.StandardClaims{
-			Subject:   subject,
-			Audience:  RefreshAudience,
-			IssuedAt:  time.Now().Unix(),
-			ExpiresAt: time.Now().Add(j.refreshDuration).Unix(),
-			NotBefore: time.Now().Unix(),
+	j.mu.RUnlock()
+	return j.signToken(
+		subject, duration, &Claims{
+			StandardClaims: jwt.StandardClaims{Id: tokenID, Audience: audience},
+			SessionID:      sessionID,
 		},
 	)
+}
 
-	tokenString, err := token.SignedString(j.privateKey)
+// signToken completes the claims with the subject and the validity period, and signs them with the signing key.
+func (j *Jwt) signToken(subject string, duration time.Duration, claims *Claims) (string, error) {
+	if subject == "" {
+		return "", fmt.Errorf("subject is empty") // TODO: CHANGE ERROR TYPE
+	}
+	j.mu.RLock()
+	signingKey := j.signingKey
+	j.mu.RUnlock()
+	now := time.Now()
+	claims.Subject = subject
+	claims.IssuedAt = now.Unix()
+	claims.IssuedAtMilli = now.UnixMilli()
+	claims.ExpiresAt = now.Add(duration).Unix()
+	claims.NotBefore = now.Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+
+	token.Header["kid"] = signingKey.ID
+
+	tokenString, err := token.SignedString(signingKey.PrivateKey)
 	if err != nil {
 		return "", err
 	}
@@ -149,16 +272,23 @@ func (j *Jwt) RefreshToken(token string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if _, ok := claims["act"]; ok {
+		return "", fmt.Errorf("impersonation token cannot be refreshed")
+	}
 
 	// Check if token is expired
-	if time.Unix(int64(claims["exp"].(float64)), 0).Sub(time.Now()) > j.refreshBuffer {
+	j.mu.RLock()
+	refreshBuffer := j.refreshBuffer
+	j.mu.RUnlock()
+	if time.Unix(int64(claims["exp"].(float64)), 0).Sub(time.Now()) > refreshBuffer {
 		return "", fmt.Errorf(
 			"token is not expired yet: %v", time.Unix(int64(claims["exp"].(float64)), 0).Sub(time.Now()),
 		)
 	}
 
 	// Generate new token
-	newToken, err := j.GenerateAccessToken(claims["sub"].(string))
+	sessionID, _ := claims["sid"].(string)
+	newToken, err := j.GenerateAccessToken(claims["sub"].(string), sessionID)
 	if err != nil {
 		return "", err
 	}
@@ -169,11 +299,7 @@ func (j *Jwt) RefreshToken(token string) (string, error) {
 func (j *Jwt) ExtractClaims(token string) (map[string]interface{}, error) {
 	claims := jwt.MapClaims{}
 
-	t, err := jwt.ParseWithClaims(
-		token, claims, func(token *jwt.Token) (interface{}, error) {
-			return j.publicKey, nil
-		},
-	)
+	t, err := jwt.ParseWithClaims(token, claims, j.keyFunc)
 	if err != nil || !t.Valid {
 		return nil, err
 	}
@@ -204,3 +330,38 @@ func (j *Jwt) VerifyRefreshToken(token string) (string, error) {
 	}
 	return claims["sub"].(string), nil
 }
+
+// ParseAccessToken verifies the access token and returns its claims.
+func (j *Jwt) ParseAccessToken(token string) (*Claims, error) {
+	return j.parseToken(token, AccessAudience)
+}
+
+// ParseRefreshToken verifies the refresh token and returns its claims.
+func (j *Jwt) ParseRefreshToken(token string) (*Claims, error) {
+	return j.parseToken(token, RefreshAudience)
+}
+
+// ParseExpiredAccessToken verifies the signature of the access token and returns its claims, also when the token has
+// expired, e.g. to end the session of the token on logout.
+func (j *Jwt) ParseExpiredAccessToken(token string) (*Claims, error) {
+	return j.parseTokenWith(&jwt.Parser{SkipClaimsValidation: true}, token, AccessAudience)
+}
+
+func (j *Jwt) parseToken(token, audience string) (*Claims, error) {
+	return j.parseTokenWith(&jwt.Parser{}, token, audience)
+}
+
+func (j *Jwt) parseTokenWith(parser *jwt.Parser, token, audience string) (*Claims, error) {
+	claims := &Claims{}
+	t, err := parser.ParseWithClaims(token, claims, j.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+	if !t.Valid {
+		return nil, fmt.Errorf("token invalid")
+	}
+	if claims.Audience != audience {
+		return nil, fmt.Errorf("invalid audience")
+	}
+	return claims, nil
+}
@@ -0,0 +1,90 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"time"
+)
+
+// Key is an ES256 key of the ring, named by the kid header of the tokens it signs.
+type Key struct {
+	ID         string
+	PrivateKey *ecdsa.PrivateKey
+	CreatedAt  time.Time
+}
+
+// JSONWebKey is an EC public key in JWK format (RFC 7517).
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JSONWebKeySet is a JWK Set (RFC 7517), as served on /.well-known/jwks.json.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// GenerateKey generates a P-256 key with a random ID.
+func GenerateKey() (*Key, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Key{ID: hex.EncodeToString(id), PrivateKey: privateKey, CreatedAt: time.Now()}, nil
+}
+
+// MarshalPrivateKey encodes the private key of the key in SEC 1 DER form, for persisting it.
+func (k *Key) MarshalPrivateKey() ([]byte, error) {
+	return x509.MarshalECPrivateKey(k.PrivateKey)
+}
+
+// ParseKey decodes a key persisted with MarshalPrivateKey.
+func ParseKey(id string, der []byte, createdAt time.Time) (*Key, error) {
+	privateKey, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{ID: id, PrivateKey: privateKey, CreatedAt: createdAt}, nil
+}
+
+// JSONWebKeySet returns the public keys of the ring, so that other services can verify the tokens.
+func (j *Jwt) JSONWebKeySet() *JSONWebKeySet {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	keys := make([]*Key, 0, len(j.keys))
+	for _, key := range j.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a].CreatedAt.After(keys[b].CreatedAt) })
+	set := &JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		publicKey := key.PrivateKey.PublicKey
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		set.Keys = append(
+			set.Keys, JSONWebKey{
+				Kty: "EC",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: "ES256",
+				Crv: publicKey.Curve.Params().Name,
+				X:   base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size))),
+				Y:   base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size))),
+			},
+		)
+	}
+	return set
+}
@@ -0,0 +1,179 @@
+package ldap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// BER identifier octets used by LDAPv3 (RFC 4511). Only tag numbers below 31 are needed.
+const (
+	TagBoolean     byte = 0x01
+	TagInteger     byte = 0x02
+	TagOctetString byte = 0x04
+	TagNull        byte = 0x05
+	TagEnumerated  byte = 0x0a
+	TagSequence    byte = 0x30
+	TagSet         byte = 0x31
+
+	TagBindRequest           byte = 0x60
+	TagBindResponse          byte = 0x61
+	TagUnbindRequest         byte = 0x42
+	TagSearchRequest         byte = 0x63
+	TagSearchResultEntry     byte = 0x64
+	TagSearchResultDone      byte = 0x65
+	TagSearchResultReference byte = 0x73
+
+	TagAuthSimple byte = 0x80
+
+	TagFilterAnd      byte = 0xa0
+	TagFilterOr       byte = 0xa1
+	TagFilterNot      byte = 0xa2
+	TagFilterEquality byte = 0xa3
+	TagFilterPresent  byte = 0x87
+
+	constructedBit byte = 0x20
+	maxPacketSize       = 16 << 20
+)
+
+// Packet is a BER element. Constructed elements have children, primitive elements have a value.
+type Packet struct {
+	Tag      byte
+	Value    []byte
+	Children []*Packet
+}
+
+func NewPrimitive(tag byte, value []byte) *Packet {
+	return &Packet{Tag: tag, Value: value}
+}
+
+func NewConstructed(tag byte, children ...*Packet) *Packet {
+	return &Packet{Tag: tag | constructedBit, Children: children}
+}
+
+func NewOctetString(s string) *Packet {
+	return NewPrimitive(TagOctetString, []byte(s))
+}
+
+func NewInteger(tag byte, v int64) *Packet {
+	var b []byte
+	for {
+		b = append([]byte{byte(v)}, b...)
+		if (v >= -128 && v < 128) || len(b) == 8 {
+			break
+		}
+		v >>= 8
+	}
+	return NewPrimitive(tag, b)
+}
+
+func NewBoolean(v bool) *Packet {
+	if v {
+		return NewPrimitive(TagBoolean, []byte{0xff})
+	}
+	return NewPrimitive(TagBoolean, []byte{0x00})
+}
+
+// NewResult returns an LDAPResult with the tag of the response operation.
+func NewResult(tag byte, resultCode int64, message string) *Packet {
+	return NewConstructed(tag, NewInteger(TagEnumerated, resultCode), NewOctetString(""), NewOctetString(message))
+}
+
+// NewMessage wraps the protocol operation into an LDAPMessage.
+func NewMessage(messageID int64, op *Packet) *Packet {
+	return NewConstructed(TagSequence, NewInteger(TagInteger, messageID), op)
+}
+
+// Constructed reports whether the packet has children.
+func (p *Packet) Constructed() bool {
+	return p.Tag&constructedBit != 0
+}
+
+// Int decodes the value as a two's complement integer.
+func (p *Packet) Int() int64 {
+	var v int64
+	for i, b := range p.Value {
+		if i == 0 && b&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(b)
+	}
+	return v
+}
+
+func (p *Packet) String() string {
+	return string(p.Value)
+}
+
+// Bytes encodes the packet.
+func (p *Packet) Bytes() []byte {
+	content := p.Value
+	if p.Constructed() {
+		var buf bytes.Buffer
+		for _, child := range p.Children {
+			buf.Write(child.Bytes())
+		}
+		content = buf.Bytes()
+	}
+	out := append([]byte{p.Tag}, encodeLength(len(content))...)
+	return append(out, content...)
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// ReadPacket reads and decodes one packet.
+func ReadPacket(r io.Reader) (*Packet, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 {
+			return nil, fmt.Errorf("unsupported length encoding")
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		length = 0
+		for _, v := range b {
+			length = length<<8 | int(v)
+		}
+	}
+	if length > maxPacketSize {
+		return nil, fmt.Errorf("packet too large")
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+	return decode(header[0], content)
+}
+
+func decode(tag byte, content []byte) (*Packet, error) {
+	p := &Packet{Tag: tag}
+	if !p.Constructed() {
+		p.Value = content
+		return p, nil
+	}
+	r := bytes.NewReader(content)
+	for r.Len() > 0 {
+		child, err := ReadPacket(r)
+		if err != nil {
+			return nil, fmt.Errorf("malformed packet: %w", err)
+		}
+		p.Children = append(p.Children, child)
+	}
+	return p, nil
+}
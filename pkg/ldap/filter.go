@@ -0,0 +1,113 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EscapeFilter escapes the special characters of a filter assertion value (RFC 4515).
+func EscapeFilter(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', '*', '(', ')', 0:
+			b.WriteString(fmt.Sprintf("\\%02x", c))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// CompileFilter encodes a string filter. The and, or, not, equality and presence filters are supported.
+func CompileFilter(filter string) (*Packet, error) {
+	p, rest, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q after filter", rest)
+	}
+	return p, nil
+}
+
+func compileFilter(s string) (*Packet, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("filter should start with '('")
+	}
+	s = s[1:]
+	if s == "" {
+		return nil, "", fmt.Errorf("unexpected end of filter")
+	}
+	switch s[0] {
+	case '&', '|':
+		tag := TagFilterAnd
+		if s[0] == '|' {
+			tag = TagFilterOr
+		}
+		p := NewConstructed(tag)
+		s = s[1:]
+		for strings.HasPrefix(s, "(") {
+			child, rest, err := compileFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			p.Children = append(p.Children, child)
+			s = rest
+		}
+		if !strings.HasPrefix(s, ")") {
+			return nil, "", fmt.Errorf("missing ')'")
+		}
+		return p, s[1:], nil
+	case '!':
+		child, rest, err := compileFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", fmt.Errorf("missing ')'")
+		}
+		return NewConstructed(TagFilterNot, child), rest[1:], nil
+	}
+	end := strings.IndexByte(s, ')') // ')' in values is escaped
+	if end < 0 {
+		return nil, "", fmt.Errorf("missing ')'")
+	}
+	item, rest := s[:end], s[end+1:]
+	attr, value, ok := strings.Cut(item, "=")
+	if !ok || attr == "" {
+		return nil, "", fmt.Errorf("invalid filter item %q", item)
+	}
+	if value == "*" {
+		return NewPrimitive(TagFilterPresent, []byte(attr)), rest, nil
+	}
+	if strings.Contains(value, "*") {
+		return nil, "", fmt.Errorf("substring filters are not supported")
+	}
+	unescaped, err := unescapeFilter(value)
+	if err != nil {
+		return nil, "", err
+	}
+	return NewConstructed(TagFilterEquality, NewOctetString(attr), NewOctetString(unescaped)), rest, nil
+}
+
+func unescapeFilter(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+3 > len(s) {
+			return "", fmt.Errorf("invalid escape in %q", s)
+		}
+		decoded, err := hex.DecodeString(s[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("invalid escape in %q", s)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,233 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	e "errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LDAP result codes (RFC 4511)
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultNoSuchObject       = 32
+	ResultInvalidCredentials = 49
+)
+
+// Error is a non-success result returned by the directory.
+type Error struct {
+	ResultCode int64
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ldap result code %d: %s", e.ResultCode, e.Message)
+}
+
+// IsInvalidCredentials reports whether the error is a failed bind.
+func IsInvalidCredentials(err error) bool {
+	var ldapErr *Error
+	return e.As(err, &ldapErr) && ldapErr.ResultCode == ResultInvalidCredentials
+}
+
+// Entry is an entry returned by a search.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// GetAll returns the values of the attribute, attribute names are case-insensitive.
+func (en *Entry) GetAll(name string) []string {
+	for attr, values := range en.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Get returns the first value of the attribute, or "" if it is missing.
+func (en *Entry) Get(name string) string {
+	if values := en.GetAll(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Conn is a connection to a directory, supporting simple binds and searches. It is not safe for concurrent use.
+type Conn struct {
+	conn      net.Conn
+	timeout   time.Duration
+	messageID int64
+}
+
+// Dial connects to the ldap:// or ldaps:// URL.
+func Dial(ctx context.Context, rawURL string, timeout time.Duration, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "ldap":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "389")
+		}
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	case "ldaps":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: u.Hostname()}
+		}
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{conn: conn, timeout: timeout}, nil
+}
+
+// Bind authenticates with a simple bind. An empty password is rejected, since directories treat it as an
+// unauthenticated bind which always succeeds.
+func (c *Conn) Bind(dn, password string) error {
+	if password == "" {
+		return &Error{ResultCode: ResultInvalidCredentials, Message: "empty password"}
+	}
+	op := NewConstructed(
+		TagBindRequest, NewInteger(TagInteger, 3), NewOctetString(dn), NewPrimitive(TagAuthSimple, []byte(password)),
+	)
+	messageID, err := c.send(op)
+	if err != nil {
+		return err
+	}
+	resp, err := c.receive(messageID)
+	if err != nil {
+		return err
+	}
+	if resp.Tag != TagBindResponse {
+		return fmt.Errorf("unexpected response to bind")
+	}
+	return resultError(resp)
+}
+
+// Search searches the subtree of the base DN.
+func (c *Conn) Search(baseDN, filter string, attributes []string, sizeLimit int64) ([]*Entry, error) {
+	compiled, err := CompileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	attrs := NewConstructed(TagSequence)
+	for _, attr := range attributes {
+		attrs.Children = append(attrs.Children, NewOctetString(attr))
+	}
+	op := NewConstructed(
+		TagSearchRequest,
+		NewOctetString(baseDN),
+		NewInteger(TagEnumerated, 2), // wholeSubtree
+		NewInteger(TagEnumerated, 0), // neverDerefAliases
+		NewInteger(TagInteger, sizeLimit),
+		NewInteger(TagInteger, int64(c.timeout.Seconds())),
+		NewBoolean(false),
+		compiled,
+		attrs,
+	)
+	messageID, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for {
+		resp, err := c.receive(messageID)
+		if err != nil {
+			return nil, err
+		}
+		switch resp.Tag {
+		case TagSearchResultEntry:
+			entry, err := parseEntry(resp)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case TagSearchResultReference:
+			// referrals are not followed
+		case TagSearchResultDone:
+			if err = resultError(resp); err != nil {
+				return nil, err
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("unexpected response to search")
+		}
+	}
+}
+
+// Close unbinds and closes the connection.
+func (c *Conn) Close() error {
+	_, _ = c.send(NewPrimitive(TagUnbindRequest, nil))
+	return c.conn.Close()
+}
+
+func (c *Conn) send(op *Packet) (int64, error) {
+	c.messageID++
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	_, err := c.conn.Write(NewMessage(c.messageID, op).Bytes())
+	return c.messageID, err
+}
+
+func (c *Conn) receive(messageID int64) (*Packet, error) {
+	for {
+		msg, err := ReadPacket(c.conn)
+		if err != nil {
+			return nil, err
+		}
+		if msg.Tag != TagSequence || len(msg.Children) < 2 {
+			return nil, fmt.Errorf("malformed ldap message")
+		}
+		if id := msg.Children[0].Int(); id == messageID {
+			return msg.Children[1], nil
+		} else if id == 0 { // unsolicited notification, e.g. notice of disconnection
+			return nil, resultError(msg.Children[1])
+		}
+	}
+}
+
+func resultError(resp *Packet) error {
+	if len(resp.Children) < 3 {
+		return fmt.Errorf("malformed ldap result")
+	}
+	if code := resp.Children[0].Int(); code != ResultSuccess {
+		return &Error{ResultCode: code, Message: resp.Children[2].String()}
+	}
+	return nil
+}
+
+func parseEntry(p *Packet) (*Entry, error) {
+	if len(p.Children) < 2 {
+		return nil, fmt.Errorf("malformed search result entry")
+	}
+	entry := &Entry{DN: p.Children[0].String(), Attributes: map[string][]string{}}
+	for _, attr := range p.Children[1].Children {
+		if len(attr.Children) < 2 {
+			return nil, fmt.Errorf("malformed attribute")
+		}
+		values := make([]string, 0, len(attr.Children[1].Children))
+		for _, v := range attr.Children[1].Children {
+			values = append(values, v.String())
+		}
+		entry.Attributes[attr.Children[0].String()] = values
+	}
+	return entry, nil
+}
@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mailer sends plain text mails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTPMailer sends mails through an SMTP server, authenticating with PLAIN auth if a username is given.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (s *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, message(s.from, to, subject, body))
+}
+
+// FileMailer appends mails to a file instead of sending them, for local development and testing. The path "stdout"
+// writes to the standard output.
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+func NewFileMailer(path, from string) *FileMailer {
+	return &FileMailer{path: path, from: from}
+}
+
+func (f *FileMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var w io.Writer = os.Stdout
+	if f.path != "stdout" {
+		file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		w = file
+	}
+	_, err := fmt.Fprintf(w, "%s\r\n", message(f.from, to, subject, body))
+	return err
+}
+
+func message(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
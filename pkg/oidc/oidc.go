@@ -0,0 +1,300 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/golang-jwt/jwt"
+)
+
+// Provider is an OpenID Connect relying party using the authorization code flow with PKCE. The discovery document
+// and the signing keys of the issuer are fetched on first use, so the server starts even if the issuer is down.
+type Provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	client       *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]interface{}
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// Claims are the claims of a verified ID token.
+type Claims map[string]interface{}
+
+func New(issuer, clientID, clientSecret, redirectURL string, scopes []string, timeout time.Duration) *Provider {
+	return &Provider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: timeout},
+	}
+}
+
+// Issuer returns the issuer identifier of the provider.
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+// NewCodeVerifier returns a random PKCE code verifier.
+func NewCodeVerifier() (string, error) {
+	return crypt.RandomToken(32)
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint the user agent should be sent to.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.clientID},
+		"redirect_uri":          {p.redirectURL},
+		"scope":                 {strings.Join(p.scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return d.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems the authorization code at the token endpoint and returns the raw ID token.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.redirectURL},
+		"client_id":     {p.clientID},
+		"code_verifier": {codeVerifier},
+	}
+	if p.clientSecret != "" {
+		form.Set("client_secret", p.clientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("invalid token response (status %d)", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return "", fmt.Errorf("token endpoint returned %d: %s %s", resp.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return "", fmt.Errorf("token response has no id_token")
+	}
+	return token.IDToken, nil
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of the ID token and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+			switch token.Method.(type) {
+			case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			default:
+				return nil, fmt.Errorf("unexpected signing method %s", token.Header["alg"])
+			}
+			kid, _ := token.Header["kid"].(string)
+			return p.getKey(ctx, kid)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if iss, _ := claims["iss"].(string); iss != p.issuer {
+		return nil, fmt.Errorf("unexpected issuer %s", iss)
+	}
+	if !containsAudience(claims["aud"], p.clientID) {
+		return nil, fmt.Errorf("id token is not issued for this client")
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("id token has no expiry")
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, fmt.Errorf("nonce mismatch")
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("id token has no subject")
+	}
+	return Claims(claims), nil
+}
+
+func containsAudience(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	d := new(discovery)
+	if err := p.getJSON(ctx, p.issuer+"/.well-known/openid-configuration", d); err != nil {
+		return nil, fmt.Errorf("failed to discover issuer: %w", err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("discovered issuer %s does not match %s", d.Issuer, p.issuer)
+	}
+	p.discovery = d
+	return d, nil
+}
+
+// getKey returns the signing key with the key ID, fetching the key set again if the key is unknown, so that keys
+// rotated by the issuer are picked up.
+func (p *Provider) getKey(ctx context.Context, kid string) (interface{}, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	var set jsonWebKeySet
+	if err = p.getJSON(ctx, d.JwksURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	p.keys = keys
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing key %s not found", kid)
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// String returns the string claim, or "" if it is missing.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Strings returns the claim as a list of strings, accepting a single string as well. The second value reports
+// whether the claim is present.
+func (c Claims) Strings(name string) ([]string, bool) {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list, true
+	default:
+		return nil, false
+	}
+}
+
+// Bool returns the boolean claim. The second value reports whether the claim is present.
+func (c Claims) Bool(name string) (bool, bool) {
+	switch v := c[name].(type) {
+	case bool:
+		return v, true
+	case string: // some providers send "true" / "false"
+		return v == "true", true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Storage stores uploaded files under names made of slash-separated segments, and serves them at a URL.
+type Storage interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Delete(ctx context.Context, name string) error
+	URL(name string) string
+}
+
+// LocalStorage stores the files in a directory of the local file system, served by the application under the URL
+// prefix.
+type LocalStorage struct {
+	dir       string
+	urlPrefix string
+}
+
+func NewLocalStorage(dir, urlPrefix string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{dir: dir, urlPrefix: strings.TrimSuffix(urlPrefix, "/")}, nil
+}
+
+func (l *LocalStorage) Put(ctx context.Context, name string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p, err := l.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	// Written to a temporary file first so that a failed upload never leaves a truncated file behind
+	tmp := p + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// Delete removes the file, deleting a missing file is not an error.
+func (l *LocalStorage) Delete(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p, err := l.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *LocalStorage) URL(name string) string {
+	return l.urlPrefix + "/" + name
+}
+
+// path returns the path of the file in the directory, rejecting the names escaping it.
+func (l *LocalStorage) path(name string) (string, error) {
+	cleaned := path.Clean("/" + name)[1:]
+	if cleaned == "" || cleaned != name {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,167 @@
+package agreement
+
+import "sort"
+
+// Ratings maps each item to the label given by each rater, i.e. ratings[item][rater] = label.
+type Ratings map[string]map[string]string
+
+// Pair is the agreement of two raters on the items both of them have rated.
+type Pair struct {
+	RaterA    string
+	RaterB    string
+	Items     int64
+	Agreement float64  // Observed agreement
+	Kappa     *float64 // Cohen's kappa, nil if undefined (i.e. the chance agreement is 1)
+}
+
+// Rater is the agreement of a rater with the consensus of the other raters.
+type Rater struct {
+	Rater        string
+	Items        int64   // Number of items rated
+	Compared     int64   // Number of items compared with the consensus
+	Disagreement float64 // Rate of disagreement with the consensus
+}
+
+// Pairwise computes the observed agreement and Cohen's kappa of every pair of raters that have rated at least one
+// common item. Pairs are sorted by raters.
+func Pairwise(ratings Ratings) []Pair {
+	raters := raters(ratings)
+	pairs := make([]Pair, 0)
+	for i := 0; i < len(raters); i++ {
+		for j := i + 1; j < len(raters); j++ {
+			a, b := raters[i], raters[j]
+			var n, agree float64
+			countA, countB := map[string]float64{}, map[string]float64{}
+			for _, labels := range ratings {
+				labelA, okA := labels[a]
+				labelB, okB := labels[b]
+				if !okA || !okB {
+					continue
+				}
+				n++
+				if labelA == labelB {
+					agree++
+				}
+				countA[labelA]++
+				countB[labelB]++
+			}
+			if n == 0 {
+				continue
+			}
+			observed := agree / n
+			var chance float64
+			for label, count := range countA {
+				chance += (count / n) * (countB[label] / n)
+			}
+			pairs = append(
+				pairs, Pair{RaterA: a, RaterB: b, Items: int64(n), Agreement: observed, Kappa: kappa(observed, chance)},
+			)
+		}
+	}
+	return pairs
+}
+
+// Fleiss computes Fleiss' kappa over the items rated by at least two raters. The number of raters may vary between
+// items. Returns the number of items, the mean observed agreement and the kappa, which is nil if undefined.
+func Fleiss(ratings Ratings) (int64, float64, *float64) {
+	var (
+		items, observed, total float64
+		counts                 = map[string]float64{}
+	)
+	for _, labels := range ratings {
+		if len(labels) < 2 {
+			continue
+		}
+		n := float64(len(labels))
+		itemCounts := map[string]float64{}
+		for _, label := range labels {
+			itemCounts[label]++
+			counts[label]++
+		}
+		var agree float64
+		for _, count := range itemCounts {
+			agree += count * (count - 1)
+		}
+		observed += agree / (n * (n - 1))
+		total += n
+		items++
+	}
+	if items == 0 {
+		return 0, 0, nil
+	}
+	observed /= items
+	var chance float64
+	for _, count := range counts {
+		chance += (count / total) * (count / total)
+	}
+	return int64(items), observed, kappa(observed, chance)
+}
+
+// Consensus compares every rater with the consensus of the other raters, which is the label given by the strict
+// majority of the other raters of an item. Items without other raters or without a strict majority are not compared.
+// Raters are sorted by name.
+func Consensus(ratings Ratings) []Rater {
+	stats := map[string]*Rater{}
+	disagreements := map[string]float64{}
+	for _, labels := range ratings {
+		counts := map[string]int{}
+		for _, label := range labels {
+			counts[label]++
+		}
+		others := len(labels) - 1
+		for rater, label := range labels {
+			stat, ok := stats[rater]
+			if !ok {
+				stat = &Rater{Rater: rater}
+				stats[rater] = stat
+			}
+			stat.Items++
+			if others == 0 {
+				continue
+			}
+			counts[label]-- // Exclude the rater itself
+			for consensus, count := range counts {
+				if 2*count > others {
+					stat.Compared++
+					if consensus != label {
+						disagreements[rater]++
+					}
+					break
+				}
+			}
+			counts[label]++
+		}
+	}
+	result := make([]Rater, 0, len(stats))
+	for rater, stat := range stats {
+		if stat.Compared > 0 {
+			stat.Disagreement = disagreements[rater] / float64(stat.Compared)
+		}
+		result = append(result, *stat)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Rater < result[j].Rater })
+	return result
+}
+
+func raters(ratings Ratings) []string {
+	set := map[string]struct{}{}
+	for _, labels := range ratings {
+		for rater := range labels {
+			set[rater] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(set))
+	for rater := range set {
+		result = append(result, rater)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func kappa(observed, chance float64) *float64 {
+	if chance >= 1 {
+		return nil
+	}
+	k := (observed - chance) / (1 - chance)
+	return &k
+}
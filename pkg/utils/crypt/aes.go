@@ -0,0 +1,48 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Encrypt seals the plaintext with AES-256-GCM under a key derived from the secret. The random nonce is prepended to
+// the ciphertext.
+func Encrypt(secret string, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext sealed by Encrypt with the same secret.
+func Decrypt(secret string, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(secret string) (cipher.AEAD, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("secret is empty")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
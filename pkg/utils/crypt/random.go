@@ -0,0 +1,15 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RandomToken returns a random hex string encoding n bytes read from crypto/rand.
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,11 @@
+package crypt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+func SHA256(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
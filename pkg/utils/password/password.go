@@ -0,0 +1,142 @@
+package password
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Character classes of generated passwords, without look-alike characters
+const (
+	upperChars   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	lowerChars   = "abcdefghijkmnpqrstuvwxyz"
+	digitChars   = "23456789"
+	specialChars = "!@#$%^&*-_=+?"
+)
+
+//go:embed common.txt
+var commonList string
+
+// Policy holds the rules a new password must follow.
+type Policy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// Lowercase passwords which are too common to be accepted
+	CommonPasswords map[string]struct{}
+}
+
+// CommonPasswords returns the built-in list of common passwords, extended with the passwords of the file at path (one
+// per line) if path is not empty.
+func CommonPasswords(path string) (map[string]struct{}, error) {
+	passwords := make(map[string]struct{})
+	add := func(scanner *bufio.Scanner) error {
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				passwords[strings.ToLower(line)] = struct{}{}
+			}
+		}
+		return scanner.Err()
+	}
+	if err := add(bufio.NewScanner(strings.NewReader(commonList))); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return passwords, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if err = add(bufio.NewScanner(file)); err != nil {
+		return nil, err
+	}
+	return passwords, nil
+}
+
+// Validate returns an error describing the first rule the password breaks, or nil. The password must not contain the
+// username or the local part of the email either.
+func (p *Policy) Validate(password, username, email string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("must be at least %d characters long", p.MinLength)
+	}
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			special = true
+		}
+	}
+	switch {
+	case p.RequireUpper && !upper:
+		return fmt.Errorf("must contain an uppercase letter")
+	case p.RequireLower && !lower:
+		return fmt.Errorf("must contain a lowercase letter")
+	case p.RequireDigit && !digit:
+		return fmt.Errorf("must contain a digit")
+	case p.RequireSpecial && !special:
+		return fmt.Errorf("must contain a special character")
+	}
+	lowered := strings.ToLower(password)
+	if _, ok := p.CommonPasswords[lowered]; ok {
+		return fmt.Errorf("is too common")
+	}
+	if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		return fmt.Errorf("must not contain the username")
+	}
+	if local, _, _ := strings.Cut(email, "@"); local != "" && strings.Contains(lowered, strings.ToLower(local)) {
+		return fmt.Errorf("must not contain the email")
+	}
+	return nil
+}
+
+// Generate returns a random password of the given length, read from crypto/rand, with at least one character of each
+// class: uppercase, lowercase, digit and special. The length is raised to 4 if lower.
+func Generate(length int) (string, error) {
+	classes := []string{upperChars, lowerChars, digitChars, specialChars}
+	length = max(length, len(classes))
+	all := strings.Join(classes, "")
+	password := make([]byte, length)
+	for i := range password {
+		chars := all
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+	// Shuffle so that the classes are not always in the first positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
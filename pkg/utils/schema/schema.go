@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const resourceName = "schema.json"
+
+// Compile compiles the JSON Schema document, returns an error if the document is not a valid schema.
+func Compile(document string) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(resourceName, strings.NewReader(document)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(resourceName)
+}
+
+// Validate validates the value against the JSON Schema document.
+func Validate(document string, value map[string]interface{}) error {
+	compiled, err := Compile(document)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		value = map[string]interface{}{}
+	}
+	// Round trip through encoding/json, so that the value only contains the types the validator understands
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var v interface{}
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	return compiled.Validate(v)
+}
@@ -0,0 +1,76 @@
+package spdx
+
+import "sort"
+
+// licenses maps the SPDX identifiers accepted for contributed data to whether the license is permissive, i.e. it
+// allows redistribution and commercial use without share-alike obligations.
+// See https://spdx.org/licenses/ for the full list.
+var licenses = map[string]bool{
+	// Public domain dedications and permissive licenses
+	"CC0-1.0":             true,
+	"CC-BY-3.0":           true,
+	"CC-BY-4.0":           true,
+	"PDDL-1.0":            true,
+	"ODC-By-1.0":          true,
+	"MIT":                 true,
+	"MIT-0":               true,
+	"Apache-2.0":          true,
+	"BSD-2-Clause":        true,
+	"BSD-3-Clause":        true,
+	"0BSD":                true,
+	"ISC":                 true,
+	"Zlib":                true,
+	"Unlicense":           true,
+	"CDLA-Permissive-1.0": true,
+	"CDLA-Permissive-2.0": true,
+	// Copyleft and share-alike licenses
+	"CC-BY-SA-3.0":      false,
+	"CC-BY-SA-4.0":      false,
+	"ODbL-1.0":          false,
+	"CDLA-Sharing-1.0":  false,
+	"GFDL-1.3-only":     false,
+	"GFDL-1.3-or-later": false,
+	"GPL-2.0-only":      false,
+	"GPL-2.0-or-later":  false,
+	"GPL-3.0-only":      false,
+	"GPL-3.0-or-later":  false,
+	"LGPL-2.1-only":     false,
+	"LGPL-2.1-or-later": false,
+	"LGPL-3.0-only":     false,
+	"LGPL-3.0-or-later": false,
+	"AGPL-3.0-only":     false,
+	"AGPL-3.0-or-later": false,
+	"MPL-2.0":           false,
+	// Non-commercial and no-derivatives licenses
+	"CC-BY-NC-3.0":    false,
+	"CC-BY-NC-4.0":    false,
+	"CC-BY-NC-SA-3.0": false,
+	"CC-BY-NC-SA-4.0": false,
+	"CC-BY-ND-3.0":    false,
+	"CC-BY-ND-4.0":    false,
+	"CC-BY-NC-ND-3.0": false,
+	"CC-BY-NC-ND-4.0": false,
+}
+
+// IsValid reports whether the identifier is a supported SPDX license identifier.
+func IsValid(id string) bool {
+	_, ok := licenses[id]
+	return ok
+}
+
+// IsPermissive reports whether the identifier is a supported permissive SPDX license identifier.
+func IsPermissive(id string) bool {
+	return licenses[id]
+}
+
+// PermissiveLicenses returns the sorted identifiers of the supported permissive licenses.
+func PermissiveLicenses() []string {
+	ids := make([]string, 0, len(licenses))
+	for id, permissive := range licenses {
+		if permissive {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,73 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/qiniu/qmgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessToken(t *testing.T) {
+	// t.Skip("Skip TestAccessToken")
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		accessTokenDao = injector.AccessTokenDao
+		userID         = injector.UserDaoMock.RandomUserID()
+		otherUserID    = injector.UserDaoMock.RandomUserID()
+		tokenHash      = crypt.SHA256(config.AccessTokenPrefix + mock.RandomString(32))
+		expiredHash    = crypt.SHA256(config.AccessTokenPrefix + mock.RandomString(32))
+		scopes         = []string{config.AccessTokenScopeDatasetWrite}
+	)
+
+	accessTokenID, err := accessTokenDao.InsertAccessToken(
+		ctx, userID, "script", tokenHash, scopes, time.Now().Add(time.Hour),
+	)
+	assert.NoError(t, err)
+	expiredID, err := accessTokenDao.InsertAccessToken(
+		ctx, userID, "expired", expiredHash, scopes, time.Now().Add(-time.Hour),
+	)
+	assert.NoError(t, err)
+
+	accessToken, err := accessTokenDao.GetAccessTokenByHash(ctx, tokenHash)
+	assert.NoError(t, err)
+	assert.Equal(t, accessTokenID, accessToken.AccessTokenID)
+	assert.Equal(t, scopes, accessToken.Scopes)
+	_, err = accessTokenDao.GetAccessTokenByHash(ctx, expiredHash)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	accessTokenList, err := accessTokenDao.GetAccessTokenList(ctx, userID)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(accessTokenList), 2)
+	count, err := accessTokenDao.CountAccessToken(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(accessTokenList)-1), *count)
+
+	// Last-used time is throttled by the interval
+	err = accessTokenDao.UpdateAccessTokenLastUsed(ctx, accessTokenID, time.Minute)
+	assert.NoError(t, err)
+	accessToken, err = accessTokenDao.GetAccessTokenByHash(ctx, tokenHash)
+	assert.NoError(t, err)
+	lastUsedAt := accessToken.LastUsedAt
+	assert.False(t, lastUsedAt.IsZero())
+	err = accessTokenDao.UpdateAccessTokenLastUsed(ctx, accessTokenID, time.Minute)
+	assert.NoError(t, err)
+	accessToken, err = accessTokenDao.GetAccessTokenByHash(ctx, tokenHash)
+	assert.NoError(t, err)
+	assert.True(t, lastUsedAt.Equal(accessToken.LastUsedAt))
+
+	// Tokens can only be deleted by their owners
+	err = accessTokenDao.DeleteAccessToken(ctx, otherUserID, accessTokenID)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+	err = accessTokenDao.DeleteAccessToken(ctx, userID, accessTokenID)
+	assert.NoError(t, err)
+	err = accessTokenDao.DeleteAccessToken(ctx, userID, expiredID)
+	assert.NoError(t, err)
+	_, err = accessTokenDao.GetAccessTokenByHash(ctx, tokenHash)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+}
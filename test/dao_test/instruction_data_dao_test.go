@@ -32,7 +32,7 @@ func TestInsertInstructionData(t *testing.T) {
 	instructionDataID, err = instructionDataDao.InsertInstructionData(
 		ctx, userID,
 		instruction, input, output, theme, source, note,
-		statusCode, statusMsg,
+		statusCode, statusMsg, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, instructionDataID)
@@ -96,7 +96,7 @@ func TestGetInstructionDataList(t *testing.T) {
 	)
 	instructionDataList, count, err := instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, nil,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -109,7 +109,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, &userID, nil, nil,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -120,7 +120,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, &theme, nil,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -131,7 +131,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, &statusCode,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -142,7 +142,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, nil,
-		&createStartTime, &createEndTime, nil, nil, nil,
+		&createStartTime, &createEndTime, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -154,7 +154,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, nil,
-		nil, nil, &updateStartTime, &updateEndTime, nil,
+		nil, nil, &updateStartTime, &updateEndTime, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -166,7 +166,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, nil,
-		nil, nil, nil, nil, &query,
+		nil, nil, nil, nil, &query, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -177,7 +177,7 @@ func TestGetInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, &userID, &theme, &statusCode,
-		&createStartTime, &createEndTime, &updateStartTime, &updateEndTime, &query,
+		&createStartTime, &createEndTime, &updateStartTime, &updateEndTime, &query, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -209,7 +209,7 @@ func TestCountInstructionData(t *testing.T) {
 		err                error
 	)
 	count, err := instructionDataDao.CountInstructionData(
-		ctx, nil, nil, nil, nil, nil, nil, nil,
+		ctx, nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -233,7 +233,7 @@ func TestCountInstructionData(t *testing.T) {
 	t.Logf("=====================================")
 
 	count, err = instructionDataDao.CountInstructionData(
-		ctx, &userID, &theme, &statusCode, &createStartTime, &createEndTime, &updateStartTime, &updateEndTime,
+		ctx, &userID, &theme, &statusCode, &createStartTime, &createEndTime, &updateStartTime, &updateEndTime, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -268,7 +268,7 @@ func TestUpdateInstructionData(t *testing.T) {
 
 	err = instructionDataDao.UpdateInstructionData(
 		ctx, instructionDataID, &userID, &instruction, &input, &output, &theme, &source, &note,
-		&statusCode, &statusMsg,
+		&statusCode, &statusMsg, nil, nil,
 	)
 	assert.NoError(t, err)
 
@@ -331,7 +331,7 @@ func TestDeleteInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err := instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, &theme, nil,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.Empty(t, instructionDataList)
 
@@ -346,7 +346,7 @@ func TestDeleteInstructionDataList(t *testing.T) {
 
 	instructionDataList, count, err = instructionDataDao.GetInstructionDataList(
 		ctx, 0, 10, false, nil, nil, &statusCode,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.Empty(t, instructionDataList)
 }
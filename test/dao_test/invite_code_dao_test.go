@@ -0,0 +1,64 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/qiniu/qmgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteCode(t *testing.T) {
+	// t.Skip("Skip TestInviteCode")
+	var (
+		injector      = wire.GetInjector()
+		ctx           = injector.Ctx
+		inviteCodeDao = injector.InviteCodeDao
+		code          = mock.RandomString(16)
+		expiredCode   = mock.RandomString(16)
+		organization  = "ORG"
+		createdBy     = injector.UserDaoMock.RandomUserID()
+		userID        = injector.UserDaoMock.RandomUserID()
+	)
+
+	inviteCodeID, err := inviteCodeDao.InsertInviteCode(
+		ctx, code, config.UserRoleUser, organization, 2, time.Now().Add(time.Hour), createdBy,
+	)
+	assert.NoError(t, err)
+	_, err = inviteCodeDao.InsertInviteCode(
+		ctx, expiredCode, config.UserRoleUser, organization, 2, time.Now().Add(-time.Hour), createdBy,
+	)
+	assert.NoError(t, err)
+
+	inviteCode, err := inviteCodeDao.RedeemInviteCode(ctx, code)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), inviteCode.UsedCount)
+	assert.Equal(t, organization, inviteCode.Organization)
+	err = inviteCodeDao.InsertRedemption(ctx, inviteCodeID, userID, "Username")
+	assert.NoError(t, err)
+
+	_, err = inviteCodeDao.RedeemInviteCode(ctx, code)
+	assert.NoError(t, err)
+	_, err = inviteCodeDao.RedeemInviteCode(ctx, code)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	// A released use can be redeemed again
+	err = inviteCodeDao.ReleaseInviteCode(ctx, inviteCodeID)
+	assert.NoError(t, err)
+	_, err = inviteCodeDao.RedeemInviteCode(ctx, code)
+	assert.NoError(t, err)
+
+	_, err = inviteCodeDao.RedeemInviteCode(ctx, expiredCode)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	inviteCode, err = inviteCodeDao.GetInviteCodeByID(ctx, inviteCodeID)
+	assert.NoError(t, err)
+	assert.Len(t, inviteCode.Redemptions, 1)
+	assert.Equal(t, userID, inviteCode.Redemptions[0].UserID)
+
+	err = inviteCodeDao.DeleteInviteCode(ctx, inviteCodeID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/entity"
+	"data-collection-hub-server/pkg/jwt"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestJwtKey(t *testing.T) {
+	// t.Skip("Skip TestJwtKey")
+	var (
+		injector  = wire.GetInjector()
+		ctx       = injector.Ctx
+		jwtKeyDao = injector.JwtKeyDao
+	)
+	key, err := jwt.GenerateKey()
+	assert.NoError(t, err)
+	err = jwtKeyDao.InsertJwtKey(ctx, key)
+	assert.NoError(t, err)
+
+	keys, err := jwtKeyDao.GetJwtKeyList(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, keys)
+	assert.Equal(t, key.ID, keys[0].ID) // newest first
+	assert.True(t, key.PrivateKey.Equal(keys[0].PrivateKey))
+
+	// The private key is stored encrypted
+	var doc entity.JwtKeyModel
+	err = injector.Mongo.MongoClient.Database(injector.Mongo.DatabaseName).Collection(config.JwtKeyCollectionName).
+		Find(ctx, bson.M{"_id": key.ID}).One(&doc)
+	assert.NoError(t, err)
+	der, err := key.MarshalPrivateKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, der, doc.PrivateKey)
+
+	// Retired keys are kept until they expire
+	count, err := jwtKeyDao.RetireJwtKeyList(ctx, key.ID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(0))
+	retiredKeys, err := jwtKeyDao.GetJwtKeyList(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, len(keys), len(retiredKeys))
+	count, err = jwtKeyDao.RetireJwtKeyList(ctx, key.ID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
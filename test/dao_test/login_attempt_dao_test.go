@@ -0,0 +1,68 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/dao"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAttempt(t *testing.T) {
+	// t.Skip("Skip TestLoginAttempt")
+	var (
+		injector        = wire.GetInjector()
+		ctx             = injector.Ctx
+		loginAttemptDao = injector.LoginAttemptDao
+		email           = mock.RandomString(10) + "@User.com"
+		kind            = config.LoginLockoutKindAccount
+	)
+
+	failures, err := loginAttemptDao.IncrLoginFailure(ctx, kind, email, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), failures)
+	failures, err = loginAttemptDao.IncrLoginFailure(ctx, kind, email, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), failures)
+
+	err = loginAttemptDao.SetLoginDelay(ctx, kind, email, time.Minute)
+	assert.NoError(t, err)
+	delay, err := loginAttemptDao.GetLoginDelay(ctx, kind, email)
+	assert.NoError(t, err)
+	assert.Greater(t, delay, time.Duration(0))
+
+	// Failures and delays are forgotten on reset
+	err = loginAttemptDao.ResetLoginFailure(ctx, kind, email)
+	assert.NoError(t, err)
+	delay, err = loginAttemptDao.GetLoginDelay(ctx, kind, email)
+	assert.NoError(t, err)
+	assert.Zero(t, delay)
+	failures, err = loginAttemptDao.IncrLoginFailure(ctx, kind, email, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), failures)
+
+	_, err = loginAttemptDao.GetLoginLockout(ctx, kind, email)
+	assert.ErrorIs(t, err, dao.CacheNil{})
+	err = loginAttemptDao.LockLogin(ctx, kind, email, 5, time.Minute)
+	assert.NoError(t, err)
+	lockout, err := loginAttemptDao.GetLoginLockout(ctx, kind, email)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), lockout.Failures)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), lockout.ExpiresAt, 2*time.Second)
+
+	lockoutList, err := loginAttemptDao.GetLoginLockoutList(ctx)
+	assert.NoError(t, err)
+	var listed bool
+	for _, l := range lockoutList {
+		listed = listed || (l.Kind == kind && l.Value == lockout.Value)
+	}
+	assert.True(t, listed)
+
+	err = loginAttemptDao.DeleteLoginLockout(ctx, kind, email)
+	assert.NoError(t, err)
+	_, err = loginAttemptDao.GetLoginLockout(ctx, kind, email)
+	assert.ErrorIs(t, err, dao.CacheNil{})
+}
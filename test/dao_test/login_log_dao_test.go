@@ -73,7 +73,7 @@ func TestSyncLoginLog(t *testing.T) {
 	// t.Skip("Skip TestSyncLoginLog")
 	loginLogDao.SyncLoginLog(ctx)
 	loginLogList, count, err := loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, &ipAddress, &userAgent, nil,
+		ctx, 0, 10, false, nil, nil, nil, &ipAddress, &userAgent, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -108,7 +108,7 @@ func TestGetLoginLogList(t *testing.T) {
 	}
 
 	loginLogList, count, err := loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -120,7 +120,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, &startTime, &endTime, nil, nil, nil, nil,
+		ctx, 0, 10, false, &startTime, &endTime, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -131,7 +131,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, &userID, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, &userID, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -141,7 +141,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, &ipAddress, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, &ipAddress, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -151,7 +151,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, nil, &userAgent, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil, &userAgent, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -161,7 +161,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, nil, nil, &query,
+		ctx, 0, 10, false, nil, nil, nil, nil, nil, nil, &query,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -171,7 +171,7 @@ func TestGetLoginLogList(t *testing.T) {
 	t.Logf("=====================================")
 
 	loginLogList, count, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, &startTime, &endTime, &userID, &ipAddress, &userAgent, &query,
+		ctx, 0, 10, false, &startTime, &endTime, &userID, &ipAddress, &userAgent, nil, &query,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, count)
@@ -223,7 +223,7 @@ func TestDeleteLoginLogList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, count)
 	loginLogList, _, err := loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, &userID, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, &userID, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.Empty(t, loginLogList)
@@ -235,7 +235,7 @@ func TestDeleteLoginLogList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, count)
 	loginLogList, _, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, &ipAddress, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, &ipAddress, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.Empty(t, loginLogList)
@@ -247,7 +247,7 @@ func TestDeleteLoginLogList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, count)
 	loginLogList, _, err = loginLogDao.GetLoginLogList(
-		ctx, 0, 10, false, nil, nil, nil, nil, &userAgent, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil, &userAgent, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.Empty(t, loginLogList)
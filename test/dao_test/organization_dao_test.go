@@ -0,0 +1,67 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/qiniu/qmgo"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestOrganization(t *testing.T) {
+	// t.Skip("Skip TestOrganization")
+	var (
+		injector        = wire.GetInjector()
+		ctx             = injector.Ctx
+		organizationDao = injector.OrganizationDao
+		userDao         = injector.UserDao
+		name            = mock.RandomString(16)
+		description     = "Organization"
+		newDescription  = "Updated organization"
+	)
+
+	organizationID, err := organizationDao.InsertOrganization(ctx, name, description)
+	assert.NoError(t, err)
+	_, err = organizationDao.InsertOrganization(ctx, name, description)
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+
+	err = organizationDao.UpdateOrganization(ctx, organizationID, &newDescription)
+	assert.NoError(t, err)
+	organization, err := organizationDao.GetOrganizationByID(ctx, organizationID)
+	assert.NoError(t, err)
+	assert.Equal(t, name, organization.Name)
+	assert.Equal(t, newDescription, organization.Description)
+	organization, err = organizationDao.GetOrganizationByName(ctx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, organizationID, organization.OrganizationID)
+
+	organizationList, count, err := organizationDao.GetOrganizationList(ctx, 0, 10, false, &name)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *count)
+	assert.Equal(t, organizationID, organizationList[0].OrganizationID)
+
+	// Instruction data is scoped to the members of the organization in the context
+	userID, err := userDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@member.com", "Member@123", config.UserRoleUser, name,
+	)
+	assert.NoError(t, err)
+	userIDList, err := userDao.GetUserIDListByOrganization(ctx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{userID}, userIDList)
+	scopedCtx := context.WithValue(ctx, config.OrganizationKey, name)
+	total, err := injector.InstructionDataDao.CountInstructionData(scopedCtx, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), *total)
+	err = userDao.DeleteUser(ctx, userID)
+	assert.NoError(t, err)
+
+	err = organizationDao.DeleteOrganization(ctx, organizationID)
+	assert.NoError(t, err)
+	_, err = organizationDao.GetOrganizationByID(ctx, organizationID)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+}
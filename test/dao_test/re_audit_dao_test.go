@@ -0,0 +1,56 @@
+package dao_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestReAudit(t *testing.T) {
+	// t.Skip("Skip TestReAudit")
+	var (
+		injector          = wire.GetInjector()
+		ctx               = injector.Ctx
+		reAuditDao        = injector.ReAuditDao
+		instructionDataID = injector.InstructionDataDaoMock.RandomInstructionDataID()
+		reviewerID        = injector.UserDaoMock.RandomUserID()
+		auditorID         = injector.UserDaoMock.RandomUserID()
+		theme             = "Theme"
+		pending           = config.ReAuditStatusPending
+	)
+
+	reAuditID, err := reAuditDao.InsertReAudit(ctx, instructionDataID, reviewerID, "Reviewer", theme)
+	assert.NoError(t, err)
+
+	// The same approval is sampled only once
+	_, err = reAuditDao.InsertReAudit(ctx, instructionDataID, reviewerID, "Reviewer", theme)
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+
+	reAuditList, count, err := reAuditDao.GetReAuditList(
+		ctx, 0, 10, false, &reviewerID, nil, &theme, &pending, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, *count, int64(1))
+	assert.NotEmpty(t, reAuditList)
+
+	// The queue of the reviewer does not contain their own approvals
+	_, count, err = reAuditDao.GetReAuditList(ctx, 0, 10, false, &reviewerID, &reviewerID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), *count)
+
+	err = reAuditDao.UpdateReAudit(ctx, reAuditID, auditorID, config.ReAuditStatusOverturned, "Wrong")
+	assert.NoError(t, err)
+
+	reAudit, err := reAuditDao.GetReAuditByID(ctx, reAuditID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.ReAuditStatusOverturned, reAudit.Status)
+	assert.Equal(t, auditorID, reAudit.AuditorID)
+	assert.Equal(t, "Wrong", reAudit.Message)
+
+	// A re-audit can only be submitted once
+	err = reAuditDao.UpdateReAudit(ctx, reAuditID, auditorID, config.ReAuditStatusConfirmed, "")
+	assert.Error(t, err)
+}
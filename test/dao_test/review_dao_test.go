@@ -0,0 +1,39 @@
+package dao_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertReview(t *testing.T) {
+	// t.Skip("Skip TestUpsertReview")
+	var (
+		injector          = wire.GetInjector()
+		ctx               = injector.Ctx
+		reviewDao         = injector.ReviewDao
+		instructionDataID = injector.InstructionDataDaoMock.RandomInstructionDataID()
+		reviewerID        = injector.UserDaoMock.RandomUserID()
+		theme             = "Theme"
+	)
+
+	err := reviewDao.UpsertReview(ctx, instructionDataID, reviewerID, theme, config.InstructionDataStatusApproved, "")
+	assert.NoError(t, err)
+
+	// A second decision of the same reviewer replaces the first one
+	err = reviewDao.UpsertReview(
+		ctx, instructionDataID, reviewerID, theme, config.InstructionDataStatusRejected, "Rejected",
+	)
+	assert.NoError(t, err)
+
+	reviewList, count, err := reviewDao.GetReviewList(
+		ctx, 0, 10, false, &instructionDataID, &reviewerID, nil, nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *count)
+	assert.Equal(t, config.InstructionDataStatusRejected, reviewList[0].Decision)
+	assert.Equal(t, "Rejected", reviewList[0].Message)
+	assert.Equal(t, theme, reviewList[0].Theme)
+}
@@ -0,0 +1,122 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/qiniu/qmgo"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSession(t *testing.T) {
+	// t.Skip("Skip TestSession")
+	var (
+		injector   = wire.GetInjector()
+		ctx        = injector.Ctx
+		sessionDao = injector.SessionDao
+		userID     = injector.UserDaoMock.RandomUserID()
+		ipAddress  = "127.0.0.1"
+		userAgent  = "Mozilla/5.0"
+		expiresAt  = time.Now().Add(time.Hour)
+
+		refreshTokenID    = primitive.NewObjectID().Hex()
+		newRefreshTokenID = primitive.NewObjectID().Hex()
+	)
+
+	otherUserID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, "ORG",
+	)
+	assert.NoError(t, err)
+	sessionID, err := sessionDao.InsertSession(ctx, userID, ipAddress, userAgent, refreshTokenID, expiresAt)
+	assert.NoError(t, err)
+	otherSessionID, err := sessionDao.InsertSession(ctx, userID, ipAddress, userAgent, refreshTokenID, expiresAt)
+	assert.NoError(t, err)
+	expiredID, err := sessionDao.InsertSession(ctx, userID, ipAddress, userAgent, refreshTokenID, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+
+	session, err := sessionDao.GetSessionByID(ctx, sessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, userAgent, session.UserAgent)
+	_, err = sessionDao.GetSessionByID(ctx, expiredID)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	sessionList, err := sessionDao.GetSessionList(ctx, userID)
+	assert.NoError(t, err)
+	for _, s := range sessionList {
+		assert.NotEqual(t, expiredID, s.SessionID)
+	}
+
+	// Last-seen time is throttled by the interval
+	err = sessionDao.UpdateSessionLastSeen(ctx, sessionID, time.Minute)
+	assert.NoError(t, err)
+	session, err = sessionDao.GetSessionByID(ctx, sessionID)
+	assert.NoError(t, err)
+	lastSeenAt := session.LastSeenAt
+	err = sessionDao.UpdateSessionLastSeen(ctx, sessionID, 0)
+	assert.NoError(t, err)
+	session, err = sessionDao.GetSessionByID(ctx, sessionID)
+	assert.NoError(t, err)
+	assert.True(t, session.LastSeenAt.After(lastSeenAt))
+
+	// A refresh token can only be rotated once
+	newExpiresAt := time.Now().Add(2 * time.Hour)
+	err = sessionDao.RotateSessionRefreshToken(ctx, sessionID, refreshTokenID, newRefreshTokenID, newExpiresAt)
+	assert.NoError(t, err)
+	session, err = sessionDao.GetSessionByID(ctx, sessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, newRefreshTokenID, session.RefreshTokenID)
+	assert.WithinDuration(t, newExpiresAt, session.ExpiresAt, time.Second)
+	err = sessionDao.RotateSessionRefreshToken(ctx, sessionID, refreshTokenID, newRefreshTokenID, newExpiresAt)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	// Sessions can only be revoked by their users, and stay revoked in cache
+	err = sessionDao.DeleteSession(ctx, otherUserID, sessionID)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+	revoked, err := sessionDao.IsSessionRevoked(ctx, sessionID.Hex())
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	err = sessionDao.DeleteSession(ctx, userID, sessionID)
+	assert.NoError(t, err)
+	revoked, err = sessionDao.IsSessionRevoked(ctx, sessionID.Hex())
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	_, err = sessionDao.GetSessionByID(ctx, sessionID)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	count, err := sessionDao.DeleteSessionList(ctx, userID, &otherSessionID)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(1))
+	_, err = sessionDao.GetSessionByID(ctx, otherSessionID)
+	assert.NoError(t, err)
+	_, err = sessionDao.DeleteSessionList(ctx, userID, nil)
+	assert.NoError(t, err)
+	revoked, err = sessionDao.IsSessionRevoked(ctx, otherSessionID.Hex())
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Revoking the tokens of a user revokes its sessions and sets the valid-after time
+	validAfter, err := sessionDao.GetUserTokensValidAfter(ctx, otherUserID.Hex())
+	assert.NoError(t, err)
+	assert.Zero(t, validAfter)
+	lastSessionID, err := sessionDao.InsertSession(ctx, otherUserID, ipAddress, userAgent, refreshTokenID, expiresAt)
+	assert.NoError(t, err)
+	err = sessionDao.RevokeUserTokens(ctx, otherUserID)
+	assert.NoError(t, err)
+	validAfter, err = sessionDao.GetUserTokensValidAfter(ctx, otherUserID.Hex())
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Now().UnixMilli(), validAfter, 5000)
+
+	// The valid-after time is kept on the user and outlives the cache
+	assert.NoError(t, injector.Cache.Delete(ctx, config.TokenValidAfterCachePrefix+":"+otherUserID.Hex()))
+	storedValidAfter, err := sessionDao.GetUserTokensValidAfter(ctx, otherUserID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, validAfter, storedValidAfter)
+	revoked, err = sessionDao.IsSessionRevoked(ctx, lastSessionID.Hex())
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
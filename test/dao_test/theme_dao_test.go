@@ -0,0 +1,111 @@
+package dao_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var themeID primitive.ObjectID
+
+func TestInsertTheme(t *testing.T) {
+	// t.Skip("Skip TestInsertTheme")
+	var (
+		injector    = wire.GetInjector()
+		themeDao    = injector.ThemeDao
+		ctx         = injector.Ctx
+		name        = "Theme"
+		description = "Description"
+		schema      = `{"type": "object", "properties": {"difficulty": {"type": "string"}}}`
+		err         error
+	)
+
+	themeID, err = themeDao.InsertTheme(ctx, name, description, schema)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, themeID)
+
+	theme, err := themeDao.GetThemeByID(ctx, themeID)
+	assert.NoError(t, err)
+	assert.NotNil(t, theme)
+	assert.Equal(t, name, theme.Name)
+	assert.Equal(t, description, theme.Description)
+	assert.Equal(t, schema, theme.Schema)
+
+	_, err = themeDao.InsertTheme(ctx, name, description, schema)
+	assert.Error(t, err)
+}
+
+func TestGetThemeByName(t *testing.T) {
+	// t.Skip("Skip TestGetThemeByName")
+	var (
+		injector = wire.GetInjector()
+		ctx      = injector.Ctx
+		themeDao = injector.ThemeDao
+	)
+
+	theme, err := themeDao.GetThemeByName(ctx, "Theme")
+	assert.NoError(t, err)
+	assert.Equal(t, themeID, theme.ThemeID)
+
+	// The second lookup is served from the cache
+	theme, err = themeDao.GetThemeByName(ctx, "Theme")
+	assert.NoError(t, err)
+	assert.Equal(t, themeID, theme.ThemeID)
+}
+
+func TestGetThemeList(t *testing.T) {
+	// t.Skip("Skip TestGetThemeList")
+	var (
+		injector = wire.GetInjector()
+		ctx      = injector.Ctx
+		themeDao = injector.ThemeDao
+		query    = "them"
+	)
+
+	themeList, count, err := themeDao.GetThemeList(ctx, 0, 10, false, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, count)
+	assert.NotEmpty(t, themeList)
+
+	themeList, count, err = themeDao.GetThemeList(ctx, 0, 10, true, &query)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, count)
+	t.Logf("Query: %s", query)
+	t.Logf("Theme Count: %d", *count)
+	t.Logf("Theme List: %v", themeList)
+}
+
+func TestUpdateTheme(t *testing.T) {
+	// t.Skip("Skip TestUpdateTheme")
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		themeDao    = injector.ThemeDao
+		description = "New Description"
+	)
+
+	err := themeDao.UpdateTheme(ctx, themeID, nil, &description, nil)
+	assert.NoError(t, err)
+
+	theme, err := themeDao.GetThemeByName(ctx, "Theme")
+	assert.NoError(t, err)
+	assert.Equal(t, description, theme.Description)
+}
+
+func TestDeleteTheme(t *testing.T) {
+	// t.Skip("Skip TestDeleteTheme")
+	var (
+		injector = wire.GetInjector()
+		ctx      = injector.Ctx
+		themeDao = injector.ThemeDao
+	)
+
+	err := themeDao.DeleteTheme(ctx, themeID)
+	assert.NoError(t, err)
+
+	theme, err := themeDao.GetThemeByID(ctx, themeID)
+	assert.Error(t, err)
+	assert.Nil(t, theme)
+}
@@ -0,0 +1,43 @@
+package dao_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/qiniu/qmgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorPolicy(t *testing.T) {
+	// t.Skip("Skip TestTwoFactorPolicy")
+	var (
+		injector           = wire.GetInjector()
+		ctx                = injector.Ctx
+		twoFactorPolicyDao = injector.TwoFactorPolicyDao
+		role               = "TWO_FACTOR_" + mock.RandomString(6)
+		updatedBy          = injector.UserDaoMock.RandomUserID()
+	)
+
+	_, err := twoFactorPolicyDao.GetTwoFactorPolicyByRole(ctx, role)
+	assert.ErrorIs(t, err, qmgo.ErrNoSuchDocuments)
+
+	err = twoFactorPolicyDao.UpsertTwoFactorPolicy(ctx, role, true, updatedBy)
+	assert.NoError(t, err)
+	policy, err := twoFactorPolicyDao.GetTwoFactorPolicyByRole(ctx, role)
+	assert.NoError(t, err)
+	assert.True(t, policy.Enforced)
+	assert.Equal(t, updatedBy, policy.UpdatedBy)
+
+	// Upserting again updates the same policy
+	err = twoFactorPolicyDao.UpsertTwoFactorPolicy(ctx, role, false, updatedBy)
+	assert.NoError(t, err)
+	updated, err := twoFactorPolicyDao.GetTwoFactorPolicyByRole(ctx, role)
+	assert.NoError(t, err)
+	assert.False(t, updated.Enforced)
+	assert.Equal(t, policy.TwoFactorPolicyID, updated.TwoFactorPolicyID)
+
+	policyList, err := twoFactorPolicyDao.GetTwoFactorPolicyList(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, policyList)
+}
@@ -133,7 +133,7 @@ func TestGetUserList(t *testing.T) {
 		query              = "Fo"
 	)
 	userList, count, err := userDao.GetUserList(
-		ctx, 0, 10, false, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -148,7 +148,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, &organization, nil, nil,
+		ctx, 0, 10, false, &organization, nil, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -160,7 +160,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, &organization, &role, nil,
+		ctx, 0, 10, false, &organization, &role, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -174,7 +174,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, nil, &createStartTime,
+		ctx, 0, 10, false, nil, nil, nil, &createStartTime,
 		&createEndTime, nil, nil, nil,
 		nil, nil,
 	)
@@ -188,7 +188,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil,
 		nil, &updateStartTime, &updateEndTime, nil,
 		nil, nil,
 	)
@@ -202,7 +202,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil,
 		nil, nil, nil, &lastLoginStartTime,
 		&lastLoginEndTime, nil,
 	)
@@ -216,7 +216,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, nil, nil,
+		ctx, 0, 10, false, nil, nil, nil, nil,
 		nil, nil, nil, nil,
 		nil, &query,
 	)
@@ -229,7 +229,7 @@ func TestGetUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, &organization, &role, &createStartTime,
+		ctx, 0, 10, false, &organization, &role, nil, &createStartTime,
 		&createEndTime, &updateStartTime, &updateEndTime, &lastLoginStartTime,
 		&lastLoginEndTime, &query,
 	)
@@ -276,6 +276,84 @@ func TestUpdateUser(t *testing.T) {
 	assert.True(t, crypt.Compare("User@123", user.Password))
 }
 
+func TestUpdateUserAvatar(t *testing.T) {
+	var (
+		injector = wire.GetInjector()
+		ctx      = injector.Ctx
+		userDao  = injector.UserDao
+		avatar   = "avatar/" + userID.Hex() + ".png"
+	)
+	err := userDao.UpdateUserAvatar(ctx, userID, avatar)
+	assert.NoError(t, err)
+
+	user, err := userDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, avatar, user.Avatar)
+}
+
+func TestSuspendUser(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		userDao     = injector.UserDao
+		suspendedBy = primitive.NewObjectID()
+		expiresAt   = time.Now().Add(time.Hour)
+		suspended   = true
+	)
+	err := userDao.SuspendUser(ctx, userID, "spam", &expiresAt, suspendedBy)
+	assert.NoError(t, err)
+
+	user, err := userDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, user.Suspension.Suspended)
+	assert.Equal(t, "spam", user.Suspension.Reason)
+	assert.Equal(t, suspendedBy, user.Suspension.SuspendedBy)
+	assert.WithinDuration(t, expiresAt, user.Suspension.ExpiresAt, time.Second)
+
+	userList, _, err := userDao.GetUserList(
+		ctx, 0, 100, true, nil, nil, &suspended,
+		nil, nil, nil, nil,
+		nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, userIDList(userList), userID)
+
+	err = userDao.ReactivateUser(ctx, userID)
+	assert.NoError(t, err)
+	user, err = userDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, user.Suspension.Suspended)
+
+	userList, _, err = userDao.GetUserList(
+		ctx, 0, 100, true, nil, nil, &suspended,
+		nil, nil, nil, nil,
+		nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.NotContains(t, userIDList(userList), userID)
+
+	// An expired suspension is not listed
+	expiresAt = time.Now().Add(-time.Hour)
+	err = userDao.SuspendUser(ctx, userID, "spam", &expiresAt, suspendedBy)
+	assert.NoError(t, err)
+	userList, _, err = userDao.GetUserList(
+		ctx, 0, 100, true, nil, nil, &suspended,
+		nil, nil, nil, nil,
+		nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.NotContains(t, userIDList(userList), userID)
+	assert.NoError(t, userDao.ReactivateUser(ctx, userID))
+}
+
+func userIDList(userList []entity.UserModel) []primitive.ObjectID {
+	userIDs := make([]primitive.ObjectID, 0, len(userList))
+	for _, user := range userList {
+		userIDs = append(userIDs, user.UserID)
+	}
+	return userIDs
+}
+
 func TestDeleteUser(t *testing.T) {
 	// t.Skip("Skip TestDeleteUser")
 	var (
@@ -284,13 +362,30 @@ func TestDeleteUser(t *testing.T) {
 		userDao  = injector.UserDao
 		err      error
 	)
-	err = userDao.SoftDeleteUser(ctx, userID)
+	deletedAt := time.Now().Truncate(time.Millisecond)
+	err = userDao.SoftDeleteUser(ctx, userID, deletedAt, entity.DeletionModel{Roles: []string{"USER"}})
 	assert.NoError(t, err)
 
 	user, err := userDao.GetUserByID(ctx, userID)
 	assert.Error(t, err)
 	assert.Nil(t, user)
 
+	user, err = userDao.GetDeletedUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, deletedAt.Equal(user.DeletedAt))
+	assert.Equal(t, []string{"USER"}, user.Deletion.Roles)
+	userList, _, err := userDao.GetDeletedUserList(ctx, 0, 0, false, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, userIDList(userList), userID)
+
+	err = userDao.RestoreUser(ctx, userID)
+	assert.NoError(t, err)
+	user, err = userDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	_, err = userDao.GetDeletedUserByID(ctx, userID)
+	assert.Error(t, err)
+
 	err = userDao.DeleteUser(ctx, userID)
 	assert.NoError(t, err)
 
@@ -317,7 +412,7 @@ func TestDeleteUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err := userDao.GetUserList(
-		ctx, 0, 10, false, &organization, nil, nil,
+		ctx, 0, 10, false, &organization, nil, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -331,7 +426,7 @@ func TestDeleteUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, &role, nil,
+		ctx, 0, 10, false, nil, &role, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -345,7 +440,7 @@ func TestDeleteUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, &role, nil,
+		ctx, 0, 10, false, nil, &role, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -359,7 +454,7 @@ func TestDeleteUserList(t *testing.T) {
 	t.Logf("=====================================")
 
 	userList, count, err = userDao.GetUserList(
-		ctx, 0, 10, false, nil, &role, nil,
+		ctx, 0, 10, false, nil, &role, nil, nil,
 		nil, nil, nil, nil,
 		nil, nil,
 	)
@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"data-collection-hub-server/pkg/jwt"
 	"data-collection-hub-server/test/common"
 	"data-collection-hub-server/test/wire"
+	jwtgo "github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,12 +36,12 @@ func TestJwtGenerateAccessToken(t *testing.T) {
 		j        = injector.Jwt
 		err      error
 	)
-	accessToken, err := j.GenerateAccessToken(sub)
+	accessToken, err := j.GenerateAccessToken(sub, "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, accessToken)
 	t.Logf("access token: %s", accessToken)
 
-	accessToken, err = j.GenerateAccessToken(invalidSubject)
+	accessToken, err = j.GenerateAccessToken(invalidSubject, "")
 	assert.Error(t, err)
 	assert.Empty(t, accessToken)
 }
@@ -50,13 +52,13 @@ func TestJwtGenerateRefreshToken(t *testing.T) {
 		j        = injector.Jwt
 		err      error
 	)
-	refreshToken, err := j.GenerateRefreshToken(sub)
+	refreshToken, err := j.GenerateRefreshToken(sub, "", "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, refreshToken)
 	t.Logf("refresh token: %s", refreshToken)
 
 	invalidSubject := ""
-	refreshToken, err = j.GenerateRefreshToken(invalidSubject)
+	refreshToken, err = j.GenerateRefreshToken(invalidSubject, "", "")
 	assert.Error(t, err)
 	assert.Empty(t, refreshToken)
 }
@@ -67,7 +69,7 @@ func TestJwtVerifyToken(t *testing.T) {
 		j        = injector.Jwt
 		err      error
 	)
-	accessToken, err := j.GenerateAccessToken(sub)
+	accessToken, err := j.GenerateAccessToken(sub, "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, accessToken)
 	t.Logf("access token: %s", accessToken)
@@ -89,7 +91,7 @@ func TestJwtRefreshToken(t *testing.T) {
 		j        = injector.Jwt
 		err      error
 	)
-	refreshToken, err := j.GenerateRefreshToken(sub)
+	refreshToken, err := j.GenerateRefreshToken(sub, "", "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, refreshToken)
 	t.Logf("refresh token: %s", refreshToken)
@@ -112,7 +114,7 @@ func TestJwtExtractClaims(t *testing.T) {
 		j        = injector.Jwt
 		err      error
 	)
-	accessToken, err := j.GenerateAccessToken(sub)
+	accessToken, err := j.GenerateAccessToken(sub, "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, accessToken)
 	t.Logf("access token: %s", accessToken)
@@ -126,3 +128,162 @@ func TestJwtExtractClaims(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, claims)
 }
+
+func TestJwtParseToken(t *testing.T) {
+	var (
+		injector  = wire.GetInjector()
+		j         = injector.Jwt
+		sessionID = "session"
+		tokenID   = "token"
+	)
+	accessToken, err := j.GenerateAccessToken(sub, sessionID)
+	assert.NoError(t, err)
+	refreshToken, err := j.GenerateRefreshToken(sub, sessionID, tokenID)
+	assert.NoError(t, err)
+
+	claims, err := j.ParseAccessToken(accessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, claims.Subject)
+	assert.Equal(t, sessionID, claims.SessionID)
+	assert.Equal(t, claims.IssuedAt, claims.IssuedAtMillis()/1000) // the issue time has sub-second precision
+	claims, err = j.ParseRefreshToken(refreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, claims.SessionID)
+	assert.Equal(t, tokenID, claims.Id)
+
+	// The audiences are not interchangeable
+	_, err = j.ParseAccessToken(refreshToken)
+	assert.Error(t, err)
+	_, err = j.ParseRefreshToken(accessToken)
+	assert.Error(t, err)
+}
+
+func TestJwtKeyRotation(t *testing.T) {
+	var (
+		injector = wire.GetInjector()
+		ctx      = injector.Ctx
+		j        = injector.Jwt
+	)
+	keys, err := injector.JwtKeyDao.GetJwtKeyList(ctx)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, j.SetKeys(keys)) }()
+
+	oldToken, err := j.GenerateAccessToken(sub, "")
+	assert.NoError(t, err)
+	oldKeyID := j.SigningKeyID()
+	key, err := jwt.GenerateKey()
+	assert.NoError(t, err)
+	assert.NoError(t, j.SetKeys(append(keys, key)))
+	assert.Equal(t, key.ID, j.SigningKeyID())
+
+	// New tokens name the new key, old ones keep verifying
+	newToken, err := j.GenerateAccessToken(sub, "")
+	assert.NoError(t, err)
+	parsed, _, err := new(jwtgo.Parser).ParseUnverified(newToken, &jwt.Claims{})
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, parsed.Header["kid"])
+	_, err = j.ParseAccessToken(oldToken)
+	assert.NoError(t, err)
+
+	set := j.JSONWebKeySet()
+	kids := make([]string, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		assert.Equal(t, "EC", k.Kty)
+		assert.Equal(t, "P-256", k.Crv)
+		kids = append(kids, k.Kid)
+	}
+	assert.Contains(t, kids, key.ID)
+	assert.Contains(t, kids, oldKeyID)
+
+	// Tokens of keys dropped from the ring, and not in the database either, are rejected
+	assert.NoError(t, j.SetKeys(keys))
+	_, err = j.ParseAccessToken(newToken)
+	assert.Error(t, err)
+}
+
+func TestJwtKeyReload(t *testing.T) {
+	var (
+		injector  = wire.GetInjector()
+		ctx       = injector.Ctx
+		j         = injector.Jwt
+		jwtKeyDao = injector.JwtKeyDao
+		loadKeys  = func() ([]*jwt.Key, error) { return jwtKeyDao.GetJwtKeyList(ctx) }
+	)
+	keys, err := jwtKeyDao.GetJwtKeyList(ctx)
+	assert.NoError(t, err)
+	defer func() {
+		j.SetKeyLoader(loadKeys, injector.Config.JWTConfig.KeyReloadInterval)
+		assert.NoError(t, j.SetKeys(keys))
+	}()
+	j.SetKeyLoader(loadKeys, time.Hour)
+
+	// A token of a key generated by another instance
+	key, err := jwt.GenerateKey()
+	assert.NoError(t, err)
+	assert.NoError(t, j.SetKeys([]*jwt.Key{key}))
+	token, err := j.GenerateAccessToken(sub, "")
+	assert.NoError(t, err)
+	assert.NoError(t, j.SetKeys(keys))
+	_, err = j.ParseAccessToken(token)
+	assert.Error(t, err)
+
+	// The ring is reloaded for it, but not more often than the interval
+	assert.NoError(t, jwtKeyDao.InsertJwtKey(ctx, key))
+	_, err = j.ParseAccessToken(token)
+	assert.Error(t, err)
+	j.SetKeyLoader(loadKeys, 0)
+	claims, err := j.ParseAccessToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, claims.Subject)
+}
+
+func TestJwtGenerateImpersonationToken(t *testing.T) {
+	var (
+		injector = wire.GetInjector()
+		j        = injector.Jwt
+		actor    = "admin"
+	)
+	token, err := j.GenerateImpersonationToken(sub, actor, true, time.Minute)
+	assert.NoError(t, err)
+
+	claims, err := j.ParseAccessToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, claims.Subject)
+	assert.Equal(t, actor, claims.Actor.Subject)
+	assert.True(t, claims.ReadOnly)
+	assert.Empty(t, claims.SessionID)
+	assert.LessOrEqual(t, claims.ExpiresAt, time.Now().Add(time.Minute).Unix())
+
+	// Impersonation tokens cannot be turned into regular ones
+	_, err = j.RefreshToken(token)
+	assert.Error(t, err)
+
+	_, err = j.GenerateImpersonationToken(sub, "", true, time.Minute)
+	assert.Error(t, err)
+	_, err = j.GenerateImpersonationToken(sub, actor, true, 0)
+	assert.Error(t, err)
+}
+
+func TestJwtParseExpiredAccessToken(t *testing.T) {
+	var (
+		injector = wire.GetInjector()
+		j        = injector.Jwt
+	)
+	token, err := j.GenerateImpersonationToken(sub, "admin", true, time.Second)
+	assert.NoError(t, err)
+	time.Sleep(2 * time.Second) // wait for token to expire
+
+	_, err = j.ParseAccessToken(token)
+	assert.Error(t, err)
+	claims, err := j.ParseExpiredAccessToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, claims.Subject)
+
+	// The signature is still checked
+	_, err = j.ParseExpiredAccessToken(token[:len(token)-2] + "AA")
+	assert.Error(t, err)
+	refreshToken, err := j.GenerateRefreshToken(sub, "", "")
+	assert.NoError(t, err)
+	_, err = j.ParseExpiredAccessToken(refreshToken)
+	assert.Error(t, err)
+}
@@ -62,7 +62,7 @@ func (m *InstructionDataDaoMock) GenerateInstructionDataModel() *entity.Instruct
 	instruction, input, output, theme, source, note, statusCode, statusMessage := randomInstructionData()
 	instructionDataID, err := m.InstructionDataDao.InsertInstructionData(
 		context.Background(), userID, instruction, input, output, theme, source, note, statusCode,
-		statusMessage,
+		statusMessage, nil, randomProvenance(),
 	)
 	if err != nil {
 		panic(err)
@@ -78,7 +78,7 @@ func (m *InstructionDataDaoMock) GenerateInstructionDataModelWithUserID(userID p
 	instruction, input, output, theme, source, note, statusCode, statusMessage := randomInstructionData()
 	instructionDataID, err := m.InstructionDataDao.InsertInstructionData(
 		context.Background(), userID, instruction, input, output, theme, source, note, statusCode,
-		statusMessage,
+		statusMessage, nil, randomProvenance(),
 	)
 	if err != nil {
 		panic(err)
@@ -108,3 +108,12 @@ func randomInstructionData() (string, string, string, string, string, string, st
 			},
 		), RandomString(10)
 }
+
+func randomProvenance() *entity.Provenance {
+	return &entity.Provenance{
+		SourceType:     RandomEnum([]string{"ORIGINAL", "WEB", "BOOK", "SYNTHETIC"}),
+		URL:            "https://" + RandomString(10) + ".com",
+		License:        RandomEnum([]string{"CC0-1.0", "CC-BY-4.0", "MIT", "CC-BY-SA-4.0", "CC-BY-NC-4.0"}),
+		RightsAttested: true,
+	}
+}
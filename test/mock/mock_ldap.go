@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"data-collection-hub-server/pkg/ldap"
+)
+
+// MockLDAP is an in-process directory answering simple binds and searches over entries held in memory. The
+// password of an entry is its "userPassword" attribute.
+type MockLDAP struct {
+	Addr string
+
+	listener net.Listener
+	mu       sync.Mutex
+	entries  map[string]map[string][]string
+}
+
+// NewMockLDAP starts a mock directory listening on addr, or on a random port if addr is empty.
+func NewMockLDAP(addr string) (*MockLDAP, error) {
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	m := &MockLDAP{
+		Addr:     listener.Addr().String(),
+		listener: listener,
+		entries:  map[string]map[string][]string{},
+	}
+	go m.serve()
+	return m, nil
+}
+
+// URL returns the ldap:// URL of the directory.
+func (m *MockLDAP) URL() string {
+	return "ldap://" + m.Addr
+}
+
+// AddEntry adds or replaces the entry with the DN.
+func (m *MockLDAP) AddEntry(dn string, attributes map[string][]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[strings.ToLower(dn)] = attributes
+	if _, ok := attributes["dn"]; !ok {
+		attributes["dn"] = []string{dn}
+	}
+}
+
+func (m *MockLDAP) Close() error {
+	return m.listener.Close()
+}
+
+func (m *MockLDAP) serve() {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return
+		}
+		go m.handle(conn)
+	}
+}
+
+func (m *MockLDAP) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		msg, err := ldap.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		messageID, op := msg.Children[0].Int(), msg.Children[1]
+		switch op.Tag {
+		case ldap.TagBindRequest:
+			code, message := m.bind(op)
+			_, _ = conn.Write(ldap.NewMessage(messageID, ldap.NewResult(ldap.TagBindResponse, code, message)).Bytes())
+		case ldap.TagSearchRequest:
+			for _, entry := range m.search(op) {
+				_, _ = conn.Write(ldap.NewMessage(messageID, entry).Bytes())
+			}
+			_, _ = conn.Write(
+				ldap.NewMessage(messageID, ldap.NewResult(ldap.TagSearchResultDone, ldap.ResultSuccess, "")).Bytes(),
+			)
+		case ldap.TagUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (m *MockLDAP) bind(op *ldap.Packet) (int64, string) {
+	if len(op.Children) < 3 {
+		return 2, "protocol error"
+	}
+	dn, password := op.Children[1].String(), op.Children[2].String()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[strings.ToLower(dn)]
+	if !ok || password == "" || !contains(entry["userPassword"], password) {
+		return ldap.ResultInvalidCredentials, "invalid credentials"
+	}
+	return ldap.ResultSuccess, ""
+}
+
+func (m *MockLDAP) search(op *ldap.Packet) []*ldap.Packet {
+	if len(op.Children) < 8 {
+		return nil
+	}
+	baseDN, filter, requested := strings.ToLower(op.Children[0].String()), op.Children[6], op.Children[7].Children
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var results []*ldap.Packet
+	for dn, attributes := range m.entries {
+		if !strings.HasSuffix(dn, baseDN) || !matchFilter(filter, attributes) {
+			continue
+		}
+		attrs := ldap.NewConstructed(ldap.TagSequence)
+		for name, values := range attributes {
+			if name == "dn" || strings.EqualFold(name, "userPassword") || !isRequested(requested, name) {
+				continue
+			}
+			vals := ldap.NewConstructed(ldap.TagSet)
+			for _, v := range values {
+				vals.Children = append(vals.Children, ldap.NewOctetString(v))
+			}
+			attrs.Children = append(
+				attrs.Children, ldap.NewConstructed(ldap.TagSequence, ldap.NewOctetString(name), vals),
+			)
+		}
+		results = append(
+			results,
+			ldap.NewConstructed(ldap.TagSearchResultEntry, ldap.NewOctetString(attributes["dn"][0]), attrs),
+		)
+	}
+	return results
+}
+
+func matchFilter(filter *ldap.Packet, attributes map[string][]string) bool {
+	switch filter.Tag {
+	case ldap.TagFilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case ldap.TagFilterOr:
+		for _, child := range filter.Children {
+			if matchFilter(child, attributes) {
+				return true
+			}
+		}
+		return false
+	case ldap.TagFilterNot:
+		return len(filter.Children) == 1 && !matchFilter(filter.Children[0], attributes)
+	case ldap.TagFilterEquality:
+		if len(filter.Children) < 2 {
+			return false
+		}
+		for _, v := range attributeValues(attributes, filter.Children[0].String()) {
+			if strings.EqualFold(v, filter.Children[1].String()) {
+				return true
+			}
+		}
+		return false
+	case ldap.TagFilterPresent:
+		return len(attributeValues(attributes, filter.String())) > 0
+	default:
+		return false
+	}
+}
+
+func attributeValues(attributes map[string][]string, name string) []string {
+	for attr, values := range attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+func isRequested(requested []*ldap.Packet, name string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		if r.String() == "*" || strings.EqualFold(r.String(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
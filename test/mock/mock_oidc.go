@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"data-collection-hub-server/pkg/oidc"
+	"github.com/golang-jwt/jwt"
+)
+
+const mockIdPKeyID = "mock-key"
+
+// MockIdP is a local OpenID Connect provider for testing the authorization code flow with PKCE. Every authorization
+// request is granted at once for the user set with SetUser.
+type MockIdP struct {
+	Server       *httptest.Server
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	claims map[string]interface{}
+	codes  map[string]mockAuthorization
+}
+
+type mockAuthorization struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	claims        map[string]interface{}
+}
+
+// NewMockIdP starts a mock provider listening on addr, or on a random port if addr is empty.
+func NewMockIdP(addr, clientID, clientSecret string) (*MockIdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	m := &MockIdP{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		claims:       map[string]interface{}{},
+		codes:        map[string]mockAuthorization{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.handleDiscovery)
+	mux.HandleFunc("/jwks", m.handleJwks)
+	mux.HandleFunc("/authorize", m.handleAuthorize)
+	mux.HandleFunc("/token", m.handleToken)
+	m.Server = httptest.NewUnstartedServer(mux)
+	if addr != "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		_ = m.Server.Listener.Close()
+		m.Server.Listener = listener
+	}
+	m.Server.Start()
+	m.Issuer = m.Server.URL
+	return m, nil
+}
+
+// SetUser sets the claims of the user signing in with the next authorization requests, "sub" is required.
+func (m *MockIdP) SetUser(claims map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claims = claims
+}
+
+// Authorize plays the user agent: it follows the authorization URL and returns the code and state the provider
+// redirects back with.
+func (m *MockIdP) Authorize(authURL string) (code, state string, err error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorization failed with status %d", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (m *MockIdP) Close() {
+	m.Server.Close()
+}
+
+func (m *MockIdP) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(
+		w, http.StatusOK, map[string]interface{}{
+			"issuer":                                m.Issuer,
+			"authorization_endpoint":                m.Issuer + "/authorize",
+			"token_endpoint":                        m.Issuer + "/token",
+			"jwks_uri":                              m.Issuer + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+			"code_challenge_methods_supported":      []string{"S256"},
+		},
+	)
+}
+
+func (m *MockIdP) handleJwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(
+		w, http.StatusOK, map[string]interface{}{
+			"keys": []interface{}{oidc.RSAPublicJWK(mockIdPKeyID, &m.key.PublicKey)},
+		},
+	)
+}
+
+func (m *MockIdP) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != m.ClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	code := RandomString(32)
+	m.mu.Lock()
+	m.codes[code] = mockAuthorization{
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		claims:        m.claims,
+	}
+	m.mu.Unlock()
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (m *MockIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	if r.PostForm.Get("client_id") != m.ClientID || r.PostForm.Get("client_secret") != m.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	m.mu.Lock()
+	authorization, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+	if !ok || authorization.redirectURI != r.PostForm.Get("redirect_uri") ||
+		oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != authorization.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	claims := jwt.MapClaims{
+		"iss":   m.Issuer,
+		"aud":   m.ClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": authorization.nonce,
+	}
+	for k, v := range authorization.claims {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = mockIdPKeyID
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(
+		w, http.StatusOK, map[string]interface{}{
+			"access_token": RandomString(32),
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     idToken,
+		},
+	)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
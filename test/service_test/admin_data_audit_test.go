@@ -1,9 +1,12 @@
 package service_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/spdx"
 	"data-collection-hub-server/test/wire"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,13 +42,13 @@ func TestGetInstructionDataList(t *testing.T) {
 	)
 	resp, err := dataAuditService.GetInstructionDataList(
 		ctx, &page, &pageSize, &desc, &userID, &createStartTime, &createEndTime, &updateStartTime, &updateEndTime,
-		&theme, &status, &query,
+		&theme, &status, &query, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
 	resp, err = dataAuditService.GetInstructionDataList(
-		ctx, &page, &pageSize, &desc, nil, nil, nil, nil, nil, nil, nil, nil,
+		ctx, &page, &pageSize, &desc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -62,6 +65,7 @@ func TestApproveInstructionData(t *testing.T) {
 		dataAuditService  = injector.AdminDataAuditService
 		instructionDataID = injector.InstructionDataDaoMock.RandomInstructionDataID()
 	)
+	ctx = context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
 	err := dataAuditService.ApproveInstructionData(ctx, &instructionDataID)
 	assert.NoError(t, err)
 
@@ -81,6 +85,7 @@ func TestRejectInstructionData(t *testing.T) {
 		instructionDataID = injector.InstructionDataDaoMock.RandomInstructionDataID()
 		message           = "Message"
 	)
+	ctx = context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
 	err := dataAuditService.RejectInstructionData(ctx, &instructionDataID, &message)
 	assert.NoError(t, err)
 
@@ -108,7 +113,7 @@ func TestUpdateInstructionData(t *testing.T) {
 		note              = "Note"
 	)
 	err := dataAuditService.UpdateInstructionData(
-		ctx, &instructionDataID, &userID, &instruction, &input, &output, &theme, &source, &note,
+		ctx, &instructionDataID, &userID, &instruction, &input, &output, &theme, &source, &note, nil,
 	)
 	assert.NoError(t, err)
 
@@ -142,16 +147,27 @@ func TestExportInstructionData(t *testing.T) {
 	)
 	resp, err := dataAuditService.ExportInstructionData(
 		ctx, &desc, &userID, &createStartTime, &createEndTime, &updateStartTime, &updateEndTime, &theme, &status,
+		nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
 	resp, err = dataAuditService.ExportInstructionData(
-		ctx, &desc, nil, nil, nil, nil, nil, nil, nil,
+		ctx, &desc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp.InstructionDataList)
 
+	// Records with a copyleft or non-commercial license are excluded
+	permissiveOnly := true
+	resp, err = dataAuditService.ExportInstructionData(
+		ctx, &desc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &permissiveOnly,
+	)
+	assert.NoError(t, err)
+	for _, instructionData := range resp.InstructionDataList {
+		assert.True(t, spdx.IsPermissive(instructionData.Provenance.License))
+	}
+
 	t.Logf("Response Data: %+v", resp)
 }
 
@@ -171,12 +187,13 @@ func TestExportInstructionDataAsAlpaca(t *testing.T) {
 	)
 	resp, err := dataAuditService.ExportInstructionDataAsAlpaca(
 		ctx, &desc, &userID, &createStartTime, &createEndTime, &updateStartTime, &updateEndTime, &theme, &status,
+		nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
 	resp, err = dataAuditService.ExportInstructionDataAsAlpaca(
-		ctx, &desc, nil, nil, nil, nil, nil, nil, nil,
+		ctx, &desc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
@@ -21,11 +21,11 @@ func TestGetLoginLogList(t *testing.T) {
 		createEndTime   = time.Now()
 		query           = "a"
 	)
-	resp, err := logsService.GetLoginLogList(ctx, &page, &pageSize, &desc, &query, &createStartTime, &createEndTime)
+	resp, err := logsService.GetLoginLogList(ctx, &page, &pageSize, &desc, &query, nil, &createStartTime, &createEndTime)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
-	resp, err = logsService.GetLoginLogList(ctx, &page, &pageSize, &desc, nil, nil, nil)
+	resp, err = logsService.GetLoginLogList(ctx, &page, &pageSize, &desc, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp.LoginLogList)
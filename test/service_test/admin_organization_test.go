@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOrganization(t *testing.T) {
+	var (
+		injector            = wire.GetInjector()
+		ctx                 = injector.Ctx
+		organizationService = injector.AdminOrganizationService
+		userDao             = injector.UserDao
+		enforcer            = injector.Enforcer
+		name                = mock.RandomString(16)
+		otherName           = mock.RandomString(16)
+		description         = "Organization"
+		page                = int64(1)
+		pageSize            = int64(10)
+	)
+
+	resp, err := organizationService.InsertOrganization(ctx, &name, &description)
+	assert.NoError(t, err)
+	organizationID, err := primitive.ObjectIDFromHex(resp.OrganizationID)
+	assert.NoError(t, err)
+	_, err = organizationService.InsertOrganization(ctx, &name, &description)
+	assert.Error(t, err)
+	otherResp, err := organizationService.InsertOrganization(ctx, &otherName, nil)
+	assert.NoError(t, err)
+	otherOrganizationID, err := primitive.ObjectIDFromHex(otherResp.OrganizationID)
+	assert.NoError(t, err)
+
+	userID, err := userDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@member.com", "Member@123", config.UserRoleUser, "",
+	)
+	assert.NoError(t, err)
+	_, err = enforcer.AddRoleForUser(userID.Hex(), config.UserRoleUser)
+	assert.NoError(t, err)
+
+	// Only members can be organization admins
+	err = organizationService.InsertOrganizationAdmin(ctx, &organizationID, &userID)
+	assert.Error(t, err)
+	err = organizationService.InsertOrganizationMember(ctx, &organizationID, &userID)
+	assert.NoError(t, err)
+	err = organizationService.InsertOrganizationAdmin(ctx, &organizationID, &userID)
+	assert.NoError(t, err)
+	domain, err := service.OrganizationAdminDomain(enforcer, userID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, organizationID, domain)
+
+	// Organization admins have a single organization
+	_, err = enforcer.AddNamedGroupingPolicy("g2", userID.Hex(), config.UserRoleOrgAdmin, otherOrganizationID.Hex())
+	assert.NoError(t, err)
+	_, err = service.OrganizationAdminDomain(enforcer, userID.Hex())
+	assert.ErrorIs(t, err, service.ErrMultipleOrganizationAdminDomains)
+	_, err = enforcer.RemoveNamedGroupingPolicy("g2", userID.Hex(), config.UserRoleOrgAdmin, otherOrganizationID.Hex())
+	assert.NoError(t, err)
+
+	organization, err := organizationService.GetOrganization(ctx, &organizationID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), organization.MemberCount)
+	assert.Equal(t, []string{userID.Hex()}, organization.AdminIDList)
+	memberList, err := organizationService.GetOrganizationMemberList(ctx, &organizationID, &page, &pageSize)
+	assert.NoError(t, err)
+	assert.Len(t, memberList.MemberList, 1)
+	assert.True(t, memberList.MemberList[0].OrgAdmin)
+
+	// Organization admins only see their own organization
+	scopedCtx := context.WithValue(ctx, config.OrganizationKey, name)
+	_, err = organizationService.GetOrganization(scopedCtx, &organizationID)
+	assert.NoError(t, err)
+	_, err = organizationService.GetOrganization(scopedCtx, &otherOrganizationID)
+	assert.Error(t, err)
+
+	// Organizations with members cannot be deleted
+	err = organizationService.DeleteOrganization(ctx, &organizationID)
+	assert.Error(t, err)
+
+	// Moving to another organization drops the organization admin role
+	err = organizationService.InsertOrganizationMember(ctx, &otherOrganizationID, &userID)
+	assert.NoError(t, err)
+	domain, err = service.OrganizationAdminDomain(enforcer, userID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, primitive.NilObjectID, domain)
+	hasRole, err := enforcer.HasRoleForUser(userID.Hex(), config.UserRoleOrgAdmin)
+	assert.NoError(t, err)
+	assert.False(t, hasRole)
+
+	err = organizationService.DeleteOrganizationMember(ctx, &organizationID, &userID)
+	assert.Error(t, err)
+	err = organizationService.DeleteOrganizationMember(ctx, &otherOrganizationID, &userID)
+	assert.NoError(t, err)
+
+	err = organizationService.DeleteOrganization(ctx, &organizationID)
+	assert.NoError(t, err)
+	err = organizationService.DeleteOrganization(ctx, &otherOrganizationID)
+	assert.NoError(t, err)
+	_, err = enforcer.DeleteUser(userID.Hex())
+	assert.NoError(t, err)
+	err = userDao.DeleteUser(ctx, userID)
+	assert.NoError(t, err)
+}
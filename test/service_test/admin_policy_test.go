@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPolicy(t *testing.T) {
+	var (
+		injector      = wire.GetInjector()
+		ctx           = injector.Ctx
+		policyService = injector.AdminPolicyService
+		customRole    = "AUDITOR_" + mock.RandomString(8)
+		reviewer      = config.UserRoleReviewer
+		admin         = config.UserRoleAdmin
+		object        = config.PolicyObjectStatistic
+		readAction    = config.PolicyActionRead
+		reviewAction  = config.PolicyActionReview
+		dataObject    = config.PolicyObjectInstructionData
+	)
+
+	userID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@policy.com", "Policy@123", config.UserRoleUser, "",
+	)
+	assert.NoError(t, err)
+	userIDHex := userID.Hex()
+
+	// Built-in policies are seeded and cannot be deleted
+	policyList, err := policyService.GetPolicyList(ctx, &reviewer)
+	assert.NoError(t, err)
+	assert.Len(t, policyList.PolicyList, 2)
+	assert.True(t, policyList.PolicyList[0].BuiltIn)
+	err = policyService.DeletePolicy(ctx, &reviewer, &dataObject, &reviewAction)
+	assert.Error(t, err)
+
+	// A custom role is created by granting it a permission
+	err = policyService.InsertPolicy(ctx, &customRole, &object, &readAction)
+	assert.NoError(t, err)
+	err = policyService.InsertPolicy(ctx, &customRole, &object, &readAction)
+	assert.Error(t, err)
+
+	// USER and ADMIN are managed through the user API
+	err = policyService.InsertRoleGrouping(ctx, &userID, &admin)
+	assert.Error(t, err)
+	// Only roles with policies can be granted, not e.g. the ID of another user
+	unknownRole := "UNKNOWN_" + mock.RandomString(8)
+	err = policyService.InsertRoleGrouping(ctx, &userID, &unknownRole)
+	assert.Error(t, err)
+	otherUserIDHex := primitive.NewObjectID().Hex()
+	err = policyService.InsertRoleGrouping(ctx, &userID, &otherUserIDHex)
+	assert.Error(t, err)
+	err = policyService.InsertRoleGrouping(ctx, &userID, &reviewer)
+	assert.NoError(t, err)
+	err = policyService.DeleteRoleGrouping(ctx, &userID, &reviewer)
+	assert.NoError(t, err)
+	err = policyService.InsertRoleGrouping(ctx, &userID, &customRole)
+	assert.NoError(t, err)
+	groupingList, err := policyService.GetRoleGroupingList(ctx, &userIDHex, &customRole)
+	assert.NoError(t, err)
+	assert.Len(t, groupingList.RoleGroupingList, 1)
+
+	permission, err := policyService.GetUserPermission(ctx, &userID)
+	assert.NoError(t, err)
+	assert.Contains(t, permission.RoleList, customRole)
+	check, err := policyService.CheckPermission(ctx, &userID, &object, &readAction)
+	assert.NoError(t, err)
+	assert.True(t, check.Allowed)
+	assert.Equal(t, []string{customRole, object, readAction}, check.MatchedPolicy)
+
+	err = policyService.DeleteRoleGrouping(ctx, &userID, &customRole)
+	assert.NoError(t, err)
+	err = policyService.DeleteRoleGrouping(ctx, &userID, &customRole)
+	assert.Error(t, err)
+	check, err = policyService.CheckPermission(ctx, &userID, &dataObject, &reviewAction)
+	assert.NoError(t, err)
+	assert.False(t, check.Allowed)
+
+	err = policyService.DeletePolicy(ctx, &customRole, &object, &readAction)
+	assert.NoError(t, err)
+	err = injector.UserDao.DeleteUser(ctx, userID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReAudit(t *testing.T) {
+	var (
+		injector          = wire.GetInjector()
+		ctx               = injector.Ctx
+		reAuditService    = injector.AdminReAuditService
+		instructionDataID = injector.InstructionDataDaoMock.RandomInstructionDataID()
+		reviewerID        = injector.UserDaoMock.RandomUserID()
+		auditorID         = injector.UserDaoMock.RandomUserID()
+		theme             = "Theme"
+		page              = int64(1)
+		pageSize          = int64(10)
+		desc              = true
+		overturned        = config.ReAuditStatusOverturned
+		message           = "Message"
+	)
+	for auditorID == reviewerID {
+		auditorID = injector.UserDaoMock.RandomUserID()
+	}
+	reAuditID, err := injector.ReAuditDao.InsertReAudit(ctx, instructionDataID, reviewerID, "Reviewer", theme)
+	assert.NoError(t, err)
+
+	// Reviewers cannot re-audit their own approvals
+	reviewerCtx := context.WithValue(ctx, config.UserIDKey, reviewerID.Hex())
+	resp, err := reAuditService.GetReAuditList(reviewerCtx, &page, &pageSize, &desc, &reviewerID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Total)
+	err = reAuditService.SubmitReAudit(reviewerCtx, &reAuditID, &overturned, &message)
+	assert.Error(t, err)
+
+	auditorCtx := context.WithValue(ctx, config.UserIDKey, auditorID.Hex())
+	resp, err = reAuditService.GetReAuditList(auditorCtx, &page, &pageSize, &desc, &reviewerID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.ReAuditList)
+	err = reAuditService.SubmitReAudit(auditorCtx, &reAuditID, &overturned, &message)
+	assert.NoError(t, err)
+	err = reAuditService.SubmitReAudit(auditorCtx, &reAuditID, &overturned, &message)
+	assert.Error(t, err)
+
+	statistic, err := reAuditService.GetReAuditStatistic(ctx, &theme, nil, nil)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, statistic.Data.OverturnedCount, int64(1))
+	for _, reviewer := range statistic.Reviewers {
+		assert.LessOrEqual(t, reviewer.Data.OverturnedCount, reviewer.Data.AuditedCount)
+		assert.LessOrEqual(t, reviewer.Data.AuditedCount, reviewer.Data.SampledCount)
+	}
+
+	t.Logf("Response Data: %+v", statistic)
+}
@@ -71,3 +71,26 @@ func TestGetUserStatisticList(t *testing.T) {
 
 	t.Logf("Response Data: %+v", resp)
 }
+
+func TestGetReviewAgreement(t *testing.T) {
+	var (
+		injector         = wire.GetInjector()
+		ctx              = injector.Ctx
+		statisticService = injector.AdminStatisticService
+		startTime        = time.Now().AddDate(0, 0, -7)
+		endTime          = time.Now()
+	)
+	resp, err := statisticService.GetReviewAgreement(ctx, nil, &startTime, &endTime)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	for _, reviewer := range resp.Reviewers {
+		assert.LessOrEqual(t, reviewer.ComparedCount, reviewer.ReviewCount)
+	}
+	for _, theme := range resp.ThemeAgreement {
+		for _, pair := range theme.ReviewerPairs {
+			assert.LessOrEqual(t, pair.ItemCount, theme.ItemCount)
+		}
+	}
+
+	t.Logf("Response Data: %+v", resp)
+}
@@ -1,8 +1,17 @@
 package service_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/domain/vo/admin"
+	"data-collection-hub-server/pkg/utils/crypt"
 	"data-collection-hub-server/test/mock"
 	"data-collection-hub-server/test/wire"
 	"github.com/stretchr/testify/assert"
@@ -46,12 +55,12 @@ func TestGetUserList(t *testing.T) {
 		query       = "a"
 	)
 
-	userList, err := userService.GetUserList(ctx, &page, &pageSize, &desc, &role, nil, nil, nil, nil, &query)
+	userList, err := userService.GetUserList(ctx, &page, &pageSize, &desc, &role, nil, nil, nil, nil, nil, &query)
 	assert.NoError(t, err)
 	assert.NotNil(t, userList)
 	t.Logf("User List: %+v", userList)
 
-	userList, err = userService.GetUserList(ctx, &page, &pageSize, &desc, nil, nil, nil, nil, nil, nil)
+	userList, err = userService.GetUserList(ctx, &page, &pageSize, &desc, nil, nil, nil, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, userList)
 	assert.Equal(t, pageSize, int64(len(userList.UserList)))
@@ -68,7 +77,7 @@ func TestUpdateUser(t *testing.T) {
 		email        = mock.RandomString(10) + "@user.com"
 		organization = mock.RandomString(10)
 	)
-	err := userService.UpdateUser(ctx, &userID, &username, &email, &organization)
+	err := userService.UpdateUser(ctx, &userID, &username, &email, nil, &organization)
 	assert.NoError(t, err)
 
 	user, err := injector.UserDao.GetUserByID(ctx, userID)
@@ -95,3 +104,385 @@ func TestDeleteUser(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, user)
 }
+
+func TestImportUser(t *testing.T) {
+	var (
+		injector     = wire.GetInjector()
+		ctx          = injector.Ctx
+		userService  = injector.AdminUserService
+		username     = mock.RandomString(10)
+		email        = mock.RandomString(10) + "@import.com"
+		role         = "USER"
+		adminRole    = "ADMIN"
+		organization = mock.RandomString(10)
+		row          = &admin.ImportUserRow{
+			Username: &username, Email: &email, Role: &role, Organization: &organization,
+		}
+	)
+
+	// Validate only does not create the user
+	result, err := userService.ImportUser(ctx, row, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE", result.Action)
+	assert.Empty(t, result.Password)
+	_, err = injector.UserDao.GetUserByEmail(ctx, email)
+	assert.Error(t, err)
+
+	result, err = userService.ImportUser(ctx, row, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE", result.Action)
+	assert.NotEmpty(t, result.Password)
+	user, err := injector.UserDao.GetUserByEmail(ctx, email)
+	assert.NoError(t, err)
+	assert.True(t, crypt.Compare(result.Password, user.Password))
+
+	// Users are matched by email
+	row.Role = &adminRole
+	result, err = userService.ImportUser(ctx, row, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE", result.Action)
+	assert.Empty(t, result.Password)
+	user, err = injector.UserDao.GetUserByEmail(ctx, email)
+	assert.NoError(t, err)
+	assert.Equal(t, adminRole, user.Role)
+
+	// The username must not be taken by another user
+	otherEmail := mock.RandomString(10) + "@import.com"
+	row.Email = &otherEmail
+	_, err = userService.ImportUser(ctx, row, true)
+	assert.Error(t, err)
+
+	err = userService.DeleteUser(ctx, &user.UserID)
+	assert.NoError(t, err)
+}
+
+func TestImpersonateUser(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		userService = injector.AdminUserService
+	)
+	adminID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@admin.com", "", config.UserRoleAdmin, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	userID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	ctx = context.WithValue(ctx, config.UserIDKey, adminID.Hex())
+
+	resp, err := userService.ImpersonateUser(ctx, &userID, true)
+	assert.NoError(t, err)
+	assert.True(t, resp.ReadOnly)
+	claims, err := injector.Jwt.ParseAccessToken(resp.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, userID.Hex(), claims.Subject)
+	assert.Equal(t, adminID.Hex(), claims.Actor.Subject)
+	assert.True(t, claims.ReadOnly)
+
+	// Admins, including the admin itself, cannot be impersonated
+	_, err = userService.ImpersonateUser(ctx, &adminID, true)
+	assert.Error(t, err)
+	otherAdminID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@admin.com", "", config.UserRoleAdmin, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	_, err = userService.ImpersonateUser(ctx, &otherAdminID, false)
+	assert.Error(t, err)
+
+	for _, id := range []primitive.ObjectID{adminID, userID, otherAdminID} {
+		assert.NoError(t, injector.UserDao.DeleteUser(ctx, id))
+	}
+}
+
+func TestSuspendUser(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		userService = injector.AdminUserService
+		reason      = "spam"
+		expiresAt   = time.Now().Add(time.Hour)
+	)
+	adminID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@admin.com", "", config.UserRoleAdmin, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	userID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	ctx = context.WithValue(ctx, config.UserIDKey, adminID.Hex())
+
+	// Admins cannot suspend themselves, and the expiry must be in the future
+	assert.Error(t, userService.SuspendUser(ctx, &adminID, &reason, nil))
+	past := time.Now().Add(-time.Hour)
+	assert.Error(t, userService.SuspendUser(ctx, &userID, &reason, &past))
+
+	err = userService.SuspendUser(ctx, &userID, &reason, &expiresAt)
+	assert.NoError(t, err)
+	user, err := userService.GetUser(ctx, &userID)
+	assert.NoError(t, err)
+	assert.NotNil(t, user.Suspension)
+	assert.Equal(t, reason, user.Suspension.Reason)
+	assert.Equal(t, adminID.Hex(), user.Suspension.SuspendedBy)
+
+	err = userService.ReactivateUser(ctx, &userID)
+	assert.NoError(t, err)
+	user, err = userService.GetUser(ctx, &userID)
+	assert.NoError(t, err)
+	assert.Nil(t, user.Suspension)
+	assert.Error(t, userService.ReactivateUser(ctx, &userID))
+
+	for _, id := range []primitive.ObjectID{adminID, userID} {
+		assert.NoError(t, injector.UserDao.DeleteUser(ctx, id))
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	var (
+		injector           = wire.GetInjector()
+		ctx                = injector.Ctx
+		userService        = injector.AdminUserService
+		instructionDataDao = injector.InstructionDataDao
+		email              = mock.RandomString(10) + "@user.com"
+	)
+	adminID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@admin.com", "", config.UserRoleAdmin, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	userID, err := injector.UserDao.InsertUser(ctx, mock.RandomString(10), email, "", config.UserRoleUser, "FOO")
+	assert.NoError(t, err)
+	ctx = context.WithValue(ctx, config.UserIDKey, adminID.Hex())
+	approvedID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusApproved, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	pendingID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusPending, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	draftID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusDraft, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	_, err = injector.LoginLogDao.InsertLoginLog(ctx, userID, "127.0.0.1", "test")
+	assert.NoError(t, err)
+	assert.NoError(
+		t, injector.ReviewDao.UpsertReview(
+			ctx, approvedID, userID, "Theme", config.InstructionDataStatusApproved, "",
+		),
+	)
+	reAuditID, err := injector.ReAuditDao.InsertReAudit(ctx, approvedID, userID, "Reviewer", "Theme")
+	assert.NoError(t, err)
+	_, err = injector.AccessTokenDao.InsertAccessToken(
+		ctx, userID, "Token", mock.RandomString(32), nil, time.Now().Add(time.Hour),
+	)
+	assert.NoError(t, err)
+
+	archive, err := userService.ExportUserData(ctx, &userID)
+	assert.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+	assert.Len(t, reader.File, 6)
+	// Drafts are exported along with the rest of the instruction data
+	for _, file := range reader.File {
+		if file.Name != "instruction_data.json" {
+			continue
+		}
+		f, err := file.Open()
+		assert.NoError(t, err)
+		data, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		for _, id := range []primitive.ObjectID{approvedID, pendingID, draftID} {
+			assert.Contains(t, string(data), id.Hex())
+		}
+	}
+
+	_, err = userService.EraseUser(ctx, &adminID)
+	assert.Error(t, err)
+	resp, err := userService.EraseUser(ctx, &userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.InstructionDataKept)
+	assert.Equal(t, int64(2), resp.InstructionDataDeleted)
+	assert.Equal(t, int64(1), resp.ReviewsAnonymised)
+	assert.Equal(t, int64(1), resp.ReAuditsAnonymised)
+	assert.Equal(t, int64(1), resp.LoginLogsAnonymised)
+	assert.Equal(t, int64(1), resp.AccessTokensDeleted)
+
+	// The approved data is kept under a pseudonym, the rest is deleted
+	approved, err := instructionDataDao.GetInstructionDataByID(ctx, approvedID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, userID, approved.UserID)
+	assert.True(t, strings.HasPrefix(approved.Username, "erased-"))
+	_, err = instructionDataDao.GetInstructionDataByID(ctx, pendingID)
+	assert.Error(t, err)
+	loginLogList, _, err := injector.LoginLogDao.GetLoginLogList(
+		ctx, 0, 0, false, nil, nil, &userID, nil, nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, loginLogList)
+	reviewList, _, err := injector.ReviewDao.GetReviewList(ctx, 0, 0, false, &approvedID, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, reviewList, 1)
+	assert.NotEqual(t, userID, reviewList[0].ReviewerID)
+	assert.True(t, strings.HasPrefix(reviewList[0].ReviewerName, "erased-"))
+	reAudit, err := injector.ReAuditDao.GetReAuditByID(ctx, reAuditID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, userID, reAudit.ReviewerID)
+	assert.True(t, strings.HasPrefix(reAudit.ReviewerName, "erased-"))
+	accessTokenList, err := injector.AccessTokenDao.GetAccessTokenList(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, accessTokenList)
+	_, err = injector.UserDao.GetUserByID(ctx, userID)
+	assert.Error(t, err)
+
+	assert.NoError(t, instructionDataDao.DeleteInstructionData(ctx, approvedID))
+	assert.NoError(t, injector.UserDao.DeleteUser(ctx, adminID))
+}
+
+func TestRestoreUser(t *testing.T) {
+	var (
+		injector           = wire.GetInjector()
+		ctx                = injector.Ctx
+		userService        = injector.AdminUserService
+		instructionDataDao = injector.InstructionDataDao
+		enforcer           = injector.Enforcer
+		username           = mock.RandomString(10)
+		email              = mock.RandomString(10) + "@user.com"
+		password           = "User@123"
+		organization       = mock.RandomString(10)
+	)
+	userIDHex, err := userService.InsertUser(ctx, &username, &email, &password, &organization)
+	assert.NoError(t, err)
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	assert.NoError(t, err)
+	_, err = enforcer.AddRoleForUser(userIDHex, config.UserRoleReviewer)
+	assert.NoError(t, err)
+	keptID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusPending, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	deletedID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusPending, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, instructionDataDao.SoftDeleteInstructionData(ctx, deletedID))
+
+	// The user, its roles and its instruction data are gone until it is restored
+	assert.NoError(t, userService.DeleteUser(ctx, &userID))
+	_, err = injector.UserDao.GetUserByID(ctx, userID)
+	assert.Error(t, err)
+	roles, err := enforcer.GetRolesForUser(userIDHex)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+	_, err = instructionDataDao.GetInstructionDataByID(ctx, keptID)
+	assert.Error(t, err)
+
+	page, pageSize, desc := int64(1), int64(100), true
+	deletedList, err := userService.GetDeletedUserList(ctx, &page, &pageSize, &desc)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, deletedList.UserList)
+	assert.Equal(t, userIDHex, deletedList.UserList[0].UserID)
+
+	resp, err := userService.RestoreUser(ctx, &userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.InstructionDataRestored)
+	_, err = injector.UserDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	roles, err = enforcer.GetRolesForUser(userIDHex)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.UserRoleUser, config.UserRoleReviewer}, roles)
+	_, err = instructionDataDao.GetInstructionDataByID(ctx, keptID)
+	assert.NoError(t, err)
+	// The data deleted before the user stays deleted
+	_, err = instructionDataDao.GetInstructionDataByID(ctx, deletedID)
+	assert.Error(t, err)
+	_, err = userService.RestoreUser(ctx, &userID)
+	assert.Error(t, err)
+
+	assert.NoError(t, instructionDataDao.DeleteInstructionData(ctx, keptID))
+	assert.NoError(t, instructionDataDao.DeleteInstructionData(ctx, deletedID))
+	_, err = enforcer.DeleteUser(userIDHex)
+	assert.NoError(t, err)
+	assert.NoError(t, injector.UserDao.DeleteUser(ctx, userID))
+}
+
+func TestPurgeUserList(t *testing.T) {
+	var (
+		injector           = wire.GetInjector()
+		ctx                = injector.Ctx
+		userService        = injector.AdminUserService
+		instructionDataDao = injector.InstructionDataDao
+		retention          = injector.Config.UserRetentionConfig
+	)
+	defer func() { injector.Config.UserRetentionConfig = retention }()
+	ownerID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	userID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	instructionDataID, err := instructionDataDao.InsertInstructionData(
+		ctx, userID, "Instruction", "Input", "Output", "Theme", "Source", "Note",
+		config.InstructionDataStatusPending, "", nil, nil,
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, userService.DeleteUser(ctx, &userID))
+	// The avatar of the failing user cannot be deleted from the storage
+	failingID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@user.com", "", config.UserRoleUser, mock.RandomString(10),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, injector.UserDao.UpdateUserAvatar(ctx, failingID, "../avatar.png"))
+	assert.NoError(t, userService.DeleteUser(ctx, &failingID))
+
+	// Users deleted within the retention period are kept
+	injector.Config.UserRetentionConfig.Period = time.Hour
+	resp, err := userService.PurgeUserList(ctx)
+	assert.NoError(t, err)
+	for _, purged := range resp.UserList {
+		assert.NotEqual(t, userID.Hex(), purged.UserID)
+	}
+
+	injector.Config.UserRetentionConfig.Period = 0
+	injector.Config.UserRetentionConfig.PurgePolicy = config.UserPurgePolicyReassign
+	injector.Config.UserRetentionConfig.ReassignTo = ownerID.Hex()
+	resp, err = userService.PurgeUserList(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, config.UserPurgePolicyReassign, resp.Policy)
+	assert.GreaterOrEqual(t, resp.Failed, int64(1))
+	for _, purged := range resp.UserList {
+		switch purged.UserID {
+		case userID.Hex():
+			assert.Empty(t, purged.Error)
+		case failingID.Hex():
+			assert.NotEmpty(t, purged.Error)
+		}
+	}
+	_, err = injector.UserDao.GetDeletedUserByID(ctx, userID)
+	assert.Error(t, err)
+	// The failing user is left for the next purge, which finishes it once the cause is gone
+	_, err = injector.UserDao.GetDeletedUserByID(ctx, failingID)
+	assert.NoError(t, err)
+	assert.NoError(t, injector.UserDao.UpdateUserAvatar(ctx, failingID, ""))
+	_, err = userService.PurgeUserList(ctx)
+	assert.NoError(t, err)
+	_, err = injector.UserDao.GetDeletedUserByID(ctx, failingID)
+	assert.Error(t, err)
+	// The instruction data deleted along with the user is visible again under the new owner
+	instructionData, err := instructionDataDao.GetInstructionDataByID(ctx, instructionDataID)
+	assert.NoError(t, err)
+	assert.Equal(t, ownerID, instructionData.UserID)
+
+	assert.NoError(t, instructionDataDao.DeleteInstructionData(ctx, instructionDataID))
+	assert.NoError(t, injector.UserDao.DeleteUser(ctx, ownerID))
+}
@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAccessToken(t *testing.T) {
+	var (
+		injector           = wire.GetInjector()
+		ctx                = injector.Ctx
+		accessTokenService = injector.CommonAccessTokenService
+		accessTokenDao     = injector.AccessTokenDao
+		userID             = injector.UserDaoMock.RandomUserID()
+		otherUserID        = injector.UserDaoMock.RandomUserID()
+		userCtx            = context.WithValue(ctx, config.UserIDKey, userID.Hex())
+		otherUserCtx       = context.WithValue(ctx, config.UserIDKey, otherUserID.Hex())
+		name               = "generator"
+		scopes             = []string{config.AccessTokenScopeDatasetRead, config.AccessTokenScopeDatasetWrite}
+		expiresAt          = time.Now().Add(24 * time.Hour)
+		pastExpiresAt      = time.Now().Add(-time.Minute)
+		farExpiresAt       = time.Now().Add(injector.Config.AccessTokenConfig.MaxTTL + time.Hour)
+	)
+
+	// Expiry must be in the future and within the max TTL
+	_, err := accessTokenService.InsertAccessToken(userCtx, &name, scopes, &pastExpiresAt)
+	assert.Error(t, err)
+	_, err = accessTokenService.InsertAccessToken(userCtx, &name, scopes, &farExpiresAt)
+	assert.Error(t, err)
+
+	resp, err := accessTokenService.InsertAccessToken(userCtx, &name, scopes, &expiresAt)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Token, config.AccessTokenPrefix))
+
+	// Only the hash is stored
+	accessToken, err := accessTokenDao.GetAccessTokenByHash(ctx, crypt.SHA256(resp.Token))
+	assert.NoError(t, err)
+	assert.Equal(t, resp.AccessTokenID, accessToken.AccessTokenID.Hex())
+	assert.NotEqual(t, resp.Token, accessToken.TokenHash)
+
+	listResp, err := accessTokenService.GetAccessTokenList(userCtx)
+	assert.NoError(t, err)
+	found := false
+	for _, item := range listResp.AccessTokenList {
+		if item.AccessTokenID == resp.AccessTokenID {
+			found = true
+			assert.Equal(t, name, item.Name)
+			assert.False(t, item.Expired)
+		}
+	}
+	assert.True(t, found)
+
+	// Other users can not revoke the token
+	accessTokenID, err := primitive.ObjectIDFromHex(resp.AccessTokenID)
+	assert.NoError(t, err)
+	err = accessTokenService.DeleteAccessToken(otherUserCtx, &accessTokenID)
+	assert.Error(t, err)
+	err = accessTokenService.DeleteAccessToken(userCtx, &accessTokenID)
+	assert.NoError(t, err)
+	err = accessTokenService.DeleteAccessToken(userCtx, &accessTokenID)
+	assert.Error(t, err)
+}
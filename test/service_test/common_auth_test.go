@@ -17,6 +17,7 @@ func TestLogin(t *testing.T) {
 		injector     = wire.GetInjector()
 		ctx          = injector.Ctx
 		authService  = injector.CommonAuthService
+		ipAddress    = "127.0.0.1"
 		userDaoMock  = injector.UserDaoMock
 		username     = mock.RandomString(10)
 		email        = mock.RandomString(10) + "@user.com"
@@ -32,7 +33,7 @@ func TestLogin(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, userID)
 
-	resp, err := authService.Login(ctx, &email, &password)
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	t.Logf("Response Data: %+v", resp)
@@ -43,6 +44,7 @@ func TestLogout(t *testing.T) {
 		injector     = wire.GetInjector()
 		ctx          = injector.Ctx
 		authService  = injector.CommonAuthService
+		ipAddress    = "127.0.0.1"
 		userDaoMock  = injector.UserDaoMock
 		username     = mock.RandomString(10)
 		email        = mock.RandomString(10) + "@user.com"
@@ -58,7 +60,7 @@ func TestLogout(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, userID)
 
-	resp, err := authService.Login(ctx, &email, &password)
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
@@ -70,6 +72,11 @@ func TestLogout(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, cache)
 
+	// The session ends with the logout
+	refreshToken := resp.RefreshToken
+	_, err = authService.RefreshToken(ctx, &refreshToken)
+	assert.Error(t, err)
+
 	t.Logf("AccessToken: %s", accessToken)
 }
 
@@ -78,6 +85,7 @@ func TestRefreshToken(t *testing.T) {
 		injector     = wire.GetInjector()
 		ctx          = injector.Ctx
 		authService  = injector.CommonAuthService
+		ipAddress    = "127.0.0.1"
 		userDaoMock  = injector.UserDaoMock
 		username     = mock.RandomString(10)
 		email        = mock.RandomString(10) + "@user.com"
@@ -92,7 +100,7 @@ func TestRefreshToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, userID)
 
-	resp, err := authService.Login(ctx, &email, &password)
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
@@ -112,10 +120,11 @@ func TestChangePassword(t *testing.T) {
 		injector     = wire.GetInjector()
 		ctx          = injector.Ctx
 		authService  = injector.CommonAuthService
+		ipAddress    = "127.0.0.1"
 		userDaoMock  = injector.UserDaoMock
 		username     = mock.RandomString(10)
 		email        = mock.RandomString(10) + "@user.com"
-		password     = "123456"
+		password     = "User@123"
 		role         = "USER"
 		organization = "ORG"
 	)
@@ -126,18 +135,131 @@ func TestChangePassword(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, userID)
 
-	resp, err := authService.Login(ctx, &email, &password)
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
 	ctx = context.WithValue(ctx, config.UserIDKey, userID.Hex())
-	newPassword := "1234567"
+	// The new password must follow the policy
+	weakPassword := "1234567"
+	err = authService.ChangePassword(ctx, &password, &weakPassword)
+	assert.Error(t, err)
+
+	newPassword := "User@1234"
 	err = authService.ChangePassword(ctx, &password, &newPassword)
 	assert.NoError(t, err)
 
-	resp, err = authService.Login(ctx, &email, &newPassword)
+	// Recent passwords can not be reused
+	err = authService.ChangePassword(ctx, &newPassword, &password)
+	assert.Error(t, err)
+
+	// Tokens issued before the change are revoked
+	validAfter, err := injector.SessionDao.GetUserTokensValidAfter(ctx, userID.Hex())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, validAfter, time.Now().Add(-time.Minute).UnixMilli())
+	refreshToken := resp.RefreshToken
+	_, err = authService.RefreshToken(ctx, &refreshToken)
+	assert.Error(t, err)
+
+	resp, err = authService.Login(ctx, &email, &newPassword, &ipAddress)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
+	// The password from two changes ago is in the history, even with the user cached as by the auth middleware
+	thirdPassword := "User@12345"
+	err = authService.ChangePassword(ctx, &newPassword, &thirdPassword)
+	assert.NoError(t, err)
+	_, err = userDaoMock.UserDao.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	err = authService.ChangePassword(ctx, &thirdPassword, &password)
+	assert.Error(t, err)
+
 	t.Logf("Response Data: %+v", resp)
 }
+
+func TestRegister(t *testing.T) {
+	var (
+		injector     = wire.GetInjector()
+		ctx          = injector.Ctx
+		authService  = injector.CommonAuthService
+		code         = mock.RandomString(16)
+		username     = mock.RandomString(10)
+		email        = mock.RandomString(10) + "@user.com"
+		password     = "User@123"
+		organization = "ORG"
+	)
+	inviteCodeID, err := injector.InviteCodeDao.InsertInviteCode(
+		ctx, code, config.UserRoleUser, organization, 1, time.Now().Add(time.Hour), injector.UserDaoMock.RandomUserID(),
+	)
+	assert.NoError(t, err)
+
+	userIDHex, err := authService.Register(ctx, &code, &username, &email, &password)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, userIDHex)
+
+	hasRole, err := injector.Enforcer.HasRoleForUser(userIDHex, config.UserRoleUser)
+	assert.NoError(t, err)
+	assert.True(t, hasRole)
+
+	inviteCode, err := injector.InviteCodeDao.GetInviteCodeByID(ctx, inviteCodeID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), inviteCode.UsedCount)
+	assert.Len(t, inviteCode.Redemptions, 1)
+	assert.Equal(t, userIDHex, inviteCode.Redemptions[0].UserID.Hex())
+
+	// The code is used up
+	username, email = mock.RandomString(10), mock.RandomString(10)+"@user.com"
+	_, err = authService.Register(ctx, &code, &username, &email, &password)
+	assert.Error(t, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	var (
+		injector     = wire.GetInjector()
+		ctx          = injector.Ctx
+		authService  = injector.CommonAuthService
+		ipAddress    = "127.0.0.1"
+		userDaoMock  = injector.UserDaoMock
+		username     = mock.RandomString(10)
+		email        = mock.RandomString(10) + "@user.com"
+		unknown      = mock.RandomString(10) + "@user.com"
+		password     = "User@123"
+		newPassword  = "User@456"
+		token        = crypt.MD5(mock.RandomString(16)) + crypt.MD5(mock.RandomString(16))
+		role         = "USER"
+		organization = "ORG"
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	userID, err := userDaoMock.UserDao.InsertUser(ctx, username, email, passwordHash, role, organization)
+	assert.NoError(t, err)
+
+	userIDHex, err := authService.ForgotPassword(ctx, &email)
+	assert.NoError(t, err)
+	assert.Equal(t, userID.Hex(), userIDHex)
+
+	// Unknown emails are not reported
+	userIDHex, err = authService.ForgotPassword(ctx, &unknown)
+	assert.NoError(t, err)
+	assert.Empty(t, userIDHex)
+
+	err = injector.Cache.Set(
+		ctx, config.PasswordResetCachePrefix+":"+crypt.MD5(token), userID.Hex(),
+		&injector.Config.PasswordResetConfig.TokenTTL,
+	)
+	assert.NoError(t, err)
+	// A password rejected by the policy does not use up the token
+	weakPassword := "1234567"
+	_, err = authService.ResetPassword(ctx, &token, &weakPassword)
+	assert.Error(t, err)
+	userIDHex, err = authService.ResetPassword(ctx, &token, &newPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, userID.Hex(), userIDHex)
+
+	_, err = authService.Login(ctx, &email, &newPassword, &ipAddress)
+	assert.NoError(t, err)
+
+	// The token can only be used once
+	_, err = authService.ResetPassword(ctx, &token, &password)
+	assert.Error(t, err)
+}
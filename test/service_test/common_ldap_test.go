@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPLogin(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		cfg         = injector.Config.LDAPConfig
+		authService = injector.CommonAuthService
+		ipAddress   = "127.0.0.1"
+		uid         = strings.ToLower(mock.RandomString(10))
+		email       = uid + "@lab.org"
+		password    = "Ldap@123"
+		wrong       = "Wrong@123"
+		dn          = "uid=" + uid + ",ou=people,dc=lab,dc=org"
+	)
+	directory, err := mock.NewMockLDAP(strings.TrimPrefix(cfg.URL, "ldap://"))
+	assert.NoError(t, err)
+	defer directory.Close()
+	directory.AddEntry(cfg.BindDN, map[string][]string{"userPassword": {cfg.BindPassword}})
+	directory.AddEntry(
+		dn, map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {uid},
+			"mail":         {email},
+			"o":            {"Lab"},
+			"memberOf":     {cfg.UserGroups[0]},
+			"userPassword": {password},
+		},
+	)
+
+	_, err = authService.Login(ctx, &email, &wrong, &ipAddress)
+	assert.Error(t, err)
+	time.Sleep(injector.Config.LoginProtectionConfig.DelayBase) // a failure delays the next login
+
+	// The first bind provisions the user
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, uid, resp.Meta.Username)
+	assert.Equal(t, config.UserRoleUser, resp.Meta.Role)
+	user, err := injector.UserDao.GetUserByEmail(ctx, email)
+	assert.NoError(t, err)
+	assert.Equal(t, "Lab", user.Organization)
+	assert.Equal(t, dn, user.LDAP.DN)
+	roles, err := injector.Enforcer.GetRolesForUser(user.UserID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.UserRoleUser}, roles)
+
+	// The role follows the group membership
+	directory.AddEntry(
+		dn, map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {uid},
+			"mail":         {email},
+			"memberOf":     {cfg.AdminGroups[0]},
+			"userPassword": {password},
+		},
+	)
+	resp, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, user.UserID.Hex(), resp.Meta.UserID)
+	assert.Equal(t, config.UserRoleAdmin, resp.Meta.Role)
+	roles, err = injector.Enforcer.GetRolesForUser(user.UserID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.UserRoleAdmin}, roles)
+
+	// Members of no allowed group can not log in
+	directory.AddEntry(
+		dn, map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {uid},
+			"mail":         {email},
+			"memberOf":     {"cn=others,ou=groups,dc=lab,dc=org"},
+			"userPassword": {password},
+		},
+	)
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.Error(t, err)
+
+	// Entries carrying the email of a local account can neither sign in as it nor change its role
+	localEmail := strings.ToLower(mock.RandomString(10)) + "@lab.org"
+	passwordHash, err := crypt.Hash("Local@123")
+	assert.NoError(t, err)
+	localUserID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), localEmail, passwordHash, config.UserRoleUser, "ORG",
+	)
+	assert.NoError(t, err)
+	_, err = injector.Enforcer.AddRoleForUser(localUserID.Hex(), config.UserRoleUser)
+	assert.NoError(t, err)
+	time.Sleep(injector.Config.LoginProtectionConfig.DelayBase)
+	otherUID := strings.ToLower(mock.RandomString(10))
+	directory.AddEntry(
+		"uid="+otherUID+",ou=people,dc=lab,dc=org", map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {otherUID},
+			"mail":         {localEmail},
+			"memberOf":     {cfg.AdminGroups[0]},
+			"userPassword": {password},
+		},
+	)
+	_, err = authService.Login(ctx, &localEmail, &password, &ipAddress)
+	assert.Error(t, err)
+	localUser, err := injector.UserDao.GetUserByID(ctx, localUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.UserRoleUser, localUser.Role)
+	roles, err = injector.Enforcer.GetRolesForUser(localUserID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.UserRoleUser}, roles)
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	e "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/errors"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorCode(err error) int {
+	var appErr *errors.AppError
+	if e.As(err, &appErr) {
+		return appErr.Code()
+	}
+	return 0
+}
+
+func TestLoginProtection(t *testing.T) {
+	var (
+		injector            = wire.GetInjector()
+		ctx                 = injector.Ctx
+		cfg                 = injector.Config.LoginProtectionConfig
+		authService         = injector.CommonAuthService
+		loginLockoutService = injector.AdminLoginLockoutService
+		ipAddress           = "10.0.0." + mock.RandomString(3)
+		username            = mock.RandomString(10)
+		email               = strings.ToLower(mock.RandomString(10)) + "@user.com"
+		password            = "User@123"
+		wrong               = "Wrong@123"
+		kind                = config.LoginLockoutKindAccount
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	_, err = injector.UserDaoMock.UserDao.InsertUser(ctx, username, email, passwordHash, config.UserRoleUser, "ORG")
+	assert.NoError(t, err)
+
+	// A failure delays the next login, even with the right password
+	_, err = authService.Login(ctx, &email, &wrong, &ipAddress)
+	assert.Equal(t, errors.CodeAuthFailed, errorCode(err))
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.Equal(t, errors.CodeTooManyAttempts, errorCode(err))
+
+	// A successful login clears the failures
+	time.Sleep(cfg.DelayBase)
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+
+	// The account is locked once the failures reach the threshold
+	for i := int64(1); i <= cfg.AccountThreshold; i++ {
+		time.Sleep(cfg.DelayMax)
+		_, err = authService.Login(ctx, &email, &wrong, &ipAddress)
+		if i < cfg.AccountThreshold {
+			assert.Equal(t, errors.CodeAuthFailed, errorCode(err))
+		} else {
+			assert.Equal(t, errors.CodeTooManyAttempts, errorCode(err))
+		}
+	}
+	time.Sleep(cfg.DelayMax)
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.Equal(t, errors.CodeTooManyAttempts, errorCode(err))
+
+	resp, err := loginLockoutService.GetLoginLockoutList(ctx)
+	assert.NoError(t, err)
+	var lockedFailures int64
+	for _, lockout := range resp.LockoutList {
+		if lockout.Kind == kind && lockout.Value == email {
+			lockedFailures = lockout.Failures
+		}
+	}
+	assert.Equal(t, cfg.AccountThreshold, lockedFailures)
+
+	// Admins can lift the lockout
+	err = loginLockoutService.DeleteLoginLockout(ctx, &kind, &email)
+	assert.NoError(t, err)
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+
+	// The lockout also expires on its own
+	for i := int64(1); i <= cfg.AccountThreshold; i++ {
+		time.Sleep(cfg.DelayMax)
+		_, _ = authService.Login(ctx, &email, &wrong, &ipAddress)
+	}
+	time.Sleep(cfg.LockoutDuration)
+	_, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+}
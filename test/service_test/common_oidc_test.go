@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDC(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		cfg         = injector.Config.OIDCConfig
+		oidcService = injector.CommonOIDCService
+		userDao     = injector.UserDao
+		email       = strings.ToLower(mock.RandomString(10)) + "@sso.com"
+		subject     = mock.RandomString(16)
+	)
+	idp, err := mock.NewMockIdP(strings.TrimPrefix(cfg.Issuer, "http://"), cfg.ClientID, cfg.ClientSecret)
+	assert.NoError(t, err)
+	defer idp.Close()
+
+	signIn := func(claims map[string]interface{}) (string, error) {
+		idp.SetUser(claims)
+		authorization, err := oidcService.Authorize(ctx)
+		if err != nil {
+			return "", err
+		}
+		code, state, err := idp.Authorize(authorization.AuthorizationURL)
+		if err != nil {
+			return "", err
+		}
+		resp, err := oidcService.Callback(ctx, &code, &state)
+		if err != nil {
+			return "", err
+		}
+		assert.NotEmpty(t, resp.AccessToken)
+		return resp.Meta.UserID, nil
+	}
+
+	// Users outside the allowed groups can not sign in
+	_, err = signIn(map[string]interface{}{"sub": subject, "email": email, "groups": []string{"others"}})
+	assert.Error(t, err)
+
+	// First sign-in provisions the user
+	userIDHex, err := signIn(
+		map[string]interface{}{
+			"sub": subject, "email": email, "email_verified": true, "preferred_username": "sso-" + subject,
+			"organization": "SSO", "groups": []string{"hub-users"},
+		},
+	)
+	assert.NoError(t, err)
+	user, err := userDao.GetUserByOIDCSubject(ctx, idp.Issuer, subject)
+	assert.NoError(t, err)
+	assert.Equal(t, userIDHex, user.UserID.Hex())
+	assert.Equal(t, email, user.Email)
+	assert.Equal(t, "SSO", user.Organization)
+	assert.Equal(t, config.UserRoleUser, user.Role)
+	roles, err := injector.Enforcer.GetRolesForUser(userIDHex)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.UserRoleUser}, roles)
+
+	// Later sign-ins find the user by subject and follow the groups, keeping the roles granted by admins
+	_, err = injector.Enforcer.AddRoleForUser(userIDHex, config.UserRoleReviewer)
+	assert.NoError(t, err)
+	adminUserIDHex, err := signIn(map[string]interface{}{"sub": subject, "email": email, "groups": "hub-admins"})
+	assert.NoError(t, err)
+	assert.Equal(t, userIDHex, adminUserIDHex)
+	roles, err = injector.Enforcer.GetRolesForUser(userIDHex)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.UserRoleAdmin, config.UserRoleReviewer}, roles)
+
+	// Leaving the admin groups revokes the tokens issued as an admin
+	downgradedAt := time.Now().UnixMilli()
+	_, err = signIn(map[string]interface{}{"sub": subject, "email": email, "groups": "hub-users"})
+	assert.NoError(t, err)
+	validAfter, err := injector.SessionDao.GetUserTokensValidAfter(ctx, userIDHex)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, validAfter, downgradedAt)
+	roles, err = injector.Enforcer.GetRolesForUser(userIDHex)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.UserRoleUser, config.UserRoleReviewer}, roles)
+
+	// Existing users are linked by email, but not to a second identity
+	linkedEmail := strings.ToLower(mock.RandomString(10)) + "@sso.com"
+	passwordHash, err := crypt.Hash("User@123")
+	assert.NoError(t, err)
+	linkedUserID, err := userDao.InsertUser(
+		ctx, mock.RandomString(10), linkedEmail, passwordHash, config.UserRoleUser, "ORG",
+	)
+	assert.NoError(t, err)
+	// Unverified emails are not linked, neither are emails without a verification claim
+	_, err = signIn(
+		map[string]interface{}{
+			"sub": subject + "-unverified", "email": linkedEmail, "email_verified": false, "groups": "hub-users",
+		},
+	)
+	assert.Error(t, err)
+	_, err = signIn(map[string]interface{}{"sub": subject + "-unclaimed", "email": linkedEmail, "groups": "hub-users"})
+	assert.Error(t, err)
+
+	userIDHex, err = signIn(
+		map[string]interface{}{
+			"sub": subject + "-linked", "email": linkedEmail, "email_verified": true, "groups": "hub-users",
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, linkedUserID.Hex(), userIDHex)
+	_, err = signIn(
+		map[string]interface{}{
+			"sub": subject + "-other", "email": linkedEmail, "email_verified": true, "groups": "hub-users",
+		},
+	)
+	assert.Error(t, err)
+
+	// States are single-use
+	idp.SetUser(map[string]interface{}{"sub": subject, "email": email, "groups": "hub-users"})
+	authorization, err := oidcService.Authorize(ctx)
+	assert.NoError(t, err)
+	parsed, err := url.Parse(authorization.AuthorizationURL)
+	assert.NoError(t, err)
+	state := parsed.Query().Get("state")
+	code, _, err := idp.Authorize(authorization.AuthorizationURL)
+	assert.NoError(t, err)
+	_, err = oidcService.Callback(ctx, &code, &state)
+	assert.NoError(t, err)
+	_, err = oidcService.Callback(ctx, &code, &state)
+	assert.Error(t, err)
+}
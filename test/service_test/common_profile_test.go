@@ -2,9 +2,13 @@ package service_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/internal/pkg/service"
+	"data-collection-hub-server/test/mock"
 	"data-collection-hub-server/test/wire"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,5 +24,108 @@ func TestGetProfile(t *testing.T) {
 	resp, err := profileService.GetProfile(ctx)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
+	assert.Nil(t, resp.Impersonation)
 	t.Logf("Response Data: %+v", resp)
 }
+
+func TestGetProfileImpersonated(t *testing.T) {
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		profileService = injector.CommonProfileService
+		userID         = injector.UserDaoMock.RandomUserID()
+		impersonatorID = injector.UserDaoMock.RandomUserID()
+		impersonation  = &service.Impersonation{
+			ImpersonatorID: impersonatorID.Hex(),
+			ReadOnly:       true,
+			ExpiresAt:      time.Now().Add(time.Minute),
+		}
+	)
+	ctx = context.WithValue(ctx, config.UserIDKey, userID.Hex())
+	ctx = context.WithValue(ctx, config.ImpersonationKey, impersonation)
+	resp, err := profileService.GetProfile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, userID.Hex(), resp.UserID)
+	assert.NotNil(t, resp.Impersonation)
+	assert.Equal(t, impersonatorID.Hex(), resp.Impersonation.ImpersonatorID)
+	assert.NotEmpty(t, resp.Impersonation.ImpersonatorUsername)
+	assert.True(t, resp.Impersonation.ReadOnly)
+}
+
+func TestUpdateProfile(t *testing.T) {
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		profileService = injector.CommonProfileService
+		username       = mock.RandomString(10)
+		organization   = mock.RandomString(10)
+	)
+	userID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@profile.com", "", config.UserRoleUser, "",
+	)
+	assert.NoError(t, err)
+	otherID, err := injector.UserDao.InsertUser(
+		ctx, mock.RandomString(10), mock.RandomString(10)+"@profile.com", "", config.UserRoleUser, "",
+	)
+	assert.NoError(t, err)
+	other, err := injector.UserDao.GetUserByID(ctx, otherID)
+	assert.NoError(t, err)
+	ctx = context.WithValue(ctx, config.UserIDKey, userID.Hex())
+
+	err = profileService.UpdateProfile(ctx, &username, &organization)
+	assert.NoError(t, err)
+	resp, err := profileService.GetProfile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, username, resp.Username)
+	assert.Equal(t, organization, resp.Organization)
+
+	// Usernames are unique
+	err = profileService.UpdateProfile(ctx, &other.Username, nil)
+	assert.Error(t, err)
+
+	// The members of the organizations managed as tenants are managed by their admins
+	tenant := mock.RandomString(16)
+	organizationID, err := injector.OrganizationDao.InsertOrganization(ctx, tenant, "")
+	assert.NoError(t, err)
+	err = profileService.UpdateProfile(ctx, nil, &tenant)
+	assert.Error(t, err)
+
+	// The email is only changed once confirmed
+	email := mock.RandomString(10) + "@profile.com"
+	err = profileService.ChangeEmail(ctx, &email)
+	assert.NoError(t, err)
+	err = profileService.ChangeEmail(ctx, &other.Email)
+	assert.Error(t, err)
+	invalidToken := strings.Repeat("0", 64)
+	_, err = profileService.ConfirmEmail(ctx, &invalidToken)
+	assert.Error(t, err)
+	resp, err = profileService.GetProfile(ctx)
+	assert.NoError(t, err)
+	assert.NotEqual(t, email, resp.Email)
+
+	assert.NoError(t, injector.OrganizationDao.DeleteOrganization(ctx, organizationID))
+	assert.NoError(t, injector.UserDao.DeleteUser(ctx, userID))
+	assert.NoError(t, injector.UserDao.DeleteUser(ctx, otherID))
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		profileService = injector.CommonProfileService
+		userID         = injector.UserDaoMock.RandomUserID()
+		png            = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	)
+	ctx = context.WithValue(ctx, config.UserIDKey, userID.Hex())
+
+	resp, err := profileService.UpdateAvatar(ctx, png)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Avatar)
+	profile, err := profileService.GetProfile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Avatar, profile.Avatar)
+
+	// Only images are accepted
+	_, err = profileService.UpdateAvatar(ctx, []byte("not an image"))
+	assert.Error(t, err)
+}
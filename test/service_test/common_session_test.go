@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSession(t *testing.T) {
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		authService    = injector.CommonAuthService
+		sessionService = injector.CommonSessionService
+		userService    = injector.AdminUserService
+		ipAddress      = "127.0.0.1"
+		userAgent      = "Mozilla/5.0"
+		deviceCtx      = context.WithValue(
+			context.WithValue(ctx, config.IPAddressKey, ipAddress), config.UserAgentKey, userAgent,
+		)
+		email    = mock.RandomString(10) + "@user.com"
+		password = "User@123"
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	userID, err := injector.UserDaoMock.UserDao.InsertUser(
+		ctx, mock.RandomString(10), email, passwordHash, config.UserRoleUser, "ORG",
+	)
+	assert.NoError(t, err)
+
+	// Each login starts a session on its device
+	first, err := authService.Login(deviceCtx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	second, err := authService.Login(deviceCtx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	firstClaims, err := injector.Jwt.ParseAccessToken(first.AccessToken)
+	assert.NoError(t, err)
+	secondClaims, err := injector.Jwt.ParseAccessToken(second.AccessToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, firstClaims.SessionID, secondClaims.SessionID)
+
+	userCtx := context.WithValue(
+		context.WithValue(ctx, config.UserIDKey, userID.Hex()), config.SessionIDKey, firstClaims.SessionID,
+	)
+	listResp, err := sessionService.GetSessionList(userCtx)
+	assert.NoError(t, err)
+	assert.Len(t, listResp.SessionList, 2)
+	for _, session := range listResp.SessionList {
+		assert.Equal(t, ipAddress, session.IPAddress)
+		assert.Equal(t, userAgent, session.UserAgent)
+		assert.Equal(t, session.SessionID == firstClaims.SessionID, session.Current)
+	}
+
+	// Refresh tokens work until their session is revoked
+	_, err = authService.RefreshToken(ctx, &second.RefreshToken)
+	assert.NoError(t, err)
+	secondSessionID, err := primitive.ObjectIDFromHex(secondClaims.SessionID)
+	assert.NoError(t, err)
+	err = sessionService.DeleteSession(userCtx, &secondSessionID)
+	assert.NoError(t, err)
+	err = sessionService.DeleteSession(userCtx, &secondSessionID)
+	assert.Error(t, err)
+	_, err = authService.RefreshToken(ctx, &second.RefreshToken)
+	assert.Error(t, err)
+
+	// The current session is kept when the others are revoked
+	_, err = authService.Login(deviceCtx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	count, err := sessionService.DeleteSessionList(userCtx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	refreshResp, err := authService.RefreshToken(ctx, &first.RefreshToken)
+	assert.NoError(t, err)
+
+	// Admins can log users out of all devices
+	count, err = userService.DeleteUserSessionList(ctx, &userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, err = authService.RefreshToken(ctx, &refreshResp.RefreshToken)
+	assert.Error(t, err)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	var (
+		injector    = wire.GetInjector()
+		ctx         = injector.Ctx
+		authService = injector.CommonAuthService
+		ipAddress   = "127.0.0.1"
+		email       = mock.RandomString(10) + "@user.com"
+		password    = "User@123"
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	_, err = injector.UserDaoMock.UserDao.InsertUser(
+		ctx, mock.RandomString(10), email, passwordHash, config.UserRoleUser, "ORG",
+	)
+	assert.NoError(t, err)
+
+	loginResp, err := authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	first, err := authService.RefreshToken(ctx, &loginResp.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, loginResp.RefreshToken, first.RefreshToken)
+	second, err := authService.RefreshToken(ctx, &first.RefreshToken)
+	assert.NoError(t, err)
+
+	// A consumed refresh token revokes the whole family, including the latest token
+	_, err = authService.RefreshToken(ctx, &loginResp.RefreshToken)
+	assert.Error(t, err)
+	_, err = authService.RefreshToken(ctx, &second.RefreshToken)
+	assert.Error(t, err)
+	claims, err := injector.Jwt.ParseAccessToken(second.AccessToken)
+	assert.NoError(t, err)
+	revoked, err := injector.SessionDao.IsSessionRevoked(ctx, claims.SessionID)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/internal/pkg/config"
+	"data-collection-hub-server/pkg/utils/crypt"
+	"data-collection-hub-server/test/mock"
+	"data-collection-hub-server/test/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactor(t *testing.T) {
+	var (
+		injector         = wire.GetInjector()
+		ctx              = injector.Ctx
+		authService      = injector.CommonAuthService
+		ipAddress        = "127.0.0.1"
+		twoFactorService = injector.CommonTwoFactorService
+		username         = mock.RandomString(10)
+		email            = mock.RandomString(10) + "@user.com"
+		password         = "User@123"
+		wrongCode        = "000000"
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	userID, err := injector.UserDaoMock.UserDao.InsertUser(ctx, username, email, passwordHash, config.UserRoleUser, "ORG")
+	assert.NoError(t, err)
+	userCtx := context.WithValue(ctx, config.UserIDKey, userID.Hex())
+
+	// Enroll as a logged-in user
+	enrollment, err := twoFactorService.Enroll(userCtx, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, enrollment.Secret)
+	_, err = twoFactorService.Confirm(userCtx, nil, &wrongCode)
+	assert.Error(t, err)
+	code, err := crypt.TOTPCode(enrollment.Secret, time.Now())
+	assert.NoError(t, err)
+	confirmation, err := twoFactorService.Confirm(userCtx, nil, &code)
+	assert.NoError(t, err)
+	assert.Len(t, confirmation.RecoveryCodes, injector.Config.TwoFactorConfig.RecoveryCodeCount)
+	assert.Nil(t, confirmation.Login)
+
+	// Login now stops at the challenge
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.False(t, resp.EnrollmentRequired)
+	assert.Empty(t, resp.AccessToken)
+	challengeToken := resp.ChallengeToken
+
+	// The code used to confirm can not be replayed
+	_, err = twoFactorService.Verify(ctx, &challengeToken, &code, nil)
+	assert.Error(t, err)
+	login, err := twoFactorService.Verify(ctx, &challengeToken, nil, &confirmation.RecoveryCodes[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, login.AccessToken)
+
+	// Challenges and recovery codes are single-use
+	_, err = twoFactorService.Verify(ctx, &challengeToken, nil, &confirmation.RecoveryCodes[1])
+	assert.Error(t, err)
+	resp, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	_, err = twoFactorService.Verify(ctx, &resp.ChallengeToken, nil, &confirmation.RecoveryCodes[0])
+	assert.Error(t, err)
+
+	nextCode, err := crypt.TOTPCode(enrollment.Secret, time.Now().Add(30*time.Second))
+	assert.NoError(t, err)
+	err = twoFactorService.Disable(userCtx, &password, &nextCode)
+	assert.NoError(t, err)
+	resp, err = authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	assert.False(t, resp.TwoFactorRequired)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestTwoFactorPolicy(t *testing.T) {
+	var (
+		injector         = wire.GetInjector()
+		ctx              = injector.Ctx
+		authService      = injector.CommonAuthService
+		ipAddress        = "127.0.0.1"
+		twoFactorService = injector.CommonTwoFactorService
+		policyService    = injector.AdminTwoFactorPolicyService
+		role             = "TWO_FACTOR_" + mock.RandomString(6)
+		username         = mock.RandomString(10)
+		email            = mock.RandomString(10) + "@user.com"
+		password         = "User@123"
+		enforced         = true
+		relaxed          = false
+	)
+	passwordHash, err := crypt.Hash(password)
+	assert.NoError(t, err)
+	_, err = injector.UserDaoMock.UserDao.InsertUser(ctx, username, email, passwordHash, role, "ORG")
+	assert.NoError(t, err)
+
+	adminCtx := context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
+	err = policyService.UpdateTwoFactorPolicy(adminCtx, &role, &enforced)
+	assert.NoError(t, err)
+	defer func() { _ = policyService.UpdateTwoFactorPolicy(adminCtx, &role, &relaxed) }()
+
+	// Users of an enforced role have to enroll before the login completes
+	resp, err := authService.Login(ctx, &email, &password, &ipAddress)
+	assert.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.True(t, resp.EnrollmentRequired)
+	_, err = twoFactorService.Verify(ctx, &resp.ChallengeToken, nil, nil)
+	assert.Error(t, err)
+
+	enrollment, err := twoFactorService.Enroll(ctx, &resp.ChallengeToken)
+	assert.NoError(t, err)
+	code, err := crypt.TOTPCode(enrollment.Secret, time.Now())
+	assert.NoError(t, err)
+	confirmation, err := twoFactorService.Confirm(ctx, &resp.ChallengeToken, &code)
+	assert.NoError(t, err)
+	assert.NotNil(t, confirmation.Login)
+	assert.NotEmpty(t, confirmation.Login.AccessToken)
+
+	// Enforced 2FA can not be disabled
+	userCtx := context.WithValue(ctx, config.UserIDKey, confirmation.Login.Meta.UserID)
+	nextCode, err := crypt.TOTPCode(enrollment.Secret, time.Now().Add(30*time.Second))
+	assert.NoError(t, err)
+	err = twoFactorService.Disable(userCtx, &password, &nextCode)
+	assert.Error(t, err)
+}
@@ -22,10 +22,15 @@ func TestInsertInstructionData(t *testing.T) {
 		theme          = "THEME1"
 		status         = "PENDING"
 		note           = ""
+		sourceType     = config.SourceTypeOriginal
+		license        = "CC-BY-4.0"
+		rightsAttested = true
 	)
 
 	ctx = context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
-	resp, err := datasetService.InsertInstructionData(ctx, &instruction, &input, &output, &theme, &status, &note)
+	resp, err := datasetService.InsertInstructionData(
+		ctx, &instruction, &input, &output, &theme, &status, &note, nil, &sourceType, nil, &license, &rightsAttested,
+	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	t.Logf("Response Data: %+v", resp)
@@ -88,7 +93,7 @@ func TestUserUpdateInstructionData(t *testing.T) {
 	)
 
 	err := datasetService.UpdateInstructionData(
-		ctx, &instructionDataID, &instruction, &input, &output, &theme, &source, &note,
+		ctx, &instructionDataID, &instruction, &input, &output, &theme, &source, &note, nil, nil, nil, nil, nil,
 	)
 	assert.NoError(t, err)
 	instructionData, err := injector.InstructionDataDao.GetInstructionDataByID(ctx, instructionDataID)
@@ -114,10 +119,15 @@ func TestUserDeleteInstructionData(t *testing.T) {
 		theme          = "THEME1"
 		status         = "PENDING"
 		note           = ""
+		sourceType     = config.SourceTypeOriginal
+		license        = "CC-BY-4.0"
+		rightsAttested = true
 	)
 
 	ctx = context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
-	resp, err := datasetService.InsertInstructionData(ctx, &instruction, &input, &output, &theme, &status, &note)
+	resp, err := datasetService.InsertInstructionData(
+		ctx, &instruction, &input, &output, &theme, &status, &note, nil, &sourceType, nil, &license, &rightsAttested,
+	)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	instructionDataID, err := primitive.ObjectIDFromHex(resp)
@@ -129,3 +139,55 @@ func TestUserDeleteInstructionData(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, instructionData)
 }
+
+func TestUserSubmitInstructionDataDraft(t *testing.T) {
+	var (
+		injector       = wire.GetInjector()
+		ctx            = injector.Ctx
+		datasetService = injector.UserDatasetService
+		instruction    = mock.RandomString(10)
+		input          = mock.RandomString(10)
+		output         = mock.RandomString(10)
+		theme          = "THEME1"
+		source         = "https://" + mock.RandomString(10) + ".com"
+		sourceType     = config.SourceTypeWeb
+		license        = "CC-BY-4.0"
+		rightsAttested = true
+	)
+
+	ctx = context.WithValue(ctx, config.UserIDKey, injector.UserDaoMock.RandomUserID().Hex())
+	resp, err := datasetService.InsertInstructionDataDraft(
+		ctx, &instruction, &input, nil, &theme, &source, nil, nil, &sourceType, nil, &license, nil,
+	)
+	assert.NoError(t, err)
+	instructionDataID, err := primitive.ObjectIDFromHex(resp)
+	assert.NoError(t, err)
+	instructionData, err := injector.InstructionDataDao.GetInstructionDataByID(ctx, instructionDataID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.InstructionDataStatusDraft, instructionData.Status.Code)
+
+	// An incomplete draft cannot be submitted
+	err = datasetService.SubmitInstructionData(ctx, &instructionDataID)
+	assert.Error(t, err)
+
+	err = datasetService.UpdateInstructionData(
+		ctx, &instructionDataID, nil, nil, &output, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	assert.NoError(t, err)
+	// A web source without its url or the rights attestation cannot be submitted either
+	err = datasetService.SubmitInstructionData(ctx, &instructionDataID)
+	assert.Error(t, err)
+
+	err = datasetService.UpdateInstructionData(
+		ctx, &instructionDataID, nil, nil, nil, nil, nil, nil, nil, nil, &source, nil, &rightsAttested,
+	)
+	assert.NoError(t, err)
+	err = datasetService.SubmitInstructionData(ctx, &instructionDataID)
+	assert.NoError(t, err)
+	instructionData, err = injector.InstructionDataDao.GetInstructionDataByID(ctx, instructionDataID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.InstructionDataStatusPending, instructionData.Status.Code)
+
+	err = injector.InstructionDataDao.DeleteInstructionData(ctx, instructionDataID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,28 @@
+package utils_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	plaintext := []byte("private key")
+	ciphertext, err := crypt.Encrypt("secret", plaintext)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), string(plaintext))
+
+	decrypted, err := crypt.Decrypt("secret", ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	// Other secrets and tampered ciphertexts are rejected
+	_, err = crypt.Decrypt("other", ciphertext)
+	assert.Error(t, err)
+	ciphertext[len(ciphertext)-1] ^= 1
+	_, err = crypt.Decrypt("secret", ciphertext)
+	assert.Error(t, err)
+	_, err = crypt.Encrypt("", plaintext)
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package utils_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/pkg/utils/agreement"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgreement(t *testing.T) {
+	ratings := agreement.Ratings{
+		"1": {"a": "APPROVED", "b": "APPROVED"},
+		"2": {"a": "APPROVED", "b": "REJECTED"},
+		"3": {"a": "REJECTED", "b": "REJECTED"},
+		"4": {"a": "REJECTED", "b": "REJECTED"},
+		"5": {"a": "APPROVED"},
+	}
+
+	pairs := agreement.Pairwise(ratings)
+	assert.Len(t, pairs, 1)
+	assert.Equal(t, "a", pairs[0].RaterA)
+	assert.Equal(t, "b", pairs[0].RaterB)
+	assert.Equal(t, int64(4), pairs[0].Items)
+	assert.InDelta(t, 0.75, pairs[0].Agreement, 1e-9)
+	assert.NotNil(t, pairs[0].Kappa)
+	assert.InDelta(t, 0.5, *pairs[0].Kappa, 1e-9)
+
+	items, observed, kappa := agreement.Fleiss(ratings)
+	assert.Equal(t, int64(4), items)
+	assert.InDelta(t, 0.75, observed, 1e-9)
+	assert.NotNil(t, kappa)
+	assert.InDelta(t, 7.0/15.0, *kappa, 1e-9)
+
+	raters := agreement.Consensus(ratings)
+	assert.Len(t, raters, 2)
+	assert.Equal(t, "a", raters[0].Rater)
+	assert.Equal(t, int64(5), raters[0].Items)
+	assert.Equal(t, int64(4), raters[0].Compared)
+	assert.InDelta(t, 0.25, raters[0].Disagreement, 1e-9)
+
+	// Without a strict majority of the other raters there is no consensus to compare with
+	raters = agreement.Consensus(agreement.Ratings{"1": {"a": "APPROVED", "b": "APPROVED", "c": "REJECTED"}})
+	assert.Len(t, raters, 3)
+	assert.Equal(t, int64(0), raters[0].Compared)
+	assert.Equal(t, int64(1), raters[2].Compared)
+	assert.InDelta(t, 1.0, raters[2].Disagreement, 1e-9)
+
+	// Kappa is undefined when every rating has the same label
+	_, observed, kappa = agreement.Fleiss(agreement.Ratings{"1": {"a": "APPROVED", "b": "APPROVED"}})
+	assert.InDelta(t, 1.0, observed, 1e-9)
+	assert.Nil(t, kappa)
+}
@@ -0,0 +1,69 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/pkg/ldap"
+	"data-collection-hub-server/test/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPFilter(t *testing.T) {
+	_, err := ldap.CompileFilter("(&(objectClass=person)(|(mail=a@b.com)(uid=a))(!(disabled=*)))")
+	assert.NoError(t, err)
+	_, err = ldap.CompileFilter("(mail=a*)")
+	assert.Error(t, err)
+	_, err = ldap.CompileFilter("(&(mail=a)")
+	assert.Error(t, err)
+	assert.Equal(t, `a\2a\28\29\5c`, ldap.EscapeFilter(`a*()\`))
+	filter, err := ldap.CompileFilter("(mail=" + ldap.EscapeFilter("x)(uid=*") + ")")
+	assert.NoError(t, err)
+	assert.Equal(t, ldap.TagFilterEquality, filter.Tag)
+	assert.Equal(t, "x)(uid=*", filter.Children[1].String())
+}
+
+func TestLDAP(t *testing.T) {
+	directory, err := mock.NewMockLDAP("")
+	assert.NoError(t, err)
+	defer directory.Close()
+	directory.AddEntry(
+		"cn=service,dc=lab,dc=org", map[string][]string{"cn": {"service"}, "userPassword": {"service-secret"}},
+	)
+	directory.AddEntry(
+		"uid=alice,ou=people,dc=lab,dc=org", map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {"alice"},
+			"mail":         {"alice@lab.org"},
+			"o":            {"Lab"},
+			"memberOf":     {"cn=annotators,ou=groups,dc=lab,dc=org"},
+			"userPassword": {"alice-secret"},
+		},
+	)
+
+	conn, err := ldap.Dial(context.Background(), directory.URL(), 5*time.Second, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	assert.True(t, ldap.IsInvalidCredentials(conn.Bind("cn=service,dc=lab,dc=org", "wrong")))
+	assert.True(t, ldap.IsInvalidCredentials(conn.Bind("cn=service,dc=lab,dc=org", "")))
+	assert.NoError(t, conn.Bind("cn=service,dc=lab,dc=org", "service-secret"))
+
+	entries, err := conn.Search(
+		"dc=lab,dc=org", "(&(objectClass=person)(mail="+ldap.EscapeFilter("alice@lab.org")+"))",
+		[]string{"uid", "mail", "o", "memberOf"}, 2,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "uid=alice,ou=people,dc=lab,dc=org", entries[0].DN)
+	assert.Equal(t, "alice", entries[0].Get("UID"))
+	assert.Equal(t, []string{"cn=annotators,ou=groups,dc=lab,dc=org"}, entries[0].GetAll("memberof"))
+	assert.Empty(t, entries[0].GetAll("userPassword"))
+
+	entries, err = conn.Search("dc=lab,dc=org", "(mail=nobody@lab.org)", nil, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, conn.Bind("uid=alice,ou=people,dc=lab,dc=org", "alice-secret"))
+}
@@ -0,0 +1,29 @@
+package utils_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"data-collection-hub-server/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "mail.log")
+		m    = mailer.NewFileMailer(path, "noreply@example.com")
+	)
+	assert.NoError(t, m.Send(ctx, "foo@example.com", "Password reset", "reset link"))
+	assert.NoError(t, m.Send(ctx, "bar@example.com", "Password reset", "another link"))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: foo@example.com")
+	assert.Contains(t, string(content), "To: bar@example.com")
+	assert.Contains(t, string(content), "Subject: Password reset")
+	assert.Contains(t, string(content), "reset link")
+	t.Logf("Mails: %s", content)
+}
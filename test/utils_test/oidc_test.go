@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/pkg/oidc"
+	"data-collection-hub-server/test/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDC(t *testing.T) {
+	idp, err := mock.NewMockIdP("", "hub", "secret")
+	assert.NoError(t, err)
+	defer idp.Close()
+
+	var (
+		ctx         = context.Background()
+		redirectURL = "http://localhost:3000/sso/callback"
+		provider    = oidc.New(idp.Issuer, "hub", "secret", redirectURL, []string{"openid", "email"}, 5*time.Second)
+		nonce       = mock.RandomString(16)
+		state       = mock.RandomString(16)
+	)
+	verifier, err := oidc.NewCodeVerifier()
+	assert.NoError(t, err)
+	idp.SetUser(
+		map[string]interface{}{
+			"sub": "user-1", "email": "sso@user.com", "email_verified": true, "groups": []string{"hub-admins"},
+		},
+	)
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	assert.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, oidc.CodeChallenge(verifier), parsed.Query().Get("code_challenge"))
+	assert.Equal(t, redirectURL, parsed.Query().Get("redirect_uri"))
+
+	code, returnedState, err := idp.Authorize(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, state, returnedState)
+
+	// The code is bound to the verifier
+	_, err = provider.Exchange(ctx, code, "wrong-verifier")
+	assert.Error(t, err)
+	code, _, err = idp.Authorize(authURL)
+	assert.NoError(t, err)
+	idToken, err := provider.Exchange(ctx, code, verifier)
+	assert.NoError(t, err)
+
+	_, err = provider.VerifyIDToken(ctx, idToken, "other-nonce")
+	assert.Error(t, err)
+	claims, err := provider.VerifyIDToken(ctx, idToken, nonce)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", claims.String("sub"))
+	assert.Equal(t, "sso@user.com", claims.String("email"))
+	verified, ok := claims.Bool("email_verified")
+	assert.True(t, ok)
+	assert.True(t, verified)
+	groups, ok := claims.Strings("groups")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"hub-admins"}, groups)
+
+	// Tokens of another client are rejected
+	other := oidc.New(idp.Issuer, "other", "secret", redirectURL, []string{"openid"}, 5*time.Second)
+	_, err = other.VerifyIDToken(ctx, idToken, nonce)
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/pkg/utils/password"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	common, err := password.CommonPasswords("")
+	assert.NoError(t, err)
+	assert.Contains(t, common, "password123")
+
+	policy := password.Policy{
+		MinLength:       8,
+		RequireUpper:    true,
+		RequireLower:    true,
+		RequireDigit:    true,
+		RequireSpecial:  true,
+		CommonPasswords: common,
+	}
+	assert.NoError(t, policy.Validate("Str0ng#Pass", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("Sh0rt#", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("n0upper#pass", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("N0LOWER#PASS", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("No#Digits#Pass", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("N0Special1Pass", "alice", "alice@example.com"))
+	assert.Error(t, policy.Validate("Alice#2024x", "alice", "bob@example.com"))
+	assert.Error(t, policy.Validate("Bob#2024xyz", "alice", "bob@example.com"))
+
+	policy.RequireUpper, policy.RequireSpecial = false, false
+	assert.Error(t, policy.Validate("Password123", "alice", "alice@example.com"))
+}
+
+func TestGeneratePassword(t *testing.T) {
+	policy := password.Policy{
+		MinLength:      16,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		generated, err := password.Generate(16)
+		assert.NoError(t, err)
+		assert.Len(t, generated, 16)
+		assert.NoError(t, policy.Validate(generated, "", ""))
+		seen[generated] = struct{}{}
+	}
+	assert.Len(t, seen, 100)
+
+	generated, err := password.Generate(2)
+	assert.NoError(t, err)
+	assert.Len(t, generated, 4)
+}
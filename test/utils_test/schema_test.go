@@ -0,0 +1,30 @@
+package utils_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/pkg/utils/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	document := `{
+		"type": "object",
+		"properties": {
+			"difficulty": {"type": "string", "enum": ["easy", "medium", "hard"]},
+			"language": {"type": "string"}
+		},
+		"required": ["difficulty"],
+		"additionalProperties": false
+	}`
+
+	_, err := schema.Compile(document)
+	assert.NoError(t, err)
+	_, err = schema.Compile(`{"type": 1}`)
+	assert.Error(t, err)
+
+	assert.NoError(t, schema.Validate(document, map[string]interface{}{"difficulty": "easy", "language": "en"}))
+	assert.Error(t, schema.Validate(document, nil))
+	assert.Error(t, schema.Validate(document, map[string]interface{}{"difficulty": "unknown"}))
+	assert.Error(t, schema.Validate(document, map[string]interface{}{"difficulty": "easy", "foo": "bar"}))
+}
@@ -0,0 +1,23 @@
+package utils_test
+
+import (
+	"testing"
+
+	"data-collection-hub-server/pkg/utils/spdx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpdx(t *testing.T) {
+	assert.True(t, spdx.IsValid("CC-BY-4.0"))
+	assert.True(t, spdx.IsValid("CC-BY-NC-4.0"))
+	assert.False(t, spdx.IsValid("cc-by-4.0"))
+	assert.False(t, spdx.IsValid(""))
+
+	assert.True(t, spdx.IsPermissive("CC0-1.0"))
+	assert.False(t, spdx.IsPermissive("CC-BY-SA-4.0"))
+	assert.False(t, spdx.IsPermissive("Unknown"))
+
+	permissive := spdx.PermissiveLicenses()
+	assert.Contains(t, permissive, "MIT")
+	assert.NotContains(t, permissive, "GPL-3.0-only")
+}
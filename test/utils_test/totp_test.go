@@ -0,0 +1,44 @@
+package utils_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"data-collection-hub-server/pkg/utils/crypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 appendix B test vectors (SHA1), truncated to 6 digits
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		code, err := crypt.TOTPCode(secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code)
+	}
+
+	secret, err := crypt.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	now := time.Now()
+	code, err := crypt.TOTPCode(secret, now)
+	assert.NoError(t, err)
+	assert.True(t, crypt.ValidateTOTP(secret, code, now, 1))
+	assert.True(t, crypt.ValidateTOTP(secret, code, now.Add(30*time.Second), 1))
+	assert.False(t, crypt.ValidateTOTP(secret, code, now.Add(5*time.Minute), 1))
+	assert.False(t, crypt.ValidateTOTP(secret, "abcdef", now, 1))
+
+	uri := crypt.TOTPProvisioningURI("Data Collection Hub", "foo@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Data%20Collection%20Hub:foo@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	t.Logf("URI: %s", uri)
+}
@@ -44,8 +44,18 @@ type Injector struct {
 	InstructionDataDao daos.InstructionDataDao
 	NoticeDao          daos.NoticeDao
 	DocumentationDao   daos.DocumentationDao
+	ThemeDao           daos.ThemeDao
+	ReviewDao          daos.ReviewDao
+	ReAuditDao         daos.ReAuditDao
+	InviteCodeDao      daos.InviteCodeDao
+	TwoFactorPolicyDao daos.TwoFactorPolicyDao
+	AccessTokenDao     daos.AccessTokenDao
+	LoginAttemptDao    daos.LoginAttemptDao
+	SessionDao         daos.SessionDao
+	JwtKeyDao          daos.JwtKeyDao
 	LoginLogDao        daos.LoginLogDao
 	OperationLogDao    daos.OperationLogDao
+	OrganizationDao    daos.OrganizationDao
 
 	// Mocks for DAOs
 	UserDaoMock            *mock.UserDaoMock
@@ -57,18 +67,30 @@ type Injector struct {
 
 	// Services
 	// Admin services
-	AdminDataAuditService     adminservices.DataAuditService
-	AdminDocumentationService adminservices.DocumentationService
-	AdminNoticeService        adminservices.NoticeService
-	AdminLogsService          adminservices.LogsService
-	AdminStatisticService     adminservices.StatisticService
-	AdminUserService          adminservices.UserService
+	AdminDataAuditService       adminservices.DataAuditService
+	AdminDocumentationService   adminservices.DocumentationService
+	AdminNoticeService          adminservices.NoticeService
+	AdminLogsService            adminservices.LogsService
+	AdminStatisticService       adminservices.StatisticService
+	AdminUserService            adminservices.UserService
+	AdminThemeService           adminservices.ThemeService
+	AdminReAuditService         adminservices.ReAuditService
+	AdminInviteCodeService      adminservices.InviteCodeService
+	AdminTwoFactorPolicyService adminservices.TwoFactorPolicyService
+	AdminLoginLockoutService    adminservices.LoginLockoutService
+	AdminOrganizationService    adminservices.OrganizationService
+	AdminPolicyService          adminservices.PolicyService
 	// Common services
 	CommonAuthService          commonservices.AuthService
 	CommonIdempotencyService   commonservices.IdempotencyService
 	CommonDocumentationService commonservices.DocumentationService
 	CommonNoticeService        commonservices.NoticeService
 	CommonProfileService       commonservices.ProfileService
+	CommonThemeService         commonservices.ThemeService
+	CommonTwoFactorService     commonservices.TwoFactorService
+	CommonAccessTokenService   commonservices.AccessTokenService
+	CommonOIDCService          commonservices.OIDCService
+	CommonSessionService       commonservices.SessionService
 	// Sys services
 	SysLogsService sysservices.LogsService
 	// User services
@@ -92,11 +114,24 @@ var (
 		adminservices.NewNoticeService,
 		adminservices.NewDocumentationService,
 		adminservices.NewLogsService,
+		adminservices.NewThemeService,
+		adminservices.NewReAuditService,
+		adminservices.NewInviteCodeService,
+		adminservices.NewTwoFactorPolicyService,
+		adminservices.NewLoginLockoutService,
+		adminservices.NewOrganizationService,
+		adminservices.NewPolicyService,
 		commonservices.NewAuthService,
+		commonservices.NewAuthenticatorChain,
 		commonservices.NewProfileService,
 		commonservices.NewDocumentationService,
 		commonservices.NewNoticeService,
 		commonservices.NewIdempotencyService,
+		commonservices.NewThemeService,
+		commonservices.NewTwoFactorService,
+		commonservices.NewAccessTokenService,
+		commonservices.NewOIDCService,
+		commonservices.NewSessionService,
 		userservices.NewDatasetService,
 		userservices.NewStatisticService,
 		sysservices.NewLogsService,
@@ -111,6 +146,16 @@ var (
 		daos.NewLoginLogDao,
 		daos.NewOperationLogDao,
 		daos.NewDocumentationDao,
+		daos.NewThemeDao,
+		daos.NewReviewDao,
+		daos.NewReAuditDao,
+		daos.NewInviteCodeDao,
+		daos.NewTwoFactorPolicyDao,
+		daos.NewAccessTokenDao,
+		daos.NewLoginAttemptDao,
+		daos.NewSessionDao,
+		daos.NewJwtKeyDao,
+		daos.NewOrganizationDao,
 	)
 
 	MockProviderSet = wire.NewSet(
@@ -131,6 +176,9 @@ func InitializeTestInjector(ctx context.Context, config *config.Config, n int) (
 		InitializeJwt,
 		InitializePrometheus,
 		InitializeCasbinEnforcer,
+		InitializeMailer,
+		InitializeStorage,
+		InitializeOIDC,
 		MockProviderSet,
 		ServiceProviderSet,
 		DaoProviderSet,
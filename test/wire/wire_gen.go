@@ -46,12 +46,16 @@ func InitializeTestInjector(ctx context.Context, config2 *config.Config, n int)
 	if err != nil {
 		return nil, err
 	}
-	jwt, err := InitializeJwt(config2)
+	prometheus := InitializePrometheus(config2)
+	core, err := dao.NewCore(ctx, mongo, zap, config2)
 	if err != nil {
 		return nil, err
 	}
-	prometheus := InitializePrometheus(config2)
-	core, err := dao.NewCore(ctx, mongo, zap, config2)
+	jwtKeyDao, err := mods.NewJwtKeyDao(ctx, core)
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := InitializeJwt(ctx, config2, jwtKeyDao)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +75,35 @@ func InitializeTestInjector(ctx context.Context, config2 *config.Config, n int)
 	if err != nil {
 		return nil, err
 	}
+	themeDao, err := mods.NewThemeDao(ctx, core, cache)
+	if err != nil {
+		return nil, err
+	}
+	reviewDao, err := mods.NewReviewDao(ctx, core, userDao)
+	if err != nil {
+		return nil, err
+	}
+	reAuditDao, err := mods.NewReAuditDao(ctx, core, userDao)
+	if err != nil {
+		return nil, err
+	}
+	inviteCodeDao, err := mods.NewInviteCodeDao(ctx, core)
+	if err != nil {
+		return nil, err
+	}
+	twoFactorPolicyDao, err := mods.NewTwoFactorPolicyDao(ctx, core)
+	if err != nil {
+		return nil, err
+	}
+	accessTokenDao, err := mods.NewAccessTokenDao(ctx, core)
+	if err != nil {
+		return nil, err
+	}
+	loginAttemptDao := mods.NewLoginAttemptDao(core, cache)
+	sessionDao, err := mods.NewSessionDao(ctx, core, cache)
+	if err != nil {
+		return nil, err
+	}
 	loginLogDao, err := mods.NewLoginLogDao(ctx, core, cache, userDao)
 	if err != nil {
 		return nil, err
@@ -79,6 +112,10 @@ func InitializeTestInjector(ctx context.Context, config2 *config.Config, n int)
 	if err != nil {
 		return nil, err
 	}
+	organizationDao, err := mods.NewOrganizationDao(ctx, core)
+	if err != nil {
+		return nil, err
+	}
 	userDaoMock := mock.NewUserDaoMockWithRandomData(n, userDao)
 	instructionDataDaoMock := mock.NewInstructionDataDaoMockWithRandomData(n, userDaoMock, instructionDataDao)
 	noticeDaoMock := mock.NewNoticeDaoMockWithRandomData(n, noticeDao)
@@ -88,60 +125,107 @@ func InitializeTestInjector(ctx context.Context, config2 *config.Config, n int)
 	serviceCore := &service.Core{
 		Config: config2,
 	}
-	dataAuditService := mods2.NewDataAuditService(serviceCore, instructionDataDao)
+	dataAuditService := mods2.NewDataAuditService(serviceCore, instructionDataDao, themeDao, reviewDao)
 	documentationService := mods2.NewDocumentationService(serviceCore, documentationDao)
 	noticeService := mods2.NewNoticeService(serviceCore, noticeDao)
 	logsService := mods2.NewLogsService(serviceCore, loginLogDao, operationLogDao)
-	statisticService := mods2.NewStatisticService(serviceCore, instructionDataDao, userDao)
+	statisticService := mods2.NewStatisticService(serviceCore, instructionDataDao, userDao, reviewDao)
 	enforcer, err := InitializeCasbinEnforcer(config2)
 	if err != nil {
 		return nil, err
 	}
-	userService := mods2.NewUserService(serviceCore, userDao, enforcer)
-	authService := mods3.NewAuthService(serviceCore, userDao, cache, jwt)
+	storageStorage, err := InitializeStorage(config2)
+	if err != nil {
+		return nil, err
+	}
+	userService := mods2.NewUserService(serviceCore, userDao, sessionDao, instructionDataDao, reviewDao, reAuditDao, loginLogDao, operationLogDao, accessTokenDao, enforcer, jwt, storageStorage)
+	themeService := mods2.NewThemeService(serviceCore, themeDao)
+	reAuditService := mods2.NewReAuditService(serviceCore, reAuditDao)
+	inviteCodeService := mods2.NewInviteCodeService(serviceCore, inviteCodeDao)
+	twoFactorPolicyService := mods2.NewTwoFactorPolicyService(serviceCore, twoFactorPolicyDao)
+	loginLockoutService := mods2.NewLoginLockoutService(serviceCore, loginAttemptDao)
+	organizationService := mods2.NewOrganizationService(serviceCore, organizationDao, userDao, enforcer)
+	policyService := mods2.NewPolicyService(serviceCore, userDao, enforcer)
+	mailerMailer, err := InitializeMailer(config2)
+	if err != nil {
+		return nil, err
+	}
+	authenticatorChain, err := mods3.NewAuthenticatorChain(serviceCore, userDao, sessionDao, enforcer)
+	if err != nil {
+		return nil, err
+	}
+	authService := mods3.NewAuthService(serviceCore, userDao, inviteCodeDao, twoFactorPolicyDao, cache, jwt, enforcer, mailerMailer, authenticatorChain, loginAttemptDao, sessionDao, operationLogDao)
 	idempotencyService := mods3.NewIdempotencyService(serviceCore, cache)
 	modsDocumentationService := mods3.NewDocumentationService(serviceCore, documentationDao)
 	modsNoticeService := mods3.NewNoticeService(serviceCore, noticeDao)
-	profileService := mods3.NewProfileService(serviceCore, userDao)
+	profileService := mods3.NewProfileService(serviceCore, userDao, organizationDao, instructionDataDao, reviewDao, reAuditDao, loginLogDao, operationLogDao, cache, mailerMailer, storageStorage)
+	modsThemeService := mods3.NewThemeService(serviceCore, themeDao)
+	twoFactorService := mods3.NewTwoFactorService(serviceCore, userDao, twoFactorPolicyDao, cache, jwt, sessionDao)
+	accessTokenService := mods3.NewAccessTokenService(serviceCore, accessTokenDao)
+	provider := InitializeOIDC(config2)
+	oidcService := mods3.NewOIDCService(serviceCore, userDao, cache, jwt, enforcer, provider, sessionDao)
+	sessionService := mods3.NewSessionService(serviceCore, sessionDao)
 	modsLogsService := mods4.NewLogsService(serviceCore, loginLogDao, operationLogDao)
-	datasetService := mods5.NewDatasetService(serviceCore, instructionDataDao, operationLogDao)
+	datasetService := mods5.NewDatasetService(serviceCore, instructionDataDao, themeDao, operationLogDao)
 	modsStatisticService := mods5.NewStatisticService(serviceCore, instructionDataDao)
 	wireInjector := &Injector{
-		Ctx:                        ctx,
-		Config:                     config2,
-		Cache:                      cache,
-		Mongo:                      mongo,
-		Redis:                      redis,
-		Zap:                        zap,
-		Jwt:                        jwt,
-		Prometheus:                 prometheus,
-		UserDao:                    userDao,
-		InstructionDataDao:         instructionDataDao,
-		NoticeDao:                  noticeDao,
-		DocumentationDao:           documentationDao,
-		LoginLogDao:                loginLogDao,
-		OperationLogDao:            operationLogDao,
-		UserDaoMock:                userDaoMock,
-		InstructionDataDaoMock:     instructionDataDaoMock,
-		NoticeDaoMock:              noticeDaoMock,
-		DocumentationDaoMock:       documentationDaoMock,
-		LoginLogDaoMock:            loginLogDaoMock,
-		OperationLogDaoMock:        operationLogDaoMock,
-		AdminDataAuditService:      dataAuditService,
-		AdminDocumentationService:  documentationService,
-		AdminNoticeService:         noticeService,
-		AdminLogsService:           logsService,
-		AdminStatisticService:      statisticService,
-		AdminUserService:           userService,
-		CommonAuthService:          authService,
-		CommonIdempotencyService:   idempotencyService,
-		CommonDocumentationService: modsDocumentationService,
-		CommonNoticeService:        modsNoticeService,
-		CommonProfileService:       profileService,
-		SysLogsService:             modsLogsService,
-		UserDatasetService:         datasetService,
-		UserStatisticService:       modsStatisticService,
-		Enforcer:                   enforcer,
+		Ctx:                         ctx,
+		Config:                      config2,
+		Cache:                       cache,
+		Mongo:                       mongo,
+		Redis:                       redis,
+		Zap:                         zap,
+		Jwt:                         jwt,
+		Prometheus:                  prometheus,
+		UserDao:                     userDao,
+		InstructionDataDao:          instructionDataDao,
+		NoticeDao:                   noticeDao,
+		DocumentationDao:            documentationDao,
+		ThemeDao:                    themeDao,
+		ReviewDao:                   reviewDao,
+		ReAuditDao:                  reAuditDao,
+		InviteCodeDao:               inviteCodeDao,
+		TwoFactorPolicyDao:          twoFactorPolicyDao,
+		AccessTokenDao:              accessTokenDao,
+		LoginAttemptDao:             loginAttemptDao,
+		SessionDao:                  sessionDao,
+		JwtKeyDao:                   jwtKeyDao,
+		LoginLogDao:                 loginLogDao,
+		OperationLogDao:             operationLogDao,
+		OrganizationDao:             organizationDao,
+		UserDaoMock:                 userDaoMock,
+		InstructionDataDaoMock:      instructionDataDaoMock,
+		NoticeDaoMock:               noticeDaoMock,
+		DocumentationDaoMock:        documentationDaoMock,
+		LoginLogDaoMock:             loginLogDaoMock,
+		OperationLogDaoMock:         operationLogDaoMock,
+		AdminDataAuditService:       dataAuditService,
+		AdminDocumentationService:   documentationService,
+		AdminNoticeService:          noticeService,
+		AdminLogsService:            logsService,
+		AdminStatisticService:       statisticService,
+		AdminUserService:            userService,
+		AdminThemeService:           themeService,
+		AdminReAuditService:         reAuditService,
+		AdminInviteCodeService:      inviteCodeService,
+		AdminTwoFactorPolicyService: twoFactorPolicyService,
+		AdminLoginLockoutService:    loginLockoutService,
+		AdminOrganizationService:    organizationService,
+		AdminPolicyService:          policyService,
+		CommonAuthService:           authService,
+		CommonIdempotencyService:    idempotencyService,
+		CommonDocumentationService:  modsDocumentationService,
+		CommonNoticeService:         modsNoticeService,
+		CommonProfileService:        profileService,
+		CommonThemeService:          modsThemeService,
+		CommonTwoFactorService:      twoFactorService,
+		CommonAccessTokenService:    accessTokenService,
+		CommonOIDCService:           oidcService,
+		CommonSessionService:        sessionService,
+		SysLogsService:              modsLogsService,
+		UserDatasetService:          datasetService,
+		UserStatisticService:        modsStatisticService,
+		Enforcer:                    enforcer,
 	}
 	return wireInjector, nil
 }
@@ -164,8 +248,18 @@ type Injector struct {
 	InstructionDataDao mods.InstructionDataDao
 	NoticeDao          mods.NoticeDao
 	DocumentationDao   mods.DocumentationDao
+	ThemeDao           mods.ThemeDao
+	ReviewDao          mods.ReviewDao
+	ReAuditDao         mods.ReAuditDao
+	InviteCodeDao      mods.InviteCodeDao
+	TwoFactorPolicyDao mods.TwoFactorPolicyDao
+	AccessTokenDao     mods.AccessTokenDao
+	LoginAttemptDao    mods.LoginAttemptDao
+	SessionDao         mods.SessionDao
+	JwtKeyDao          mods.JwtKeyDao
 	LoginLogDao        mods.LoginLogDao
 	OperationLogDao    mods.OperationLogDao
+	OrganizationDao    mods.OrganizationDao
 
 	// Mocks for DAOs
 	UserDaoMock            *mock.UserDaoMock
@@ -177,18 +271,30 @@ type Injector struct {
 
 	// Services
 	// Admin services
-	AdminDataAuditService     mods2.DataAuditService
-	AdminDocumentationService mods2.DocumentationService
-	AdminNoticeService        mods2.NoticeService
-	AdminLogsService          mods2.LogsService
-	AdminStatisticService     mods2.StatisticService
-	AdminUserService          mods2.UserService
+	AdminDataAuditService       mods2.DataAuditService
+	AdminDocumentationService   mods2.DocumentationService
+	AdminNoticeService          mods2.NoticeService
+	AdminLogsService            mods2.LogsService
+	AdminStatisticService       mods2.StatisticService
+	AdminUserService            mods2.UserService
+	AdminThemeService           mods2.ThemeService
+	AdminReAuditService         mods2.ReAuditService
+	AdminInviteCodeService      mods2.InviteCodeService
+	AdminTwoFactorPolicyService mods2.TwoFactorPolicyService
+	AdminLoginLockoutService    mods2.LoginLockoutService
+	AdminOrganizationService    mods2.OrganizationService
+	AdminPolicyService          mods2.PolicyService
 	// Common services
 	CommonAuthService          mods3.AuthService
 	CommonIdempotencyService   mods3.IdempotencyService
 	CommonDocumentationService mods3.DocumentationService
 	CommonNoticeService        mods3.NoticeService
 	CommonProfileService       mods3.ProfileService
+	CommonThemeService         mods3.ThemeService
+	CommonTwoFactorService     mods3.TwoFactorService
+	CommonAccessTokenService   mods3.AccessTokenService
+	CommonOIDCService          mods3.OIDCService
+	CommonSessionService       mods3.SessionService
 	// Sys services
 	SysLogsService mods4.LogsService
 	// User services
@@ -200,9 +306,9 @@ type Injector struct {
 }
 
 var (
-	ServiceProviderSet = wire.NewSet(wire.Struct(new(service.Core), "*"), wire.Struct(new(admin.Admin), "*"), wire.Struct(new(user.User), "*"), wire.Struct(new(common.Common), "*"), wire.Struct(new(sys.Sys), "*"), mods2.NewDataAuditService, mods2.NewStatisticService, mods2.NewUserService, mods2.NewNoticeService, mods2.NewDocumentationService, mods2.NewLogsService, mods3.NewAuthService, mods3.NewProfileService, mods3.NewDocumentationService, mods3.NewNoticeService, mods3.NewIdempotencyService, mods5.NewDatasetService, mods5.NewStatisticService, mods4.NewLogsService)
+	ServiceProviderSet = wire.NewSet(wire.Struct(new(service.Core), "*"), wire.Struct(new(admin.Admin), "*"), wire.Struct(new(user.User), "*"), wire.Struct(new(common.Common), "*"), wire.Struct(new(sys.Sys), "*"), mods2.NewDataAuditService, mods2.NewStatisticService, mods2.NewUserService, mods2.NewNoticeService, mods2.NewDocumentationService, mods2.NewLogsService, mods2.NewThemeService, mods2.NewReAuditService, mods2.NewInviteCodeService, mods2.NewTwoFactorPolicyService, mods2.NewLoginLockoutService, mods2.NewOrganizationService, mods2.NewPolicyService, mods3.NewAuthService, mods3.NewAuthenticatorChain, mods3.NewProfileService, mods3.NewDocumentationService, mods3.NewNoticeService, mods3.NewIdempotencyService, mods3.NewThemeService, mods3.NewTwoFactorService, mods3.NewAccessTokenService, mods3.NewOIDCService, mods3.NewSessionService, mods5.NewDatasetService, mods5.NewStatisticService, mods4.NewLogsService)
 
-	DaoProviderSet = wire.NewSet(dao.NewCore, dao.NewCache, mods.NewUserDao, mods.NewInstructionDataDao, mods.NewNoticeDao, mods.NewLoginLogDao, mods.NewOperationLogDao, mods.NewDocumentationDao)
+	DaoProviderSet = wire.NewSet(dao.NewCore, dao.NewCache, mods.NewUserDao, mods.NewInstructionDataDao, mods.NewNoticeDao, mods.NewLoginLogDao, mods.NewOperationLogDao, mods.NewDocumentationDao, mods.NewThemeDao, mods.NewReviewDao, mods.NewReAuditDao, mods.NewInviteCodeDao, mods.NewTwoFactorPolicyDao, mods.NewAccessTokenDao, mods.NewLoginAttemptDao, mods.NewSessionDao, mods.NewJwtKeyDao, mods.NewOrganizationDao)
 
 	MockProviderSet = wire.NewSet(mock.NewUserDaoMockWithRandomData, mock.NewInstructionDataDaoMockWithRandomData, mock.NewNoticeDaoMockWithRandomData, mock.NewLoginLogDaoMockWithRandomData, mock.NewOperationLogDaoMockWithRandomData, mock.NewDocumentationDaoMockWithRandomData)
 )